@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/ahmadimt/SwagFluence/internal/ci"
+	"github.com/ahmadimt/SwagFluence/pkg/converter"
+)
+
+// reportToCI publishes the run outcome to the configured CI system
+func reportToCI(mode string, report *converter.Report, runErr error) error {
+	switch mode {
+	case "":
+		return nil
+	case "github":
+		return reportToGitHub(os.Stdout, report, runErr)
+	default:
+		return fmt.Errorf("unsupported ci mode %q (expected github)", mode)
+	}
+}
+
+// reportToGitHub emits annotations to out, the job summary and step
+// outputs
+func reportToGitHub(out io.Writer, report *converter.Report, runErr error) error {
+	gh := ci.NewGitHub(out)
+
+	for _, warning := range report.Warnings {
+		gh.Warning("", warning)
+	}
+	for _, page := range report.Failed() {
+		gh.Error(page.Method+" "+page.Path, page.Error)
+	}
+	if runErr != nil {
+		gh.Error("", runErr.Error())
+	}
+
+	summary := ci.Summary{
+		APITitle:      report.APITitle,
+		APIVersion:    report.APIVersion,
+		ParentPageURL: report.ParentPageURL,
+	}
+	for _, page := range report.Pages {
+		summary.Pages = append(summary.Pages, ci.Page{
+			Title:  page.Title,
+			Method: page.Method,
+			Path:   page.Path,
+			URL:    page.URL,
+			Error:  page.Error,
+		})
+	}
+	if err := gh.WriteSummary(summary); err != nil {
+		return err
+	}
+
+	outputs := map[string]string{
+		"parent_page_id":  report.ParentPageID,
+		"parent_page_url": report.ParentPageURL,
+		"pages_published": strconv.Itoa(report.Succeeded()),
+		"pages_failed":    strconv.Itoa(len(report.Failed())),
+	}
+	for _, name := range []string{"parent_page_id", "parent_page_url", "pages_published", "pages_failed"} {
+		if err := gh.SetOutput(name, outputs[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/pkg/converter"
+)
+
+func TestReportToGitHub(t *testing.T) {
+	tests := []struct {
+		name            string
+		report          *converter.Report
+		runErr          error
+		wantAnnotations string
+		wantOutputs     string
+	}{
+		{
+			name: "published",
+			report: &converter.Report{
+				APITitle: "Pets", ParentPageID: "42", ParentPageURL: "https://wiki/42",
+				Pages: []converter.PageResult{{Title: "List Pets", Method: "GET", Path: "/pets"}},
+			},
+			wantOutputs: "parent_page_id=42\nparent_page_url=https://wiki/42\npages_published=1\npages_failed=0\n",
+		},
+		{
+			name: "failed page and warning",
+			report: &converter.Report{
+				APITitle: "Pets",
+				Warnings: []string{"example request GET /pets failed"},
+				Pages: []converter.PageResult{
+					{Title: "List Pets", Method: "GET", Path: "/pets"},
+					{Title: "Get Pet", Method: "GET", Path: "/pets/{id}", Error: "permission denied"},
+				},
+			},
+			runErr: errors.New("1 of 2 pages failed"),
+			wantAnnotations: "::warning::example request GET /pets failed\n" +
+				"::error title=GET /pets/{id}::permission denied\n" +
+				"::error::1 of 2 pages failed\n",
+			wantOutputs: "parent_page_id=\nparent_page_url=\npages_published=1\npages_failed=1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("GITHUB_OUTPUT", filepath.Join(dir, "output"))
+			t.Setenv("GITHUB_STEP_SUMMARY", filepath.Join(dir, "summary.md"))
+
+			var out strings.Builder
+			if err := reportToGitHub(&out, tt.report, tt.runErr); err != nil {
+				t.Fatalf("reportToGitHub() error = %v", err)
+			}
+			if out.String() != tt.wantAnnotations {
+				t.Errorf("annotations = %q, want %q", out.String(), tt.wantAnnotations)
+			}
+			outputs, err := os.ReadFile(filepath.Join(dir, "output"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(outputs) != tt.wantOutputs {
+				t.Errorf("outputs = %q, want %q", outputs, tt.wantOutputs)
+			}
+			summary, err := os.ReadFile(filepath.Join(dir, "summary.md"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(string(summary), "## Pets ") {
+				t.Errorf("summary = %q", summary)
+			}
+		})
+	}
+}
+
+func TestReportToCI_UnsupportedMode(t *testing.T) {
+	if err := reportToCI("gitlab", &converter.Report{}, nil); err == nil {
+		t.Error("reportToCI() error = nil, want an unsupported mode error")
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+	"github.com/ahmadimt/SwagFluence/pkg/converter"
+)
+
+// commandAliases maps every accepted command name to its canonical name
+var commandAliases = map[string]string{
+	"publish":    "publish",
+	"export":     "export",
+	"validate":   "validate",
+	"diff":       "diff",
+	"diff-specs": "diff",
+	"clean":      "clean",
+	"watch":      "watch",
+	"serve":      "serve",
+	"help":       "help",
+}
+
+// splitCommand returns the command named by the first argument and the
+// arguments following it. Without a command, args are published, as they
+// were before subcommands existed.
+func splitCommand(args []string) (string, []string) {
+	if len(args) > 0 {
+		if command, ok := commandAliases[args[0]]; ok {
+			return command, args[1:]
+		}
+	}
+	return "publish", args
+}
+
+// specArgs returns the spec sources to process: the positional arguments,
+// or the specs listed in the config file
+func specArgs(cfg *config.Config, args []string) ([]string, bool) {
+	if len(args) == 0 {
+		args = cfg.SpecSources()
+	}
+	if len(args) < 1 {
+		printUsage()
+		return nil, false
+	}
+
+	stdinArgs := 0
+	for _, arg := range args {
+		if arg == swagger.StdinSource {
+			stdinArgs++
+		}
+	}
+	if stdinArgs > 1 {
+		fmt.Fprintln(os.Stderr, "Error: standard input (-) can only be read once")
+		return nil, false
+	}
+	return args, true
+}
+
+// runValidate implements "swagfluence validate", linting the specs and
+// rendering their pages without publishing anything
+func runValidate(ctx context.Context, cfg *config.Config, args []string) int {
+	args, ok := specArgs(cfg, args)
+	if !ok {
+		return exitCodeError
+	}
+
+	// Validation always lints; error findings fail unless told otherwise
+	cfg.Lint.Enabled = true
+	if cfg.Lint.FailOn == "" {
+		cfg.Lint.FailOn = "error"
+	}
+
+	swaggerParser, err := swagger.NewParserWithConfig(cfg.Spec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitCodeError
+	}
+	formatter, err := confluence.NewFormatterWithConfig(cfg.Templates)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitCodeError
+	}
+	conv := converter.New(converter.WithParser(swaggerParser), converter.WithOptions(converter.Options{
+		Formatter: formatter,
+		Lint:      cfg.Lint,
+	}))
+
+	failed := 0
+	for _, source := range args {
+		if len(args) > 1 {
+			fmt.Printf("\n### %s\n\n", source)
+		}
+		report, err := conv.Validate(ctx, source)
+		if ciErr := reportToCI(cfg.CI, report, err); ciErr != nil {
+			fmt.Fprintf(os.Stderr, "Error writing CI output: %v\n", ciErr)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", source, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		if len(args) > 1 {
+			fmt.Fprintf(os.Stderr, "%d of %d specs failed validation\n", failed, len(args))
+		}
+		return exitCodeError
+	}
+	return exitCodeSuccess
+}
+
+// runClean implements "swagfluence clean", pruning the generated pages of
+// endpoints that are no longer in the specs
+func runClean(ctx context.Context, cfg *config.Config, args []string) int {
+	if cfg.Publisher != "confluence" {
+		fmt.Fprintf(os.Stderr, "Error: clean supports the confluence publisher only, not %s\n", cfg.Publisher)
+		return exitCodeError
+	}
+	if !cfg.Confluence.Enabled {
+		fmt.Fprintln(os.Stderr, "Error: clean needs Confluence credentials and a space (see CONFLUENCE_* below)")
+		printUsage()
+		return exitCodeError
+	}
+
+	args, ok := specArgs(cfg, args)
+	if !ok {
+		return exitCodeError
+	}
+
+	swaggerParser, err := swagger.NewParserWithConfig(cfg.Spec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitCodeError
+	}
+	client, err := confluence.NewClient(cfg.Confluence)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitCodeError
+	}
+	conv := converter.New(converter.WithParser(swaggerParser), converter.WithPublisher(client),
+		converter.WithOptions(converter.Options{Versions: cfg.Versions, Prune: cfg.Prune}))
+
+	failed := 0
+	for _, source := range args {
+		stale, err := conv.Clean(ctx, source, cfg.DryRun)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", source, err)
+			failed++
+			continue
+		}
+		if cfg.DryRun {
+			fmt.Printf("%d stale pages found for %s (dry run, nothing deleted)\n", len(stale), source)
+		} else {
+			fmt.Printf("%d stale pages pruned for %s\n", len(stale), source)
+		}
+	}
+
+	if failed > 0 {
+		return exitCodeError
+	}
+	return exitCodeSuccess
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/internal/diff"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// runDiffSpecs implements "swagfluence diff <old> <new>", comparing
+// two spec versions and, with --publish, writing the comparison to a
+// Confluence page
+func runDiffSpecs(ctx context.Context, cfg *config.Config, args []string) int {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	fs.String("config", "", "YAML config file (read before flag parsing)")
+	jsonOut := fs.String("json", "", "also write the report as JSON to this file (- for stdout only)")
+	failOnBreaking := fs.Bool("fail-on-breaking", false, "exit with status 1 when breaking changes are found")
+	insecure := fs.Bool("insecure", false, "skip TLS certificate verification")
+	caCert := fs.String("ca-cert", "", "PEM CA bundle trusted when fetching the specs")
+	publish := fs.Bool("publish", false, "also publish the comparison to Confluence")
+
+	if err := fs.Parse(args); err != nil || fs.NArg() != 2 {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid arguments: %v\n\n", err)
+		}
+		printDiffSpecsUsage()
+		return exitCodeError
+	}
+
+	if *publish && !cfg.Confluence.Enabled {
+		fmt.Fprintln(os.Stderr, "Error: --publish needs Confluence credentials and a space (see CONFLUENCE_* in swagfluence help)")
+		return exitCodeError
+	}
+
+	cfg.Spec.TLS.InsecureSkipVerify = cfg.Spec.TLS.InsecureSkipVerify || *insecure
+	if *caCert != "" {
+		cfg.Spec.TLS.CACertFile = *caCert
+	}
+
+	parser, err := swagger.NewParserWithConfig(cfg.Spec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitCodeError
+	}
+
+	specs := make([]*swagger.Spec, 2)
+	for i, source := range fs.Args() {
+		if specs[i], err = parser.Parse(ctx, source); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to parse %s: %v\n", source, err)
+			return exitCodeError
+		}
+	}
+
+	report := diff.NewReport(specs[0], specs[1])
+
+	switch *jsonOut {
+	case "-":
+		err = report.WriteJSON(os.Stdout)
+	case "":
+		err = report.WriteText(os.Stdout)
+	default:
+		if err = report.WriteText(os.Stdout); err == nil {
+			err = writeDiffJSON(*jsonOut, report)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitCodeError
+	}
+
+	if *publish {
+		if err := publishDiff(ctx, cfg, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitCodeError
+		}
+	}
+
+	if *failOnBreaking && report.Breaking > 0 {
+		return exitCodeError
+	}
+	return exitCodeSuccess
+}
+
+func writeDiffJSON(path string, report *diff.Report) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer file.Close()
+
+	return report.WriteJSON(file)
+}
+
+// publishDiff writes the comparison to a page under the configured parent
+// page, or at the space root when there is none
+func publishDiff(ctx context.Context, cfg *config.Config, report *diff.Report) error {
+	client, err := confluence.NewClient(cfg.Confluence)
+	if err != nil {
+		return err
+	}
+	parentPageID, err := client.ResolveParentPage(ctx)
+	if err != nil {
+		return err
+	}
+
+	title := confluence.ComparisonPageTitle(report.New.Title, report.Old.Version, report.New.Version)
+	content := confluence.NewFormatter().FormatChangelogPage(report.New.Title, report.Old.Version, report.New.Version, report.Changes)
+	pageID, err := client.CreateOrUpdatePage(ctx, title, content, parentPageID)
+	if err != nil {
+		return fmt.Errorf("failed to publish comparison page: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Published %s: %s\n", title, client.PageURL(pageID))
+	return nil
+}
+
+func printDiffSpecsUsage() {
+	fmt.Println("Usage: swagfluence diff [flags] <old-spec-url> <new-spec-url>")
+	fmt.Println("       (diff-specs is accepted as an alias)")
+	fmt.Println("\nFlags:")
+	fmt.Println("  --json <file|->           Also write a JSON report to a file, or only JSON to stdout with -")
+	fmt.Println("  --fail-on-breaking        Exit with status 1 when breaking changes are found")
+	fmt.Println("  --publish                 Also publish the comparison to Confluence (CONFLUENCE_* settings)")
+	fmt.Println("  --insecure                Skip TLS certificate verification")
+	fmt.Println("  --ca-cert <file>          Trust an additional PEM CA bundle")
+}
@@ -0,0 +1,376 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/internal/lint"
+	"github.com/ahmadimt/SwagFluence/pkg/converter"
+)
+
+// configPath returns the --config file named in args, falling back to
+// SWAGFLUENCE_CONFIG. It is looked up before the other flags are parsed,
+// because they override the file.
+func configPath(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "config" {
+			continue
+		}
+		if hasValue {
+			return value
+		}
+		if i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return os.Getenv("SWAGFLUENCE_CONFIG")
+}
+
+// pruneFlag is the --prune flag: given alone it deletes stale pages,
+// --prune=archive archives them instead
+type pruneFlag struct {
+	mode *string
+}
+
+func (f pruneFlag) String() string {
+	if f.mode == nil {
+		return ""
+	}
+	return *f.mode
+}
+
+func (f pruneFlag) Set(value string) error {
+	switch value {
+	case "true":
+		*f.mode = converter.PruneDelete
+	case "false":
+		*f.mode = ""
+	case converter.PruneDelete, converter.PruneArchive:
+		*f.mode = value
+	default:
+		return fmt.Errorf("expected delete or archive, got %q", value)
+	}
+	return nil
+}
+
+// IsBoolFlag lets --prune be given without a value
+func (f pruneFlag) IsBoolFlag() bool {
+	return true
+}
+
+// parseFlags applies command line flags on top of the loaded configuration
+// and returns the remaining positional arguments
+func parseFlags(args []string, cfg *config.Config) ([]string, error) {
+	fs := flag.NewFlagSet("swagfluence", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	// Read by configPath before the configuration is loaded
+	fs.String("config", "", "YAML config file; environment variables and flags override it")
+
+	fs.StringVar(&cfg.Publisher, "publisher", cfg.Publisher,
+		"documentation backend to publish to (confluence|xwiki|notion|files|stdout)")
+	fs.StringVar(&cfg.CI, "ci", cfg.CI,
+		"emit CI-specific output (github)")
+	fs.StringVar(&cfg.Confluence.SpaceKey, "space", cfg.Confluence.SpaceKey,
+		"key of the Confluence space pages are published to")
+	fs.StringVar(&cfg.Confluence.ParentPageID, "parent-page", cfg.Confluence.ParentPageID,
+		"ID of the page to publish under")
+	fs.StringVar(&cfg.Confluence.ParentPageTitle, "parent-title", cfg.Confluence.ParentPageTitle,
+		"title of the parent page to publish under")
+	fs.BoolVar(&cfg.Confluence.CreateParent, "create-parent", cfg.Confluence.CreateParent,
+		"create the parent page when --parent-title does not exist")
+	fs.BoolVar(&cfg.DryRun, "dry-run", cfg.DryRun,
+		"show which pages would be created or updated, with diffs, without writing anything (clean: list stale pages only)")
+	fs.StringVar(&cfg.Spec.Format, "spec-format", cfg.Spec.Format,
+		"force the spec document format (json|yaml|apib)")
+	fs.StringVar(&cfg.Spec.Version, "spec-version", cfg.Spec.Version,
+		"force the spec version (2|3|3.1)")
+	fs.StringVar(&cfg.Spec.TitleTemplate, "title-template", cfg.Spec.TitleTemplate,
+		"text/template for endpoint page titles, e.g. '{{.APITitle}} - {{.Method}} {{.Path}}'")
+	fs.StringVar(&cfg.Spec.TitlePrefix, "title-prefix", cfg.Spec.TitlePrefix,
+		"text added before every endpoint page title, e.g. '[Payments] '")
+	fs.StringVar(&cfg.Spec.TitleSuffix, "title-suffix", cfg.Spec.TitleSuffix,
+		"text added after every endpoint page title, e.g. ' (Payments)'")
+
+	fs.StringVar(&cfg.Collection.Format, "collection", cfg.Collection.Format,
+		"export a request collection alongside the docs (insomnia|bruno)")
+	fs.StringVar(&cfg.Collection.Output, "collection-out", cfg.Collection.Output,
+		"collection output file (insomnia) or directory (bruno)")
+
+	fs.Func("overlay", "OpenAPI Overlay document applied before rendering (repeatable)", func(value string) error {
+		cfg.Spec.Overlays = append(cfg.Spec.Overlays, value)
+		return nil
+	})
+
+	fs.Func("include-tags", "comma-separated tags whose endpoints are documented (repeatable)",
+		listFlag(&cfg.Spec.IncludeTags))
+	fs.Func("exclude-tags", "comma-separated tags whose endpoints are left out (repeatable)",
+		listFlag(&cfg.Spec.ExcludeTags))
+	fs.Func("include-paths", "comma-separated path globs to document, e.g. /public/** (repeatable)",
+		listFlag(&cfg.Spec.IncludePaths))
+	fs.Func("methods", "comma-separated HTTP methods to document (repeatable)",
+		listFlag(&cfg.Spec.Methods))
+
+	fs.BoolVar(&cfg.Spec.IncludeHidden, "include-hidden", cfg.Spec.IncludeHidden,
+		"document operations, parameters and properties marked x-internal or x-hidden")
+
+	fs.StringVar(&cfg.Templates.Page, "template", cfg.Templates.Page,
+		"text/template file replacing the endpoint page layout")
+	fs.Func("template-block", "text/template file overriding named page blocks (repeatable)", func(value string) error {
+		cfg.Templates.Blocks = append(cfg.Templates.Blocks, value)
+		return nil
+	})
+
+	fs.Func("samples", "comma-separated code sample languages (curl, httpie, python, javascript, go) or none (repeatable)",
+		listFlag(&cfg.Templates.Samples))
+
+	fs.Func("har", "HAR file whose recorded JSON payloads replace generated examples (repeatable)", func(value string) error {
+		cfg.Examples.HARFiles = append(cfg.Examples.HARFiles, value)
+		return nil
+	})
+	fs.Func("har-redact", "comma-separated extra field names masked in recorded examples", func(value string) error {
+		cfg.Examples.Redact = append(cfg.Examples.Redact, config.SplitList(value)...)
+		return nil
+	})
+	fs.BoolVar(&cfg.Examples.Faker, "faker", cfg.Examples.Faker,
+		"fill generated examples with realistic names, addresses and phone numbers, stable across runs")
+	fs.IntVar(&cfg.Examples.MaxDepth, "example-depth", cfg.Examples.MaxDepth,
+		"levels of nested schemas shown in generated examples before a placeholder (default 10)")
+	fs.IntVar(&cfg.Examples.ArrayItems, "example-items", cfg.Examples.ArrayItems,
+		"items in generated example arrays (default 1)")
+
+	fs.StringVar(&cfg.Smoke.BaseURL, "smoke-url", cfg.Smoke.BaseURL,
+		"execute the example requests against this base URL and annotate the pages")
+	fs.BoolVar(&cfg.Smoke.AllowWrites, "smoke-writes", cfg.Smoke.AllowWrites,
+		"also execute non-GET example requests (sandbox servers only)")
+	fs.Func("smoke-param", "path or query parameter value for example requests as name=value (repeatable)", func(value string) error {
+		name, paramValue, ok := strings.Cut(value, "=")
+		if !ok {
+			return fmt.Errorf("expected name=value, got %q", value)
+		}
+		if cfg.Smoke.Params == nil {
+			cfg.Smoke.Params = make(map[string]string)
+		}
+		cfg.Smoke.Params[name] = paramValue
+		return nil
+	})
+	fs.Func("smoke-header", "extra \"Name: value\" header for example requests (repeatable)", func(value string) error {
+		cfg.Smoke.Headers = append(cfg.Smoke.Headers, value)
+		return nil
+	})
+
+	fs.BoolVar(&cfg.Lint.Enabled, "lint", cfg.Lint.Enabled,
+		"check documentation quality rules before publishing")
+	fs.StringVar(&cfg.Lint.FailOn, "lint-fail-on", cfg.Lint.FailOn,
+		"fail the run when a lint finding reaches this severity (warning|error)")
+	fs.Func("lint-rule", "override a lint rule severity as name=severity (repeatable)", func(value string) error {
+		name, severity, ok := strings.Cut(value, "=")
+		if !ok || !lint.ValidSeverity(severity) {
+			return fmt.Errorf("expected name=off|info|warning|error, got %q", value)
+		}
+		if cfg.Lint.Rules == nil {
+			cfg.Lint.Rules = make(map[string]string)
+		}
+		cfg.Lint.Rules[name] = severity
+		return nil
+	})
+
+	fs.StringVar(&cfg.Directory, "directory", cfg.Directory,
+		"title of a landing page listing every published API (default for batch runs: "+config.DefaultDirectoryTitle+")")
+
+	fs.Var(pruneFlag{&cfg.Prune}, "prune",
+		"delete endpoint pages no longer in the spec after publishing (--prune=archive moves them to an archive page)")
+
+	fs.BoolVar(&cfg.ContinueOnError, "continue-on-error", cfg.ContinueOnError,
+		"keep publishing when an endpoint page fails and list the failures at the end")
+
+	fs.StringVar(&cfg.Report, "report", cfg.Report,
+		"write a JSON summary of the run (pages, page IDs and URLs, errors) to this file")
+
+	fs.StringVar(&cfg.Baseline, "baseline-spec", cfg.Baseline,
+		"previous spec version to compare against for breaking changes")
+	fs.StringVar(&cfg.Jira.IssueType, "jira-issue-type", cfg.Jira.IssueType,
+		"issue type for breaking-change issues (default Task)")
+
+	fs.StringVar(&cfg.Export.Dir, "export-dir", cfg.Export.Dir,
+		"directory written by the files publisher")
+	fs.StringVar(&cfg.Export.Dir, "out", cfg.Export.Dir,
+		"directory written by the files publisher (same as --export-dir)")
+	fs.StringVar(&cfg.Export.Format, "format", cfg.Export.Format,
+		"what the files publisher writes (storage|markdown|html|asciidoc)")
+	fs.StringVar(&cfg.Export.PublishFrom, "publish-from", cfg.Export.PublishFrom,
+		"publish a directory written by the files publisher instead of a spec")
+
+	fs.DurationVar(&cfg.Watch.Interval, "interval", cfg.Watch.Interval,
+		"time between polls of the specs by watch, e.g. 30s or 5m")
+
+	fs.StringVar(&cfg.Server.Listen, "listen", cfg.Server.Listen,
+		"address serve listens on for sync webhooks")
+
+	fs.StringVar(&cfg.PDF.Output, "pdf-out", cfg.PDF.Output,
+		"export the published pages to PDF files in this directory")
+	fs.BoolVar(&cfg.PDF.Attach, "pdf-attach", cfg.PDF.Attach,
+		"attach the PDF exports to the parent page")
+
+	insecure := fs.Bool("insecure", false,
+		"skip TLS certificate verification for the spec source and Confluence")
+	caCert := fs.String("ca-cert", "",
+		"PEM CA bundle trusted for the spec source and Confluence")
+	proxy := fs.String("proxy", "",
+		"URL of the proxy used for the spec source and Confluence (default: HTTPS_PROXY/HTTP_PROXY)")
+
+	fs.StringVar(&cfg.Confluence.UpdateMode, "update-mode", cfg.Confluence.UpdateMode,
+		"how existing pages are updated (full|region)")
+
+	fs.StringVar(&cfg.Versions.Label, "version-label", cfg.Versions.Label,
+		"publish into a per-version subtree with this label (e.g. v2)")
+	fs.Func("link-versions", "comma-separated labels of other published versions to cross-link", func(value string) error {
+		cfg.Versions.Linked = config.SplitList(value)
+		return nil
+	})
+
+	fs.BoolVar(&cfg.Confluence.TagLabels, "tag-labels", cfg.Confluence.TagLabels,
+		"label endpoint pages with their operation tags")
+	fs.BoolVar(&cfg.Confluence.OrderPages, "order-pages", cfg.Confluence.OrderPages,
+		"position endpoint pages in the page tree in spec order")
+	fs.BoolVar(&cfg.Confluence.ManagedLabels, "managed-labels", cfg.Confluence.ManagedLabels,
+		"label generated pages with swagfluence, the API name and, on endpoint pages, swagfluence-endpoint")
+
+	fs.BoolVar(&cfg.Confluence.AttachSpec, "attach-spec", cfg.Confluence.AttachSpec,
+		"attach the source spec to the API page and link to it")
+	fs.StringVar(&cfg.Confluence.SpecEmbed, "spec-embed", cfg.Confluence.SpecEmbed,
+		"also show the attached spec on the API page: code, or the name of a viewer macro")
+
+	fs.StringVar(&cfg.Confluence.SwaggerUIURL, "swagger-ui", cfg.Confluence.SwaggerUIURL,
+		"URL of a Swagger UI for the API, embedded on the API page")
+
+	fs.StringVar(&cfg.Confluence.VersionMessage, "version-message", cfg.Confluence.VersionMessage,
+		"version comment of updated pages, with {version} replaced by the API version")
+	fs.BoolVar(&cfg.Confluence.MinorEdit, "minor-edit", cfg.Confluence.MinorEdit,
+		"save page updates as minor edits that don't notify watchers")
+
+	fs.StringVar(&cfg.Confluence.AuthType, "auth-type", cfg.Confluence.AuthType,
+		"Confluence authentication: basic (default) or bearer for personal access tokens")
+
+	fs.BoolVar(&cfg.Confluence.LowMemory, "low-memory", cfg.Confluence.LowMemory,
+		"look pages up one at a time instead of indexing them, for very large APIs")
+	fs.IntVar(&cfg.Confluence.MaxAttempts, "max-attempts", cfg.Confluence.MaxAttempts,
+		"attempts per Confluence request when rate limited (429/503) (default 5)")
+	fs.DurationVar(&cfg.Confluence.MaxRetryAfter, "max-retry-after", cfg.Confluence.MaxRetryAfter,
+		"longest wait a Retry-After header may ask for before a retry (default 5m)")
+	fs.IntVar(&cfg.Confluence.CollapseProperties, "collapse-properties", cfg.Confluence.CollapseProperties,
+		"collapse schema tables with more properties than this in expand macros, -1 never (default 30)")
+	fs.IntVar(&cfg.Confluence.CollapseLines, "collapse-lines", cfg.Confluence.CollapseLines,
+		"collapse example bodies longer than this many lines in expand macros, -1 never (default 80)")
+	fs.StringVar(&cfg.Confluence.API, "confluence-api", cfg.Confluence.API,
+		"Confluence REST API to publish through (v1|v2)")
+	fs.StringVar(&cfg.Confluence.StateFile, "state-file", cfg.Confluence.StateFile,
+		"file recording page IDs between runs so unchanged pages skip the lookup")
+
+	fs.BoolVar(&cfg.Confluence.SharedModels, "shared-models", cfg.Confluence.SharedModels,
+		"document component schemas once on model pages included by endpoint pages")
+
+	fs.BoolVar(&cfg.Confluence.Changelog, "changelog", cfg.Confluence.Changelog,
+		"publish a changelog page listing the changes since the previous run")
+
+	fs.BoolVar(&cfg.Confluence.History, "history", cfg.Confluence.History,
+		"archive the previous rendering of changed pages under a History page")
+
+	labelsSet := false
+	fs.Func("labels", "comma-separated labels applied to every generated page", func(value string) error {
+		// The flag replaces CONFLUENCE_LABELS; repeating it accumulates
+		if !labelsSet {
+			cfg.Confluence.Labels = nil
+			labelsSet = true
+		}
+		cfg.Confluence.Labels = append(cfg.Confluence.Labels, config.SplitList(value)...)
+		return nil
+	})
+
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	cfg.Normalize()
+
+	if cfg.Lint.FailOn != "" && !lint.ValidSeverity(cfg.Lint.FailOn) {
+		return nil, fmt.Errorf("invalid --lint-fail-on %q (expected warning or error)", cfg.Lint.FailOn)
+	}
+
+	switch cfg.Export.Format {
+	case "", "storage", "markdown", "html", "asciidoc":
+	default:
+		return nil, fmt.Errorf("invalid --format %q (expected storage, markdown, html or asciidoc)", cfg.Export.Format)
+	}
+
+	if cfg.Watch.Interval <= 0 {
+		return nil, fmt.Errorf("invalid --interval %s (must be positive)", cfg.Watch.Interval)
+	}
+
+	switch cfg.Prune {
+	case "", converter.PruneDelete, converter.PruneArchive:
+	default:
+		return nil, fmt.Errorf("invalid prune mode %q (expected delete or archive)", cfg.Prune)
+	}
+
+	switch cfg.Confluence.UpdateMode {
+	case "", "full", "region":
+	default:
+		return nil, fmt.Errorf("invalid --update-mode %q (expected full or region)", cfg.Confluence.UpdateMode)
+	}
+
+	// Without the version the history cannot tell which release a page shows
+	if cfg.Confluence.VersionMessage != "" && !strings.Contains(cfg.Confluence.VersionMessage, confluence.VersionPlaceholder) {
+		return nil, fmt.Errorf("invalid --version-message %q (must contain %s)", cfg.Confluence.VersionMessage, confluence.VersionPlaceholder)
+	}
+
+	switch cfg.Confluence.AuthType {
+	case "", confluence.AuthBasic, confluence.AuthBearer:
+	default:
+		return nil, fmt.Errorf("invalid --auth-type %q (expected basic or bearer)", cfg.Confluence.AuthType)
+	}
+
+	switch cfg.Confluence.API {
+	case "", confluence.APIv1, confluence.APIv2:
+	default:
+		return nil, fmt.Errorf("invalid --confluence-api %q (expected v1 or v2)", cfg.Confluence.API)
+	}
+
+	// TLS and proxy flags apply to every outbound connection
+	for _, tlsCfg := range cfg.TLSConfigs() {
+		if *insecure {
+			tlsCfg.InsecureSkipVerify = true
+		}
+		if *caCert != "" {
+			tlsCfg.CACertFile = *caCert
+		}
+		if *proxy != "" {
+			tlsCfg.Proxy = *proxy
+		}
+	}
+
+	return fs.Args(), nil
+}
+
+// listFlag returns a flag function for a comma-separated list setting. The
+// first use replaces the value from the config file or environment;
+// repeating the flag accumulates.
+func listFlag(dst *[]string) func(string) error {
+	set := false
+	return func(value string) error {
+		if !set {
+			*dst = nil
+			set = true
+		}
+		*dst = append(*dst, config.SplitList(value)...)
+		return nil
+	}
+}
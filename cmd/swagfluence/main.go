@@ -3,13 +3,21 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/ahmadimt/SwagFluence/internal/asciidoc"
 	"github.com/ahmadimt/SwagFluence/internal/config"
 	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/internal/export"
+	"github.com/ahmadimt/SwagFluence/internal/jira"
+	"github.com/ahmadimt/SwagFluence/internal/markdown"
+	"github.com/ahmadimt/SwagFluence/internal/notion"
+	"github.com/ahmadimt/SwagFluence/internal/site"
 	"github.com/ahmadimt/SwagFluence/internal/swagger"
+	"github.com/ahmadimt/SwagFluence/internal/xwiki"
 	"github.com/ahmadimt/SwagFluence/pkg/converter"
 )
 
@@ -23,48 +31,404 @@ func main() {
 }
 
 func run() int {
-	// Setup context with cancellation
-	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	// Setup context with cancellation. The first Ctrl+C stops scheduling new
+	// pages; default signal handling is then restored so a second one quits.
+	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		signal.Stop(signals)
+		fmt.Fprintln(os.Stderr, "\nInterrupted: finishing the current page (press Ctrl+C again to quit now)")
+		cancel()
+	}()
+
+	// Load configuration: the config file, then environment variables, then
+	// flags, each overriding the previous
+	cfg, err := config.Load(configPath(os.Args[1:]))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		return exitCodeError
+	}
+
+	command, args := splitCommand(os.Args[1:])
+	switch command {
+	case "help":
+		printUsage()
+		return exitCodeSuccess
+	case "diff":
+		return runDiffSpecs(ctx, cfg, args)
+	}
 
 	// Parse command line arguments
-	if len(os.Args) < 2 {
+	args, err = parseFlags(args, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
 		printUsage()
 		return exitCodeError
 	}
 
-	swaggerURL := os.Args[1]
+	switch command {
+	case "validate":
+		return runValidate(ctx, cfg, args)
+	case "clean":
+		return runClean(ctx, cfg, args)
+	case "watch":
+		return runWatch(ctx, cfg, args)
+	case "serve":
+		return runServe(ctx, cfg, args)
+	case "export":
+		cfg.Publisher = "files"
+	}
+	return runPublish(ctx, cfg, args)
+}
+
+// runPublish implements "swagfluence publish", converting the specs and
+// publishing them with the configured publisher
+func runPublish(ctx context.Context, cfg *config.Config, args []string) int {
+	// A dry run writes nothing. With Confluence credentials it reports how
+	// each page would change; otherwise the pages are printed.
+	if cfg.DryRun {
+		if cfg.Publisher != "confluence" || !cfg.Confluence.Enabled {
+			cfg.Publisher = "confluence"
+			cfg.Confluence.Enabled = false
+		}
+		cfg.Confluence.DryRun = true
+		cfg.Jira.Enabled = false
+		cfg.PDF = config.PDFConfig{}
+	}
+
+	// Push a reviewed export directory without touching the spec
+	if cfg.Export.PublishFrom != "" {
+		return publishFrom(ctx, cfg)
+	}
+
+	args, ok := specArgs(cfg, args)
+	if !ok {
+		return exitCodeError
+	}
 
-	// Load configuration
-	cfg, err := config.LoadFromEnv()
+	conv, err := newConverter(cfg)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitCodeError
+	}
+
+	// Execute conversion; a batch keeps going past failing specs
+	var reports []*converter.Report
+	failed := 0
+	for _, swaggerURL := range args {
+		if ctx.Err() != nil {
+			fmt.Fprintf(os.Stderr, "Skipped %s: interrupted\n", swaggerURL)
+			failed++
+			continue
+		}
+		if len(args) > 1 {
+			fmt.Fprintf(progressOutput(cfg), "\n### %s\n\n", swaggerURL)
+		}
+		// Specs with settings of their own in the config file get their
+		// own converter
+		specConv := conv
+		if specCfg := cfg.ForSpec(swaggerURL); specCfg != cfg {
+			if specConv, err = newConverter(specCfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s: %v\n", swaggerURL, err)
+				failed++
+				continue
+			}
+		}
+		report, convErr := specConv.Convert(ctx, swaggerURL)
+		if err := reportToCI(cfg.CI, report, convErr); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing CI output: %v\n", err)
+		}
+		if convErr != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", swaggerURL, convErr)
+			report.Error = convErr.Error()
+			failed++
+		}
+		reports = append(reports, report)
+	}
+
+	// Refresh the landing page listing every API in the space
+	directory := cfg.Directory
+	if directory == "" && len(args) > 1 {
+		directory = config.DefaultDirectoryTitle
+	}
+	if directory != "" && ctx.Err() == nil {
+		if err := conv.PublishDirectory(ctx, directory, reports); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitCodeError
+		}
+	}
+
+	if cfg.Report != "" {
+		if err := writeRunReport(cfg.Report, reports, failed); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitCodeError
+		}
+	}
+
+	if failed > 0 {
+		if len(args) > 1 {
+			fmt.Fprintf(os.Stderr, "%d of %d specs failed\n", failed, len(args))
+		}
 		return exitCodeError
 	}
 
-	// Initialize components
-	swaggerParser := swagger.NewParser()
-	confluenceClient := confluence.NewClient(cfg.Confluence)
-	conv := converter.New(swaggerParser, confluenceClient)
+	return exitCodeSuccess
+}
+
+// newConverter sets up the parser, publisher and options described by cfg
+func newConverter(cfg *config.Config) (*converter.Converter, error) {
+	swaggerParser, err := swagger.NewParserWithConfig(cfg.Spec)
+	if err != nil {
+		return nil, err
+	}
+	publisher, err := newPublisher(cfg)
+	if err != nil {
+		return nil, err
+	}
+	formatter, err := confluence.NewFormatterWithConfig(cfg.Templates)
+	if err != nil {
+		return nil, err
+	}
+	formatter = formatter.WithCollapse(cfg.Confluence.CollapseProperties, cfg.Confluence.CollapseLines)
+	opts := converter.Options{
+		Formatter:       formatter,
+		Collection:      cfg.Collection,
+		Lint:            cfg.Lint,
+		Baseline:        cfg.Baseline,
+		PDF:             cfg.PDF,
+		Examples:        cfg.Examples,
+		Smoke:           cfg.Smoke,
+		Versions:        cfg.Versions,
+		TagLabels:       cfg.Confluence.TagLabels,
+		OrderPages:      cfg.Confluence.OrderPages,
+		SharedModels:    cfg.Confluence.SharedModels,
+		Changelog:       cfg.Confluence.Changelog,
+		Prune:           cfg.Prune,
+		DryRun:          cfg.DryRun,
+		ContinueOnError: cfg.ContinueOnError,
+	}
+	if cfg.Jira.Enabled {
+		issues, err := jira.NewClient(cfg.Jira)
+		if err != nil {
+			return nil, err
+		}
+		opts.Issues = issues
+	}
+	hooks, err := converter.NewHooks(cfg.Hooks)
+	if err != nil {
+		return nil, err
+	}
+	return converter.New(converter.WithParser(swaggerParser), converter.WithPublisher(publisher),
+		converter.WithOptions(opts), converter.WithHooks(hooks), converter.WithProgress(progressOutput(cfg))), nil
+}
+
+// progressOutput returns where progress messages go: standard error when
+// the stdout publisher writes the pages to standard output
+func progressOutput(cfg *config.Config) io.Writer {
+	if cfg.Publisher == "stdout" {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// newPublisher creates the documentation backend selected in the config
+func newPublisher(cfg *config.Config) (converter.Publisher, error) {
+	switch cfg.Publisher {
+	case "confluence":
+		if cfg.Confluence.API == confluence.APIv2 && cfg.Confluence.Enabled {
+			return confluence.NewCloudClient(cfg.Confluence)
+		}
+		return confluence.NewClient(cfg.Confluence)
+	case "xwiki":
+		return xwiki.NewClient(cfg.XWiki)
+	case "notion":
+		return notion.NewClient(cfg.Notion)
+	case "files":
+		switch cfg.Export.Format {
+		case "markdown":
+			return markdown.NewWriter(cfg.Export.Dir)
+		case "html":
+			return site.NewWriter(cfg.Export.Dir)
+		case "asciidoc":
+			return asciidoc.NewWriter(cfg.Export.Dir)
+		}
+		return export.NewWriter(cfg.Export.Dir)
+	case "stdout":
+		return export.NewStreamWriter(os.Stdout), nil
+	default:
+		return nil, fmt.Errorf("unsupported publisher %q (expected confluence, xwiki, notion, files or stdout)", cfg.Publisher)
+	}
+}
+
+// publishFrom publishes the pages of an export directory
+func publishFrom(ctx context.Context, cfg *config.Config) int {
+	if cfg.Publisher == "files" || cfg.Publisher == "stdout" {
+		fmt.Fprintf(os.Stderr, "Error: --publish-from needs a wiki publisher, not %s\n", cfg.Publisher)
+		return exitCodeError
+	}
 
-	// Execute conversion
-	if err := conv.Convert(ctx, swaggerURL); err != nil {
+	publisher, err := newPublisher(cfg)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return exitCodeError
 	}
 
+	count, err := export.Publish(ctx, cfg.Export.PublishFrom, publisher)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitCodeError
+	}
+
+	fmt.Printf("\nPublished %d pages from %s\n", count, cfg.Export.PublishFrom)
 	return exitCodeSuccess
 }
 
 func printUsage() {
-	fmt.Println("Usage: swagfluence <swagger-url>")
+	fmt.Println("Usage: swagfluence <command> [flags] <spec> [<spec>...]")
+	fmt.Println("       (a spec is an http(s) URL, a local file path, or - for stdin)")
+	fmt.Println("\nCommands:")
+	fmt.Println("  publish    Publish the specs (the default when no command is given)")
+	fmt.Println("  export     Write the pages to files, like --publisher files (--format markdown|html|asciidoc)")
+	fmt.Println("  validate   Lint the specs and render their pages without publishing")
+	fmt.Println("  diff       Compare two spec versions: diff [flags] <old-spec> <new-spec>")
+	fmt.Println("  watch      Publish the specs, then again whenever they change (--interval, default 5m)")
+	fmt.Println("  serve      Publish on POST /sync webhooks (--listen, SWAGFLUENCE_WEBHOOK_SECRET)")
+	fmt.Println("  clean      Delete (--prune=archive: archive) endpoint pages no longer in the spec (Confluence)")
+	fmt.Println("  help       Show this help")
 	fmt.Println("\nExample:")
-	fmt.Println("  swagfluence https://petstore.swagger.io/v2/swagger.json")
+	fmt.Println("  swagfluence publish https://petstore.swagger.io/v2/swagger.json")
+	fmt.Println("  swagfluence publish --space DOCS --parent-page 12345 ./build/openapi.yaml")
+	fmt.Println("  swagfluence export --format markdown --out ./docs ./build/openapi.yaml")
+	fmt.Println("  swagfluence validate ./build/openapi.yaml")
+	fmt.Println("  swagfluence clean --dry-run ./build/openapi.yaml")
+	fmt.Println("  swagfluence watch --interval 5m https://petstore.swagger.io/v2/swagger.json")
+	fmt.Println("  swagfluence publish --publish-from <dir>")
+	fmt.Println("  generate-spec | swagfluence publish -")
+	fmt.Println("\nFlags:")
+	fmt.Println("  --config <file>           YAML config file; environment variables and flags override it")
+	fmt.Println("  --publisher <name>        Documentation backend: confluence (default), xwiki, notion, files or stdout")
+	fmt.Println("  --dry-run                 Show what would be created/updated (with diffs) without writing anything;")
+	fmt.Println("                            clean only lists stale pages")
+	fmt.Println("  --space <key>             Confluence space key (overrides CONFLUENCE_SPACE_KEY)")
+	fmt.Println("  --parent-page <id>        Publish under this page ID (overrides CONFLUENCE_PARENT_PAGE_ID)")
+	fmt.Println("  --parent-title <title>    Publish under the page with this title instead of a page ID")
+	fmt.Println("  --create-parent           Create the --parent-title page if it does not exist")
+	fmt.Println("  --update-mode <mode>      full (default) owns whole pages; region replaces only the generated block")
+	fmt.Println("  --version-label <label>   Publish into a per-version subtree (e.g. v2)")
+	fmt.Println("  --link-versions <a,b>     Cross-link endpoint pages with these other version labels")
+	fmt.Println("  --directory <title>       Landing page listing every published API (batch default: API Directory)")
+	fmt.Println("  --prune[=archive]         Delete (or archive) endpoint pages no longer in the spec after publishing")
+	fmt.Println("  --report <file>           Write a JSON summary of the run (pages, IDs, URLs, errors) for automation")
+	fmt.Println("  --continue-on-error       Keep publishing past failing endpoint pages; list the failures at the end")
+	fmt.Println("  --attach-spec             Attach the source spec to the API page and link to it")
+	fmt.Println("  --spec-embed <macro>      Also show the attached spec: code, or the name of a viewer macro")
+	fmt.Println("  --swagger-ui <url>        Embed this Swagger UI on the API page in an iframe macro")
+	fmt.Println("  --version-message <text>  Version comment of written pages; {version} is the API version")
+	fmt.Println("  --minor-edit              Save page updates as minor edits so watchers aren't notified")
+	fmt.Println("  --auth-type <type>        Confluence auth: basic (default) or bearer (Server/Data Center PATs)")
+	fmt.Println("  --low-memory              Bound memory for very large APIs by looking pages up one at a time")
+	fmt.Println("  --state-file <file>       Remember page IDs between runs to skip page lookups")
+	fmt.Println("  --max-attempts <n>        Attempts per Confluence request when rate limited (default: 5)")
+	fmt.Println("  --max-retry-after <d>     Longest Retry-After wait before a retry (default: 5m)")
+	fmt.Println("  --collapse-properties <n> Collapse schema tables with more properties than this (default: 30, -1 never)")
+	fmt.Println("  --collapse-lines <n>      Collapse example bodies longer than this many lines (default: 80, -1 never)")
+	fmt.Println("  --confluence-api <v1|v2>  v2 publishes through the Cloud v2 API with endpoint pages as ADF")
+	fmt.Println("  --shared-models           Document schemas once on model pages and include them on endpoint pages")
+	fmt.Println("  --changelog               Publish a changelog page with the changes since the previous run")
+	fmt.Println("  --history                 Archive previous renderings under a History page when the API version changes")
+	fmt.Println("  --labels <a,b,c>          Labels applied to every generated page")
+	fmt.Println("  --tag-labels=false        Don't label endpoint pages with their operation tags")
+	fmt.Println("  --managed-labels=false    Don't label generated pages with swagfluence and the API name")
+	fmt.Println("  --order-pages=false       Don't reorder endpoint pages in the page tree to match the spec")
+	fmt.Println("  --spec-format <fmt>       Force the spec format (json|yaml|apib) instead of auto-detecting it")
+	fmt.Println("  --spec-version <2|3|3.1>  Force the spec version for documents missing it")
+	fmt.Println("  --title-template <tmpl>   Endpoint page title template, e.g. '{{.APITitle}} - {{.Method}} {{.Path}}'")
+	fmt.Println("  --title-prefix <text>     Text added before every endpoint page title, e.g. '[Payments] '")
+	fmt.Println("  --title-suffix <text>     Text added after every endpoint page title, e.g. ' (Payments)'")
+	fmt.Println("  --include-tags <a,b>      Only document endpoints with one of these tags")
+	fmt.Println("  --exclude-tags <a,b>      Leave out endpoints with any of these tags")
+	fmt.Println("  --include-paths <globs>   Only document paths matching these globs (* within a segment, ** across)")
+	fmt.Println("  --methods <get,post>      Only document these HTTP methods")
+	fmt.Println("  --include-hidden          Document operations and fields marked x-internal or x-hidden")
+	fmt.Println("  --overlay <file>          Apply an OpenAPI Overlay document before rendering (repeatable)")
+	fmt.Println("  --insecure                Skip TLS certificate verification (spec and Confluence)")
+	fmt.Println("  --ca-cert <file>          Trust an additional PEM CA bundle (spec and Confluence)")
+	fmt.Println("  --proxy <url>             Send spec and Confluence requests through this proxy")
+	fmt.Println("  --template <file>         Replace the endpoint page layout with a text/template file")
+	fmt.Println("  --template-block <file>   Override named page blocks (header, parameters, responses, footer, ...), repeatable")
+	fmt.Println("  --samples <list>          Code samples on endpoint pages: curl, httpie, python, javascript, go or none (default curl)")
+	fmt.Println("  --har <file>              Use sanitized recorded payloads as examples (repeatable)")
+	fmt.Println("  --har-redact <a,b>        Extra field names masked in recorded examples")
+	fmt.Println("  --faker                   Fill generated examples with realistic names, addresses and phone numbers")
+	fmt.Println("  --example-depth <n>       Levels of nesting shown in generated examples (default 10)")
+	fmt.Println("  --example-items <n>       Items in generated example arrays (default 1)")
+	fmt.Println("  --smoke-url <url>         Execute example requests against this server and annotate pages")
+	fmt.Println("  --smoke-writes            Also execute non-GET example requests (sandbox only)")
+	fmt.Println("  --smoke-param <n=v>       Parameter value used in example requests (repeatable)")
+	fmt.Println("  --smoke-header <h: v>     Extra header sent with example requests (repeatable)")
+	fmt.Println("  --lint                    Check documentation lint rules before publishing")
+	fmt.Println("  --lint-fail-on <severity> Fail before publishing on findings at warning or error")
+	fmt.Println("  --lint-rule <name=sev>    Override a rule severity (off|info|warning|error), repeatable")
+	fmt.Println("  --baseline-spec <url>     Compare against a previous spec and report breaking changes")
+	fmt.Println("  --jira-issue-type <type>  Issue type for breaking-change issues (default: Task)")
+	fmt.Println("  --export-dir <dir>        Directory written by --publisher files (default: docs/confluence)")
+	fmt.Println("  --out <dir>               Same as --export-dir")
+	fmt.Println("  --format <fmt>            What --publisher files writes: storage (default), markdown (default dir: docs/api)")
+	fmt.Println("                            html (default dir: docs/site) or asciidoc (default dir: docs/asciidoc)")
+	fmt.Println("  --publish-from <dir>      Publish a reviewed --publisher files export instead of a spec")
+	fmt.Println("  --interval <duration>     Time between spec polls of watch (default: 5m)")
+	fmt.Println("  --listen <addr>           Address serve listens on (default: :8080)")
+	fmt.Println("  --pdf-out <dir>           Export the published pages to PDF files (Confluence)")
+	fmt.Println("  --pdf-attach              Attach the PDF exports to the parent page")
+	fmt.Println("  --ci github               Emit GitHub Actions annotations, job summary and outputs")
+	fmt.Println("  --collection <format>     Also export a request collection (insomnia|bruno)")
+	fmt.Println("  --collection-out <path>   Collection file (insomnia) or directory (bruno)")
 	fmt.Println("\nEnvironment variables (optional for Confluence integration):")
+	fmt.Println("  SWAGFLUENCE_CONFIG        - (Optional) YAML config file, like --config")
 	fmt.Println("  CONFLUENCE_BASE_URL       - Base URL of your Confluence instance")
 	fmt.Println("  CONFLUENCE_USERNAME       - Your Confluence username/email")
-	fmt.Println("  CONFLUENCE_API_TOKEN      - Your Confluence API token")
+	fmt.Println("  CONFLUENCE_API_TOKEN      - Your Confluence API token (or personal access token)")
+	fmt.Println("  CONFLUENCE_AUTH_TYPE      - (Optional) basic (default) or bearer for Server/Data Center PATs")
 	fmt.Println("  CONFLUENCE_SPACE_KEY      - Space key where pages will be created")
 	fmt.Println("  CONFLUENCE_PARENT_PAGE_ID - (Optional) Parent page ID for documentation")
+	fmt.Println("  CONFLUENCE_PARENT_PAGE_TITLE - (Optional) Parent page title, resolved to an ID at startup")
+	fmt.Println("  CONFLUENCE_CREATE_PARENT  - Create the parent page by title when missing (true/false)")
+	fmt.Println("  CONFLUENCE_TAG_LABELS     - Label endpoint pages with their tags (default: true)")
+	fmt.Println("  CONFLUENCE_MANAGED_LABELS - Label pages with swagfluence and the API name (default: true)")
+	fmt.Println("  CONFLUENCE_ORDER_PAGES    - Order endpoint pages in the page tree as in the spec (default: true)")
+	fmt.Println("  CONFLUENCE_STATE_FILE     - (Optional) File remembering page IDs between runs")
+	fmt.Println("  CONFLUENCE_MAX_ATTEMPTS   - (Optional) Attempts per request when rate limited (default: 5)")
+	fmt.Println("  CONFLUENCE_MAX_RETRY_AFTER - (Optional) Longest Retry-After wait before a retry (default: 5m)")
+	fmt.Println("  CONFLUENCE_API_VERSION    - (Optional) REST API to publish through: v1 (default) or v2")
+	fmt.Println("  CONFLUENCE_SHARED_MODELS  - Include shared schemas from model pages (true/false)")
+	fmt.Println("  CONFLUENCE_ATTACH_SPEC    - Attach the source spec to the API page (true/false)")
+	fmt.Println("  CONFLUENCE_UPDATE_MODE    - (Optional) full (default) or region to replace only the generated block")
+	fmt.Println("  CONFLUENCE_CHANGELOG      - Publish a changelog page since the previous run (true/false)")
+	fmt.Println("  CONFLUENCE_HISTORY        - Archive previous renderings on version changes (true/false)")
+	fmt.Println("  CONFLUENCE_LABELS         - (Optional) Comma-separated labels for generated pages")
 	fmt.Println("  CONFLUENCE_ENABLED        - Whether write to Confluence")
-}
\ No newline at end of file
+	fmt.Println("\nXWiki publisher (--publisher xwiki):")
+	fmt.Println("  XWIKI_BASE_URL            - Base URL of your XWiki instance")
+	fmt.Println("  XWIKI_WIKI                - Wiki name (default: xwiki)")
+	fmt.Println("  XWIKI_SPACE               - Space where pages will be created")
+	fmt.Println("  XWIKI_PARENT_PAGE         - (Optional) Parent page name within the space")
+	fmt.Println("  XWIKI_USERNAME            - XWiki username")
+	fmt.Println("  XWIKI_PASSWORD            - XWiki password")
+	fmt.Println("\nNotion publisher (--publisher notion):")
+	fmt.Println("  NOTION_TOKEN              - Notion integration token")
+	fmt.Println("  NOTION_PARENT_PAGE_ID     - Page the endpoint database is created under")
+	fmt.Println("  NOTION_DATABASE_ID        - (Optional) Existing endpoint database to reuse")
+	fmt.Println("\nJira issues for breaking changes (with --baseline-spec):")
+	fmt.Println("  JIRA_BASE_URL             - Base URL of your Jira instance")
+	fmt.Println("  JIRA_USERNAME             - Jira username/email")
+	fmt.Println("  JIRA_API_TOKEN            - Jira API token")
+	fmt.Println("  JIRA_PROJECT_KEY          - Project the issue is created in")
+	fmt.Println("  JIRA_ISSUE_TYPE           - (Optional) Issue type (default: Task)")
+	fmt.Println("  JIRA_LABELS               - (Optional) Comma-separated issue labels")
+	fmt.Println("\nWebhook server (serve):")
+	fmt.Println("  SWAGFLUENCE_WEBHOOK_SECRET - Shared secret sync requests send as a bearer token")
+	fmt.Println("  SWAGFLUENCE_LISTEN        - (Optional) Address to listen on, like --listen")
+}
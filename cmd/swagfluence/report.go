@@ -0,0 +1,31 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ahmadimt/SwagFluence/pkg/converter"
+)
+
+// runReport is the JSON summary written by --report, one entry per spec
+type runReport struct {
+	Specs  []*converter.Report `json:"specs"`
+	Failed int                 `json:"failed"`
+}
+
+// writeRunReport writes the reports of a run as indented JSON
+func writeRunReport(path string, reports []*converter.Report, failed int) error {
+	if reports == nil {
+		reports = []*converter.Report{}
+	}
+
+	data, err := json.MarshalIndent(runReport{Specs: reports, Failed: failed}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode run report: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write run report: %w", err)
+	}
+	return nil
+}
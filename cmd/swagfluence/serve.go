@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/server"
+)
+
+// runServe implements "swagfluence serve", publishing the documentation
+// whenever a sync webhook arrives, until interrupted
+func runServe(ctx context.Context, cfg *config.Config, args []string) int {
+	if len(args) == 0 {
+		args = cfg.SpecSources()
+	}
+
+	srv, err := server.New(cfg.Server.Secret, func(ctx context.Context, job server.Job) error {
+		// Each sync starts from the loaded settings; the request only
+		// overrides the spec and space
+		jobCfg := *cfg
+		specs := args
+		if job.Spec != "" {
+			specs = []string{job.Spec}
+		}
+		if len(specs) == 0 {
+			return errors.New("no spec given in the request or the configuration")
+		}
+		if job.Space != "" && job.Space != cfg.Confluence.SpaceKey {
+			// Page IDs belong to one space; a parent title is looked up
+			// in the requested one
+			jobCfg.Confluence.SpaceKey = job.Space
+			jobCfg.Confluence.ParentPageID = ""
+			jobCfg.Normalize()
+		}
+
+		fmt.Printf("\nSync requested: %v\n", specs)
+		if code := runPublish(ctx, &jobCfg, specs); code != exitCodeSuccess {
+			return errors.New("publishing failed")
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v (set SWAGFLUENCE_WEBHOOK_SECRET or server.secret)\n", err)
+		return exitCodeError
+	}
+
+	if err := srv.ListenAndServe(ctx, cfg.Server.Listen); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitCodeError
+	}
+	return exitCodeSuccess
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/httpclient"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+	"github.com/ahmadimt/SwagFluence/internal/watch"
+)
+
+// runWatch implements "swagfluence watch", publishing the specs and
+// publishing them again whenever their content changes, until interrupted
+func runWatch(ctx context.Context, cfg *config.Config, args []string) int {
+	args, ok := specArgs(cfg, args)
+	if !ok {
+		return exitCodeError
+	}
+	for _, source := range args {
+		if source == swagger.StdinSource {
+			fmt.Fprintln(os.Stderr, "Error: watch needs a URL or file, not standard input")
+			return exitCodeError
+		}
+	}
+
+	httpClient, err := httpclient.New(cfg.Spec.TLS)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitCodeError
+	}
+	pollers := make([]*watch.Poller, 0, len(args))
+	for _, source := range args {
+		pollers = append(pollers, watch.NewPoller(source, httpClient))
+	}
+
+	fmt.Printf("Watching %d specs every %s (Ctrl+C to stop)\n", len(args), cfg.Watch.Interval)
+	err = watch.Run(ctx, pollers, cfg.Watch.Interval, func(ctx context.Context, sources []string) error {
+		fmt.Printf("\nChanged: %v\n", sources)
+		if code := runPublish(ctx, cfg, sources); code != exitCodeSuccess {
+			return fmt.Errorf("publishing failed")
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitCodeError
+	}
+	return exitCodeSuccess
+}
@@ -4,4 +4,7 @@ go 1.24.0
 
 toolchain go1.24.10
 
-require golang.org/x/text v0.31.0
+require (
+	golang.org/x/text v0.31.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,226 @@
+package asciidoc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/docfile"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// format renders pages as AsciiDoc
+type format struct{}
+
+// Ext returns the AsciiDoc file extension
+func (format) Ext() string {
+	return ext
+}
+
+// Endpoint renders an endpoint page as AsciiDoc
+func (format) Endpoint(page *docfile.EndpointPage) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "= %s\n\n", page.Title)
+	fmt.Fprintf(&sb, "%s\n\n", code(page.Method+" "+page.Path))
+
+	if page.Summary != "" {
+		fmt.Fprintf(&sb, "%s\n\n", page.Summary)
+	}
+	if page.Description != "" {
+		fmt.Fprintf(&sb, "%s\n\n", page.Description)
+	}
+	if page.OperationID != "" {
+		fmt.Fprintf(&sb, "*Operation ID:* %s\n\n", code(page.OperationID))
+	}
+	if len(page.Tags) > 0 {
+		fmt.Fprintf(&sb, "*Tags:* %s\n\n", strings.Join(page.Tags, ", "))
+	}
+
+	sb.WriteString("== Parameters\n\n")
+	writeParameters(&sb, page.Parameters)
+
+	if request := page.Request; request != nil {
+		sb.WriteString("== Request Body\n\n")
+		if request.ContentType != "" {
+			fmt.Fprintf(&sb, "Content type: %s\n\n", code(request.ContentType))
+		}
+		if request.Schema != nil {
+			writeSchema(&sb, request.Schema)
+			writeRequestExample(&sb, request.Minimal, request.Full)
+		}
+	}
+
+	if len(page.Responses) > 0 {
+		sb.WriteString("== Responses\n\n")
+		for _, response := range page.Responses {
+			fmt.Fprintf(&sb, "=== %s\n\n", response.Code)
+			if response.Description != "" {
+				fmt.Fprintf(&sb, "%s\n\n", response.Description)
+			}
+			if response.Schema != nil {
+				writeSchema(&sb, response.Schema)
+				writeExample(&sb, response.Example)
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// Page renders a generic page, reducing storage markup to plain text
+// paragraphs
+func (format) Page(title, content string) string {
+	return fmt.Sprintf("= %s\n\n%s", title, docfile.StorageText(content))
+}
+
+// Document returns the page content as is; AsciiDoc pages have no layout
+func (format) Document(title string, entries []docfile.Entry, i int) (string, error) {
+	return entries[i].Content(), nil
+}
+
+// Index renders the index page linking every written page with xrefs
+func (format) Index(title string, entries []docfile.Entry) (string, error) {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "= %s\n\n", title)
+	var endpoints, pages []docfile.Entry
+	for _, entry := range entries {
+		if entry.Method != "" {
+			endpoints = append(endpoints, entry)
+		} else {
+			pages = append(pages, entry)
+		}
+	}
+
+	if len(endpoints) > 0 {
+		sb.WriteString("== Endpoints\n\n")
+		sb.WriteString("[cols=\"1,3,3\",options=\"header\"]\n|===\n")
+		sb.WriteString("|Method |Path |Page\n")
+		for _, entry := range endpoints {
+			fmt.Fprintf(&sb, "\n|%s\n|%s\n|%s\n", entry.Method, code(entry.Path), cell(xref(entry)))
+		}
+		sb.WriteString("|===\n\n")
+	}
+	if len(pages) > 0 {
+		sb.WriteString("== Pages\n\n")
+		for _, entry := range pages {
+			fmt.Fprintf(&sb, "* %s\n", xref(entry))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String(), nil
+}
+
+// writeParameters writes the parameters as a table
+func writeParameters(sb *strings.Builder, params []docfile.Parameter) {
+	if len(params) == 0 {
+		sb.WriteString("This endpoint requires no parameters.\n\n")
+		return
+	}
+
+	sb.WriteString("[cols=\"2,1,2,1,4\",options=\"header\"]\n|===\n")
+	sb.WriteString("|Name |In |Type |Required |Description\n")
+	for _, param := range params {
+		fmt.Fprintf(sb, "\n|%s\n|%s\n|%s\n|%s\n|%s\n",
+			code(param.Name), param.In, typeName(param.Type, param.Format), yesNo(param.Required), cell(param.Description))
+	}
+	sb.WriteString("|===\n\n")
+}
+
+// writeSchema writes the properties of a schema as a table, or the variants
+// of a oneOf/anyOf schema
+func writeSchema(sb *strings.Builder, table *docfile.SchemaTable) {
+	if table.ArrayOf != "" {
+		fmt.Fprintf(sb, "Array of %s.\n\n", code(table.ArrayOf))
+	}
+
+	if len(table.Fields) > 0 {
+		sb.WriteString("[cols=\"2,2,1,4\",options=\"header\"]\n|===\n")
+		sb.WriteString("|Field |Type |Required |Description\n")
+		for _, field := range table.Fields {
+			fmt.Fprintf(sb, "\n|%s\n|%s\n|%s\n|%s\n",
+				code(field.Name), propertyType(field.Property), yesNo(field.Required), cell(field.Property.Description))
+		}
+		sb.WriteString("|===\n\n")
+	}
+
+	for _, group := range table.Variants {
+		fmt.Fprintf(sb, "*%s:*\n\n", group.Label)
+		for _, variant := range group.Variants {
+			fmt.Fprintf(sb, "* %s", variant.Name)
+			if variant.Type != "" {
+				fmt.Fprintf(sb, " (%s)", code(variant.Type))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// writeExample writes an example JSON listing block
+func writeExample(sb *strings.Builder, exampleJSON string) {
+	if exampleJSON == "" {
+		return
+	}
+	fmt.Fprintf(sb, "[source,json]\n----\n%s\n----\n\n", exampleJSON)
+}
+
+// writeRequestExample writes a generated request example, as minimal and
+// full bodies in collapsible blocks when they differ
+func writeRequestExample(sb *strings.Builder, minimal, full string) {
+	if minimal == "" {
+		writeExample(sb, full)
+		return
+	}
+	fmt.Fprintf(sb, ".Minimal example (required fields only)\n[%%collapsible]\n====\n[source,json]\n----\n%s\n----\n====\n\n", minimal)
+	fmt.Fprintf(sb, ".Full example\n[%%collapsible]\n====\n[source,json]\n----\n%s\n----\n====\n\n", full)
+}
+
+// xref links a written page. Antora resolves the file relative to the pages
+// family; Asciidoctor turns it into a link to the converted file.
+func xref(entry docfile.Entry) string {
+	return fmt.Sprintf("xref:%s[%s]", entry.File, strings.ReplaceAll(entry.Title, "]", `\]`))
+}
+
+// propertyType describes the type of a property
+func propertyType(prop swagger.Property) string {
+	switch {
+	case prop.Ref != "":
+		return code(swagger.ExtractRefName(prop.Ref))
+	case prop.Type == "array" && prop.Items != nil:
+		return "array of " + code(docfile.ItemName(prop.Items))
+	default:
+		return typeName(prop.Type, prop.Format)
+	}
+}
+
+// typeName formats a type with its format, e.g. `integer` (int64)
+func typeName(typ, format string) string {
+	if typ == "" {
+		return ""
+	}
+	if format != "" {
+		return fmt.Sprintf("%s (%s)", code(typ), format)
+	}
+	return code(typ)
+}
+
+// code formats text as literal monospace, so path templates such as {id}
+// aren't read as attribute references
+func code(text string) string {
+	return "`+" + text + "+`"
+}
+
+// cell escapes text for a table cell
+func cell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,23 @@
+package asciidoc
+
+import (
+	"github.com/ahmadimt/SwagFluence/internal/docfile"
+)
+
+// IndexFile is the name of the index page linking every written page
+const IndexFile = "index" + ext
+
+// DefaultDir is used when no output directory is configured
+const DefaultDir = "docs/asciidoc"
+
+const ext = ".adoc"
+
+// NewWriter creates a publisher that renders the parsed spec as AsciiDoc
+// files in dir, one per endpoint plus an index that links them with xrefs,
+// for Asciidoctor and Antora
+func NewWriter(dir string) (*docfile.Writer, error) {
+	if dir == "" {
+		dir = DefaultDir
+	}
+	return docfile.NewWriter(dir, format{})
+}
@@ -0,0 +1,130 @@
+package ci
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Page is a published page as shown in the job summary
+type Page struct {
+	Title  string
+	Method string
+	Path   string
+	URL    string
+	Error  string
+}
+
+// Summary is the data rendered into the GitHub job summary
+type Summary struct {
+	APITitle      string
+	APIVersion    string
+	ParentPageURL string
+	Pages         []Page
+}
+
+// GitHub emits GitHub Actions workflow commands, job summaries and step outputs
+type GitHub struct {
+	out         io.Writer
+	summaryPath string
+	outputPath  string
+}
+
+// NewGitHub creates a GitHub Actions reporter using the runner's environment
+func NewGitHub(out io.Writer) *GitHub {
+	return &GitHub{
+		out:         out,
+		summaryPath: os.Getenv("GITHUB_STEP_SUMMARY"),
+		outputPath:  os.Getenv("GITHUB_OUTPUT"),
+	}
+}
+
+// Error emits an error annotation, titled unless title is empty
+func (g *GitHub) Error(title, message string) {
+	g.annotate("error", title, message)
+}
+
+// Warning emits a warning annotation, titled unless title is empty
+func (g *GitHub) Warning(title, message string) {
+	g.annotate("warning", title, message)
+}
+
+// annotate emits a workflow command annotating the run
+func (g *GitHub) annotate(command, title, message string) {
+	properties := ""
+	if title != "" {
+		properties = " title=" + escapeProperty(title)
+	}
+	fmt.Fprintf(g.out, "::%s%s::%s\n", command, properties, escapeData(message))
+}
+
+// SetOutput sets a step output. It is a no-op outside of GitHub Actions.
+func (g *GitHub) SetOutput(name, value string) error {
+	if g.outputPath == "" {
+		return nil
+	}
+	return appendFile(g.outputPath, fmt.Sprintf("%s=%s\n", name, value))
+}
+
+// WriteSummary appends a markdown table of pages to the job summary. It is
+// a no-op outside of GitHub Actions.
+func (g *GitHub) WriteSummary(summary Summary) error {
+	if g.summaryPath == "" {
+		return nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s %s\n\n", summary.APITitle, summary.APIVersion)
+	if summary.ParentPageURL != "" {
+		fmt.Fprintf(&sb, "Documentation: %s\n\n", summary.ParentPageURL)
+	}
+
+	sb.WriteString("| Status | Method | Path | Page |\n")
+	sb.WriteString("|--------|--------|------|------|\n")
+	for _, page := range summary.Pages {
+		status := "✅"
+		link := escapeCell(page.Title)
+		if page.URL != "" {
+			link = fmt.Sprintf("[%s](%s)", escapeCell(page.Title), page.URL)
+		}
+		if page.Error != "" {
+			status = "❌"
+			link += " – " + escapeCell(page.Error)
+		}
+		fmt.Fprintf(&sb, "| %s | %s | `%s` | %s |\n", status, page.Method, page.Path, link)
+	}
+	sb.WriteString("\n")
+
+	return appendFile(g.summaryPath, sb.String())
+}
+
+// escapeData escapes a workflow command message
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a workflow command property, which also ends at
+// a colon or comma
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// escapeCell keeps text from breaking a markdown table row
+func escapeCell(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
+
+// appendFile appends content to a file created by the runner
+func appendFile(path, content string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(content); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
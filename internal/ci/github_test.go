@@ -0,0 +1,135 @@
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGitHub_Annotations(t *testing.T) {
+	tests := []struct {
+		name string
+		emit func(g *GitHub)
+		want string
+	}{
+		{"error", func(g *GitHub) { g.Error("", "failed") }, "::error::failed\n"},
+		{"warning", func(g *GitHub) { g.Warning("", "slow") }, "::warning::slow\n"},
+		{
+			"message escaping",
+			func(g *GitHub) { g.Error("", "100% done\r\nnext: a, b") },
+			"::error::100%25 done%0D%0Anext: a, b\n",
+		},
+		{
+			"title escaping",
+			func(g *GitHub) { g.Error("GET /a,b: 50%\n", "denied") },
+			"::error title=GET /a%2Cb%3A 50%25%0A::denied\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			tt.emit(NewGitHub(&out))
+			if out.String() != tt.want {
+				t.Errorf("annotation = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestGitHub_WriteSummary(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary Summary
+		want    string
+	}{
+		{
+			name: "published and failed pages",
+			summary: Summary{
+				APITitle: "Pets", APIVersion: "1.0", ParentPageURL: "https://wiki/pets",
+				Pages: []Page{
+					{Title: "List Pets", Method: "GET", Path: "/pets", URL: "https://wiki/list"},
+					{Title: "A|B", Method: "POST", Path: "/pets", Error: "bad\nrequest"},
+				},
+			},
+			want: "## Pets 1.0\n\nDocumentation: https://wiki/pets\n\n" +
+				"| Status | Method | Path | Page |\n" +
+				"|--------|--------|------|------|\n" +
+				"| ✅ | GET | `/pets` | [List Pets](https://wiki/list) |\n" +
+				"| ❌ | POST | `/pets` | A\\|B – bad request |\n\n",
+		},
+		{
+			name:    "no parent page",
+			summary: Summary{APITitle: "Pets", APIVersion: "1.0"},
+			want: "## Pets 1.0\n\n" +
+				"| Status | Method | Path | Page |\n" +
+				"|--------|--------|------|------|\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "summary.md")
+			t.Setenv("GITHUB_STEP_SUMMARY", path)
+			if err := os.WriteFile(path, []byte("earlier step\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := NewGitHub(&strings.Builder{}).WriteSummary(tt.summary); err != nil {
+				t.Fatalf("WriteSummary() error = %v", err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := string(data), "earlier step\n"+tt.want; got != want {
+				t.Errorf("summary = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestGitHub_SetOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		outputs [][2]string
+		want    string
+	}{
+		{"single", [][2]string{{"pages_failed", "0"}}, "pages_failed=0\n"},
+		{"appended", [][2]string{{"parent_page_id", "42"}, {"parent_page_url", "https://wiki/42"}},
+			"parent_page_id=42\nparent_page_url=https://wiki/42\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "output")
+			t.Setenv("GITHUB_OUTPUT", path)
+			gh := NewGitHub(&strings.Builder{})
+			for _, output := range tt.outputs {
+				if err := gh.SetOutput(output[0], output[1]); err != nil {
+					t.Fatalf("SetOutput() error = %v", err)
+				}
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("outputs = %q, want %q", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitHub_OutsideActions(t *testing.T) {
+	t.Setenv("GITHUB_OUTPUT", "")
+	t.Setenv("GITHUB_STEP_SUMMARY", "")
+	gh := NewGitHub(&strings.Builder{})
+	if err := gh.SetOutput("pages_failed", "0"); err != nil {
+		t.Errorf("SetOutput() error = %v", err)
+	}
+	if err := gh.WriteSummary(Summary{APITitle: "Pets"}); err != nil {
+		t.Errorf("WriteSummary() error = %v", err)
+	}
+}
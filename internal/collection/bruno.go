@@ -0,0 +1,143 @@
+package collection
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/docfile"
+	"github.com/ahmadimt/SwagFluence/internal/example"
+)
+
+// WriteBruno writes a Bruno collection directory with one .bru file per request
+func WriteBruno(output, apiTitle string, requests []Request) error {
+	if err := os.MkdirAll(output, 0o755); err != nil {
+		return fmt.Errorf("failed to create bruno collection: %w", err)
+	}
+
+	manifest, err := json.MarshalIndent(map[string]string{
+		"version": "1",
+		"name":    apiTitle,
+		"type":    "collection",
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal bruno.json: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(output, "bruno.json"), manifest, 0o644); err != nil {
+		return fmt.Errorf("failed to write bruno.json: %w", err)
+	}
+
+	envDir := filepath.Join(output, "environments")
+	if err := os.MkdirAll(envDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create bruno environments: %w", err)
+	}
+	env := "vars {\n  baseUrl: \n}\n"
+	if err := os.WriteFile(filepath.Join(envDir, "default.bru"), []byte(env), 0o644); err != nil {
+		return fmt.Errorf("failed to write bruno environment: %w", err)
+	}
+
+	used := make(map[string]bool)
+	for i, req := range requests {
+		dir := output
+		if req.Folder != "" {
+			dir = filepath.Join(output, docfile.FileName(req.Folder, "request"))
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return fmt.Errorf("failed to create bruno folder: %w", err)
+			}
+		}
+
+		name := docfile.FileName(req.Name, "request")
+		file := filepath.Join(dir, name+".bru")
+		for n := 2; used[file]; n++ {
+			file = filepath.Join(dir, fmt.Sprintf("%s %d.bru", name, n))
+		}
+		used[file] = true
+
+		if err := os.WriteFile(file, []byte(formatBruRequest(req, i+1)), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+// formatBruRequest renders a request in the Bru markup language
+func formatBruRequest(req Request, seq int) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "meta {\n  name: %s\n  type: http\n  seq: %d\n}\n\n", req.Name, seq)
+
+	path := req.Path
+	for _, name := range req.PathParams {
+		path = strings.ReplaceAll(path, "{"+name+"}", ":"+name)
+	}
+
+	bodyMode := "none"
+	switch {
+	case example.IsMultipart(req.ContentType) && req.Form != nil:
+		bodyMode = "multipartForm"
+	case example.IsForm(req.ContentType) && req.Form != nil:
+		bodyMode = "formUrlEncoded"
+	case req.Body != "":
+		bodyMode = "json"
+	}
+	fmt.Fprintf(&sb, "%s {\n  url: {{baseUrl}}%s\n  body: %s\n  auth: none\n}\n",
+		strings.ToLower(req.Method), path, bodyMode)
+
+	writeBruBlock(&sb, "params:query", req.QueryParams)
+	writeBruBlock(&sb, "params:path", req.PathParams)
+
+	headers := append([]string(nil), req.Headers...)
+	// Bruno sets the multipart boundary itself
+	if req.ContentType != "" && bodyMode != "multipartForm" {
+		headers = append(headers, "Content-Type: "+req.ContentType)
+	}
+	writeBruBlock(&sb, "headers", headers)
+
+	switch bodyMode {
+	case "json":
+		sb.WriteString("\nbody:json {\n")
+		for _, line := range strings.Split(req.Body, "\n") {
+			sb.WriteString("  " + line + "\n")
+		}
+		sb.WriteString("}\n")
+	case "formUrlEncoded":
+		writeBruBlock(&sb, "body:form-urlencoded", bruFormFields(req.Form))
+	case "multipartForm":
+		writeBruBlock(&sb, "body:multipart-form", bruFormFields(req.Form))
+	}
+
+	return sb.String()
+}
+
+// bruFormFields returns the entries of a form body block; file fields
+// reference a file to pick in Bruno
+func bruFormFields(fields []example.FormField) []string {
+	entries := make([]string, 0, len(fields))
+	for _, field := range fields {
+		value := field.Value
+		if field.File {
+			value = "@file(" + field.Value + ")"
+		}
+		entries = append(entries, field.Name+": "+value)
+	}
+	return entries
+}
+
+// writeBruBlock writes a key/value block; entries without a value get an empty one
+func writeBruBlock(sb *strings.Builder, name string, entries []string) {
+	if len(entries) == 0 {
+		return
+	}
+
+	fmt.Fprintf(sb, "\n%s {\n", name)
+	for _, entry := range entries {
+		if !strings.Contains(entry, ":") {
+			entry += ": "
+		}
+		fmt.Fprintf(sb, "  %s\n", entry)
+	}
+	sb.WriteString("}\n")
+}
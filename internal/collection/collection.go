@@ -0,0 +1,89 @@
+package collection
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/example"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// Supported collection formats
+const (
+	FormatInsomnia = "insomnia"
+	FormatBruno    = "bruno"
+)
+
+// Request is a client-agnostic description of a single API request
+type Request struct {
+	Name        string
+	Folder      string
+	Method      string
+	Path        string
+	PathParams  []string
+	QueryParams []string
+	Headers     []string
+	ContentType string
+	Body        string
+	// Form holds the fields of a form body, urlencoded or multipart
+	Form []example.FormField
+}
+
+// BuildRequests converts endpoints into collection requests. Request
+// bodies are filled with generated example JSON, or form fields for form
+// bodies.
+func BuildRequests(endpoints []swagger.EndpointInfo, resolver *swagger.Resolver, gen *example.Generator) []Request {
+	requests := make([]Request, 0, len(endpoints))
+
+	for _, endpoint := range endpoints {
+		op := endpoint.Operation
+		req := Request{
+			Name:   endpoint.Title,
+			Method: strings.ToUpper(endpoint.Method),
+			Path:   endpoint.Path,
+		}
+		if len(op.Tags) > 0 {
+			req.Folder = op.Tags[0]
+		}
+
+		for _, param := range op.Parameters {
+			switch param.In {
+			case "path":
+				req.PathParams = append(req.PathParams, param.Name)
+			case "query":
+				req.QueryParams = append(req.QueryParams, param.Name)
+			case "header":
+				req.Headers = append(req.Headers, param.Name)
+			}
+		}
+
+		req.ContentType, req.Body = gen.GenerateRequestExample(op, resolver)
+		if example.IsForm(req.ContentType) {
+			_, req.Form = gen.GenerateFormExample(op, resolver)
+		}
+		requests = append(requests, req)
+	}
+
+	return requests
+}
+
+// Write exports the requests in the given format to output, which is a
+// file for Insomnia and a directory for Bruno
+func Write(format, output, apiTitle string, requests []Request) error {
+	switch format {
+	case FormatInsomnia:
+		return WriteInsomnia(output, apiTitle, requests)
+	case FormatBruno:
+		return WriteBruno(output, apiTitle, requests)
+	default:
+		return fmt.Errorf("unsupported collection format %q (expected insomnia or bruno)", format)
+	}
+}
+
+// DefaultOutput returns the default output location for a format
+func DefaultOutput(format string) string {
+	if format == FormatBruno {
+		return "bruno"
+	}
+	return "insomnia.json"
+}
@@ -0,0 +1,208 @@
+package collection
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/example"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func testRequests() []Request {
+	endpoints := []swagger.EndpointInfo{
+		{
+			Path:   "/users/{id}",
+			Method: "put",
+			Title:  "Update User",
+			Operation: swagger.Operation{
+				Tags: []string{"users"},
+				Parameters: []swagger.Parameter{
+					{Name: "id", In: "path"},
+					{Name: "dryRun", In: "query"},
+					{Name: "body", In: "body", Schema: &swagger.Schema{
+						Type:       "object",
+						Properties: map[string]swagger.Property{"name": {Type: "string"}},
+					}},
+				},
+			},
+		},
+	}
+
+	return BuildRequests(endpoints, swagger.NewResolver(&swagger.Spec{}), example.NewGenerator())
+}
+
+// form returns a request body of the given content type with an object
+// schema of props
+func form(contentType string, props map[string]swagger.Property) *swagger.RequestBody {
+	return &swagger.RequestBody{Content: map[string]swagger.MediaType{
+		contentType: {Schema: &swagger.Schema{Type: "object", Properties: props}},
+	}}
+}
+
+// bodyRequest builds the request of an operation sending body
+func bodyRequest(body *swagger.RequestBody) Request {
+	endpoints := []swagger.EndpointInfo{{
+		Path: "/pets", Method: "post", Title: "Create Pet",
+		Operation: swagger.Operation{RequestBody: body},
+	}}
+	return BuildRequests(endpoints, swagger.NewResolver(&swagger.Spec{}), example.NewGenerator())[0]
+}
+
+func TestWriteInsomnia(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "insomnia.json")
+	if err := WriteInsomnia(output, "Test API", testRequests()); err != nil {
+		t.Fatalf("WriteInsomnia() error = %v", err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+
+	var export insomniaExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatalf("failed to parse export: %v", err)
+	}
+
+	var request *insomniaResource
+	for i := range export.Resources {
+		if export.Resources[i].Type == "request" {
+			request = &export.Resources[i]
+		}
+	}
+	if request == nil {
+		t.Fatal("expected a request resource")
+	}
+
+	if request.URL != "{{ _.base_url }}/users/{{ _.id }}" {
+		t.Errorf("unexpected url %q", request.URL)
+	}
+	if request.Body == nil || !strings.Contains(request.Body.Text, "\"name\"") {
+		t.Errorf("expected example body with name field, got %+v", request.Body)
+	}
+}
+
+func TestWriteBruno(t *testing.T) {
+	output := t.TempDir()
+	if err := WriteBruno(output, "Test API", testRequests()); err != nil {
+		t.Fatalf("WriteBruno() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(output, "users", "Update User.bru"))
+	if err != nil {
+		t.Fatalf("failed to read request file: %v", err)
+	}
+
+	content := string(data)
+	for _, want := range []string{"put {", "url: {{baseUrl}}/users/:id", "params:query {", "body:json {"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in bru file:\n%s", want, content)
+		}
+	}
+}
+
+func TestFormatBruRequest_Body(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    *swagger.RequestBody
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "urlencoded",
+			body: form("application/x-www-form-urlencoded", map[string]swagger.Property{
+				"name": {Type: "string", Example: "Rex"},
+			}),
+			want: []string{"body: formUrlEncoded", "Content-Type: application/x-www-form-urlencoded",
+				"body:form-urlencoded {\n  name: Rex\n}"},
+			notWant: []string{"body:json"},
+		},
+		{
+			name: "multipart",
+			body: form("multipart/form-data", map[string]swagger.Property{
+				"photo": {Type: "string", Format: "binary"},
+			}),
+			want:    []string{"body: multipartForm", "body:multipart-form {\n  photo: @file(path/to/file)\n}"},
+			notWant: []string{"body:json", "Content-Type"},
+		},
+		{
+			name: "json",
+			body: form("application/json", map[string]swagger.Property{
+				"name": {Type: "string"},
+			}),
+			want:    []string{"body: json", "Content-Type: application/json", "body:json {"},
+			notWant: []string{"body:form-urlencoded", "body:multipart-form"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := formatBruRequest(bodyRequest(tt.body), 1)
+			for _, want := range tt.want {
+				if !strings.Contains(content, want) {
+					t.Errorf("expected %q in bru file:\n%s", want, content)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(content, notWant) {
+					t.Errorf("unexpected %q in bru file:\n%s", notWant, content)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteInsomnia_FormBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body *swagger.RequestBody
+		want insomniaBody
+	}{
+		{
+			name: "urlencoded",
+			body: form("application/x-www-form-urlencoded", map[string]swagger.Property{
+				"name": {Type: "string", Example: "Rex"},
+			}),
+			want: insomniaBody{MimeType: "application/x-www-form-urlencoded", Params: []insomniaParam{
+				{Name: "name", Value: "Rex"},
+			}},
+		},
+		{
+			name: "multipart",
+			body: form("multipart/form-data", map[string]swagger.Property{
+				"name":  {Type: "string", Example: "Rex"},
+				"photo": {Type: "string", Format: "binary"},
+			}),
+			want: insomniaBody{MimeType: "multipart/form-data", Params: []insomniaParam{
+				{Name: "name", Value: "Rex"},
+				{Name: "photo", Type: "file", FileName: "path/to/file"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "insomnia.json")
+			if err := WriteInsomnia(output, "Test API", []Request{bodyRequest(tt.body)}); err != nil {
+				t.Fatalf("WriteInsomnia() error = %v", err)
+			}
+			data, err := os.ReadFile(output)
+			if err != nil {
+				t.Fatalf("failed to read export: %v", err)
+			}
+
+			var export insomniaExport
+			if err := json.Unmarshal(data, &export); err != nil {
+				t.Fatalf("failed to parse export: %v", err)
+			}
+			request := export.Resources[len(export.Resources)-1]
+			if request.Body == nil || !reflect.DeepEqual(*request.Body, tt.want) {
+				t.Errorf("body = %+v, want %+v", request.Body, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package collection
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/example"
+)
+
+// insomniaExport is the Insomnia v4 export document
+type insomniaExport struct {
+	Type         string             `json:"_type"`
+	ExportFormat int                `json:"__export_format"`
+	ExportSource string             `json:"__export_source"`
+	Resources    []insomniaResource `json:"resources"`
+}
+
+// insomniaResource is a workspace, environment, folder or request
+type insomniaResource struct {
+	ID         string                 `json:"_id"`
+	Type       string                 `json:"_type"`
+	ParentID   string                 `json:"parentId,omitempty"`
+	Name       string                 `json:"name"`
+	Method     string                 `json:"method,omitempty"`
+	URL        string                 `json:"url,omitempty"`
+	Body       *insomniaBody          `json:"body,omitempty"`
+	Parameters []insomniaPair         `json:"parameters,omitempty"`
+	Headers    []insomniaPair         `json:"headers,omitempty"`
+	Data       map[string]interface{} `json:"data,omitempty"`
+}
+
+// insomniaBody is a request body: text, or the fields of a form body
+type insomniaBody struct {
+	MimeType string          `json:"mimeType"`
+	Text     string          `json:"text,omitempty"`
+	Params   []insomniaParam `json:"params,omitempty"`
+}
+
+// insomniaParam is a form body field; file fields name the file to upload
+type insomniaParam struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Type     string `json:"type,omitempty"`
+	FileName string `json:"fileName,omitempty"`
+}
+
+// insomniaPair is a name/value pair for parameters and headers
+type insomniaPair struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// WriteInsomnia writes an Insomnia v4 export file
+func WriteInsomnia(output, apiTitle string, requests []Request) error {
+	const workspaceID = "wrk_swagfluence"
+
+	export := insomniaExport{
+		Type:         "export",
+		ExportFormat: 4,
+		ExportSource: "swagfluence",
+	}
+
+	envData := map[string]interface{}{"base_url": ""}
+	export.Resources = append(export.Resources, insomniaResource{
+		ID:   workspaceID,
+		Type: "workspace",
+		Name: apiTitle,
+	})
+
+	folders := make(map[string]string)
+	var requestResources []insomniaResource
+
+	for i, req := range requests {
+		parentID := workspaceID
+		if req.Folder != "" {
+			folderID, ok := folders[req.Folder]
+			if !ok {
+				folderID = fmt.Sprintf("fld_%d", len(folders)+1)
+				folders[req.Folder] = folderID
+				export.Resources = append(export.Resources, insomniaResource{
+					ID:       folderID,
+					Type:     "request_group",
+					ParentID: workspaceID,
+					Name:     req.Folder,
+				})
+			}
+			parentID = folderID
+		}
+
+		url := "{{ _.base_url }}" + req.Path
+		for _, name := range req.PathParams {
+			url = strings.ReplaceAll(url, "{"+name+"}", "{{ _."+name+" }}")
+			envData[name] = ""
+		}
+
+		resource := insomniaResource{
+			ID:       fmt.Sprintf("req_%d", i+1),
+			Type:     "request",
+			ParentID: parentID,
+			Name:     req.Name,
+			Method:   req.Method,
+			URL:      url,
+		}
+		for _, name := range req.QueryParams {
+			resource.Parameters = append(resource.Parameters, insomniaPair{Name: name})
+		}
+		for _, name := range req.Headers {
+			resource.Headers = append(resource.Headers, insomniaPair{Name: name})
+		}
+		if req.ContentType != "" {
+			resource.Headers = append(resource.Headers, insomniaPair{Name: "Content-Type", Value: req.ContentType})
+			resource.Body = insomniaRequestBody(req)
+		}
+
+		requestResources = append(requestResources, resource)
+	}
+
+	export.Resources = append(export.Resources, insomniaResource{
+		ID:       "env_swagfluence",
+		Type:     "environment",
+		ParentID: workspaceID,
+		Name:     "Base Environment",
+		Data:     envData,
+	})
+	export.Resources = append(export.Resources, requestResources...)
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal insomnia export: %w", err)
+	}
+
+	if err := os.WriteFile(output, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write insomnia export: %w", err)
+	}
+
+	return nil
+}
+
+// insomniaRequestBody returns the body of a request, with form bodies as
+// params rather than text
+func insomniaRequestBody(req Request) *insomniaBody {
+	if !example.IsForm(req.ContentType) || req.Form == nil {
+		return &insomniaBody{MimeType: req.ContentType, Text: req.Body}
+	}
+
+	body := &insomniaBody{MimeType: req.ContentType, Params: []insomniaParam{}}
+	for _, field := range req.Form {
+		if field.File {
+			body.Params = append(body.Params, insomniaParam{Name: field.Name, Type: "file", FileName: field.Value})
+			continue
+		}
+		body.Params = append(body.Params, insomniaParam{Name: field.Name, Value: field.Value})
+	}
+	return body
+}
@@ -1,46 +1,697 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Config holds all application configuration
 type Config struct {
-	Confluence ConfluenceConfig
+	// Publisher selects the documentation backend ("confluence", "xwiki",
+	// "notion", "files" or "stdout")
+	Publisher string `yaml:"publisher"`
+	// CI enables CI-specific output ("github")
+	CI         string           `yaml:"ci"`
+	Confluence ConfluenceConfig `yaml:"confluence"`
+	XWiki      XWikiConfig      `yaml:"xwiki"`
+	Notion     NotionConfig     `yaml:"notion"`
+	Spec       SpecConfig       `yaml:"spec"`
+	Collection CollectionConfig `yaml:"collection"`
+	Lint       LintConfig       `yaml:"lint"`
+	Jira       JiraConfig       `yaml:"jira"`
+	PDF        PDFConfig        `yaml:"pdf"`
+	Export     ExportConfig     `yaml:"export"`
+	Examples   ExamplesConfig   `yaml:"examples"`
+	Smoke      SmokeConfig      `yaml:"smoke"`
+	Versions   VersionsConfig   `yaml:"versions"`
+	Templates  TemplateConfig   `yaml:"templates"`
+	Hooks      HooksConfig      `yaml:"hooks"`
+	Watch      WatchConfig      `yaml:"watch"`
+	Server     ServerConfig     `yaml:"server"`
+	// Baseline is the previous spec version compared against to detect
+	// breaking changes; empty disables the comparison
+	Baseline string `yaml:"baseline"`
+	// Directory is the title of the landing page listing every published
+	// API; batch runs default to DefaultDirectoryTitle
+	Directory string `yaml:"directory"`
+	// Specs are the spec sources published when none are given on the
+	// command line, each with optional settings of its own
+	Specs []SpecEntry `yaml:"specs"`
+	// DryRun renders pages to the console instead of publishing them
+	DryRun bool `yaml:"-"`
+	// Prune removes endpoint pages of operations no longer in the spec:
+	// "delete" deletes them, "archive" moves them below an archive page
+	Prune string `yaml:"prune"`
+	// Report is the file a JSON summary of the run is written to; empty
+	// disables it
+	Report string `yaml:"report"`
+	// ContinueOnError keeps publishing past endpoint pages that fail and
+	// lists the failures at the end
+	ContinueOnError bool `yaml:"continue_on_error"`
+}
+
+// DefaultDirectoryTitle is the directory page title used by batch runs
+const DefaultDirectoryTitle = "API Directory"
+
+// TemplateConfig holds custom endpoint page templates
+type TemplateConfig struct {
+	// Page is a text/template file replacing the whole page layout
+	Page string `yaml:"page"`
+	// Blocks are text/template files overriding individual named blocks
+	// (header, authentication, requestBody, parameters, samples, responses,
+	// notes, footer)
+	Blocks []string `yaml:"blocks"`
+	// Samples are the languages of the code samples on endpoint pages
+	// ("curl", "httpie", "python", "javascript", "go"); empty or "none"
+	// leaves the samples out
+	Samples []string `yaml:"samples"`
+}
+
+// DefaultWatchInterval is how often "swagfluence watch" polls by default
+const DefaultWatchInterval = 5 * time.Minute
+
+// WatchConfig holds settings for "swagfluence watch"
+type WatchConfig struct {
+	// Interval is the time between polls of the specs
+	Interval time.Duration `yaml:"interval"`
+}
+
+// DefaultListen is the address "swagfluence serve" listens on by default
+const DefaultListen = ":8080"
+
+// ServerConfig holds settings for "swagfluence serve"
+type ServerConfig struct {
+	// Listen is the address the webhook server listens on
+	Listen string `yaml:"listen"`
+	// Secret is the shared secret sync requests must send as a bearer token
+	Secret string `yaml:"secret"`
+}
+
+// HooksConfig holds the built-in hooks changing every published page
+type HooksConfig struct {
+	// Banner is text shown in a note at the top of every page
+	Banner string `yaml:"banner"`
+	// Footer is text appended to every page
+	Footer string `yaml:"footer"`
+	// Redact are regular expressions whose matches are replaced before
+	// pages are published
+	Redact []string `yaml:"redact"`
+}
+
+// VersionsConfig holds settings for publishing several spec versions side
+// by side under one API page
+type VersionsConfig struct {
+	// Label names the subtree this run publishes into (e.g. "v2"); empty
+	// publishes directly under the API page
+	Label string `yaml:"label"`
+	// Linked are the labels of other published versions to cross-link
+	Linked []string `yaml:"linked"`
+}
+
+// SmokeConfig holds settings for executing generated examples against a
+// live server
+type SmokeConfig struct {
+	// BaseURL is the server the examples run against; empty disables smoke tests
+	BaseURL string `yaml:"base_url"`
+	// AllowWrites also sends non-GET requests (use a sandbox)
+	AllowWrites bool `yaml:"allow_writes"`
+	// Params supplies path and query parameter values by name
+	Params map[string]string `yaml:"params"`
+	// Headers are extra "Name: value" request headers, e.g. authentication
+	Headers []string  `yaml:"headers"`
+	TLS     TLSConfig `yaml:"tls"`
+}
+
+// ExamplesConfig holds settings for enriching examples from recorded traffic
+type ExamplesConfig struct {
+	// HARFiles are HTTP Archives whose JSON payloads replace generated examples
+	HARFiles []string `yaml:"har_files"`
+	// Redact lists extra field names whose recorded values are masked
+	Redact []string `yaml:"redact"`
+	// Faker fills generated examples with realistic names, addresses,
+	// phone numbers and company names, seeded from each operation so they
+	// don't change between runs
+	Faker bool `yaml:"faker"`
+	// MaxDepth caps how many levels of nested schemas generated examples
+	// show; deeper objects become a placeholder. Zero uses the default of
+	// 10.
+	MaxDepth int `yaml:"max_depth"`
+	// ArrayItems is how many items generated example arrays hold; zero
+	// uses the default of 1
+	ArrayItems int `yaml:"array_items"`
+}
+
+// ExportConfig holds settings for the docs-as-code storage file export
+type ExportConfig struct {
+	// Dir is the directory written by the "files" publisher
+	Dir string `yaml:"dir"`
+	// Format is what the "files" publisher writes: "storage" (the default)
+	// for Confluence storage format pages, "markdown", "html" for a
+	// static site, or "asciidoc"
+	Format string `yaml:"format"`
+	// PublishFrom publishes a previously exported directory instead of a spec
+	PublishFrom string `yaml:"publish_from"`
+}
+
+// PDFConfig holds settings for exporting the published pages to PDF
+type PDFConfig struct {
+	// Output is the directory PDFs are written to; empty skips writing files
+	Output string `yaml:"output"`
+	// Attach uploads the PDFs as attachments of the parent page
+	Attach bool `yaml:"attach"`
+}
+
+// JiraConfig holds settings for opening issues about breaking changes
+type JiraConfig struct {
+	BaseURL    string    `yaml:"base_url"`
+	Username   string    `yaml:"username"`
+	APIToken   string    `yaml:"api_token"`
+	ProjectKey string    `yaml:"project_key"`
+	IssueType  string    `yaml:"issue_type"`
+	Labels     []string  `yaml:"labels"`
+	TLS        TLSConfig `yaml:"tls"`
+	Enabled    bool      `yaml:"-"`
+}
+
+// LintConfig holds documentation lint settings
+type LintConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// FailOn aborts the run before publishing when a finding reaches this
+	// severity ("warning" or "error"); empty only reports findings
+	FailOn string `yaml:"fail_on"`
+	// Rules overrides rule severities by rule name ("off" disables a rule)
+	Rules map[string]string `yaml:"rules"`
+}
+
+// CollectionConfig holds settings for exporting a request collection
+type CollectionConfig struct {
+	// Format is the collection format ("insomnia" or "bruno"); empty disables export
+	Format string `yaml:"format"`
+	// Output is the file (Insomnia) or directory (Bruno) to write
+	Output string `yaml:"output"`
+}
+
+// SpecConfig holds settings for fetching and parsing the specification
+type SpecConfig struct {
+	// Format forces the document format ("json", "yaml" or "apib") instead of
+	// detecting it from the Content-Type, file extension or body
+	Format string `yaml:"format"`
+	// Version forces the specification version ("2", "3" or "3.1") for
+	// documents that are missing the swagger/openapi field
+	Version string `yaml:"version"`
+	// Overlays are OpenAPI Overlay documents applied in order before rendering
+	Overlays []string `yaml:"overlays"`
+	// IncludeTags, ExcludeTags, IncludePaths (globs) and Methods select the
+	// endpoints that are documented; empty lists select every endpoint
+	IncludeTags  []string `yaml:"include_tags"`
+	ExcludeTags  []string `yaml:"exclude_tags"`
+	IncludePaths []string `yaml:"include_paths"`
+	Methods      []string `yaml:"methods"`
+	// IncludeHidden keeps operations, parameters and properties marked
+	// x-internal or x-hidden, which are left out by default
+	IncludeHidden bool `yaml:"include_hidden"`
+	// TitleTemplate is a text/template for endpoint page titles, e.g.
+	// "{{.APITitle}} - {{.Method}} {{.Path}}"; empty uses the generated
+	// titles
+	TitleTemplate string `yaml:"title_template"`
+	// TitlePrefix and TitleSuffix are added as is to every endpoint page
+	// title, e.g. "[Payments] ", so APIs sharing a space don't collide
+	TitlePrefix string    `yaml:"title_prefix"`
+	TitleSuffix string    `yaml:"title_suffix"`
+	TLS         TLSConfig `yaml:"tls"`
+}
+
+// SpecEntry is a spec listed in the config file. Its settings override the
+// global ones for that spec only; a plain string lists a source without
+// overrides.
+type SpecEntry struct {
+	Source          string `yaml:"source"`
+	SpaceKey        string `yaml:"space_key"`
+	ParentPageID    string `yaml:"parent_page_id"`
+	ParentPageTitle string `yaml:"parent_page_title"`
+	TitlePrefix     string `yaml:"title_prefix"`
+	TitleSuffix     string `yaml:"title_suffix"`
+	// IncludeTags and ExcludeTags replace the global tag filters
+	IncludeTags []string `yaml:"include_tags"`
+	ExcludeTags []string `yaml:"exclude_tags"`
+	// Labels are added to the global labels
+	Labels []string `yaml:"labels"`
+}
+
+// UnmarshalYAML accepts a source string or a mapping of settings, rejecting
+// unknown keys like the rest of the file
+func (e *SpecEntry) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&e.Source)
+	}
+
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return err
+	}
+	type plain SpecEntry
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode((*plain)(e)); err != nil {
+		return err
+	}
+	if e.Source == "" {
+		return fmt.Errorf("line %d: spec entry has no source", node.Line)
+	}
+	return nil
+}
+
+// overrides reports whether the entry changes any setting
+func (e SpecEntry) overrides() bool {
+	return e.SpaceKey != "" || e.ParentPageID != "" || e.ParentPageTitle != "" || e.TitlePrefix != "" || e.TitleSuffix != "" ||
+		e.IncludeTags != nil || e.ExcludeTags != nil || e.Labels != nil
+}
+
+// SpecSources returns the sources of the specs listed in the config file
+func (c *Config) SpecSources() []string {
+	if len(c.Specs) == 0 {
+		return nil
+	}
+	sources := make([]string, 0, len(c.Specs))
+	for _, entry := range c.Specs {
+		sources = append(sources, entry.Source)
+	}
+	return sources
+}
+
+// ForSpec returns the settings for publishing source: a copy with the
+// overrides of its entry in Specs applied, or c itself when it has none
+func (c *Config) ForSpec(source string) *Config {
+	for _, entry := range c.Specs {
+		if entry.Source != source || !entry.overrides() {
+			continue
+		}
+
+		specCfg := *c
+		// A parent page ID belongs to the space and title it was set with
+		if entry.SpaceKey != "" {
+			specCfg.Confluence.SpaceKey = entry.SpaceKey
+			specCfg.Confluence.ParentPageID = ""
+		}
+		if entry.ParentPageTitle != "" {
+			specCfg.Confluence.ParentPageTitle = entry.ParentPageTitle
+			specCfg.Confluence.ParentPageID = ""
+		}
+		if entry.ParentPageID != "" {
+			specCfg.Confluence.ParentPageID = entry.ParentPageID
+		}
+		if entry.TitlePrefix != "" {
+			specCfg.Spec.TitlePrefix = entry.TitlePrefix
+		}
+		if entry.TitleSuffix != "" {
+			specCfg.Spec.TitleSuffix = entry.TitleSuffix
+		}
+		if entry.IncludeTags != nil {
+			specCfg.Spec.IncludeTags = entry.IncludeTags
+		}
+		if entry.ExcludeTags != nil {
+			specCfg.Spec.ExcludeTags = entry.ExcludeTags
+		}
+		if entry.Labels != nil {
+			specCfg.Confluence.Labels = append(append([]string{}, c.Confluence.Labels...), entry.Labels...)
+		}
+
+		// A space given only for this spec enables Confluence; a dry run
+		// keeps the publisher it was set up with
+		if !specCfg.DryRun {
+			specCfg.Normalize()
+		}
+		return &specCfg
+	}
+	return c
+}
+
+// TLSConfig holds transport security and proxy settings for outbound
+// connections
+type TLSConfig struct {
+	// InsecureSkipVerify disables certificate verification (lab use only)
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify"`
+	// CACertFile is a PEM bundle trusted in addition to the system roots
+	CACertFile string `yaml:"ca_cert_file"`
+	// Proxy is the URL of the proxy requests are sent through; empty uses
+	// the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables
+	Proxy string `yaml:"proxy"`
+}
+
+// TLSConfigs returns the transport settings of every outbound connection,
+// for settings that apply to all of them
+func (c *Config) TLSConfigs() []*TLSConfig {
+	return []*TLSConfig{&c.Spec.TLS, &c.Confluence.TLS, &c.XWiki.TLS, &c.Notion.TLS, &c.Jira.TLS, &c.Smoke.TLS}
 }
 
 // ConfluenceConfig holds Confluence-specific settings
 type ConfluenceConfig struct {
-	BaseURL      string
-	Username     string
-	APIToken     string
-	SpaceKey     string
-	ParentPageID string
-	Enabled      bool
+	BaseURL         string   `yaml:"base_url"`
+	Username        string   `yaml:"username"`
+	APIToken        string   `yaml:"api_token"`
+	SpaceKey        string   `yaml:"space_key"`
+	ParentPageID    string   `yaml:"parent_page_id"`
+	ParentPageTitle string   `yaml:"parent_page_title"`
+	CreateParent    bool     `yaml:"create_parent"`
+	Labels          []string `yaml:"labels"`
+	// TagLabels labels each endpoint page with its sanitized operation tags
+	TagLabels bool `yaml:"tag_labels"`
+	// OrderPages positions endpoint pages in the page tree in spec order
+	OrderPages bool `yaml:"order_pages"`
+	// ManagedLabels labels every generated page with swagfluence and the API
+	// name, and endpoint pages also with swagfluence-endpoint
+	ManagedLabels bool `yaml:"managed_labels"`
+	// SharedModels documents component schemas once on model pages that
+	// endpoint pages include through excerpt-include macros
+	SharedModels bool `yaml:"shared_models"`
+	// AttachSpec attaches the source spec document to the API page and
+	// links to it
+	AttachSpec bool `yaml:"attach_spec"`
+	// SpecEmbed also shows the attached spec on the API page: "code" in a
+	// code macro, or the name of a viewer macro; empty only links to it
+	SpecEmbed string `yaml:"spec_embed"`
+	// SwaggerUIURL is a Swagger UI showing the API, embedded on the API page
+	// in an iframe macro; empty disables it
+	SwaggerUIURL string `yaml:"swagger_ui_url"`
+	// Changelog publishes a changelog page comparing each run's spec with
+	// the one published before
+	Changelog bool `yaml:"changelog"`
+	// UpdateMode is "full" (own the whole page) or "region" (replace only
+	// the generated block of an existing page)
+	UpdateMode string `yaml:"update_mode"`
+	// VersionMessage is the version comment of pages written, with
+	// {version} replaced by the API version; empty uses "API version
+	// {version}"
+	VersionMessage string `yaml:"version_message"`
+	// MinorEdit saves page updates as minor edits, which don't notify
+	// watchers
+	MinorEdit bool `yaml:"minor_edit"`
+	// History archives the previous rendering of each page under a
+	// "History" subtree when the API version changes
+	History bool `yaml:"history"`
+	// LowMemory looks pages up one at a time instead of indexing every
+	// existing page with its content, bounding memory for very large APIs
+	LowMemory bool `yaml:"low_memory"`
+	// StateFile records page IDs and versions between runs, so unchanged
+	// pages are updated without being looked up; empty disables it
+	StateFile string `yaml:"state_file"`
+	// MaxAttempts is how often a request rate limited by Confluence (429 or
+	// 503) is sent before giving up; zero uses the default of 5
+	MaxAttempts int `yaml:"max_attempts"`
+	// MaxRetryAfter caps how long a Retry-After header may make a retry
+	// wait; zero uses the default of 5m
+	MaxRetryAfter time.Duration `yaml:"max_retry_after"`
+	// CollapseProperties and CollapseLines are the sizes above which schema
+	// tables (in properties) and example bodies (in lines) are wrapped in
+	// expand macros; zero uses the defaults of 30 and 80, a negative value
+	// never collapses
+	CollapseProperties int `yaml:"collapse_properties"`
+	CollapseLines      int `yaml:"collapse_lines"`
+	// AuthType is "basic" (default, username and API token) or "bearer"
+	// (personal access token, for Server and Data Center)
+	AuthType string `yaml:"auth_type"`
+	// API is the REST API pages are written through: "v1" (default, storage
+	// format) or "v2" (Confluence Cloud, endpoint pages as ADF)
+	API     string    `yaml:"api"`
+	TLS     TLSConfig `yaml:"tls"`
+	Enabled bool      `yaml:"-"`
+	// DryRun reads the existing pages and prints what would be created or
+	// updated, with a diff, without writing anything
+	DryRun bool `yaml:"-"`
+}
+
+// XWikiConfig holds XWiki-specific settings
+type XWikiConfig struct {
+	BaseURL    string    `yaml:"base_url"`
+	Wiki       string    `yaml:"wiki"`
+	Space      string    `yaml:"space"`
+	ParentPage string    `yaml:"parent_page"`
+	Username   string    `yaml:"username"`
+	Password   string    `yaml:"password"`
+	TLS        TLSConfig `yaml:"tls"`
+}
+
+// NotionConfig holds Notion-specific settings
+type NotionConfig struct {
+	BaseURL      string `yaml:"base_url"`
+	Token        string `yaml:"token"`
+	ParentPageID string `yaml:"parent_page_id"`
+	// DatabaseID reuses an existing endpoint database instead of creating one
+	DatabaseID string    `yaml:"database_id"`
+	TLS        TLSConfig `yaml:"tls"`
 }
 
 // LoadFromEnv loads configuration from environment variables
 func LoadFromEnv() (*Config, error) {
+	return Load("")
+}
+
+// Load reads the YAML config file at path, when path is not empty, and
+// applies environment variables on top: a variable that is set overrides
+// the file value
+func Load(path string) (*Config, error) {
 	cfg := &Config{
-		Confluence: ConfluenceConfig{
-			BaseURL:      os.Getenv("CONFLUENCE_BASE_URL"),
-			Username:     os.Getenv("CONFLUENCE_USERNAME"),
-			APIToken:     os.Getenv("CONFLUENCE_API_TOKEN"),
-			SpaceKey:     os.Getenv("CONFLUENCE_SPACE_KEY"),
-			ParentPageID: os.Getenv("CONFLUENCE_PARENT_PAGE_ID"),
-		},
+		Confluence: ConfluenceConfig{TagLabels: true, ManagedLabels: true, OrderPages: true},
+		Templates:  TemplateConfig{Samples: []string{"curl"}},
+		Watch:      WatchConfig{Interval: DefaultWatchInterval},
+		Server:     ServerConfig{Listen: DefaultListen},
 	}
 
-	// Enable Confluence only if all required fields are present
-	cfg.Confluence.Enabled = cfg.Confluence.BaseURL != "" &&
-		cfg.Confluence.Username != "" &&
-		cfg.Confluence.APIToken != "" &&
-		cfg.Confluence.SpaceKey != ""
+	if path != "" {
+		if err := readFile(path, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyEnv(cfg); err != nil {
+		return nil, err
+	}
+	cfg.Normalize()
 
 	return cfg, nil
 }
 
+// Normalize derives the enabled integrations and defaults from the settings.
+// Call it again after changing settings, e.g. from command line flags.
+func (c *Config) Normalize() {
+	// Enable Confluence only if all required fields are present
+	// Personal access tokens need no username
+	c.Confluence.Enabled = c.Confluence.BaseURL != "" &&
+		(c.Confluence.Username != "" || c.Confluence.AuthType == "bearer") &&
+		c.Confluence.APIToken != "" &&
+		c.Confluence.SpaceKey != ""
+
+	// Enable Jira only if all required fields are present
+	c.Jira.Enabled = c.Jira.BaseURL != "" &&
+		c.Jira.Username != "" &&
+		c.Jira.APIToken != "" &&
+		c.Jira.ProjectKey != ""
+
+	if c.Publisher == "" {
+		c.Publisher = "confluence"
+	}
+}
+
+// readFile decodes a YAML config file into cfg, rejecting unknown keys so
+// typos are not silently ignored
+func readFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv overrides cfg with the environment variables that are set
+func applyEnv(cfg *Config) error {
+	envString(&cfg.Publisher, "SWAGFLUENCE_PUBLISHER")
+	envString(&cfg.CI, "SWAGFLUENCE_CI")
+
+	envString(&cfg.Confluence.BaseURL, "CONFLUENCE_BASE_URL")
+	envString(&cfg.Confluence.Username, "CONFLUENCE_USERNAME")
+	envString(&cfg.Confluence.APIToken, "CONFLUENCE_API_TOKEN")
+	envString(&cfg.Confluence.AuthType, "CONFLUENCE_AUTH_TYPE")
+	envString(&cfg.Confluence.SpaceKey, "CONFLUENCE_SPACE_KEY")
+	envString(&cfg.Confluence.ParentPageID, "CONFLUENCE_PARENT_PAGE_ID")
+	envString(&cfg.Confluence.ParentPageTitle, "CONFLUENCE_PARENT_PAGE_TITLE")
+	envBool(&cfg.Confluence.CreateParent, "CONFLUENCE_CREATE_PARENT")
+	envList(&cfg.Confluence.Labels, "CONFLUENCE_LABELS")
+	if value := os.Getenv("CONFLUENCE_TAG_LABELS"); value != "" {
+		// Tag labels are on unless explicitly disabled
+		cfg.Confluence.TagLabels = value != "false"
+	}
+	envBool(&cfg.Confluence.ManagedLabels, "CONFLUENCE_MANAGED_LABELS")
+	envBool(&cfg.Confluence.OrderPages, "CONFLUENCE_ORDER_PAGES")
+	envBool(&cfg.Confluence.SharedModels, "CONFLUENCE_SHARED_MODELS")
+	envBool(&cfg.Confluence.Changelog, "CONFLUENCE_CHANGELOG")
+	envBool(&cfg.Confluence.AttachSpec, "CONFLUENCE_ATTACH_SPEC")
+	envString(&cfg.Confluence.SpecEmbed, "CONFLUENCE_SPEC_EMBED")
+	envString(&cfg.Confluence.SwaggerUIURL, "CONFLUENCE_SWAGGER_UI_URL")
+	envString(&cfg.Confluence.UpdateMode, "CONFLUENCE_UPDATE_MODE")
+	envString(&cfg.Confluence.VersionMessage, "CONFLUENCE_VERSION_MESSAGE")
+	envBool(&cfg.Confluence.MinorEdit, "CONFLUENCE_MINOR_EDIT")
+	envBool(&cfg.Confluence.History, "CONFLUENCE_HISTORY")
+	envBool(&cfg.Confluence.LowMemory, "SWAGFLUENCE_LOW_MEMORY")
+	envString(&cfg.Confluence.StateFile, "CONFLUENCE_STATE_FILE")
+	envString(&cfg.Confluence.API, "CONFLUENCE_API_VERSION")
+	if err := envInt(&cfg.Confluence.MaxAttempts, "CONFLUENCE_MAX_ATTEMPTS"); err != nil {
+		return err
+	}
+	if err := envDuration(&cfg.Confluence.MaxRetryAfter, "CONFLUENCE_MAX_RETRY_AFTER"); err != nil {
+		return err
+	}
+	if err := envInt(&cfg.Confluence.CollapseProperties, "CONFLUENCE_COLLAPSE_PROPERTIES"); err != nil {
+		return err
+	}
+	if err := envInt(&cfg.Confluence.CollapseLines, "CONFLUENCE_COLLAPSE_LINES"); err != nil {
+		return err
+	}
+
+	envString(&cfg.XWiki.BaseURL, "XWIKI_BASE_URL")
+	envString(&cfg.XWiki.Wiki, "XWIKI_WIKI")
+	envString(&cfg.XWiki.Space, "XWIKI_SPACE")
+	envString(&cfg.XWiki.ParentPage, "XWIKI_PARENT_PAGE")
+	envString(&cfg.XWiki.Username, "XWIKI_USERNAME")
+	envString(&cfg.XWiki.Password, "XWIKI_PASSWORD")
+
+	envString(&cfg.Notion.BaseURL, "NOTION_BASE_URL")
+	envString(&cfg.Notion.Token, "NOTION_TOKEN")
+	envString(&cfg.Notion.ParentPageID, "NOTION_PARENT_PAGE_ID")
+	envString(&cfg.Notion.DatabaseID, "NOTION_DATABASE_ID")
+
+	envString(&cfg.Jira.BaseURL, "JIRA_BASE_URL")
+	envString(&cfg.Jira.Username, "JIRA_USERNAME")
+	envString(&cfg.Jira.APIToken, "JIRA_API_TOKEN")
+	envString(&cfg.Jira.ProjectKey, "JIRA_PROJECT_KEY")
+	envString(&cfg.Jira.IssueType, "JIRA_ISSUE_TYPE")
+	envList(&cfg.Jira.Labels, "JIRA_LABELS")
+
+	// Transport settings from the environment apply to every connection
+	for _, tlsCfg := range cfg.TLSConfigs() {
+		envString(&tlsCfg.Proxy, "SWAGFLUENCE_PROXY")
+		envString(&tlsCfg.CACertFile, "SWAGFLUENCE_CA_CERT")
+		envBool(&tlsCfg.InsecureSkipVerify, "SWAGFLUENCE_INSECURE")
+	}
+
+	envString(&cfg.Baseline, "SWAGFLUENCE_BASELINE_SPEC")
+	envString(&cfg.Directory, "SWAGFLUENCE_DIRECTORY")
+	envString(&cfg.Prune, "SWAGFLUENCE_PRUNE")
+	envBool(&cfg.ContinueOnError, "SWAGFLUENCE_CONTINUE_ON_ERROR")
+	envString(&cfg.Report, "SWAGFLUENCE_REPORT")
+	envList(&cfg.Examples.HARFiles, "SWAGFLUENCE_HAR")
+	envList(&cfg.Examples.Redact, "SWAGFLUENCE_HAR_REDACT")
+	envBool(&cfg.Examples.Faker, "SWAGFLUENCE_FAKER")
+	if err := envInt(&cfg.Examples.MaxDepth, "SWAGFLUENCE_EXAMPLE_MAX_DEPTH"); err != nil {
+		return err
+	}
+	if err := envInt(&cfg.Examples.ArrayItems, "SWAGFLUENCE_EXAMPLE_ARRAY_ITEMS"); err != nil {
+		return err
+	}
+	envString(&cfg.Spec.TitleTemplate, "SWAGFLUENCE_TITLE_TEMPLATE")
+	envString(&cfg.Spec.TitlePrefix, "SWAGFLUENCE_TITLE_PREFIX")
+	envString(&cfg.Spec.TitleSuffix, "SWAGFLUENCE_TITLE_SUFFIX")
+	envString(&cfg.Templates.Page, "SWAGFLUENCE_TEMPLATE")
+	envList(&cfg.Templates.Blocks, "SWAGFLUENCE_TEMPLATE_BLOCKS")
+	envList(&cfg.Templates.Samples, "SWAGFLUENCE_SAMPLES")
+	envString(&cfg.Versions.Label, "SWAGFLUENCE_VERSION_LABEL")
+	envList(&cfg.Versions.Linked, "SWAGFLUENCE_LINK_VERSIONS")
+	envString(&cfg.Smoke.BaseURL, "SWAGFLUENCE_SMOKE_URL")
+	envBool(&cfg.Smoke.AllowWrites, "SWAGFLUENCE_SMOKE_WRITES")
+	envString(&cfg.Export.Dir, "SWAGFLUENCE_EXPORT_DIR")
+	envString(&cfg.Export.Format, "SWAGFLUENCE_EXPORT_FORMAT")
+	envString(&cfg.Server.Listen, "SWAGFLUENCE_LISTEN")
+	envString(&cfg.Server.Secret, "SWAGFLUENCE_WEBHOOK_SECRET")
+	if err := envDuration(&cfg.Watch.Interval, "SWAGFLUENCE_WATCH_INTERVAL"); err != nil {
+		return err
+	}
+	envBool(&cfg.Lint.Enabled, "SWAGFLUENCE_LINT")
+	envString(&cfg.Lint.FailOn, "SWAGFLUENCE_LINT_FAIL_ON")
+	envString(&cfg.Spec.Format, "SWAGFLUENCE_SPEC_FORMAT")
+	envString(&cfg.Spec.Version, "SWAGFLUENCE_SPEC_VERSION")
+	envList(&cfg.Spec.IncludeTags, "SWAGFLUENCE_INCLUDE_TAGS")
+	envList(&cfg.Spec.ExcludeTags, "SWAGFLUENCE_EXCLUDE_TAGS")
+	envList(&cfg.Spec.IncludePaths, "SWAGFLUENCE_INCLUDE_PATHS")
+	envList(&cfg.Spec.Methods, "SWAGFLUENCE_METHODS")
+	envBool(&cfg.Spec.IncludeHidden, "SWAGFLUENCE_INCLUDE_HIDDEN")
+	return nil
+}
+
+// envString overrides dst with a non-empty environment variable
+func envString(dst *string, name string) {
+	if value := os.Getenv(name); value != "" {
+		*dst = value
+	}
+}
+
+// envBool overrides dst with a non-empty environment variable; only "true"
+// enables the setting
+func envBool(dst *bool, name string) {
+	if value := os.Getenv(name); value != "" {
+		*dst = value == "true"
+	}
+}
+
+// envInt overrides dst with a non-empty integer environment variable
+func envInt(dst *int, name string) error {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: expected a number", name, value)
+	}
+	*dst = n
+	return nil
+}
+
+// envDuration overrides dst with a non-empty duration environment variable
+func envDuration(dst *time.Duration, name string) error {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: expected a duration such as 5m", name, value)
+	}
+	*dst = d
+	return nil
+}
+
+// envList overrides dst with a non-empty comma-separated environment variable
+func envList(dst *[]string, name string) {
+	if value := os.Getenv(name); value != "" {
+		*dst = SplitList(value)
+	}
+}
+
 // IsConfluenceEnabled returns true if Confluence integration is enabled
 func (c *Config) IsConfluenceEnabled() bool {
 	return c.Confluence.Enabled
 }
+
+// SplitList splits a comma-separated value into trimmed, non-empty items
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,233 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "swagfluence.yaml")
+	err := os.WriteFile(path, []byte(`
+publisher: confluence
+specs:
+  - ./openapi.yaml
+confluence:
+  base_url: https://file.example.com/wiki
+  username: docs-bot
+  space_key: API
+  tag_labels: false
+  labels: [generated, api]
+lint:
+  enabled: true
+  rules:
+    missing-example: off
+hooks:
+  footer: Internal use only
+  redact: ['secret-\w+']
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("CONFLUENCE_BASE_URL", "https://env.example.com/wiki")
+	t.Setenv("CONFLUENCE_API_TOKEN", "secret")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Confluence.BaseURL != "https://env.example.com/wiki" {
+		t.Errorf("BaseURL = %q, want the environment value", cfg.Confluence.BaseURL)
+	}
+	if cfg.Confluence.Username != "docs-bot" || cfg.Confluence.SpaceKey != "API" {
+		t.Errorf("file values not loaded: %+v", cfg.Confluence)
+	}
+	if !cfg.Confluence.Enabled {
+		t.Error("expected Confluence to be enabled by the file and environment together")
+	}
+	if cfg.Confluence.TagLabels {
+		t.Error("expected tag_labels: false from the file")
+	}
+	if !reflect.DeepEqual(cfg.Confluence.Labels, []string{"generated", "api"}) {
+		t.Errorf("Labels = %v", cfg.Confluence.Labels)
+	}
+	if !reflect.DeepEqual(cfg.SpecSources(), []string{"./openapi.yaml"}) {
+		t.Errorf("Specs = %v", cfg.Specs)
+	}
+	if !cfg.Lint.Enabled || cfg.Lint.Rules["missing-example"] != "off" {
+		t.Errorf("Lint = %+v", cfg.Lint)
+	}
+	if cfg.Hooks.Footer != "Internal use only" || !reflect.DeepEqual(cfg.Hooks.Redact, []string{`secret-\w+`}) {
+		t.Errorf("Hooks = %+v", cfg.Hooks)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	dir := t.TempDir()
+	unknown := filepath.Join(dir, "unknown.yaml")
+	if err := os.WriteFile(unknown, []byte("confluence:\n  space: API\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "missing file", path: filepath.Join(dir, "missing.yaml")},
+		{name: "unknown key", path: unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(tt.path); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoad_MaxAttempts(t *testing.T) {
+	t.Setenv("CONFLUENCE_MAX_ATTEMPTS", "8")
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Confluence.MaxAttempts != 8 {
+		t.Errorf("MaxAttempts = %d, want 8", cfg.Confluence.MaxAttempts)
+	}
+
+	t.Setenv("CONFLUENCE_MAX_ATTEMPTS", "many")
+	if _, err := Load(""); err == nil {
+		t.Error("expected an error for a non-numeric value")
+	}
+}
+
+func TestLoad_MaxRetryAfter(t *testing.T) {
+	t.Setenv("CONFLUENCE_MAX_RETRY_AFTER", "30s")
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Confluence.MaxRetryAfter != 30*time.Second {
+		t.Errorf("MaxRetryAfter = %s, want 30s", cfg.Confluence.MaxRetryAfter)
+	}
+
+	t.Setenv("CONFLUENCE_MAX_RETRY_AFTER", "soon")
+	if _, err := Load(""); err == nil {
+		t.Error("expected an error for an invalid duration")
+	}
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Publisher != "confluence" || !cfg.Confluence.TagLabels {
+		t.Errorf("unexpected defaults: publisher %q, tag labels %v", cfg.Publisher, cfg.Confluence.TagLabels)
+	}
+}
+
+func TestLoad_BearerAuth(t *testing.T) {
+	t.Setenv("CONFLUENCE_BASE_URL", "https://confluence.example.com")
+	t.Setenv("CONFLUENCE_API_TOKEN", "pat")
+	t.Setenv("CONFLUENCE_SPACE_KEY", "API")
+	t.Setenv("CONFLUENCE_AUTH_TYPE", "bearer")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Confluence.AuthType != "bearer" || !cfg.Confluence.Enabled {
+		t.Errorf("expected bearer auth enabled without a username, got %+v", cfg.Confluence)
+	}
+}
+
+func TestLoad_SpecOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "swagfluence.yaml")
+	err := os.WriteFile(path, []byte(`
+specs:
+  - ./orders.yaml
+  - source: ./payments.yaml
+    space_key: PAY
+    parent_page_id: "123"
+    title_prefix: "Payments: "
+    include_tags: [public]
+    labels: [payments]
+  - source: ./identity.yaml
+    space_key: IAM
+confluence:
+  base_url: https://example.com/wiki
+  username: docs-bot
+  api_token: secret
+  space_key: API
+  parent_page_id: "999"
+  labels: [generated]
+spec:
+  exclude_tags: [internal]
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if want := []string{"./orders.yaml", "./payments.yaml", "./identity.yaml"}; !reflect.DeepEqual(cfg.SpecSources(), want) {
+		t.Errorf("SpecSources() = %v, want %v", cfg.SpecSources(), want)
+	}
+
+	if cfg.ForSpec("./orders.yaml") != cfg {
+		t.Error("expected a spec without overrides to use the global settings")
+	}
+
+	payments := cfg.ForSpec("./payments.yaml")
+	if payments.Confluence.SpaceKey != "PAY" || payments.Confluence.ParentPageID != "123" {
+		t.Errorf("payments target = %s/%s, want PAY/123", payments.Confluence.SpaceKey, payments.Confluence.ParentPageID)
+	}
+	if payments.Spec.TitlePrefix != "Payments: " || !reflect.DeepEqual(payments.Spec.IncludeTags, []string{"public"}) {
+		t.Errorf("payments spec settings = %+v", payments.Spec)
+	}
+	if !reflect.DeepEqual(payments.Spec.ExcludeTags, []string{"internal"}) {
+		t.Errorf("ExcludeTags = %v, want the global filter", payments.Spec.ExcludeTags)
+	}
+	if !reflect.DeepEqual(payments.Confluence.Labels, []string{"generated", "payments"}) {
+		t.Errorf("Labels = %v, want the global and spec labels", payments.Confluence.Labels)
+	}
+
+	identity := cfg.ForSpec("./identity.yaml")
+	if identity.Confluence.SpaceKey != "IAM" || identity.Confluence.ParentPageID != "" {
+		t.Errorf("identity target = %s/%s, want IAM without the global parent page", identity.Confluence.SpaceKey, identity.Confluence.ParentPageID)
+	}
+
+	if cfg.Confluence.SpaceKey != "API" || !reflect.DeepEqual(cfg.Confluence.Labels, []string{"generated"}) {
+		t.Errorf("global settings changed: %+v", cfg.Confluence)
+	}
+}
+
+func TestLoad_SpecEntryErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		specs string
+	}{
+		{name: "unknown key", specs: "  - source: ./a.yaml\n    space: PAY\n"},
+		{name: "no source", specs: "  - space_key: PAY\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "swagfluence.yaml")
+			if err := os.WriteFile(path, []byte("specs:\n"+tt.specs), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load(path); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
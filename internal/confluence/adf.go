@@ -0,0 +1,298 @@
+package confluence
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/example"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// ADFNode is a node of an Atlassian Document Format document
+type ADFNode struct {
+	Type    string                 `json:"type"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Content []ADFNode              `json:"content,omitempty"`
+	Text    string                 `json:"text,omitempty"`
+	Marks   []ADFMark              `json:"marks,omitempty"`
+}
+
+// ADFMark formats the text of an ADF text node
+type ADFMark struct {
+	Type  string                 `json:"type"`
+	Attrs map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// ADFDocument is the root of an ADF document
+type ADFDocument struct {
+	Version int       `json:"version"`
+	Type    string    `json:"type"`
+	Content []ADFNode `json:"content"`
+}
+
+// String returns the document as the JSON string the v2 API expects
+func (d *ADFDocument) String() string {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// ADFFormatter renders endpoint pages as Atlassian Document Format, for
+// the Confluence Cloud v2 API. Unlike Formatter it works from the parsed
+// model directly rather than from storage markup.
+type ADFFormatter struct {
+	exampleGen *example.Generator
+	// collapse sets the sizes above which schema tables and examples are
+	// wrapped in expands
+	collapse collapseLimits
+}
+
+// NewADFFormatter creates a new ADF formatter
+func NewADFFormatter() *ADFFormatter {
+	return &ADFFormatter{exampleGen: example.NewGenerator()}
+}
+
+// FormatEndpointPage generates the ADF document of an endpoint page
+func (f *ADFFormatter) FormatEndpointPage(path, method string, op swagger.Operation, resolver *swagger.Resolver) (*ADFDocument, error) {
+	seeded := *f
+	seeded.exampleGen = f.exampleGen.ForOperation(op)
+	f = &seeded
+	doc := &ADFDocument{Version: 1, Type: "doc"}
+	add := func(nodes ...ADFNode) {
+		doc.Content = append(doc.Content, nodes...)
+	}
+
+	add(adfHeading(2, adfText(strings.ToUpper(method), adfMark("strong")), adfText(" "), adfText(path, adfMark("code"))))
+	if op.Summary != "" && op.Summary != op.Description {
+		add(adfParagraph(adfText(op.Summary)))
+	}
+	if op.Description != "" {
+		add(adfParagraph(adfText(op.Description)))
+	}
+	if op.OperationID != "" {
+		add(adfParagraph(adfText("Operation ID: ", adfMark("strong")), adfText(op.OperationID, adfMark("code"))))
+	}
+	if len(op.Tags) > 0 {
+		add(adfParagraph(adfText("Tags: ", adfMark("strong")), adfText(strings.Join(op.Tags, ", "))))
+	}
+
+	add(adfHeading(3, adfText("Parameters")))
+	add(f.parametersTable(op.Parameters))
+
+	if op.RequestBody != nil || requestBodyParam(op) != nil {
+		add(adfHeading(3, adfText("Request Body")))
+		add(f.requestBodyNodes(op, resolver)...)
+	}
+
+	if len(op.Responses) > 0 {
+		add(adfHeading(3, adfText("Responses")))
+		// List responses in the order the spec documents them
+		for _, code := range op.ResponseCodes() {
+			response := op.Responses[code]
+			add(adfHeading(4, adfText(code+" - "+response.Description)))
+			for _, contentType := range contentTypes(response.Content) {
+				mediaType := response.Content[contentType]
+				add(adfContentType(contentType))
+				add(f.schemaNodes(mediaType.Schema, mediaType.Example, resolver, false)...)
+			}
+			if response.Schema != nil {
+				add(f.schemaNodes(response.Schema, example.RecordedExample(response.Examples), resolver, false)...)
+			}
+		}
+	}
+
+	return doc, nil
+}
+
+// parametersTable renders the non-body parameters as a table
+func (f *ADFFormatter) parametersTable(params []swagger.Parameter) ADFNode {
+	rows := []ADFNode{adfHeaderRow("Name", "In", "Type", "Required", "Description")}
+	for _, param := range params {
+		if param.In == "body" {
+			continue
+		}
+		rows = append(rows, adfRow(
+			adfParagraph(adfText(param.Name, adfMark("code"))),
+			adfParagraph(adfText(param.In)),
+			adfParagraph(adfText(getParameterType(param))),
+			adfParagraph(adfText(yesNo(param.Required))),
+			adfParagraph(adfText(param.Description)),
+		))
+	}
+	if len(rows) == 1 {
+		return adfParagraph(adfText("This endpoint requires no parameters", adfMark("em")))
+	}
+	return adfTable(rows)
+}
+
+// requestBodyNodes renders the request body of an operation
+func (f *ADFFormatter) requestBodyNodes(op swagger.Operation, resolver *swagger.Resolver) []ADFNode {
+	var nodes []ADFNode
+	if op.RequestBody != nil {
+		if op.RequestBody.Description != "" {
+			nodes = append(nodes, adfParagraph(adfText(op.RequestBody.Description)))
+		}
+		for _, contentType := range contentTypes(op.RequestBody.Content) {
+			mediaType := op.RequestBody.Content[contentType]
+			nodes = append(nodes, adfContentType(contentType))
+			nodes = append(nodes, f.schemaNodes(mediaType.Schema, mediaType.Example, resolver, true)...)
+		}
+	}
+	if param := requestBodyParam(op); param != nil {
+		if param.Description != "" {
+			nodes = append(nodes, adfParagraph(adfText(param.Description)))
+		}
+		nodes = append(nodes, f.schemaNodes(param.Schema, param.Example, resolver, true)...)
+	}
+	return nodes
+}
+
+// schemaNodes renders the fields of a request or response schema and an
+// example, preferring a documented one over a generated one. Generated
+// request examples are shown as minimal and full bodies in expands when
+// they differ.
+func (f *ADFFormatter) schemaNodes(schema *swagger.Schema, recorded interface{}, resolver *swagger.Resolver, request bool) []ADFNode {
+	if schema == nil {
+		return nil
+	}
+	resolved, _ := resolver.ResolveSchema(schema)
+	if resolved == nil {
+		return nil
+	}
+
+	var nodes []ADFNode
+	table := resolved
+	if resolved.Type == "array" && resolved.Items != nil && len(resolved.Properties) == 0 {
+		table = resolved.Items
+	}
+	if len(table.Properties) > 0 {
+		rows := []ADFNode{adfHeaderRow("Field", "Type", "Required", "Description")}
+		for _, name := range table.PropertyNames() {
+			prop := table.Properties[name]
+			rows = append(rows, adfRow(
+				adfParagraph(adfText(name, adfMark("code"))),
+				adfParagraph(adfText(getPropertyType(prop))),
+				adfParagraph(adfText(yesNo(isFieldRequired(name, table.Required)))),
+				adfParagraph(adfText(prop.Description)),
+			))
+		}
+		if f.collapse.collapsesTable(len(table.Properties)) {
+			nodes = append(nodes, adfExpand(fmt.Sprintf("Show %d fields", len(table.Properties)), adfTable(rows)))
+		} else {
+			nodes = append(nodes, adfTable(rows))
+		}
+	}
+
+	var exampleJSON string
+	switch {
+	case recorded != nil:
+		exampleJSON = marshalExample(recorded)
+	case request:
+		minimal, full := f.exampleGen.GenerateRequestVariants(resolved)
+		if minimal != "" {
+			return append(nodes,
+				adfExpand("Minimal example (required fields only)", adfCodeBlock(minimal)),
+				adfExpand("Full example", adfCodeBlock(full)))
+		}
+		exampleJSON = full
+	default:
+		exampleJSON = f.exampleGen.ForResponse().GenerateExampleJSON(resolved)
+	}
+	if exampleJSON == "" || exampleJSON == "null" {
+		return nodes
+	}
+	if f.collapse.collapsesExample(exampleJSON) {
+		return append(nodes, adfExpand(fmt.Sprintf("Show example (%d lines)", lineCount(exampleJSON)), adfCodeBlock(exampleJSON)))
+	}
+	return append(nodes, adfCodeBlock(exampleJSON))
+}
+
+// requestBodyParam returns the Swagger 2.0 body parameter of an operation
+func requestBodyParam(op swagger.Operation) *swagger.Parameter {
+	for i := range op.Parameters {
+		if op.Parameters[i].In == "body" {
+			return &op.Parameters[i]
+		}
+	}
+	return nil
+}
+
+// contentTypes returns the content types of a content map in a stable order
+func contentTypes(content map[string]swagger.MediaType) []string {
+	types := make([]string, 0, len(content))
+	for contentType := range content {
+		types = append(types, contentType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func adfContentType(contentType string) ADFNode {
+	return adfParagraph(adfText("Content type: ", adfMark("strong")), adfText(contentType, adfMark("code")))
+}
+
+func adfText(text string, marks ...ADFMark) ADFNode {
+	if text == "" {
+		// ADF rejects empty text nodes
+		text = " "
+	}
+	return ADFNode{Type: "text", Text: text, Marks: marks}
+}
+
+func adfMark(markType string) ADFMark {
+	return ADFMark{Type: markType}
+}
+
+func adfParagraph(content ...ADFNode) ADFNode {
+	return ADFNode{Type: "paragraph", Content: content}
+}
+
+func adfHeading(level int, content ...ADFNode) ADFNode {
+	return ADFNode{Type: "heading", Attrs: map[string]interface{}{"level": level}, Content: content}
+}
+
+func adfCodeBlock(exampleJSON string) ADFNode {
+	return ADFNode{
+		Type:    "codeBlock",
+		Attrs:   map[string]interface{}{"language": "json"},
+		Content: []ADFNode{adfText(exampleJSON)},
+	}
+}
+
+func adfExpand(title string, content ...ADFNode) ADFNode {
+	return ADFNode{Type: "expand", Attrs: map[string]interface{}{"title": title}, Content: content}
+}
+
+func adfTable(rows []ADFNode) ADFNode {
+	return ADFNode{Type: "table", Content: rows}
+}
+
+func adfRow(cells ...ADFNode) ADFNode {
+	row := ADFNode{Type: "tableRow"}
+	for _, cell := range cells {
+		row.Content = append(row.Content, ADFNode{Type: "tableCell", Content: []ADFNode{cell}})
+	}
+	return row
+}
+
+func adfHeaderRow(titles ...string) ADFNode {
+	row := ADFNode{Type: "tableRow"}
+	for _, title := range titles {
+		row.Content = append(row.Content, ADFNode{
+			Type:    "tableHeader",
+			Content: []ADFNode{adfParagraph(adfText(title, adfMark("strong")))},
+		})
+	}
+	return row
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
@@ -0,0 +1,84 @@
+package confluence
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/diff"
+)
+
+// SpecSnapshotName is the attachment of the API page holding the spec of
+// the last run, which the next run's changelog is computed against
+const SpecSnapshotName = "swagfluence-spec.json"
+
+// ChangelogPageTitle returns the title of the page listing the changes
+// since the previous published spec
+func ChangelogPageTitle(scope string) string {
+	return fmt.Sprintf("%s - Changelog", scope)
+}
+
+// ComparisonPageTitle returns the title of the page comparing two versions
+// of an API, as published by the diff command
+func ComparisonPageTitle(scope, oldVersion, newVersion string) string {
+	return fmt.Sprintf("%s - Changes %s to %s", scope, oldVersion, newVersion)
+}
+
+// FormatChangelogPage generates the page listing the changes between two
+// published versions, breaking changes flagged
+func (f *Formatter) FormatChangelogPage(scope, oldVersion, newVersion string, changes []diff.Change) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("<h1>%s Changelog</h1>\n", html.EscapeString(scope)))
+	sb.WriteString(fmt.Sprintf("<p>Changes from version <strong>%s</strong> to <strong>%s</strong>.</p>\n",
+		html.EscapeString(oldVersion), html.EscapeString(newVersion)))
+
+	if breaking := len(diff.Breaking(changes)); breaking > 0 {
+		sb.WriteString("<ac:structured-macro ac:name=\"warning\">\n<ac:rich-text-body>\n")
+		sb.WriteString(fmt.Sprintf("<p>%d breaking change(s) may require clients to be updated.</p>\n", breaking))
+		sb.WriteString("</ac:rich-text-body>\n</ac:structured-macro>\n")
+	}
+
+	var added, removed, changed, schemas []diff.Change
+	for _, change := range changes {
+		switch {
+		case change.Kind == diff.EndpointAdded:
+			added = append(added, change)
+		case change.Kind == diff.EndpointRemoved:
+			removed = append(removed, change)
+		case change.Path != "":
+			changed = append(changed, change)
+		default:
+			schemas = append(schemas, change)
+		}
+	}
+
+	f.writeChanges(&sb, "Added Endpoints", added)
+	f.writeChanges(&sb, "Removed Endpoints", removed)
+	f.writeChanges(&sb, "Changed Endpoints", changed)
+	f.writeChanges(&sb, "Changed Schemas", schemas)
+
+	return sb.String()
+}
+
+// writeChanges writes a titled list of changes, or nothing when there are
+// none
+func (f *Formatter) writeChanges(sb *strings.Builder, title string, changes []diff.Change) {
+	if len(changes) == 0 {
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("<h2>%s</h2>\n<ul>\n", title))
+	for _, change := range changes {
+		sb.WriteString("<li>")
+		if change.Breaking {
+			sb.WriteString("<ac:structured-macro ac:name=\"status\">" +
+				"<ac:parameter ac:name=\"colour\">Red</ac:parameter>" +
+				"<ac:parameter ac:name=\"title\">BREAKING</ac:parameter>" +
+				"</ac:structured-macro> ")
+		}
+		writeText(sb, change.Message)
+		sb.WriteString("</li>\n")
+	}
+	sb.WriteString("</ul>\n")
+}
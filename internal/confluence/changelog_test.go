@@ -0,0 +1,80 @@
+package confluence
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/diff"
+)
+
+func TestFormatter_FormatChangelogPage(t *testing.T) {
+	changes := []diff.Change{
+		{Kind: diff.EndpointAdded, Method: "POST", Path: "/pets", Message: "POST /pets was added"},
+		{Kind: diff.EndpointRemoved, Breaking: true, Method: "DELETE", Path: "/pets/{id}", Message: "DELETE /pets/{id} was removed"},
+		{Kind: diff.ParameterRequired, Breaking: true, Method: "GET", Path: "/pets", Message: "GET /pets: parameter limit is now required"},
+		{Kind: diff.PropertyAdded, Schema: "Pet", Message: "schema Pet: property <tag> was added"},
+	}
+
+	content := NewFormatter().FormatChangelogPage("Pets", "1.0", "2.0", changes)
+
+	for _, want := range []string{
+		"<h1>Pets Changelog</h1>",
+		"version <strong>1.0</strong> to <strong>2.0</strong>",
+		"<p>2 breaking change(s)",
+		"<h2>Added Endpoints</h2>\n<ul>\n<li>POST /pets was added</li>",
+		"<h2>Removed Endpoints</h2>",
+		"BREAKING</ac:parameter></ac:structured-macro> DELETE /pets/{id} was removed",
+		"<h2>Changed Endpoints</h2>",
+		"<h2>Changed Schemas</h2>\n<ul>\n<li>schema Pet: property &lt;tag&gt; was added</li>",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in:\n%s", want, content)
+		}
+	}
+}
+
+func TestClient_DownloadAttachment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/content/100/child/attachment" && r.URL.Query().Get("filename") == SpecSnapshotName:
+			w.Write([]byte(`{"results": [{"_links": {"download": "/download/attachments/100/spec.json?version=3"}}]}`))
+		case r.URL.Path == "/rest/api/content/200/child/attachment":
+			w.Write([]byte(`{"results": []}`))
+		case r.URL.Path == "/download/attachments/100/spec.json" && r.URL.Query().Get("version") == "3":
+			w.Write([]byte(`{"swagger": "2.0"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(config.ConfluenceConfig{
+		BaseURL:  server.URL,
+		Username: "user",
+		APIToken: "token",
+		SpaceKey: "TEST",
+		Enabled:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := c.(*ConfluenceClient)
+
+	data, err := client.DownloadAttachment(context.Background(), "100", SpecSnapshotName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"swagger": "2.0"}` {
+		t.Errorf("DownloadAttachment() = %q", data)
+	}
+
+	data, err = client.DownloadAttachment(context.Background(), "200", SpecSnapshotName)
+	if err != nil || data != nil {
+		t.Errorf("DownloadAttachment() = %q, %v; want nil for a missing attachment", data, err)
+	}
+}
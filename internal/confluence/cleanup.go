@@ -0,0 +1,143 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ChildPages returns the pages directly below pageID with their content
+func (c *ConfluenceClient) ChildPages(ctx context.Context, pageID string) ([]Page, error) {
+	if !c.cfg.Enabled || pageID == "" {
+		return nil, nil
+	}
+
+	var pages []Page
+	for start := 0; ; start += indexPageSize {
+		apiURL := fmt.Sprintf("%s/rest/api/content/%s/child/page?expand=%s&limit=%d&start=%d",
+			c.cfg.BaseURL, pageID, pageExpand, indexPageSize, start)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		c.authorize(req)
+
+		resp, err := c.do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list child pages: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(bodyBytes))
+		}
+
+		var result SearchResponse
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		pages = append(pages, result.Results...)
+		if len(result.Results) < indexPageSize {
+			return pages, nil
+		}
+	}
+}
+
+// DeletePage moves a page to the space trash and forgets it
+func (c *ConfluenceClient) DeletePage(ctx context.Context, pageID string) error {
+	if !c.cfg.Enabled || c.cfg.DryRun || pageID == "" {
+		return nil
+	}
+
+	apiURL := fmt.Sprintf("%s/rest/api/content/%s", c.cfg.BaseURL, pageID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.authorize(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to delete page %s: unexpected status %d: %s", pageID, resp.StatusCode, string(bodyBytes))
+	}
+
+	// Deleted pages must not be found again by this or later runs
+	for title, page := range c.state {
+		if page.ID == pageID {
+			delete(c.state, title)
+		}
+	}
+	for title, page := range c.pageIndex {
+		if page.ID == pageID {
+			delete(c.pageIndex, title)
+		}
+	}
+
+	return nil
+}
+
+// ArchivePageTitle returns the title of the page holding pruned endpoint
+// pages
+func ArchivePageTitle(scope string) string {
+	return fmt.Sprintf("%s - Archived Pages", scope)
+}
+
+// FormatArchivePage generates the page holding pruned endpoint pages
+func (f *Formatter) FormatArchivePage(scope string) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("<h1>%s Archived Pages</h1>\n", html.EscapeString(scope)))
+	sb.WriteString("<p>Endpoint pages whose operations were removed from the spec. They are no longer updated.</p>\n")
+	sb.WriteString("<p><ac:structured-macro ac:name=\"children\">\n")
+	sb.WriteString("<ac:parameter ac:name=\"all\">true</ac:parameter>\n")
+	sb.WriteString("<ac:parameter ac:name=\"sort\">title</ac:parameter>\n")
+	sb.WriteString("</ac:structured-macro></p>\n")
+
+	return sb.String()
+}
+
+// MovePage moves a page below parentPageID, keeping its title and content
+func (c *ConfluenceClient) MovePage(ctx context.Context, page Page, parentPageID string) error {
+	if !c.cfg.Enabled || c.cfg.DryRun || page.ID == "" {
+		return nil
+	}
+
+	version := 0
+	if page.Version != nil {
+		version = page.Version.Number
+	}
+	moved := Page{
+		ID:        page.ID,
+		Type:      "page",
+		Title:     page.Title,
+		Space:     Space{Key: c.cfg.SpaceKey},
+		Body:      Body{Storage: Storage{Value: page.Body.Storage.Value, Representation: "storage"}},
+		Version:   &Version{Number: version + 1, Message: "Archived: removed from the spec", MinorEdit: c.cfg.MinorEdit},
+		Ancestors: []PageAncestor{{ID: parentPageID}},
+	}
+	if _, err := c.updatePage(ctx, &moved); err != nil {
+		return fmt.Errorf("failed to move page %q: %w", page.Title, err)
+	}
+
+	moved.Metadata = page.Metadata
+	c.rememberPage(&moved)
+	return nil
+}
@@ -0,0 +1,93 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+func TestIsEndpointPage(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"endpoint page", NewFormatter().formatNotesSection(), true},
+		{"region mode endpoint page", "<p>intro</p>" + wrapGenerated(NewFormatter().formatNotesSection()), true},
+		{"comment markers", commentMarker(ManualStartMarker), true},
+		{"markers normalized by Confluence", "<h2>Get Pet</h2>" + storedAnchor(ManualStartMarker, "5d1c"), true},
+		{"region mode models page", wrapGenerated("<h1>Models</h1>"), false},
+		{"hand-written page", "<p>Team notes</p>", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsEndpointPage(tt.content); got != tt.want {
+				t.Errorf("IsEndpointPage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ChildPagesMoveAndDelete(t *testing.T) {
+	var deleted []string
+	var moved Page
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/content/100/child/page":
+			w.Write([]byte(`{"results": [{"id": "7", "title": "Get Pet", "version": {"number": 2},
+				"body": {"storage": {"value": "<p>pet</p>", "representation": "storage"}}}]}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/rest/api/content/7":
+			deleted = append(deleted, "7")
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPut && r.URL.Path == "/rest/api/content/7":
+			if err := json.NewDecoder(r.Body).Decode(&moved); err != nil {
+				t.Errorf("failed to decode move: %v", err)
+			}
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(config.ConfluenceConfig{
+		BaseURL:  server.URL,
+		Username: "user",
+		APIToken: "token",
+		SpaceKey: "TEST",
+		Enabled:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := c.(*ConfluenceClient)
+	ctx := context.Background()
+
+	pages, err := client.ChildPages(ctx, "100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 1 || pages[0].Title != "Get Pet" || pages[0].Body.Storage.Value != "<p>pet</p>" {
+		t.Fatalf("ChildPages() = %+v", pages)
+	}
+
+	if err := client.MovePage(ctx, pages[0], "300"); err != nil {
+		t.Fatal(err)
+	}
+	if len(moved.Ancestors) != 1 || moved.Ancestors[0].ID != "300" || moved.Version.Number != 3 ||
+		moved.Body.Storage.Value != "<p>pet</p>" {
+		t.Errorf("moved page = %+v, want page 7 version 3 below page 300 with its content", moved)
+	}
+
+	if err := client.DeletePage(ctx, pages[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(deleted) != 1 {
+		t.Errorf("deleted = %v, want page 7", deleted)
+	}
+}
@@ -4,38 +4,125 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
 	"io"
 	"net/http"
 	"net/url"
-	"time"
+	"strings"
 
 	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/httpclient"
 )
 
 type Client interface {
 	CreateOrUpdatePage(ctx context.Context, title, content, parentPageID string) (string, error)
 	CreateParentPage(ctx context.Context, apiTitle string) (string, error)
+	ResolveParentPage(ctx context.Context) (string, error)
+	AddLabels(ctx context.Context, pageID string, labels []string) error
+	ExportPDF(ctx context.Context, pageID string) ([]byte, error)
+	UploadAttachment(ctx context.Context, pageID, fileName, contentType string, data []byte) error
+	PageURL(pageID string) string
 }
 
 // Client handles Confluence API interactions
 type ConfluenceClient struct {
 	cfg        config.ConfluenceConfig
 	httpClient *http.Client
+	// retry re-sends requests throttled by Confluence
+	retry *httpclient.Retry
+
+	// apiTitle and apiVersion describe the spec being published
+	apiTitle   string
+	apiVersion string
+	// parentContent replaces the introduction of the API page when set
+	parentContent string
+	// historyPageIDs caches history subtree roots by parent page ID
+	historyPageIDs map[string]string
+	// pageIndex holds the pages below indexRoot by title, once loaded
+	pageIndex map[string]*Page
+	indexRoot string
+	// state holds the pages written by this and previous runs by title,
+	// when a state file is configured
+	state map[string]*Page
+	// operations maps operation keys to endpoint page IDs, when a state
+	// file is configured
+	operations map[string]string
+	// lastChange is the change kind of the last page published
+	lastChange string
+	// specName, specFormat and specDocument describe the source document
+	// of the spec being published
+	specName     string
+	specFormat   string
+	specDocument []byte
 }
 
 // NewClient creates a new Confluence client
-func NewClient(cfg config.ConfluenceConfig) Client {
-	return &ConfluenceClient{
-		cfg: cfg,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+func NewClient(cfg config.ConfluenceConfig) (Client, error) {
+	httpClient, err := httpclient.New(cfg.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure confluence transport: %w", err)
+	}
+
+	retry := httpclient.NewRetry(cfg.MaxAttempts)
+	if cfg.MaxRetryAfter > 0 {
+		retry.MaxRetryAfter = cfg.MaxRetryAfter
 	}
+	client := &ConfluenceClient{
+		cfg:            cfg,
+		httpClient:     httpClient,
+		retry:          retry,
+		historyPageIDs: make(map[string]string),
+	}
+
+	if cfg.StateFile != "" && cfg.Enabled {
+		if client.state, client.operations, err = loadState(cfg.StateFile, cfg.BaseURL, cfg.SpaceKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
+
+// Authentication types
+const (
+	// AuthBasic sends the username and API token as basic authentication
+	AuthBasic = "basic"
+	// AuthBearer sends the API token as a bearer token, as Confluence
+	// Server and Data Center expect personal access tokens
+	AuthBearer = "bearer"
+)
+
+// authorize adds the configured credentials to a request
+func (c *ConfluenceClient) authorize(req *http.Request) {
+	if c.cfg.AuthType == AuthBearer {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.APIToken)
+		return
+	}
+	req.SetBasicAuth(c.cfg.Username, c.cfg.APIToken)
+}
+
+// do sends a request, retrying it while Confluence rate limits the client
+func (c *ConfluenceClient) do(req *http.Request) (*http.Response, error) {
+	return c.retry.Do(c.httpClient, req)
+}
+
+// SetAPIVersion records the version of the spec being published. It is
+// stored as the version message of every page written.
+func (c *ConfluenceClient) SetAPIVersion(version string) {
+	c.apiVersion = version
 }
 
 // CreateOrUpdatePage creates or updates a Confluence page
 func (c *ConfluenceClient) CreateOrUpdatePage(ctx context.Context, title, content, parentPageID string) (string, error) {
+	return c.publishPage(ctx, title, content, parentPageID, c.cfg.History)
+}
+
+// publishPage creates or updates a page, archiving the previous rendering
+// first when archive is set and the API version changed
+func (c *ConfluenceClient) publishPage(ctx context.Context, title, content, parentPageID string, archive bool) (string, error) {
+	c.lastChange = ""
 	if !c.cfg.Enabled {
 		// Print to console if Confluence is disabled
 		fmt.Printf("\n=== Page: %s ===\n%s\n\n", title, content)
@@ -43,10 +130,50 @@ func (c *ConfluenceClient) CreateOrUpdatePage(ctx context.Context, title, conten
 	}
 
 	// Check if page exists
-	existingPageID, version, err := c.findPageByTitle(ctx, title)
+	existing, cached, err := c.lookupPage(ctx, title)
 	if err != nil {
 		return "", fmt.Errorf("failed to check existing page: %w", err)
 	}
+	generated := content
+
+	// Carry hand-written notes over from the current version
+	if existing != nil {
+		content = PreserveManualSection(existing.Body.Storage.Value, content)
+	}
+
+	// In region mode the page belongs to humans; only the generated block is ours
+	if c.cfg.UpdateMode == UpdateModeRegion {
+		if existing != nil {
+			content = ReplaceGeneratedRegion(existing.Body.Storage.Value, content)
+		} else {
+			content = ReplaceGeneratedRegion("", content)
+		}
+	}
+
+	if c.cfg.DryRun {
+		c.lastChange = c.previewChange(title, existing, content)
+		if existing == nil {
+			return "", nil
+		}
+		return existing.ID, nil
+	}
+
+	// Rewriting identical content would only add a page version and notify
+	// watchers; a page being renamed is written regardless
+	hash := contentHash(content)
+	var hashVersion int
+	if existing != nil {
+		var recorded string
+		recorded, hashVersion = recordedHash(existing)
+		if recorded == hash && existing.Title == title {
+			fmt.Printf("= Unchanged page: %s - %s\n", title, c.PageURL(existing.ID))
+			if err := c.AddLabels(ctx, existing.ID, c.pageLabels(content)); err != nil {
+				return "", err
+			}
+			c.lastChange = ChangeUnchanged
+			return existing.ID, nil
+		}
+	}
 
 	page := Page{
 		Type:  "page",
@@ -64,15 +191,104 @@ func (c *ConfluenceClient) CreateOrUpdatePage(ctx context.Context, title, conten
 		page.Ancestors = []PageAncestor{{ID: parentPageID}}
 	}
 
-	if existingPageID != "" {
+	if existing != nil && archive && parentPageID != "" {
+		if err := c.archivePage(ctx, existing, parentPageID); err != nil {
+			return "", fmt.Errorf("failed to archive previous version: %w", err)
+		}
+	}
+
+	var pageID string
+	if existing != nil {
 		// Update existing page
-		page.ID = existingPageID
-		page.Version = &Version{Number: version + 1}
-		return c.updatePage(ctx, &page)
+		version := 0
+		if existing.Version != nil {
+			version = existing.Version.Number
+		}
+		page.ID = existing.ID
+		page.Version = c.updateVersion(version + 1)
+		pageID, err = c.updatePage(ctx, &page)
+		if cached && errors.Is(err, errStalePage) {
+			// Edited or removed since the last run; look it up again
+			delete(c.state, title)
+			return c.publishPage(ctx, title, generated, parentPageID, archive)
+		}
+	} else {
+		if c.apiVersion != "" {
+			page.Version = &Version{Number: 1, Message: c.versionMessage()}
+		}
+		// Create new page
+		pageID, err = c.createPage(ctx, &page)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	page.ID = pageID
+	if page.Version == nil {
+		page.Version = &Version{Number: 1}
+	}
+	if err := c.recordHash(ctx, &page, hash, hashVersion); err != nil {
+		return "", err
+	}
+	c.rememberPage(&page)
+
+	if err := c.AddLabels(ctx, pageID, c.pageLabels(content)); err != nil {
+		return "", err
+	}
+
+	c.lastChange = ChangeCreate
+	if existing != nil {
+		c.lastChange = ChangeUpdate
+	}
+	return pageID, nil
+}
+
+// LastChange returns whether the last page published was created, updated
+// or left unchanged, or "" when nothing was written to Confluence
+func (c *ConfluenceClient) LastChange() string {
+	return c.lastChange
+}
+
+// AddLabels adds global labels to a page. Labels already on the page are
+// left untouched by Confluence.
+func (c *ConfluenceClient) AddLabels(ctx context.Context, pageID string, labels []string) error {
+	if !c.cfg.Enabled || c.cfg.DryRun || pageID == "" || len(labels) == 0 {
+		return nil
+	}
+
+	apiURL := fmt.Sprintf("%s/rest/api/content/%s/label", c.cfg.BaseURL, pageID)
+
+	payload := make([]Label, 0, len(labels))
+	for _, name := range labels {
+		payload = append(payload, Label{Prefix: "global", Name: name})
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal labels: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.authorize(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to add labels: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to add labels %s: unexpected status %d: %s",
+			strings.Join(labels, ","), resp.StatusCode, string(bodyBytes))
 	}
 
-	// Create new page
-	return c.createPage(ctx, &page)
+	return nil
 }
 
 // createPage creates a new page
@@ -89,10 +305,10 @@ func (c *ConfluenceClient) createPage(ctx context.Context, page *Page) (string,
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.SetBasicAuth(c.cfg.Username, c.cfg.APIToken)
+	c.authorize(req)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to create page: %w", err)
 	}
@@ -108,8 +324,7 @@ func (c *ConfluenceClient) createPage(ctx context.Context, page *Page) (string,
 		return "", fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	pageURL := fmt.Sprintf("%s/pages/viewpage.action?pageId=%s", c.cfg.BaseURL, result.ID)
-	fmt.Printf("✓ Created page: %s - %s\n", page.Title, pageURL)
+	fmt.Printf("✓ Created page: %s - %s\n", page.Title, c.PageURL(result.ID))
 
 	return result.ID, nil
 }
@@ -128,76 +343,214 @@ func (c *ConfluenceClient) updatePage(ctx context.Context, page *Page) (string,
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.SetBasicAuth(c.cfg.Username, c.cfg.APIToken)
+	c.authorize(req)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to update page: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusConflict || resp.StatusCode == http.StatusNotFound {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("%w: unexpected status %d: %s", errStalePage, resp.StatusCode, string(bodyBytes))
+	}
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
 		return "", fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(bodyBytes))
 	}
 
-	pageURL := fmt.Sprintf("%s/pages/viewpage.action?pageId=%s", c.cfg.BaseURL, page.ID)
-	fmt.Printf("✓ Updated page: %s - %s\n", page.Title, pageURL)
+	fmt.Printf("✓ Updated page: %s - %s\n", page.Title, c.PageURL(page.ID))
 
 	return page.ID, nil
 }
 
-// findPageByTitle finds a page by title
-func (c *ConfluenceClient) findPageByTitle(ctx context.Context, title string) (string, int, error) {
-	apiURL := fmt.Sprintf("%s/rest/api/content?spaceKey=%s&title=%s&expand=version",
-		c.cfg.BaseURL, c.cfg.SpaceKey, url.QueryEscape(title))
+// PageURL returns the browser URL of a page
+func (c *ConfluenceClient) PageURL(pageID string) string {
+	if !c.cfg.Enabled || pageID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/pages/viewpage.action?pageId=%s", c.cfg.BaseURL, pageID)
+}
+
+// findPageByTitle finds a page by title, including its current storage
+// body. It returns nil when no page matches.
+func (c *ConfluenceClient) findPageByTitle(ctx context.Context, title string) (*Page, error) {
+	apiURL := fmt.Sprintf("%s/rest/api/content?spaceKey=%s&title=%s&expand=%s",
+		c.cfg.BaseURL, c.cfg.SpaceKey, url.QueryEscape(title), pageExpand)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
 	if err != nil {
-		return "", 0, fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.SetBasicAuth(c.cfg.Username, c.cfg.APIToken)
+	c.authorize(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
-		return "", 0, fmt.Errorf("failed to search page: %w", err)
+		return nil, fmt.Errorf("failed to search page: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return "", 0, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(bodyBytes))
 	}
 
 	var result SearchResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", 0, fmt.Errorf("failed to decode response: %w", err)
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	if len(result.Results) == 0 {
-		return "", 0, nil
+		return nil, nil
+	}
+
+	return &result.Results[0], nil
+}
+
+// FindPage returns the ID of the page with the title, or "" if the space
+// has no such page
+func (c *ConfluenceClient) FindPage(ctx context.Context, title string) (string, error) {
+	if !c.cfg.Enabled {
+		return "", nil
 	}
+	page, _, err := c.lookupPage(ctx, title)
+	if err != nil || page == nil {
+		return "", err
+	}
+	return page.ID, nil
+}
 
-	page := result.Results[0]
-	version := 0
-	if page.Version != nil {
-		version = page.Version.Number
+// EditPage rewrites the content of the page with the title through edit,
+// keeping its position in the page tree. Missing pages and edits that
+// change nothing are left alone.
+func (c *ConfluenceClient) EditPage(ctx context.Context, title string, edit func(content string) string) error {
+	if !c.cfg.Enabled {
+		return nil
+	}
+	found, _, err := c.lookupPage(ctx, title)
+	if err != nil {
+		return fmt.Errorf("failed to check existing page: %w", err)
+	}
+	if found == nil {
+		return nil
+	}
+
+	// The page may have been edited since it was looked up or recorded
+	existing, err := c.getPage(ctx, found.ID)
+	if err != nil || existing == nil {
+		return err
+	}
+	content := edit(existing.Body.Storage.Value)
+	if content == existing.Body.Storage.Value {
+		return nil
+	}
+	if c.cfg.DryRun {
+		c.previewChange(title, existing, content)
+		return nil
 	}
 
-	return page.ID, version, nil
+	// The page may document another API version, so its version message is
+	// kept; the edit is minor and doesn't notify watchers
+	version := &Version{Number: 1, MinorEdit: true}
+	if existing.Version != nil {
+		version.Number = existing.Version.Number + 1
+		version.Message = existing.Version.Message
+	}
+	page := Page{
+		ID:      existing.ID,
+		Type:    "page",
+		Title:   existing.Title,
+		Space:   Space{Key: c.cfg.SpaceKey},
+		Body:    Body{Storage: Storage{Value: content, Representation: "storage"}},
+		Version: version,
+	}
+	if _, err := c.updatePage(ctx, &page); err != nil {
+		return err
+	}
+
+	// Record the edited content so the next run of the page's own version
+	// sees it as unchanged
+	_, hashVersion := recordedHash(existing)
+	if err := c.recordHash(ctx, &page, contentHash(content), hashVersion); err != nil {
+		return err
+	}
+	c.rememberPage(&page)
+	return nil
+}
+
+// ResolveParentPage resolves the configured parent page to an ID.
+// A parent given by title is looked up in the space and, if CreateParent
+// is set, created at the space root when missing.
+func (c *ConfluenceClient) ResolveParentPage(ctx context.Context) (string, error) {
+	if !c.cfg.Enabled || c.cfg.ParentPageID != "" || c.cfg.ParentPageTitle == "" {
+		return c.cfg.ParentPageID, nil
+	}
+
+	existing, err := c.findPageByTitle(ctx, c.cfg.ParentPageTitle)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up parent page %q: %w", c.cfg.ParentPageTitle, err)
+	}
+
+	var pageID string
+	if existing != nil {
+		pageID = existing.ID
+	} else {
+		if !c.cfg.CreateParent {
+			return "", fmt.Errorf("parent page %q not found in space %s", c.cfg.ParentPageTitle, c.cfg.SpaceKey)
+		}
+		content := fmt.Sprintf("<p>%s</p>\n", html.EscapeString(c.cfg.ParentPageTitle))
+		pageID, err = c.CreateOrUpdatePage(ctx, c.cfg.ParentPageTitle, content, "")
+		if err != nil {
+			return "", fmt.Errorf("failed to create parent page %q: %w", c.cfg.ParentPageTitle, err)
+		}
+	}
+
+	c.cfg.ParentPageID = pageID
+	return pageID, nil
+}
+
+// APIPageTitle returns the title of the page documenting an API
+func APIPageTitle(apiTitle string) string {
+	return fmt.Sprintf("%s - API Documentation", apiTitle)
+}
+
+// parentPageContent formats the page documenting an API: its introduction
+// followed by the Swagger UI and spec sections
+func (c *ConfluenceClient) parentPageContent(apiTitle string) string {
+	intro := c.parentContent
+	if intro == "" {
+		intro = DefaultParentPageContent(apiTitle)
+	}
+	return intro + c.swaggerUISection() + c.specSection()
+}
+
+// SetParentPageContent replaces the default introduction of the API page
+func (c *ConfluenceClient) SetParentPageContent(content string) {
+	c.parentContent = content
 }
 
 // CreateParentPage creates or updates the parent documentation page
 func (c *ConfluenceClient) CreateParentPage(ctx context.Context, apiTitle string) (string, error) {
-	title := fmt.Sprintf("%s - API Documentation", apiTitle)
-	content := fmt.Sprintf(`<h1>%s</h1>
-<p>This page contains the API documentation for %s. Each endpoint has its own page below.</p>
-<p><strong>Generated automatically from Swagger/OpenAPI specification</strong></p>
-<p><ac:structured-macro ac:name="children">
-<ac:parameter ac:name="all">true</ac:parameter>
-</ac:structured-macro></p>`, apiTitle, apiTitle)
-
-	return c.CreateOrUpdatePage(ctx, title, content, "")
+	c.apiTitle = apiTitle
+	title := APIPageTitle(apiTitle)
+
+	pageID, err := c.publishPage(ctx, title, c.parentPageContent(apiTitle), c.cfg.ParentPageID, false)
+	if err != nil {
+		return "", err
+	}
+	if err := c.attachSpec(ctx, pageID); err != nil {
+		return "", err
+	}
+
+	// Endpoint pages are indexed with one query on the first lookup
+	// instead of being searched one by one
+	if pageID != c.indexRoot {
+		c.indexRoot = pageID
+		c.pageIndex = nil
+	}
+
+	return pageID, nil
 }
@@ -2,8 +2,10 @@ package confluence
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/ahmadimt/SwagFluence/internal/config"
@@ -35,6 +37,26 @@ func (m *MockClient) CreateParentPage(ctx context.Context, apiTitle string) (str
 	return "", nil
 }
 
+func (m *MockClient) ResolveParentPage(ctx context.Context) (string, error) {
+	return m.cfg.ParentPageID, nil
+}
+
+func (m *MockClient) AddLabels(ctx context.Context, pageID string, labels []string) error {
+	return nil
+}
+
+func (m *MockClient) ExportPDF(ctx context.Context, pageID string) ([]byte, error) {
+	return []byte("%PDF-1.4"), nil
+}
+
+func (m *MockClient) UploadAttachment(ctx context.Context, pageID, fileName, contentType string, data []byte) error {
+	return nil
+}
+
+func (m *MockClient) PageURL(pageID string) string {
+	return m.cfg.BaseURL + "/pages/viewpage.action?pageId=" + pageID
+}
+
 func TestClient_CreateOrUpdatePage_Disabled(t *testing.T) {
 
 	cfg := config.ConfluenceConfig{
@@ -82,3 +104,180 @@ func TestClient_CreatePage(t *testing.T) {
 		t.Errorf("expected pageID '12345', got '%s'", pageID)
 	}
 }
+
+func TestClient_Authorization(t *testing.T) {
+	tests := []struct {
+		authType string
+		want     string
+	}{
+		{"", "Basic dXNlcjp0b2tlbg=="},
+		{AuthBasic, "Basic dXNlcjp0b2tlbg=="},
+		{AuthBearer, "Bearer token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.authType, func(t *testing.T) {
+			var got string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("Authorization")
+				if r.Method == http.MethodGet {
+					w.Write([]byte(`{"results": []}`))
+					return
+				}
+				w.Write([]byte(`{"id": "12345"}`))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(config.ConfluenceConfig{
+				BaseURL:  server.URL,
+				Username: "user",
+				APIToken: "token",
+				AuthType: tt.authType,
+				SpaceKey: "TEST",
+				Enabled:  true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := client.CreateOrUpdatePage(context.Background(), "Test Page", "Content", ""); err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Authorization = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_EditPage(t *testing.T) {
+	var updated Page
+	var hashed bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/content":
+			if r.URL.Query().Get("title") != "Get Pet (v1)" {
+				w.Write([]byte(`{"results": []}`))
+				return
+			}
+			w.Write([]byte(`{"results": [{"id": "7", "title": "Get Pet (v1)"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/content/7":
+			w.Write([]byte(`{"id": "7", "title": "Get Pet (v1)", "version": {"number": 3, "message": "Rendered from API version 1.0"},` +
+				`"body": {"storage": {"value": "<p>old</p>"}}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/rest/api/content/7":
+			if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+				t.Errorf("failed to decode page: %v", err)
+			}
+			w.Write([]byte(`{"id": "7"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/content/7/property":
+			hashed = true
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(config.ConfluenceConfig{BaseURL: server.URL, SpaceKey: "TEST", Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := c.(*ConfluenceClient)
+	ctx := context.Background()
+
+	edit := func(content string) string {
+		return strings.Replace(content, "old", "new", 1)
+	}
+	if err := client.EditPage(ctx, "Get Pet (v1)", edit); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Body.Storage.Value != "<p>new</p>" || updated.Ancestors != nil {
+		t.Errorf("updated page = %+v", updated)
+	}
+	if v := updated.Version; v == nil || v.Number != 4 || v.Message != "Rendered from API version 1.0" || !v.MinorEdit {
+		t.Errorf("version = %+v, want a minor edit keeping the message", v)
+	}
+	if !hashed {
+		t.Error("content hash of the edited page not recorded")
+	}
+
+	// Missing pages are left alone
+	if err := client.EditPage(ctx, "Get Pet (v3)", edit); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_ResolveParentPage(t *testing.T) {
+	const title = "Pets & <Owners>"
+	tests := []struct {
+		name         string
+		existing     string
+		createParent bool
+		lookupStatus int
+		want         string
+		wantCreated  bool
+		wantErr      string
+	}{
+		{name: "found", existing: `{"results": [{"id": "12", "title": "Pets & <Owners>"}]}`, want: "12"},
+		{name: "created", existing: `{"results": []}`, createParent: true, want: "99", wantCreated: true},
+		{name: "missing", existing: `{"results": []}`, wantErr: `parent page "Pets & <Owners>" not found in space TEST`},
+		{name: "lookup fails", lookupStatus: http.StatusForbidden, wantErr: `failed to look up parent page "Pets & <Owners>"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *Page
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/rest/api/content":
+					if r.URL.Query().Get("title") != title {
+						t.Errorf("looked up title %q", r.URL.Query().Get("title"))
+					}
+					if tt.lookupStatus != 0 {
+						w.WriteHeader(tt.lookupStatus)
+						return
+					}
+					w.Write([]byte(tt.existing))
+				case r.Method == http.MethodPost && r.URL.Path == "/rest/api/content":
+					created = &Page{}
+					if err := json.NewDecoder(r.Body).Decode(created); err != nil {
+						t.Errorf("failed to decode page: %v", err)
+					}
+					w.Write([]byte(`{"id": "99"}`))
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/property"):
+					w.Write([]byte(`{}`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+			}))
+			defer server.Close()
+
+			c, err := NewClient(config.ConfluenceConfig{
+				BaseURL: server.URL, SpaceKey: "TEST", Enabled: true,
+				ParentPageTitle: title, CreateParent: tt.createParent,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := c.ResolveParentPage(context.Background())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ResolveParentPage() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveParentPage() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveParentPage() = %q, want %q", got, tt.want)
+			}
+			if (created != nil) != tt.wantCreated {
+				t.Fatalf("parent page created = %v, want %v", created != nil, tt.wantCreated)
+			}
+			if created != nil && (created.Title != title || created.Body.Storage.Value != "<p>Pets &amp; &lt;Owners&gt;</p>\n") {
+				t.Errorf("created page = %q with %q, want the escaped title", created.Title, created.Body.Storage.Value)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package confluence
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Sizes above which endpoint page content is collapsed by default
+const (
+	DefaultCollapseProperties = 30
+	DefaultCollapseLines      = 80
+)
+
+// collapseLimits are the sizes above which schema tables and example
+// bodies are wrapped in expand macros. Zero uses the defaults; a negative
+// limit never collapses.
+type collapseLimits struct {
+	properties int
+	lines      int
+}
+
+// collapsesTable reports whether a schema table with this many properties
+// is collapsed
+func (l collapseLimits) collapsesTable(properties int) bool {
+	return exceedsLimit(properties, l.properties, DefaultCollapseProperties)
+}
+
+// collapsesExample reports whether an example body is collapsed
+func (l collapseLimits) collapsesExample(exampleJSON string) bool {
+	return exceedsLimit(lineCount(exampleJSON), l.lines, DefaultCollapseLines)
+}
+
+func exceedsLimit(size, limit, defaultLimit int) bool {
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	return limit > 0 && size > limit
+}
+
+func lineCount(text string) int {
+	return strings.Count(text, "\n") + 1
+}
+
+// WithCollapse returns a copy of the formatter collapsing schema tables
+// with more than properties rows and example bodies longer than lines
+func (f *Formatter) WithCollapse(properties, lines int) *Formatter {
+	configured := *f
+	configured.collapse = collapseLimits{properties: properties, lines: lines}
+	return &configured
+}
+
+// WithCollapse returns a copy of the formatter collapsing schema tables
+// with more than properties rows and example bodies longer than lines
+func (f *ADFFormatter) WithCollapse(properties, lines int) *ADFFormatter {
+	configured := *f
+	configured.collapse = collapseLimits{properties: properties, lines: lines}
+	return &configured
+}
+
+// expandMacro wraps storage markup in an expand macro titled title
+func expandMacro(title, body string) string {
+	var sb strings.Builder
+	sb.WriteString("<ac:structured-macro ac:name=\"expand\">\n")
+	sb.WriteString("<ac:parameter ac:name=\"title\">")
+	writeText(&sb, title)
+	sb.WriteString("</ac:parameter>\n")
+	sb.WriteString("<ac:rich-text-body>\n")
+	sb.WriteString(body)
+	sb.WriteString("</ac:rich-text-body>\n")
+	sb.WriteString("</ac:structured-macro>\n")
+	return sb.String()
+}
+
+// collapsibleJSON formats example JSON in a code macro, wrapped in an
+// expand macro when it is longer than the limit
+func (f *Formatter) collapsibleJSON(exampleJSON string) string {
+	if !f.collapse.collapsesExample(exampleJSON) {
+		return jsonCodeMacro(exampleJSON)
+	}
+	return expandMacro(fmt.Sprintf("Show example (%d lines)", lineCount(exampleJSON)), jsonCodeMacro(exampleJSON))
+}
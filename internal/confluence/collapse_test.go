@@ -0,0 +1,67 @@
+package confluence
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func TestFormatter_Collapse(t *testing.T) {
+	schema := &swagger.Schema{Type: "object", Properties: map[string]swagger.Property{}}
+	for i := range 5 {
+		schema.Properties[fmt.Sprintf("field%d", i)] = swagger.Property{Type: "string"}
+	}
+	exampleJSON := "{\n  \"a\": 1,\n  \"b\": 2\n}"
+
+	tests := []struct {
+		name        string
+		formatter   *Formatter
+		wantTable   bool
+		wantExample bool
+	}{
+		{name: "defaults", formatter: NewFormatter()},
+		{name: "above limits", formatter: NewFormatter().WithCollapse(4, 3), wantTable: true, wantExample: true},
+		{name: "at limits", formatter: NewFormatter().WithCollapse(5, 4)},
+		{name: "never", formatter: NewFormatter().WithCollapse(-1, -1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := tt.formatter.formatSchemaTable(schema)
+			if got := strings.Contains(table, "Show 5 fields"); got != tt.wantTable {
+				t.Errorf("table collapsed = %v, want %v:\n%s", got, tt.wantTable, table)
+			}
+			example := tt.formatter.formatExampleJSON(exampleJSON)
+			if got := strings.Contains(example, "Show example (4 lines)"); got != tt.wantExample {
+				t.Errorf("example collapsed = %v, want %v:\n%s", got, tt.wantExample, example)
+			}
+		})
+	}
+}
+
+func TestADFFormatter_Collapse(t *testing.T) {
+	op, resolver := benchmarkEndpoint(4)
+
+	doc, err := NewADFFormatter().WithCollapse(3, -1).FormatEndpointPage("/tenants/{tenant}/orders", "post", op, resolver)
+	if err != nil {
+		t.Fatalf("FormatEndpointPage() error = %v", err)
+	}
+
+	var titles []string
+	var walk func(nodes []ADFNode)
+	walk = func(nodes []ADFNode) {
+		for _, node := range nodes {
+			if node.Type == "expand" {
+				titles = append(titles, fmt.Sprint(node.Attrs["title"]))
+			}
+			walk(node.Content)
+		}
+	}
+	walk(doc.Content)
+
+	if got := strings.Count(strings.Join(titles, "\n"), "Show 4 fields"); got != 2 {
+		t.Errorf("collapsed tables = %d, want the request and response tables: %q", got, titles)
+	}
+}
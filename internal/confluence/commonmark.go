@@ -0,0 +1,204 @@
+package confluence
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// OpenAPI descriptions are CommonMark. The converter below covers the subset
+// specs use in practice: paragraphs, ATX headings, bullet and ordered lists,
+// block quotes, fenced code blocks, thematic breaks, code spans, emphasis,
+// links and autolinks. Raw HTML is escaped, since storage format must be
+// well-formed XHTML.
+
+var (
+	mdHeading     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdFence       = regexp.MustCompile("^(```+|~~~+)\\s*([\\w+-]*)")
+	mdBullet      = regexp.MustCompile(`^\s{0,3}[-*+]\s+(.*)$`)
+	mdOrdered     = regexp.MustCompile(`^\s{0,3}\d{1,9}[.)]\s+(.*)$`)
+	mdQuote       = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	mdBreak       = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	mdLink        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdAutolink    = regexp.MustCompile(`&lt;(https?://[^\s&]+)&gt;`)
+	mdStrongStar  = regexp.MustCompile(`\*\*([^*\s](?:[^*]*[^*\s])?)\*\*`)
+	mdStrongUnder = regexp.MustCompile(`(^|\W)__([^_\s](?:[^_]*[^_\s])?)__(\W|$)`)
+	mdEmStar      = regexp.MustCompile(`\*([^*\s](?:[^*]*[^*\s])?)\*`)
+	mdEmUnder     = regexp.MustCompile(`(^|\W)_([^_\s](?:[^_]*[^_\s])?)_(\W|$)`)
+)
+
+// MarkdownToStorage converts a CommonMark description to storage format
+// blocks
+func MarkdownToStorage(text string) string {
+	var sb strings.Builder
+	writeMarkdownBlocks(&sb, strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n"))
+	return sb.String()
+}
+
+// markdownCell converts a description for a table cell or another place
+// that already reads as one paragraph. A single paragraph is returned as
+// inline markup without a <p> wrapper.
+func markdownCell(text string) string {
+	text = strings.TrimSpace(text)
+	if isSingleParagraph(text) {
+		return markdownInline(text)
+	}
+	return MarkdownToStorage(text)
+}
+
+// isSingleParagraph reports whether text has no blank lines and no line
+// that starts a block other than a paragraph
+func isSingleParagraph(text string) bool {
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" || startsBlock(line) {
+			return false
+		}
+	}
+	return true
+}
+
+// startsBlock reports whether line starts a block other than a paragraph
+func startsBlock(line string) bool {
+	trimmed := strings.TrimLeft(line, " ")
+	return mdHeading.MatchString(trimmed) || mdFence.MatchString(trimmed) ||
+		mdBullet.MatchString(line) || mdOrdered.MatchString(line) ||
+		mdQuote.MatchString(line) || mdBreak.MatchString(line)
+}
+
+// writeMarkdownBlocks converts lines of block-level markdown
+func writeMarkdownBlocks(sb *strings.Builder, lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimLeft(line, " ")
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+
+		case mdFence.MatchString(trimmed):
+			match := mdFence.FindStringSubmatch(trimmed)
+			fence, language := match[1], match[2]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimLeft(lines[i], " "), fence); i++ {
+				code = append(code, lines[i])
+			}
+			i++ // closing fence
+			writeCodeMacro(sb, language, strings.Join(code, "\n"))
+
+		case mdHeading.MatchString(trimmed):
+			match := mdHeading.FindStringSubmatch(trimmed)
+			// Descriptions sit below the page's own headings
+			level := len(match[1]) + 3
+			if level > 6 {
+				level = 6
+			}
+			fmt.Fprintf(sb, "<h%d>%s</h%d>\n", level, markdownInline(match[2]), level)
+			i++
+
+		case mdBreak.MatchString(line):
+			sb.WriteString("<hr/>\n")
+			i++
+
+		case mdQuote.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && mdQuote.MatchString(lines[i]); i++ {
+				quoted = append(quoted, mdQuote.FindStringSubmatch(lines[i])[1])
+			}
+			sb.WriteString("<blockquote>\n")
+			writeMarkdownBlocks(sb, quoted)
+			sb.WriteString("</blockquote>\n")
+
+		case mdBullet.MatchString(line), mdOrdered.MatchString(line):
+			i = writeMarkdownList(sb, lines, i)
+
+		default:
+			var paragraph []string
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != "" && (len(paragraph) == 0 || !startsBlock(lines[i])); i++ {
+				paragraph = append(paragraph, strings.TrimSpace(lines[i]))
+			}
+			sb.WriteString("<p>")
+			sb.WriteString(markdownInline(strings.Join(paragraph, "\n")))
+			sb.WriteString("</p>\n")
+		}
+	}
+}
+
+// writeMarkdownList converts the list starting at lines[start] and returns
+// the index of the first line after it. Indented lines continue the current
+// item.
+func writeMarkdownList(sb *strings.Builder, lines []string, start int) int {
+	pattern, tag := mdBullet, "ul"
+	if !mdBullet.MatchString(lines[start]) {
+		pattern, tag = mdOrdered, "ol"
+	}
+
+	var items []string
+	i := start
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if match := pattern.FindStringSubmatch(line); match != nil {
+			items = append(items, match[1])
+			continue
+		}
+		if strings.TrimSpace(line) == "" || !strings.HasPrefix(line, " ") {
+			break
+		}
+		items[len(items)-1] += "\n" + strings.TrimSpace(line)
+	}
+
+	fmt.Fprintf(sb, "<%s>\n", tag)
+	for _, item := range items {
+		sb.WriteString("<li>")
+		sb.WriteString(markdownInline(item))
+		sb.WriteString("</li>\n")
+	}
+	fmt.Fprintf(sb, "</%s>\n", tag)
+	return i
+}
+
+// writeCodeMacro writes a code block as a code macro
+func writeCodeMacro(sb *strings.Builder, language, code string) {
+	sb.WriteString("<ac:structured-macro ac:name=\"code\">\n")
+	if language != "" {
+		fmt.Fprintf(sb, "<ac:parameter ac:name=\"language\">%s</ac:parameter>\n", html.EscapeString(language))
+	}
+	sb.WriteString("<ac:plain-text-body>")
+	sb.WriteString(cdata(code))
+	sb.WriteString("</ac:plain-text-body>\n")
+	sb.WriteString("</ac:structured-macro>\n")
+}
+
+// markdownInline converts inline markdown: code spans, emphasis, links and
+// autolinks. Line breaks inside a paragraph become spaces.
+func markdownInline(text string) string {
+	var sb strings.Builder
+
+	// Code spans are taken literally, so they are split off first
+	parts := strings.Split(strings.ReplaceAll(text, "\n", " "), "`")
+	for i, part := range parts {
+		switch {
+		case i%2 == 1 && i < len(parts)-1:
+			writeCode(&sb, part)
+		case i%2 == 1:
+			// Unmatched backtick
+			sb.WriteString("`")
+			sb.WriteString(inlineSpans(part))
+		default:
+			sb.WriteString(inlineSpans(part))
+		}
+	}
+	return sb.String()
+}
+
+// inlineSpans converts emphasis and links in text without code spans
+func inlineSpans(text string) string {
+	text = html.EscapeString(text)
+	text = mdAutolink.ReplaceAllString(text, `<a href="$1">$1</a>`)
+	text = mdLink.ReplaceAllString(text, `<a href="$2">$1</a>`)
+	text = mdStrongStar.ReplaceAllString(text, "<strong>$1</strong>")
+	text = mdStrongUnder.ReplaceAllString(text, "$1<strong>$2</strong>$3")
+	text = mdEmStar.ReplaceAllString(text, "<em>$1</em>")
+	text = mdEmUnder.ReplaceAllString(text, "$1<em>$2</em>$3")
+	return text
+}
@@ -0,0 +1,48 @@
+package confluence
+
+import "testing"
+
+func TestMarkdownToStorage(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain", "Returns a pet", "<p>Returns a pet</p>\n"},
+		{"paragraphs", "First line\nsame paragraph\n\nSecond", "<p>First line same paragraph</p>\n<p>Second</p>\n"},
+		{"emphasis", "**Note:** use *only* `snake_case` ids", "<p><strong>Note:</strong> use <em>only</em> <code>snake_case</code> ids</p>\n"},
+		{"snake case is not emphasis", "see pet_id and __init__ and _this_", "<p>see pet_id and <strong>init</strong> and <em>this</em></p>\n"},
+		{"links", "See [the guide](https://example.com/a?b=1&c=2) or <https://example.com>", `<p>See <a href="https://example.com/a?b=1&amp;c=2">the guide</a> or <a href="https://example.com">https://example.com</a></p>` + "\n"},
+		{"html escaped", "a < b & <b>c</b>", "<p>a &lt; b &amp; &lt;b&gt;c&lt;/b&gt;</p>\n"},
+		{"bullet list", "Statuses:\n- available\n- sold\n  for good\n\nDone", "<p>Statuses:</p>\n<ul>\n<li>available</li>\n<li>sold for good</li>\n</ul>\n<p>Done</p>\n"},
+		{"ordered list", "1. first\n2) second", "<ol>\n<li>first</li>\n<li>second</li>\n</ol>\n"},
+		{"heading", "## Errors", "<h5>Errors</h5>\n"},
+		{"quote", "> careful\n> now", "<blockquote>\n<p>careful now</p>\n</blockquote>\n"},
+		{"break", "a\n\n---\n\nb", "<p>a</p>\n<hr/>\n<p>b</p>\n"},
+		{"code block", "```json\n{\"a\": \"]]>\"}\n```", "<ac:structured-macro ac:name=\"code\">\n<ac:parameter ac:name=\"language\">json</ac:parameter>\n<ac:plain-text-body><![CDATA[{\"a\": \"]]]]><![CDATA[>\"}]]></ac:plain-text-body>\n</ac:structured-macro>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkdownToStorage(tt.input); got != tt.want {
+				t.Errorf("MarkdownToStorage(%q) =\n%s\nwant\n%s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownCell(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"The **pet** name", "The <strong>pet</strong> name"},
+		{"One of:\n- a\n- b", "<p>One of:</p>\n<ul>\n<li>a</li>\n<li>b</li>\n</ul>\n"},
+	}
+
+	for _, tt := range tests {
+		if got := markdownCell(tt.input); got != tt.want {
+			t.Errorf("markdownCell(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package confluence
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DirectoryProperty is the content property holding the directory entries,
+// so APIs published by earlier runs stay listed
+const DirectoryProperty = "swagfluence-directory"
+
+// DirectoryEntry describes one API on the directory page
+type DirectoryEntry struct {
+	Title     string    `json:"title"`
+	Version   string    `json:"version"`
+	Endpoints int       `json:"endpoints"`
+	SyncedAt  time.Time `json:"syncedAt"`
+	URL       string    `json:"url,omitempty"`
+}
+
+// MergeDirectory replaces existing entries with updated ones of the same
+// title and returns all entries sorted by title
+func MergeDirectory(existing, updates []DirectoryEntry) []DirectoryEntry {
+	byTitle := make(map[string]DirectoryEntry, len(existing)+len(updates))
+	for _, entry := range existing {
+		byTitle[entry.Title] = entry
+	}
+	for _, entry := range updates {
+		byTitle[entry.Title] = entry
+	}
+
+	merged := make([]DirectoryEntry, 0, len(byTitle))
+	for _, entry := range byTitle {
+		merged = append(merged, entry)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return strings.ToLower(merged[i].Title) < strings.ToLower(merged[j].Title)
+	})
+	return merged
+}
+
+// FormatDirectoryPage generates the landing page listing every published API
+func (f *Formatter) FormatDirectoryPage(title string, entries []DirectoryEntry) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("<h1>%s</h1>\n", html.EscapeString(title)))
+	sb.WriteString(fmt.Sprintf("<p>%d APIs documented in this space.</p>\n", len(entries)))
+
+	sb.WriteString("<table>\n<tbody>\n")
+	sb.WriteString("<tr><th>API</th><th>Version</th><th>Endpoints</th><th>Last Sync</th></tr>\n")
+	for _, entry := range entries {
+		name := html.EscapeString(entry.Title)
+		if entry.URL != "" {
+			name = fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(entry.URL), name)
+		}
+		synced := ""
+		if !entry.SyncedAt.IsZero() {
+			synced = entry.SyncedAt.UTC().Format("2006-01-02 15:04 UTC")
+		}
+		sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td><code>%s</code></td><td>%d</td><td>%s</td></tr>\n",
+			name, html.EscapeString(entry.Version), entry.Endpoints, synced))
+	}
+	sb.WriteString("</tbody>\n</table>\n")
+
+	return sb.String()
+}
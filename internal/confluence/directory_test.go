@@ -0,0 +1,58 @@
+package confluence
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMergeDirectory(t *testing.T) {
+	existing := []DirectoryEntry{
+		{Title: "Orders", Version: "1.0", Endpoints: 4},
+		{Title: "billing", Version: "2.0", Endpoints: 7},
+	}
+	updates := []DirectoryEntry{
+		{Title: "Orders", Version: "1.1", Endpoints: 5},
+		{Title: "Accounts", Version: "3.0", Endpoints: 2},
+	}
+
+	merged := MergeDirectory(existing, updates)
+
+	var titles []string
+	for _, entry := range merged {
+		titles = append(titles, entry.Title)
+	}
+	if got := strings.Join(titles, ","); got != "Accounts,billing,Orders" {
+		t.Fatalf("titles = %s, want Accounts,billing,Orders", got)
+	}
+	if merged[2].Version != "1.1" || merged[2].Endpoints != 5 {
+		t.Errorf("Orders entry = %+v, want the updated entry", merged[2])
+	}
+}
+
+func TestFormatDirectoryPage(t *testing.T) {
+	entries := []DirectoryEntry{
+		{
+			Title:     "Pets & Owners",
+			Version:   "1.0.0",
+			Endpoints: 3,
+			SyncedAt:  time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC),
+			URL:       "https://wiki.example.com/pages/viewpage.action?pageId=42",
+		},
+		{Title: "Store", Version: "2.0"},
+	}
+
+	content := NewFormatter().FormatDirectoryPage("API Directory", entries)
+
+	for _, want := range []string{
+		"<h1>API Directory</h1>",
+		"2 APIs documented",
+		`<a href="https://wiki.example.com/pages/viewpage.action?pageId=42">Pets &amp; Owners</a>`,
+		"<td>3</td><td>2024-05-01 12:30 UTC</td>",
+		"<tr><td>Store</td><td><code>2.0</code></td><td>0</td><td></td></tr>",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("content missing %q:\n%s", want, content)
+		}
+	}
+}
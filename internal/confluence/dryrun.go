@@ -0,0 +1,173 @@
+package confluence
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Change kinds of a published or previewed page
+const (
+	ChangeCreate    = "create"
+	ChangeUpdate    = "update"
+	ChangeUnchanged = "unchanged"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 2
+
+// previewChange prints what publishing content as title would do to the
+// existing page, with a line diff for updates. It returns the change kind.
+func (c *ConfluenceClient) previewChange(title string, existing *Page, content string) string {
+	if existing == nil {
+		fmt.Printf("[dry-run] %s: %s\n", ChangeCreate, title)
+		return ChangeCreate
+	}
+
+	if recorded, _ := recordedHash(existing); recorded == contentHash(content) {
+		fmt.Printf("[dry-run] %s: %s\n", ChangeUnchanged, title)
+		return ChangeUnchanged
+	}
+
+	oldLines := storageLines(existing.Body.Storage.Value)
+	newLines := storageLines(content)
+	diff := diffLines(oldLines, newLines)
+	if len(diff) == 0 {
+		fmt.Printf("[dry-run] %s: %s\n", ChangeUnchanged, title)
+		return ChangeUnchanged
+	}
+
+	fmt.Printf("[dry-run] %s: %s\n", ChangeUpdate, title)
+	for _, line := range diff {
+		fmt.Printf("    %s\n", line)
+	}
+	return ChangeUpdate
+}
+
+// storageLines splits storage format content into one element per line.
+// Confluence returns stored pages without the line breaks they were written
+// with, so lines are also broken between adjacent tags.
+func storageLines(content string) []string {
+	content = strings.ReplaceAll(content, "><", ">\n<")
+
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// maxDiffCells bounds the table used to match the changed lines between
+// the common prefix and suffix of a page. Larger changes are shown as the
+// old lines removed and the new ones added.
+const maxDiffCells = 1 << 20
+
+// edit is a line of a diff: ' ' unchanged, '-' removed or '+' added
+type edit struct {
+	op   byte
+	line string
+}
+
+// diffLines returns the changes from old to new as "-" and "+" lines with
+// diffContext unchanged lines around them, or nil if they are equal. Runs
+// of unchanged lines between changes are elided with "...".
+func diffLines(old, new []string) []string {
+	// Regenerated pages mostly change in a few places, so the lines before
+	// the first change and after the last are matched directly
+	prefix := 0
+	for prefix < len(old) && prefix < len(new) && old[prefix] == new[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(old)-prefix && suffix < len(new)-prefix && old[len(old)-1-suffix] == new[len(new)-1-suffix] {
+		suffix++
+	}
+	if prefix == len(old) && prefix == len(new) {
+		return nil
+	}
+
+	edits := make([]edit, 0, len(old)+len(new)-prefix-suffix)
+	for _, line := range old[:prefix] {
+		edits = append(edits, edit{' ', line})
+	}
+	edits = append(edits, changedLines(old[prefix:len(old)-suffix], new[prefix:len(new)-suffix])...)
+	for _, line := range old[len(old)-suffix:] {
+		edits = append(edits, edit{' ', line})
+	}
+
+	// Keep the changes and the context lines around them
+	keep := make([]bool, len(edits))
+	for k, e := range edits {
+		if e.op == ' ' {
+			continue
+		}
+		for n := max(0, k-diffContext); n <= min(len(edits)-1, k+diffContext); n++ {
+			keep[n] = true
+		}
+	}
+
+	var lines []string
+	elided := false
+	for k, e := range edits {
+		if !keep[k] {
+			elided = true
+			continue
+		}
+		if elided && len(lines) > 0 {
+			lines = append(lines, "...")
+		}
+		elided = false
+		lines = append(lines, string(e.op)+" "+e.line)
+	}
+	return lines
+}
+
+// changedLines diffs the lines between the common prefix and suffix of a
+// page by their longest common subsequence, when its table fits in
+// maxDiffCells
+func changedLines(old, new []string) []edit {
+	var edits []edit
+	if (len(old)+1)*(len(new)+1) > maxDiffCells {
+		for _, line := range old {
+			edits = append(edits, edit{'-', line})
+		}
+		for _, line := range new {
+			edits = append(edits, edit{'+', line})
+		}
+		return edits
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of
+	// old[i:] and new[j:]
+	lcs := make([][]int, len(old)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(new)+1)
+	}
+	for i := len(old) - 1; i >= 0; i-- {
+		for j := len(new) - 1; j >= 0; j-- {
+			if old[i] == new[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(old) || j < len(new) {
+		switch {
+		case i < len(old) && j < len(new) && old[i] == new[j]:
+			edits = append(edits, edit{' ', old[i]})
+			i++
+			j++
+		case i < len(old) && (j == len(new) || lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, edit{'-', old[i]})
+			i++
+		default:
+			edits = append(edits, edit{'+', new[j]})
+			j++
+		}
+	}
+	return edits
+}
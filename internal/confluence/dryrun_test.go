@@ -0,0 +1,133 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		old  []string
+		new  []string
+		want []string
+	}{
+		{
+			name: "equal",
+			old:  []string{"a", "b"},
+			new:  []string{"a", "b"},
+			want: nil,
+		},
+		{
+			name: "changed line with context",
+			old:  []string{"a", "b", "c", "d", "e", "f", "g"},
+			new:  []string{"a", "b", "c", "D", "e", "f", "g"},
+			want: []string{"  b", "  c", "- d", "+ D", "  e", "  f"},
+		},
+		{
+			name: "separate changes are elided between",
+			old:  []string{"1", "2", "3", "4", "5", "6", "7", "8"},
+			new:  []string{"0", "1", "2", "3", "4", "5", "6", "7"},
+			want: []string{"+ 0", "  1", "  2", "...", "  6", "  7", "- 8"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffLines(tt.old, tt.new); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffLines_LargePage(t *testing.T) {
+	lines := func(prefix string, n int) []string {
+		var lines []string
+		for i := 0; i < n; i++ {
+			lines = append(lines, fmt.Sprintf("<p>%s%d</p>", prefix, i))
+		}
+		return lines
+	}
+
+	// One changed line in a long page is found past the common prefix and
+	// suffix, without a table over the whole page
+	old := lines("line ", 50000)
+	new := slices.Clone(old)
+	new[30000] = "<p>changed</p>"
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	got := diffLines(old, new)
+	runtime.ReadMemStats(&after)
+	want := []string{"  <p>line 29998</p>", "  <p>line 29999</p>", "- <p>line 30000</p>", "+ <p>changed</p>", "  <p>line 30001</p>", "  <p>line 30002</p>"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffLines() = %q, want %q", got, want)
+	}
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 16<<20 {
+		t.Errorf("diffLines() allocated %d bytes for one changed line", allocated)
+	}
+
+	// Changes too large to match line by line are shown as replaced
+	got = diffLines(append([]string{"head"}, lines("old ", 2000)...), append([]string{"head"}, lines("new ", 2000)...))
+	if len(got) != 4001 || got[0] != "  head" || got[1] != "- <p>old 0</p>" || got[2001] != "+ <p>new 0</p>" {
+		t.Errorf("diffLines() of a large change = %d lines starting %q", len(got), got[:3])
+	}
+}
+
+func TestStorageLines(t *testing.T) {
+	got := storageLines("<p>a</p><p>b</p>\n  <p>c</p>\n")
+	want := []string{"<p>a</p>", "<p>b</p>", "<p>c</p>"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("storageLines() = %q, want %q", got, want)
+	}
+}
+
+func TestClient_DryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("dry run wrote: %s %s", r.Method, r.URL)
+			return
+		}
+		if r.URL.Query().Get("title") == "Get Pet" {
+			w.Write([]byte(`{"results": [{"id": "7", "title": "Get Pet", "version": {"number": 3},
+				"body": {"storage": {"value": "<p>old</p>", "representation": "storage"}}}]}`))
+			return
+		}
+		w.Write([]byte(`{"results": []}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(config.ConfluenceConfig{
+		BaseURL:  server.URL,
+		Username: "user",
+		APIToken: "token",
+		SpaceKey: "TEST",
+		Labels:   []string{"api"},
+		Enabled:  true,
+		DryRun:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	pageID, err := client.CreateOrUpdatePage(ctx, "Get Pet", "<p>new</p>", "")
+	if err != nil || pageID != "7" {
+		t.Errorf("update: CreateOrUpdatePage() = %q, %v, want the existing page ID", pageID, err)
+	}
+	pageID, err = client.CreateOrUpdatePage(ctx, "Add Pet", "<p>add</p>", "")
+	if err != nil || pageID != "" {
+		t.Errorf("create: CreateOrUpdatePage() = %q, %v, want no page ID", pageID, err)
+	}
+	if err := client.AddLabels(ctx, "7", []string{"pets"}); err != nil {
+		t.Fatal(err)
+	}
+}
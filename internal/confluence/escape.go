@@ -0,0 +1,29 @@
+package confluence
+
+import (
+	"html"
+	"strings"
+)
+
+// Every string taken from a spec (paths, names, titles, patterns, examples)
+// may contain <, > or &. Interpolated unescaped, they make the page invalid
+// XML and Confluence rejects it with a 400, so markup is only ever built from
+// spec text through these helpers or html.EscapeString.
+
+// writeText writes spec text escaped for storage format
+func writeText(sb *strings.Builder, text string) {
+	sb.WriteString(html.EscapeString(text))
+}
+
+// writeCode writes spec text as an escaped code element
+func writeCode(sb *strings.Builder, text string) {
+	sb.WriteString("<code>")
+	sb.WriteString(html.EscapeString(text))
+	sb.WriteString("</code>")
+}
+
+// cdata wraps text in a CDATA section. A section cannot contain its own
+// terminator, so any "]]>" in text is split across two sections.
+func cdata(text string) string {
+	return "<![CDATA[" + strings.ReplaceAll(text, "]]>", "]]]]><![CDATA[>") + "]]>"
+}
@@ -0,0 +1,60 @@
+package confluence
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func TestFormatter_EscapesSpecText(t *testing.T) {
+	spec := &swagger.Spec{
+		Definitions: map[string]swagger.Definition{
+			"Q&A": {Type: "object", Properties: map[string]swagger.Property{
+				"a<b": {Type: "string", Pattern: "^[a-z]{1,3}<&>$", Example: "x]]>y", Description: "1 < 2 & 3 > 2"},
+			}},
+		},
+	}
+	op := swagger.Operation{
+		OperationID: "get<Pet>&co",
+		Description: "Fetches <pets> & friends",
+		Tags:        []string{"R&D"},
+		Consumes:    []string{"application/x-<weird>"},
+		Parameters: []swagger.Parameter{
+			{Name: "q<", In: "query", Type: "string", Description: "Search <term>"},
+			{Name: "body", In: "body", Schema: &swagger.Schema{Ref: "#/definitions/Q&A"}},
+		},
+		Responses: swagger.Responses{
+			"200": {Description: "OK <done>", Schema: &swagger.Schema{Ref: "#/definitions/Q&A"}},
+		},
+	}
+
+	content, err := NewFormatter().FormatEndpointPage("/pets/<id>&more", "get", op, swagger.NewResolver(spec))
+	if err != nil {
+		t.Fatalf("FormatEndpointPage() error = %v", err)
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader("<root>" + content + "</root>"))
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("page is not well-formed XML: %v\n%s", err, content)
+		}
+		if data, ok := token.(xml.CharData); ok {
+			text.Write(data)
+		}
+	}
+
+	// The text survives escaping unchanged
+	for _, want := range []string{"/pets/<id>&more", "get<Pet>&co", "Fetches <pets> & friends", "R&D", "a<b", "^[a-z]{1,3}<&>$", "x]]>y", "OK <done>"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("page text is missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package confluence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+)
+
+// ExportPDF renders a page to PDF through the Confluence PDF export action
+func (c *ConfluenceClient) ExportPDF(ctx context.Context, pageID string) ([]byte, error) {
+	if !c.cfg.Enabled || pageID == "" {
+		return nil, nil
+	}
+
+	apiURL := fmt.Sprintf("%s/spaces/flyingpdf/pdfpageexport.action?pageId=%s", c.cfg.BaseURL, pageID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.authorize(req)
+	req.Header.Set("Accept", "application/pdf")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export page %s: %w", pageID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pdf: %w", err)
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF")) {
+		return nil, fmt.Errorf("export of page %s did not return a PDF (is PDF export enabled?)", pageID)
+	}
+
+	return data, nil
+}
+
+// UploadAttachment attaches a file to a page, adding a new version when an
+// attachment with the same name already exists
+func (c *ConfluenceClient) UploadAttachment(ctx context.Context, pageID, fileName, contentType string, data []byte) error {
+	if !c.cfg.Enabled || c.cfg.DryRun || pageID == "" {
+		return nil
+	}
+
+	apiURL := fmt.Sprintf("%s/rest/api/content/%s/child/attachment", c.cfg.BaseURL, pageID)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, fileName))
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment part: %w", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return fmt.Errorf("failed to write attachment: %w", err)
+	}
+	if err := writer.WriteField("minorEdit", "true"); err != nil {
+		return fmt.Errorf("failed to write attachment field: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to finish attachment body: %w", err)
+	}
+
+	// PUT creates the attachment or updates an existing one with the same name
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, apiURL, &body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.authorize(req)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("X-Atlassian-Token", "nocheck")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload attachment %s: %w", fileName, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to upload attachment %s: unexpected status %d: %s",
+			fileName, resp.StatusCode, string(bodyBytes))
+	}
+
+	fmt.Printf("✓ Attached %s to page %s\n", fileName, pageID)
+	return nil
+}
+
+// DownloadAttachment returns the latest version of a page's attachment, or
+// nil when the page has no attachment with that name
+func (c *ConfluenceClient) DownloadAttachment(ctx context.Context, pageID, fileName string) ([]byte, error) {
+	if !c.cfg.Enabled || pageID == "" {
+		return nil, nil
+	}
+
+	apiURL := fmt.Sprintf("%s/rest/api/content/%s/child/attachment?filename=%s",
+		c.cfg.BaseURL, pageID, url.QueryEscape(fileName))
+
+	var result struct {
+		Results []struct {
+			Links struct {
+				Download string `json:"download"`
+			} `json:"_links"`
+		} `json:"results"`
+	}
+	if err := c.getJSON(ctx, apiURL, &result); err != nil {
+		return nil, fmt.Errorf("failed to look up attachment %s: %w", fileName, err)
+	}
+	if len(result.Results) == 0 || result.Results[0].Links.Download == "" {
+		return nil, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.BaseURL+result.Results[0].Links.Download, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.authorize(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download attachment %s: %w", fileName, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to download attachment %s: unexpected status %d: %s",
+			fileName, resp.StatusCode, string(bodyBytes))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment %s: %w", fileName, err)
+	}
+	return data, nil
+}
+
+// getJSON decodes the response of a GET request into value
+func (c *ConfluenceClient) getJSON(ctx context.Context, apiURL string, value interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.authorize(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(value); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
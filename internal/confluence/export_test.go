@@ -0,0 +1,55 @@
+package confluence
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+func TestClient_ExportPDF(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{name: "pdf", status: http.StatusOK, body: "%PDF-1.4 page"},
+		{name: "export disabled", status: http.StatusOK, body: "<html>login</html>", wantErr: "did not return a PDF"},
+		{name: "error status", status: http.StatusForbidden, body: "forbidden", wantErr: "unexpected status 403"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/spaces/flyingpdf/pdfpageexport.action" || r.URL.Query().Get("pageId") != "7" {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			c, err := NewClient(config.ConfluenceConfig{BaseURL: server.URL, SpaceKey: "TEST", Enabled: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := c.ExportPDF(context.Background(), "7")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ExportPDF() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.body {
+				t.Errorf("ExportPDF() = %q, want %q", data, tt.body)
+			}
+		})
+	}
+}
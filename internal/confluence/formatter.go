@@ -1,47 +1,128 @@
 package confluence
 
 import (
+	"encoding/json"
 	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"text/template"
 
+	"github.com/ahmadimt/SwagFluence/internal/config"
 	"github.com/ahmadimt/SwagFluence/internal/example"
+	"github.com/ahmadimt/SwagFluence/internal/snippet"
 	"github.com/ahmadimt/SwagFluence/internal/swagger"
 )
 
 // Formatter generates Confluence storage format markup
 type Formatter struct {
 	exampleGen *example.Generator
+	page       *template.Template
+	// modelScope enables shared model pages, titled within this scope
+	modelScope string
+	// spec supplies the security schemes for Authentication sections and
+	// the server URL of code samples; authPageTitle is the page
+	// Authentication sections link to
+	spec          *swagger.Spec
+	authPageTitle string
+	// samples generate the code samples on endpoint pages
+	samples []snippet.Generator
+	// collapse sets the sizes above which schema tables and examples are
+	// wrapped in expand macros
+	collapse collapseLimits
 }
 
-// NewFormatter creates a new Formatter
+// NewFormatter creates a new Formatter using the default page layout
 func NewFormatter() *Formatter {
+	// The default layout is a constant and always parses
+	page, _ := loadPageTemplate(config.TemplateConfig{})
 	return &Formatter{
 		exampleGen: example.NewGenerator(),
+		page:       page,
 	}
 }
 
+// NewFormatterWithConfig creates a Formatter whose endpoint page layout or
+// individual blocks are overridden by the configured templates
+func NewFormatterWithConfig(cfg config.TemplateConfig) (*Formatter, error) {
+	page, err := loadPageTemplate(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var samples []snippet.Generator
+	for _, language := range cfg.Samples {
+		if language == SamplesNone {
+			continue
+		}
+		generator, err := snippet.Lookup(language)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, generator)
+	}
+	return &Formatter{
+		exampleGen: example.NewGenerator(),
+		page:       page,
+		samples:    samples,
+	}, nil
+}
+
+// WithExamples returns a copy of the formatter generating example bodies
+// with gen, such as a faker generator
+func (f *Formatter) WithExamples(gen *example.Generator) *Formatter {
+	configured := *f
+	configured.exampleGen = gen
+	return &configured
+}
+
 // FormatEndpointPage generates markup for an endpoint page
-func (f *Formatter) FormatEndpointPage(path, method string, op swagger.Operation, resolver *swagger.Resolver) string {
+func (f *Formatter) FormatEndpointPage(path, method string, op swagger.Operation, resolver *swagger.Resolver) (string, error) {
+	data := EndpointData{
+		Path:      path,
+		Method:    strings.ToUpper(method),
+		Operation: op,
+		formatter: f,
+		resolver:  resolver,
+	}
+
 	var sb strings.Builder
+	if err := f.page.ExecuteTemplate(&sb, "page", data); err != nil {
+		return "", fmt.Errorf("failed to render page template: %w", err)
+	}
+	return sb.String(), nil
+}
 
-	// Add layout section for full width
-	sb.WriteString("<ac:layout>\n")
-	sb.WriteString("<ac:layout-section ac:type=\"single\">\n")
-	sb.WriteString("<ac:layout-cell>\n")
+// formatHeaderSection formats the heading, description and operation details
+func (f *Formatter) formatHeaderSection(path, method string, op swagger.Operation) string {
+	var sb strings.Builder
 
 	// Header with method badge
 	sb.WriteString("<h2>")
 	sb.WriteString(f.methodBadge(method))
-	sb.WriteString(fmt.Sprintf(" %s</h2>\n", path))
+	sb.WriteString(" ")
+	writeText(&sb, path)
+	sb.WriteString("</h2>\n")
+
+	// Full request URLs; webhooks are sent by the API and have none
+	if f.spec != nil {
+		if _, ok := f.spec.Paths[path]; ok {
+			sb.WriteString(formatOperationURLs(f.spec.ServersFor(op), path))
+		}
+	}
 
 	// Description
 	if op.Description != "" {
-		sb.WriteString(fmt.Sprintf("<p>%s</p>\n", op.Description))
+		sb.WriteString(MarkdownToStorage(op.Description))
 	}
 
 	// Operation ID
 	if op.OperationID != "" {
-		sb.WriteString(fmt.Sprintf("<p><strong>Operation ID:</strong> <code>%s</code></p>\n", op.OperationID))
+		sb.WriteString("<p><strong>Operation ID:</strong> ")
+		writeCode(&sb, op.OperationID)
+		sb.WriteString("</p>\n")
 	}
 
 	// Tags
@@ -51,48 +132,174 @@ func (f *Formatter) FormatEndpointPage(path, method string, op swagger.Operation
 
 	// Content types
 	if len(op.Consumes) > 0 {
-		sb.WriteString(fmt.Sprintf("<p><strong>Consumes:</strong> <code>%s</code></p>\n", strings.Join(op.Consumes, ", ")))
+		sb.WriteString(fmt.Sprintf("<p><strong>Consumes:</strong> <code>%s</code></p>\n", html.EscapeString(strings.Join(op.Consumes, ", "))))
 	}
 	if len(op.Produces) > 0 {
-		sb.WriteString(fmt.Sprintf("<p><strong>Produces:</strong> <code>%s</code></p>\n", strings.Join(op.Produces, ", ")))
+		sb.WriteString(fmt.Sprintf("<p><strong>Produces:</strong> <code>%s</code></p>\n", html.EscapeString(strings.Join(op.Produces, ", "))))
+	}
+
+	return sb.String()
+}
+
+// methodColors maps HTTP methods to status badge colours
+var methodColors = map[string]string{
+	"GET":    "Blue",
+	"POST":   "Green",
+	"PUT":    "Yellow",
+	"DELETE": "Red",
+	"PATCH":  "Purple",
+}
+
+// methodBadge creates a colored status badge for HTTP method
+func (f *Formatter) methodBadge(method string) string {
+	method = strings.ToUpper(method)
+	color, ok := methodColors[method]
+	if !ok {
+		color = "Grey"
 	}
 
-	// Request body section
-	sb.WriteString(f.formatRequestBodySection(op, resolver))
+	return "<ac:structured-macro ac:name=\"status\">" +
+		"<ac:parameter ac:name=\"colour\">" + color + "</ac:parameter>" +
+		"<ac:parameter ac:name=\"title\">" + method + "</ac:parameter>" +
+		"</ac:structured-macro>"
+}
+
+// VersionLink points to the page of the same endpoint in another version
+type VersionLink struct {
+	Label string
+	Title string
+}
+
+// FormatParentPage generates the introduction of the page documenting an API
+func (f *Formatter) FormatParentPage(apiTitle string) string {
+	return DefaultParentPageContent(apiTitle)
+}
 
-	// Parameters section
-	sb.WriteString(f.formatParametersSection(op.Parameters))
+// DefaultParentPageContent generates the default introduction of an API
+// page, which lists the endpoint pages below it. Other publishers use it
+// for their overview page too.
+func DefaultParentPageContent(apiTitle string) string {
+	return ParentPageIntro(apiTitle) + `<p><ac:structured-macro ac:name="children">
+<ac:parameter ac:name="all">true</ac:parameter>
+</ac:structured-macro></p>
+` + formatPropertiesReport()
+}
 
-	// Response section
-	sb.WriteString(f.formatResponsesSection(op.Responses, resolver))
+// ParentPageIntro generates the heading and introduction of an API page
+// without Confluence macros, for wikis that cannot list child pages
+func ParentPageIntro(apiTitle string) string {
+	return fmt.Sprintf(`<h1>%s</h1>
+<p>This page contains the API documentation for %s. Each endpoint has its own page below.</p>
+<p><strong>Generated automatically from Swagger/OpenAPI specification</strong></p>
+`, html.EscapeString(apiTitle), html.EscapeString(apiTitle))
+}
 
-	// Close layout
-	sb.WriteString("</ac:layout-cell>\n")
-	sb.WriteString("</ac:layout-section>\n")
-	sb.WriteString("</ac:layout>\n")
+// FormatVersionPage generates markup for the root page of one API version
+func (f *Formatter) FormatVersionPage(apiTitle, label, apiVersion string) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("<h1>%s %s</h1>\n", html.EscapeString(apiTitle), html.EscapeString(label)))
+	if apiVersion != "" {
+		sb.WriteString(fmt.Sprintf("<p><strong>Spec version:</strong> <code>%s</code></p>\n", html.EscapeString(apiVersion)))
+	}
+	sb.WriteString("<p><ac:structured-macro ac:name=\"children\">\n")
+	sb.WriteString("<ac:parameter ac:name=\"all\">true</ac:parameter>\n")
+	sb.WriteString("</ac:structured-macro></p>\n")
+	sb.WriteString(formatPropertiesReport())
 
 	return sb.String()
 }
 
-// methodBadge creates a colored status badge for HTTP method
-func (f *Formatter) methodBadge(method string) string {
-	colors := map[string]string{
-		"GET":    "Blue",
-		"POST":   "Green",
-		"PUT":    "Yellow",
-		"DELETE": "Red",
-		"PATCH":  "Purple",
+// FormatVersionLinks formats links to the same endpoint in other versions.
+// The links are delimited by markers, even when there are none yet, so
+// ReplaceVersionLinks can update them once another version is published.
+func (f *Formatter) FormatVersionLinks(links []VersionLink) string {
+	var sb strings.Builder
+	sb.WriteString(anchorMacro(VersionsStartMarker))
+	if len(links) > 0 {
+		sb.WriteString("<p><strong>Other versions:</strong> ")
+		for i, link := range links {
+			if i > 0 {
+				sb.WriteString(" | ")
+			}
+			sb.WriteString(fmt.Sprintf("<ac:link><ri:page ri:content-title=\"%s\" /><ac:plain-text-link-body>%s</ac:plain-text-link-body></ac:link>",
+				html.EscapeString(link.Title), cdata(link.Label)))
+		}
+		sb.WriteString("</p>")
 	}
+	sb.WriteString(anchorMacro(VersionsEndMarker))
+	sb.WriteString("\n")
+
+	return sb.String()
+}
 
-	color, ok := colors[strings.ToUpper(method)]
+// linkTitlePattern matches the title of a linked page
+var linkTitlePattern = regexp.MustCompile(`ri:content-title="([^"]*)"`)
+
+// ReplaceVersionLinks swaps the version links of a published page for
+// links. Content without version markers, or already linking exactly those
+// pages, is returned as is; the linked titles are compared rather than the
+// markup, which Confluence rewrites when storing a page.
+func (f *Formatter) ReplaceVersionLinks(content string, links []VersionLink) string {
+	startLoc, ok := findMarker(content, VersionsStartMarker)
 	if !ok {
-		color = "Grey"
+		return content
+	}
+	endLoc, ok := findMarker(content[startLoc[1]:], VersionsEndMarker)
+	if !ok {
+		return content
+	}
+	end := startLoc[1] + endLoc[1]
+
+	var linked, want []string
+	for _, match := range linkTitlePattern.FindAllStringSubmatch(content[startLoc[1]:end], -1) {
+		linked = append(linked, html.UnescapeString(match[1]))
+	}
+	for _, link := range links {
+		want = append(want, link.Title)
+	}
+	if slices.Equal(linked, want) {
+		return content
+	}
+
+	return content[:startLoc[0]] + strings.TrimSuffix(f.FormatVersionLinks(links), "\n") + content[end:]
+}
+
+// InsertBanner places the banner of an endpoint page, such as its version
+// links and example request status, right below the page heading. Content
+// without a heading gets the banner at the top.
+func (f *Formatter) InsertBanner(content, banner string) string {
+	i := strings.Index(content, "</h2>")
+	if i < 0 {
+		return banner + content
+	}
+	i += len("</h2>")
+	if strings.HasPrefix(content[i:], "\n") {
+		i++
+	}
+	return content[:i] + banner + content[i:]
+}
+
+// FormatVerificationStatus formats the outcome of executing the page's
+// example request against a live server
+func (f *Formatter) FormatVerificationStatus(verified bool, detail string) string {
+	color, title := "Red", "FAILING"
+	if verified {
+		color, title = "Green", "VERIFIED"
 	}
 
-	return fmt.Sprintf("<ac:structured-macro ac:name=\"status\">"+
+	return fmt.Sprintf("<p><strong>Example request:</strong> <ac:structured-macro ac:name=\"status\">"+
 		"<ac:parameter ac:name=\"colour\">%s</ac:parameter>"+
 		"<ac:parameter ac:name=\"title\">%s</ac:parameter>"+
-		"</ac:structured-macro>", color, strings.ToUpper(method))
+		"</ac:structured-macro> <em>%s</em></p>\n", color, title, html.EscapeString(detail))
+}
+
+// FormatWebhookNotice explains that the page documents a request the API
+// sends to subscribers rather than one it serves
+func (f *Formatter) FormatWebhookNotice(name string) string {
+	return fmt.Sprintf("<ac:structured-macro ac:name=\"info\">\n<ac:rich-text-body>\n"+
+		"<p><strong>Webhook %s:</strong> the API sends this request to the URL registered by subscribers.</p>\n"+
+		"</ac:rich-text-body>\n</ac:structured-macro>\n", html.EscapeString(name))
 }
 
 // formatTags formats API tags
@@ -105,7 +312,9 @@ func (f *Formatter) formatTags(tags []string) string {
 		}
 		sb.WriteString("<ac:structured-macro ac:name=\"status\">")
 		sb.WriteString("<ac:parameter ac:name=\"colour\">Grey</ac:parameter>")
-		sb.WriteString(fmt.Sprintf("<ac:parameter ac:name=\"title\">%s</ac:parameter>", tag))
+		sb.WriteString("<ac:parameter ac:name=\"title\">")
+		writeText(&sb, tag)
+		sb.WriteString("</ac:parameter>")
 		sb.WriteString("</ac:structured-macro>")
 	}
 	sb.WriteString("</p>\n")
@@ -132,24 +341,30 @@ func (f *Formatter) formatRequestBodySection(op swagger.Operation, resolver *swa
 
 	sb.WriteString("<h3>Request Body</h3>\n")
 
-	var schemaToUse *swagger.Schema
+	var resolvedToUse *swagger.Schema
+	var recorded interface{}
+	var documented []example.NamedExample
 
 	// Handle OpenAPI 3.0 requestBody
 	if op.RequestBody != nil {
 		if op.RequestBody.Description != "" {
-			sb.WriteString(fmt.Sprintf("<p>%s</p>\n", op.RequestBody.Description))
+			sb.WriteString(MarkdownToStorage(op.RequestBody.Description))
 		}
 
 		if op.RequestBody.Required {
 			sb.WriteString(f.requiredBadge())
 		}
 
-		for contentType, mediaType := range op.RequestBody.Content {
-			sb.WriteString(fmt.Sprintf("<p><strong>Content-Type:</strong> <code>%s</code></p>\n", contentType))
-			schemaToUse = mediaType.Schema
+		for _, contentType := range contentTypes(op.RequestBody.Content) {
+			mediaType := op.RequestBody.Content[contentType]
+			writeContentType(&sb, contentType)
+			if examples := example.DocumentedExamples(mediaType); len(examples) > 0 {
+				documented = examples
+			}
 			resolvedSchema, _ := resolver.ResolveSchema(mediaType.Schema)
+			resolvedToUse = resolvedSchema
 			if resolvedSchema != nil {
-				sb.WriteString(f.formatSchemaTable(resolvedSchema))
+				sb.WriteString(f.formatSchema(mediaType.Schema, resolvedSchema))
 			}
 		}
 	}
@@ -157,37 +372,43 @@ func (f *Formatter) formatRequestBodySection(op swagger.Operation, resolver *swa
 	// Handle Swagger 2.0 body parameter
 	if bodyParam != nil {
 		if bodyParam.Description != "" {
-			sb.WriteString(fmt.Sprintf("<p>%s</p>\n", bodyParam.Description))
+			sb.WriteString(MarkdownToStorage(bodyParam.Description))
 		}
 
 		if bodyParam.Required {
 			sb.WriteString(f.requiredBadge())
 		}
 
+		recorded = bodyParam.Example
 		if bodyParam.Schema != nil {
-			schemaToUse = bodyParam.Schema
 			resolvedSchema, _ := resolver.ResolveSchema(bodyParam.Schema)
+			resolvedToUse = resolvedSchema
 			if resolvedSchema != nil {
-				sb.WriteString(f.formatSchemaTable(resolvedSchema))
+				sb.WriteString(f.formatSchema(bodyParam.Schema, resolvedSchema))
 			}
 		}
 	}
 
-	// Add Example JSON section
-	if schemaToUse != nil {
-		resolvedSchema, _ := resolver.ResolveSchema(schemaToUse)
-		if resolvedSchema != nil {
-			exampleJSON := f.exampleGen.GenerateExampleJSON(resolvedSchema)
-			sb.WriteString(f.formatExampleJSON(exampleJSON))
+	// Form bodies are shown as the fields sent rather than as JSON;
+	// otherwise prefer a documented or recorded example
+	if contentType, fields := f.exampleGen.GenerateFormExample(op, resolver); fields != nil {
+		sb.WriteString(f.formatFormExample(contentType, fields))
+	} else if recorded != nil {
+		sb.WriteString(f.formatExampleJSON(marshalExample(recorded)))
+	} else if len(documented) > 0 {
+		for _, ex := range documented {
+			sb.WriteString(f.formatNamedExample(ex))
 		}
+	} else if resolvedToUse != nil {
+		sb.WriteString(f.formatRequestVariants(f.exampleGen.ForOperation(op).GenerateRequestVariants(resolvedToUse)))
 	}
 
 	return sb.String()
 }
 
 // formatResponsesSection formats the responses documentation
-func (f *Formatter) formatResponsesSection(responses map[string]swagger.Response, resolver *swagger.Resolver) string {
-	if len(responses) == 0 {
+func (f *Formatter) formatResponsesSection(op swagger.Operation, resolver *swagger.Resolver) string {
+	if len(op.Responses) == 0 {
 		return ""
 	}
 
@@ -195,53 +416,141 @@ func (f *Formatter) formatResponsesSection(responses map[string]swagger.Response
 
 	sb.WriteString("<h3>Responses</h3>\n")
 
-	// Sort response codes for consistent output
-	var codes []string
-	for code := range responses {
-		codes = append(codes, code)
+	// Example bodies by status code, in the order they are rendered below
+	examples := make(map[string][]example.ResponseExample)
+	for _, ex := range f.exampleGen.GenerateResponseExamples(op, resolver) {
+		examples[ex.StatusCode] = append(examples[ex.StatusCode], ex)
 	}
 
-	for _, code := range codes {
-		response := responses[code]
-		
-		sb.WriteString(fmt.Sprintf("<h4>%s - %s</h4>\n", code, response.Description))
+	// List responses in the order the spec documents them
+	for _, code := range op.ResponseCodes() {
+		response := op.Responses[code]
+
+		sb.WriteString("<h4>")
+		writeText(&sb, code)
+		sb.WriteString(" - ")
+		sb.WriteString(markdownInline(response.Description))
+		sb.WriteString("</h4>\n")
+
+		sb.WriteString(f.formatResponseHeaders(response.Headers, resolver))
 
 		// Handle OpenAPI 3.0 responses with content
-		if len(response.Content) > 0 {
-			for contentType, mediaType := range response.Content {
-				sb.WriteString(fmt.Sprintf("<p><strong>Content-Type:</strong> <code>%s</code></p>\n", contentType))
-				
-				if mediaType.Schema != nil {
-					resolvedSchema, _ := resolver.ResolveSchema(mediaType.Schema)
-					if resolvedSchema != nil {
-						sb.WriteString(f.formatSchemaTable(resolvedSchema))
-						
-						// Add response example JSON
-						exampleJSON := f.exampleGen.GenerateExampleJSON(resolvedSchema)
-						sb.WriteString("<h5>Example Response</h5>\n")
-						sb.WriteString(f.formatExampleJSON(exampleJSON))
-					}
+		for _, contentType := range contentTypes(response.Content) {
+			writeContentType(&sb, contentType)
+
+			if schema := response.Content[contentType].Schema; schema != nil {
+				if resolvedSchema, _ := resolver.ResolveSchema(schema); resolvedSchema != nil {
+					sb.WriteString(f.formatSchema(schema, resolvedSchema))
 				}
 			}
+			sb.WriteString(f.formatResponseExample(examples[code], contentType))
 		}
 
 		// Handle Swagger 2.0 responses with direct schema
 		if response.Schema != nil {
-			resolvedSchema, _ := resolver.ResolveSchema(response.Schema)
-			if resolvedSchema != nil {
-				sb.WriteString(f.formatSchemaTable(resolvedSchema))
-				
-				// Add response example JSON
-				exampleJSON := f.exampleGen.GenerateExampleJSON(resolvedSchema)
-				sb.WriteString("<h5>Example Response</h5>\n")
-				sb.WriteString(f.formatExampleJSON(exampleJSON))
+			if resolvedSchema, _ := resolver.ResolveSchema(response.Schema); resolvedSchema != nil {
+				sb.WriteString(f.formatSchema(response.Schema, resolvedSchema))
 			}
 		}
+		if len(response.Content) == 0 && len(examples[code]) > 0 {
+			sb.WriteString(f.formatResponseExample(examples[code], examples[code][0].ContentType))
+		}
+	}
+
+	return sb.String()
+}
+
+// formatResponseExample formats the example bodies for a content type as
+// collapsed code blocks, one per named example, or returns "" when there
+// are none
+func (f *Formatter) formatResponseExample(examples []example.ResponseExample, contentType string) string {
+	var sb strings.Builder
+	for _, ex := range examples {
+		if ex.ContentType != contentType {
+			continue
+		}
+
+		if sb.Len() == 0 {
+			sb.WriteString("<h5>Example Response</h5>\n")
+		}
+		title := ex.StatusCode + " " + ex.ContentType
+		if ex.Name != "" {
+			title += " - " + exampleTitle(ex.Name, ex.Summary)
+		}
+		sb.WriteString("<ac:structured-macro ac:name=\"code\">\n")
+		sb.WriteString("<ac:parameter ac:name=\"language\">json</ac:parameter>\n")
+		sb.WriteString("<ac:parameter ac:name=\"title\">")
+		writeText(&sb, title)
+		sb.WriteString("</ac:parameter>\n")
+		sb.WriteString("<ac:parameter ac:name=\"collapse\">true</ac:parameter>\n")
+		sb.WriteString("<ac:plain-text-body>")
+		sb.WriteString(cdata(ex.JSON))
+		sb.WriteString("</ac:plain-text-body>\n")
+		sb.WriteString("</ac:structured-macro>\n")
+	}
+	return sb.String()
+}
+
+// formatResponseHeaders formats the headers of a response as a table
+func (f *Formatter) formatResponseHeaders(headers map[string]swagger.Header, resolver *swagger.Resolver) string {
+	if len(headers) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString("<h5>Headers</h5>\n")
+	sb.WriteString("<table>\n")
+	sb.WriteString("<tr><th>Header</th><th>Type</th><th>Description</th></tr>\n")
+	for _, name := range names {
+		header, err := resolver.ResolveHeader(headers[name])
+		if err != nil {
+			// Document the header by name even when its definition is missing
+			header = swagger.Header{}
+		}
+
+		sb.WriteString("<tr>\n<td>")
+		writeCode(&sb, name)
+		if header.Required {
+			sb.WriteString(" ")
+			sb.WriteString(f.requiredBadge())
+		}
+		if header.Deprecated {
+			sb.WriteString(" ")
+			sb.WriteString(f.deprecatedBadge())
+		}
+		sb.WriteString("</td>\n<td>")
+		writeCode(&sb, getHeaderType(header))
+		sb.WriteString("</td>\n<td>")
+		if header.Description != "" {
+			sb.WriteString(markdownCell(header.Description))
+		} else {
+			sb.WriteString("-")
+		}
+		sb.WriteString("</td>\n</tr>\n")
+	}
+	sb.WriteString("</table>\n")
 
 	return sb.String()
 }
 
+// formatNotesSection formats the manually maintained notes section
+func (f *Formatter) formatNotesSection() string {
+	var sb strings.Builder
+
+	sb.WriteString("<h3>Notes</h3>\n")
+	sb.WriteString(anchorMacro(ManualStartMarker))
+	sb.WriteString("\n<p><em>Add notes here. Content between the notes markers is kept when this page is regenerated.</em></p>\n")
+	sb.WriteString(anchorMacro(ManualEndMarker))
+	sb.WriteString("\n")
+
+	return sb.String()
+}
 
 // formatParametersSection formats the parameters table
 func (f *Formatter) formatParametersSection(params []swagger.Parameter) string {
@@ -255,7 +564,7 @@ func (f *Formatter) formatParametersSection(params []swagger.Parameter) string {
 	for _, param := range params {
 		if param.In != "body" {
 			hasNonBodyParams = true
-			sb.WriteString(f.formatParameter(param))
+			f.writeParameter(&sb, param)
 		}
 	}
 
@@ -269,12 +578,12 @@ func (f *Formatter) formatParametersSection(params []swagger.Parameter) string {
 	return sb.String()
 }
 
-// formatParameter formats a single parameter row
-func (f *Formatter) formatParameter(param swagger.Parameter) string {
-	var sb strings.Builder
-
+// writeParameter writes a single parameter row
+func (f *Formatter) writeParameter(sb *strings.Builder, param swagger.Parameter) {
 	sb.WriteString("<tr>\n")
-	sb.WriteString(fmt.Sprintf("<td><code>%s</code></td>\n", param.Name))
+	sb.WriteString("<td>")
+	writeCode(sb, param.Name)
+	sb.WriteString("</td>\n")
 	sb.WriteString("<td>")
 
 	// Required badge
@@ -283,12 +592,16 @@ func (f *Formatter) formatParameter(param swagger.Parameter) string {
 	} else {
 		sb.WriteString(f.optionalBadge())
 	}
+	if param.Deprecated {
+		sb.WriteString(" ")
+		sb.WriteString(f.deprecatedBadge())
+	}
 
 	sb.WriteString("<br/><br/>")
 
 	// Description
 	if param.Description != "" {
-		sb.WriteString(param.Description)
+		sb.WriteString(markdownCell(param.Description))
 	} else {
 		sb.WriteString("No description provided")
 	}
@@ -296,41 +609,57 @@ func (f *Formatter) formatParameter(param swagger.Parameter) string {
 	// Type
 	paramType := getParameterType(param)
 	if paramType != "" {
-		sb.WriteString(fmt.Sprintf("<br/><br/><strong>Type:</strong> <code>%s</code>", paramType))
+		sb.WriteString("<br/><br/><strong>Type:</strong> ")
+		writeCode(sb, paramType)
+	}
+
+	// Allowed values and default, from the schema in OpenAPI 3.x
+	enum, defaultValue := param.Enum, param.Default
+	if param.Schema != nil {
+		if enum == nil {
+			enum = param.Schema.Enum
+		}
+		if defaultValue == nil {
+			defaultValue = param.Schema.Default
+		}
+	}
+	if len(enum) > 0 {
+		sb.WriteString("<br/><br/><strong>Allowed values:</strong> ")
+		writeValues(sb, enum)
+	}
+	if defaultValue != nil {
+		sb.WriteString("<br/><br/><strong>Default:</strong> ")
+		writeValues(sb, []interface{}{defaultValue})
 	}
 
 	// Location
 	if param.In != "" {
-		sb.WriteString(fmt.Sprintf("<br/><br/><strong>Location:</strong> %s", param.In))
+		sb.WriteString("<br/><br/><strong>Location:</strong> ")
+		writeText(sb, param.In)
 	}
 
 	sb.WriteString("</td>\n")
 	sb.WriteString("</tr>\n")
-
-	return sb.String()
 }
 
 // formatSchemaTable formats a schema as an HTML table
 func (f *Formatter) formatSchemaTable(schema *swagger.Schema) string {
-	if schema == nil || len(schema.Properties) == 0 {
+	if schema == nil || (len(schema.Properties) == 0 && !schema.HasVariants()) {
 		return "<p><em>No properties defined for this schema</em></p>\n"
 	}
+	if len(schema.Properties) == 0 {
+		return f.formatVariants(schema)
+	}
 
 	var sb strings.Builder
-
-	// Handle array type
-	if schema.Type == "array" && schema.Items != nil {
-		sb.WriteString("<p><strong>Type:</strong> Array</p>\n")
-		if schema.Items.Ref != "" {
-			sb.WriteString(fmt.Sprintf("<p><strong>Items:</strong> %s</p>\n", swagger.ExtractRefName(schema.Items.Ref)))
-		}
-	}
+	// Rows are roughly 250 bytes; growing once avoids repeated copying
+	sb.Grow(256 * (len(schema.Properties) + 1))
 
 	sb.WriteString("<table>\n")
 	sb.WriteString("<tr><th>Field</th><th>Type</th><th>Description</th><th>Constraints</th><th>Example</th></tr>\n")
 
-	for fieldName, prop := range schema.Properties {
-		sb.WriteString(f.formatPropertyRow(fieldName, prop, schema.Required))
+	for _, fieldName := range schema.PropertyNames() {
+		f.writePropertyRow(&sb, fieldName, schema.Properties[fieldName], schema.Required)
 	}
 
 	sb.WriteString("</table>\n")
@@ -339,32 +668,49 @@ func (f *Formatter) formatSchemaTable(schema *swagger.Schema) string {
 		sb.WriteString("<p><em>* indicates required field</em></p>\n")
 	}
 
+	// Large models are collapsed so the page stays readable
+	if f.collapse.collapsesTable(len(schema.Properties)) {
+		table := sb.String()
+		sb.Reset()
+		sb.WriteString(expandMacro(fmt.Sprintf("Show %d fields", len(schema.Properties)), table))
+	}
+
+	if schema.HasVariants() {
+		sb.WriteString(f.formatVariants(schema))
+	}
+
 	return sb.String()
 }
 
-// formatPropertyRow formats a single property row in the schema table
-func (f *Formatter) formatPropertyRow(fieldName string, prop swagger.Property, required []string) string {
-	var sb strings.Builder
+// writePropertyRow writes a single property row of the schema table
+func (f *Formatter) writePropertyRow(sb *strings.Builder, fieldName string, prop swagger.Property, required []string) {
+	isRequired := isFieldRequired(fieldName, required)
 
 	sb.WriteString("<tr>\n")
 
 	// Field name with required indicator
 	sb.WriteString("<td><code>")
-	sb.WriteString(fieldName)
-	if isFieldRequired(fieldName, required) {
+	writeText(sb, fieldName)
+	if isRequired {
 		sb.WriteString(" *")
 	}
-	sb.WriteString("</code></td>\n")
+	sb.WriteString("</code>")
+	if prop.Deprecated {
+		sb.WriteString("<br/>")
+		sb.WriteString(f.deprecatedBadge())
+	}
+	sb.WriteString("</td>\n")
 
 	// Type
-	sb.WriteString("<td><code>")
-	sb.WriteString(getPropertyType(prop))
-	sb.WriteString("</code></td>\n")
+	sb.WriteString("<td>")
+	writeCode(sb, getPropertyType(prop))
+	sb.WriteString(f.modelLink(prop))
+	sb.WriteString("</td>\n")
 
 	// Description
 	sb.WriteString("<td>")
 	if prop.Description != "" {
-		sb.WriteString(prop.Description)
+		sb.WriteString(markdownCell(prop.Description))
 	} else {
 		sb.WriteString("-")
 	}
@@ -372,21 +718,35 @@ func (f *Formatter) formatPropertyRow(fieldName string, prop swagger.Property, r
 
 	// Constraints
 	sb.WriteString("<td>")
-	sb.WriteString(formatConstraints(fieldName, prop, required))
+	writeConstraints(sb, prop, isRequired)
 	sb.WriteString("</td>\n")
 
 	// Example
 	sb.WriteString("<td>")
 	if prop.Example != nil {
-		sb.WriteString(fmt.Sprintf("<code>%v</code>", prop.Example))
+		writeCode(sb, fmt.Sprintf("%v", prop.Example))
 	} else {
 		sb.WriteString("-")
 	}
 	sb.WriteString("</td>\n")
 
 	sb.WriteString("</tr>\n")
+}
 
-	return sb.String()
+// writeContentType writes the media type line of a body or response
+func writeContentType(sb *strings.Builder, contentType string) {
+	sb.WriteString("<p><strong>Content-Type:</strong> ")
+	writeCode(sb, contentType)
+	sb.WriteString("</p>\n")
+}
+
+// marshalExample renders a literal example as indented JSON
+func marshalExample(example interface{}) string {
+	data, err := json.MarshalIndent(example, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%v", example)
+	}
+	return string(data)
 }
 
 // formatExampleJSON formats example JSON in a code block
@@ -394,13 +754,90 @@ func (f *Formatter) formatExampleJSON(exampleJSON string) string {
 	var sb strings.Builder
 
 	sb.WriteString("<h4>Example JSON</h4>\n")
+	sb.WriteString(f.collapsibleJSON(exampleJSON))
+
+	return sb.String()
+}
+
+// jsonCodeMacro formats JSON in a code macro
+func jsonCodeMacro(exampleJSON string) string {
+	var sb strings.Builder
 	sb.WriteString("<ac:structured-macro ac:name=\"code\">\n")
 	sb.WriteString("<ac:parameter ac:name=\"language\">json</ac:parameter>\n")
-	sb.WriteString("<ac:plain-text-body><![CDATA[")
-	sb.WriteString(exampleJSON)
-	sb.WriteString("]]></ac:plain-text-body>\n")
+	sb.WriteString("<ac:plain-text-body>")
+	sb.WriteString(cdata(exampleJSON))
+	sb.WriteString("</ac:plain-text-body>\n")
 	sb.WriteString("</ac:structured-macro>\n")
+	return sb.String()
+}
 
+// formatRequestVariants formats a generated request example as minimal and
+// full bodies in expand macros, or as one code block when both are the same
+func (f *Formatter) formatRequestVariants(minimal, full string) string {
+	if minimal == "" {
+		return f.formatExampleJSON(full)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<h4>Example JSON</h4>\n")
+	for _, variant := range []struct{ title, json string }{
+		{"Minimal example (required fields only)", minimal},
+		{"Full example", full},
+	} {
+		sb.WriteString(expandMacro(variant.title, jsonCodeMacro(variant.json)))
+	}
+	return sb.String()
+}
+
+// formatNamedExample formats a documented example body, titled by its
+// summary or name when it is one of several named examples
+func (f *Formatter) formatNamedExample(ex example.NamedExample) string {
+	if ex.Name == "" {
+		return f.formatExampleJSON(ex.JSON)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<h4>Example: ")
+	writeText(&sb, exampleTitle(ex.Name, ex.Summary))
+	sb.WriteString("</h4>\n")
+	sb.WriteString(f.collapsibleJSON(ex.JSON))
+	return sb.String()
+}
+
+// exampleTitle names a named example by its summary, falling back to its
+// name
+func exampleTitle(name, summary string) string {
+	if summary != "" {
+		return summary
+	}
+	return name
+}
+
+// formatFormExample formats example form fields in a code block: encoded
+// for URL-encoded forms, one field per line for multipart ones, with files
+// marked by @ as in curl
+func (f *Formatter) formatFormExample(contentType string, fields []example.FormField) string {
+	body := example.EncodeForm(fields)
+	if example.IsMultipart(contentType) {
+		lines := make([]string, 0, len(fields))
+		for _, field := range fields {
+			value := field.Value
+			if field.File {
+				value = "@" + value
+			}
+			lines = append(lines, field.Name+"="+value)
+		}
+		body = strings.Join(lines, "\n")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<h4>Example Form</h4>\n")
+	sb.WriteString("<ac:structured-macro ac:name=\"code\">\n")
+	sb.WriteString("<ac:parameter ac:name=\"language\">text</ac:parameter>\n")
+	sb.WriteString("<ac:plain-text-body>")
+	sb.WriteString(cdata(body))
+	sb.WriteString("</ac:plain-text-body>\n")
+	sb.WriteString("</ac:structured-macro>\n")
 	return sb.String()
 }
 
@@ -420,11 +857,18 @@ func (f *Formatter) optionalBadge() string {
 		"</ac:structured-macro>"
 }
 
+func (f *Formatter) deprecatedBadge() string {
+	return "<ac:structured-macro ac:name=\"status\">" +
+		"<ac:parameter ac:name=\"colour\">Grey</ac:parameter>" +
+		"<ac:parameter ac:name=\"title\">DEPRECATED</ac:parameter>" +
+		"</ac:structured-macro>"
+}
+
 func getParameterType(param swagger.Parameter) string {
 	if param.Type != "" {
 		typeStr := param.Type
 		if param.Format != "" {
-			typeStr += fmt.Sprintf(" (%s)", param.Format)
+			typeStr += " (" + param.Format + ")"
 		}
 		return typeStr
 	}
@@ -441,50 +885,176 @@ func getParameterType(param swagger.Parameter) string {
 	return ""
 }
 
+func getHeaderType(header swagger.Header) string {
+	typeStr, format := header.Type, header.Format
+	if header.Schema != nil {
+		if header.Schema.Ref != "" {
+			return swagger.ExtractRefName(header.Schema.Ref)
+		}
+		typeStr, format = header.Schema.Type, header.Schema.Format
+	}
+	if typeStr == "" {
+		typeStr = "string"
+	}
+	if format != "" {
+		typeStr += " (" + format + ")"
+	}
+	return typeStr
+}
+
 func getPropertyType(prop swagger.Property) string {
 	if prop.Ref != "" {
 		return swagger.ExtractRefName(prop.Ref)
 	}
 
+	if prop.IsFile() {
+		return "file"
+	}
+
 	typeStr := prop.Type
 	if prop.Format != "" {
-		typeStr += fmt.Sprintf(" (%s)", prop.Format)
+		typeStr += " (" + prop.Format + ")"
 	}
 
 	if prop.Type == "array" && prop.Items != nil {
 		if prop.Items.Ref != "" {
-			typeStr += fmt.Sprintf("[%s]", swagger.ExtractRefName(prop.Items.Ref))
+			typeStr += "[" + swagger.ExtractRefName(prop.Items.Ref) + "]"
 		} else if prop.Items.Type != "" {
-			typeStr += fmt.Sprintf("[%s]", prop.Items.Type)
+			typeStr += "[" + prop.Items.Type + "]"
+		}
+		if prop.Items.Recursive {
+			typeStr += " (recursive reference)"
 		}
 	}
 
 	return typeStr
 }
 
-func formatConstraints(fieldName string, prop swagger.Property, required []string) string {
-	var constraints []string
+// writeConstraints writes the constraints cell, or "-" when there are none
+func writeConstraints(sb *strings.Builder, prop swagger.Property, required bool) {
+	n := 0
+	next := func() {
+		if n > 0 {
+			sb.WriteString("<br/>")
+		}
+		n++
+	}
+
+	if required {
+		next()
+		sb.WriteString("<strong>Required</strong>")
+	}
 
-	if isFieldRequired(fieldName, required) {
-		constraints = append(constraints, "<strong>Required</strong>")
+	if prop.Nullable {
+		next()
+		sb.WriteString("Nullable")
 	}
 
 	if prop.MinLength > 0 && prop.MaxLength > 0 {
-		constraints = append(constraints, fmt.Sprintf("Length: %d-%d", prop.MinLength, prop.MaxLength))
+		next()
+		sb.WriteString("Length: ")
+		sb.WriteString(strconv.Itoa(prop.MinLength))
+		sb.WriteString("-")
+		sb.WriteString(strconv.Itoa(prop.MaxLength))
 	} else if prop.MinLength > 0 {
-		constraints = append(constraints, fmt.Sprintf("Min length: %d", prop.MinLength))
+		next()
+		sb.WriteString("Min length: ")
+		sb.WriteString(strconv.Itoa(prop.MinLength))
 	} else if prop.MaxLength > 0 {
-		constraints = append(constraints, fmt.Sprintf("Max length: %d", prop.MaxLength))
+		next()
+		sb.WriteString("Max length: ")
+		sb.WriteString(strconv.Itoa(prop.MaxLength))
+	}
+
+	if prop.Minimum != nil {
+		next()
+		writeBound(sb, "Minimum: ", *prop.Minimum, prop.ExclusiveMinimum)
+	}
+	if prop.Maximum != nil {
+		next()
+		writeBound(sb, "Maximum: ", *prop.Maximum, prop.ExclusiveMaximum)
+	}
+	if prop.MultipleOf != nil {
+		next()
+		sb.WriteString("Multiple of: ")
+		sb.WriteString(formatNumber(*prop.MultipleOf))
+	}
+
+	if prop.MinItems > 0 && prop.MaxItems > 0 {
+		next()
+		sb.WriteString("Items: ")
+		sb.WriteString(strconv.Itoa(prop.MinItems))
+		sb.WriteString("-")
+		sb.WriteString(strconv.Itoa(prop.MaxItems))
+	} else if prop.MinItems > 0 {
+		next()
+		sb.WriteString("Min items: ")
+		sb.WriteString(strconv.Itoa(prop.MinItems))
+	} else if prop.MaxItems > 0 {
+		next()
+		sb.WriteString("Max items: ")
+		sb.WriteString(strconv.Itoa(prop.MaxItems))
+	}
+	if prop.UniqueItems {
+		next()
+		sb.WriteString("Unique items")
 	}
 
 	if prop.Pattern != "" {
-		constraints = append(constraints, fmt.Sprintf("Pattern: <code>%s</code>", prop.Pattern))
+		next()
+		sb.WriteString("Pattern: ")
+		writeCode(sb, prop.Pattern)
+	}
+
+	if len(prop.Enum) > 0 {
+		next()
+		sb.WriteString("Allowed: ")
+		writeValues(sb, prop.Enum)
+	}
+
+	if prop.Default != nil {
+		next()
+		sb.WriteString("Default: ")
+		writeValues(sb, []interface{}{prop.Default})
+	}
+
+	if n == 0 {
+		sb.WriteString("-")
 	}
+}
 
-	if len(constraints) > 0 {
-		return strings.Join(constraints, "<br/>")
+// writeBound writes a numeric bound, noting when the bound itself is
+// excluded from the range
+func writeBound(sb *strings.Builder, label string, bound float64, exclusive bool) {
+	sb.WriteString(label)
+	sb.WriteString(formatNumber(bound))
+	if exclusive {
+		sb.WriteString(" (exclusive)")
+	}
+}
+
+// formatNumber formats a schema number without trailing zeros
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// writeValues writes literal schema values, such as enum members, as a
+// comma-separated list of code elements
+func writeValues(sb *strings.Builder, values []interface{}) {
+	for i, value := range values {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		if s, ok := value.(string); ok {
+			writeCode(sb, s)
+			continue
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			data = []byte(fmt.Sprintf("%v", value))
+		}
+		writeCode(sb, string(data))
 	}
-	return "-"
 }
 
 func isFieldRequired(fieldName string, required []string) bool {
@@ -0,0 +1,544 @@
+package confluence
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// benchmarkEndpoint builds an operation whose request and response bodies
+// reference a definition with the given number of properties
+func benchmarkEndpoint(properties int) (swagger.Operation, *swagger.Resolver) {
+	props := make(map[string]swagger.Property, properties)
+	var required []string
+	for i := 0; i < properties; i++ {
+		name := fmt.Sprintf("field%d", i)
+		switch i % 4 {
+		case 0:
+			props[name] = swagger.Property{Type: "string", Description: "A name", MinLength: 1, MaxLength: 64}
+			required = append(required, name)
+		case 1:
+			props[name] = swagger.Property{Type: "integer", Format: "int64", Example: 42}
+		case 2:
+			props[name] = swagger.Property{Type: "array", Items: &swagger.Schema{Type: "string"}}
+		default:
+			props[name] = swagger.Property{Type: "string", Format: "date-time", Pattern: "^[0-9]+$"}
+		}
+	}
+
+	spec := &swagger.Spec{
+		Definitions: map[string]swagger.Definition{
+			"Order": {Type: "object", Properties: props, Required: required},
+		},
+	}
+	op := swagger.Operation{
+		OperationID: "createOrder",
+		Description: "Creates an order",
+		Tags:        []string{"orders", "checkout"},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Parameters: []swagger.Parameter{
+			{Name: "tenant", In: "path", Required: true, Type: "string"},
+			{Name: "dryRun", In: "query", Type: "boolean", Description: "Validate only"},
+			{Name: "body", In: "body", Required: true, Schema: &swagger.Schema{Ref: "#/definitions/Order"}},
+		},
+		Responses: swagger.Responses{
+			"201": {Description: "Created", Schema: &swagger.Schema{Ref: "#/definitions/Order"}},
+			"400": {Description: "Bad request"},
+		},
+	}
+	return op, swagger.NewResolver(spec)
+}
+
+func TestFormatter_FormatSchemaTableVariants(t *testing.T) {
+	schema := &swagger.Schema{
+		Discriminator: &swagger.Discriminator{
+			PropertyName: "kind",
+			Mapping:      map[string]string{"card": "#/components/schemas/Card"},
+		},
+		OneOf: []*swagger.Schema{
+			{Title: "Card", Type: "object", Properties: map[string]swagger.Property{"number": {Type: "string"}}},
+			{Type: "string"},
+		},
+	}
+
+	content := NewFormatter().formatSchemaTable(schema)
+
+	for _, want := range []string{
+		"<strong>Discriminator:</strong> <code>kind</code>",
+		"<tr><td><code>card</code></td><td>Card</td></tr>",
+		"<strong>One of</strong>",
+		"<h5>Variant 1: Card</h5>",
+		"<td><code>number</code></td>",
+		"<h5>Variant 2</h5>\n<p><strong>Type:</strong> <code>string</code></p>",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "No properties defined") {
+		t.Errorf("expected variants instead of an empty table:\n%s", content)
+	}
+}
+
+func TestFormatter_EnumDefaultDeprecated(t *testing.T) {
+	f := NewFormatter()
+
+	table := f.formatSchemaTable(&swagger.Schema{
+		Type: "object",
+		Properties: map[string]swagger.Property{
+			"status": {Type: "string", Enum: []interface{}{"available", "sold"}, Default: "available"},
+			"limit":  {Type: "integer", Default: 20},
+			"tag":    {Type: "string", Deprecated: true},
+			"nick":   {Type: "string", Nullable: true},
+		},
+		Required: []string{"nick"},
+	})
+	for _, want := range []string{
+		"Allowed: <code>available</code>, <code>sold</code><br/>Default: <code>available</code>",
+		"<strong>Required</strong><br/>Nullable",
+		"Default: <code>20</code>",
+		"<td><code>tag</code><br/><ac:structured-macro ac:name=\"status\"><ac:parameter ac:name=\"colour\">Grey</ac:parameter><ac:parameter ac:name=\"title\">DEPRECATED</ac:parameter>",
+	} {
+		if !strings.Contains(table, want) {
+			t.Errorf("expected %q in:\n%s", want, table)
+		}
+	}
+
+	params := f.formatParametersSection([]swagger.Parameter{
+		{Name: "sort", In: "query", Type: "string", Enum: []interface{}{"asc", "desc"}, Deprecated: true},
+		{Name: "page", In: "query", Schema: &swagger.Schema{Type: "integer", Default: 1}},
+	})
+	for _, want := range []string{
+		"<strong>Allowed values:</strong> <code>asc</code>, <code>desc</code>",
+		"<strong>Default:</strong> <code>1</code>",
+		">DEPRECATED<",
+	} {
+		if !strings.Contains(params, want) {
+			t.Errorf("expected %q in:\n%s", want, params)
+		}
+	}
+	if strings.Count(params, "DEPRECATED") != 1 {
+		t.Errorf("expected only the sort parameter to be deprecated:\n%s", params)
+	}
+}
+
+func TestFormatter_NumericAndArrayConstraints(t *testing.T) {
+	f := NewFormatter()
+	zero, hundred, half := 0.0, 100.0, 0.5
+
+	table := f.formatSchemaTable(&swagger.Schema{
+		Type: "object",
+		Properties: map[string]swagger.Property{
+			"score": {Type: "number", Minimum: &zero, Maximum: &hundred, ExclusiveMaximum: true, MultipleOf: &half},
+			"tags":  {Type: "array", MinItems: 1, MaxItems: 5, UniqueItems: true},
+			"ids":   {Type: "array", MaxItems: 10},
+		},
+	})
+	for _, want := range []string{
+		"Minimum: 0<br/>Maximum: 100 (exclusive)<br/>Multiple of: 0.5",
+		"Items: 1-5<br/>Unique items",
+		"Max items: 10",
+	} {
+		if !strings.Contains(table, want) {
+			t.Errorf("expected %q in:\n%s", want, table)
+		}
+	}
+}
+
+func TestFormatter_FormRequestBody(t *testing.T) {
+	f := NewFormatter()
+	spec := &swagger.Spec{}
+	op := swagger.Operation{
+		RequestBody: &swagger.RequestBody{Content: map[string]swagger.MediaType{
+			"multipart/form-data": {Schema: &swagger.Schema{
+				Type: "object",
+				Properties: map[string]swagger.Property{
+					"caption": {Type: "string", Example: "Rex"},
+					"photo":   {Type: "string", Format: "binary"},
+				},
+				PropertyOrder: []string{"caption", "photo"},
+			}},
+		}},
+	}
+
+	section := f.formatRequestBodySection(op, swagger.NewResolver(spec))
+	for _, want := range []string{
+		"<td><code>file</code>",
+		"<h4>Example Form</h4>",
+		"caption=Rex\nphoto=@path/to/file",
+	} {
+		if !strings.Contains(section, want) {
+			t.Errorf("expected %q in:\n%s", want, section)
+		}
+	}
+	if strings.Contains(section, "Example JSON") {
+		t.Errorf("form body rendered as JSON:\n%s", section)
+	}
+}
+
+func TestFormatter_ResponseHeaders(t *testing.T) {
+	spec := &swagger.Spec{
+		OpenAPI: "3.0.0",
+		Components: &swagger.Components{Headers: map[string]swagger.Header{
+			"RateLimit": {Description: "Requests left in the **current** window", Schema: &swagger.Schema{Type: "integer", Format: "int32"}},
+		}},
+	}
+	op := swagger.Operation{
+		Responses: swagger.Responses{
+			"200": {Description: "OK", Headers: map[string]swagger.Header{
+				"X-Next-Page":     {Description: "Cursor of the next page", Required: true, Schema: &swagger.Schema{Type: "string"}},
+				"X-RateLimit":     {Ref: "#/components/headers/RateLimit"},
+				"X-Legacy-Cursor": {Type: "string", Deprecated: true},
+			}},
+			"204": {Description: "No content"},
+		},
+	}
+
+	content := NewFormatter().formatResponsesSection(op, swagger.NewResolver(spec))
+
+	for _, want := range []string{
+		"<h5>Headers</h5>",
+		"<td><code>X-Next-Page</code> <ac:structured-macro ac:name=\"status\"><ac:parameter ac:name=\"colour\">Red</ac:parameter>",
+		"<td><code>string</code></td>\n<td>Cursor of the next page</td>",
+		"<td><code>integer (int32)</code></td>\n<td>Requests left in the <strong>current</strong> window</td>",
+		">DEPRECATED<",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in:\n%s", want, content)
+		}
+	}
+	// Headers are listed by name
+	if strings.Index(content, "X-Legacy-Cursor") > strings.Index(content, "X-Next-Page") {
+		t.Errorf("expected headers in name order:\n%s", content)
+	}
+	if strings.Count(content, "<h5>Headers</h5>") != 1 {
+		t.Errorf("expected a headers table for the 200 response only:\n%s", content)
+	}
+}
+
+func TestFormatter_ResponseExamples(t *testing.T) {
+	op := swagger.Operation{
+		Responses: swagger.Responses{
+			"200": {Description: "OK", Content: map[string]swagger.MediaType{
+				"application/json": {Schema: &swagger.Schema{Type: "object", Properties: map[string]swagger.Property{"id": {Type: "integer"}}}},
+			}},
+			"500": {Description: "Error", Schema: &swagger.Schema{Type: "string"}},
+		},
+	}
+
+	content := NewFormatter().formatResponsesSection(op, swagger.NewResolver(&swagger.Spec{}))
+
+	for _, want := range []string{
+		"<ac:parameter ac:name=\"title\">200 application/json</ac:parameter>",
+		"<ac:parameter ac:name=\"title\">500 application/json</ac:parameter>",
+		"<![CDATA[{\n  \"id\": 0\n}]]>",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in:\n%s", want, content)
+		}
+	}
+	if n := strings.Count(content, "<ac:parameter ac:name=\"collapse\">true</ac:parameter>"); n != 2 {
+		t.Errorf("expected 2 collapsed examples, got %d:\n%s", n, content)
+	}
+}
+
+func TestFormatter_NamedExamples(t *testing.T) {
+	spec, err := swagger.NewParser().ParseBytes([]byte(`{
+		"openapi": "3.0.0",
+		"info": {"title": "Pets", "version": "1.0.0"},
+		"paths": {"/pets": {"post": {
+			"requestBody": {"content": {"application/json": {
+				"schema": {"type": "object", "properties": {"name": {"type": "string"}}},
+				"examples": {
+					"dog": {"summary": "A dog", "value": {"name": "Rex"}},
+					"cat": {"$ref": "#/components/examples/Cat"}
+				}
+			}}},
+			"responses": {"201": {"description": "Created", "content": {"application/json": {
+				"schema": {"type": "object", "example": {"id": 7}},
+				"examples": {"created": {"value": {"id": 1}}, "external": {"externalValue": "https://example.com/pet.json"}}
+			}}}}
+		}}},
+		"components": {"examples": {"Cat": {"summary": "A cat", "value": {"name": "Tom"}}}}
+	}`), "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := spec.Paths["/pets"]["post"]
+	resolver := swagger.NewResolver(spec)
+
+	request := NewFormatter().formatRequestBodySection(op, resolver)
+	dog, cat := strings.Index(request, "<h4>Example: A dog</h4>"), strings.Index(request, "<h4>Example: A cat</h4>")
+	if dog < 0 || cat < dog || !strings.Contains(request, `"name": "Tom"`) {
+		t.Errorf("expected the named request examples in document order:\n%s", request)
+	}
+	if strings.Contains(request, "Example JSON") {
+		t.Errorf("expected no generated request example:\n%s", request)
+	}
+
+	responses := NewFormatter().formatResponsesSection(op, resolver)
+	if !strings.Contains(responses, "<ac:parameter ac:name=\"title\">201 application/json - created</ac:parameter>") ||
+		!strings.Contains(responses, "<![CDATA[{\n  \"id\": 1\n}]]>") {
+		t.Errorf("expected the named response example:\n%s", responses)
+	}
+	if strings.Contains(responses, "external") || strings.Contains(responses, `"id": 7`) {
+		t.Errorf("expected only examples with values:\n%s", responses)
+	}
+}
+
+func TestFormatter_RequestVariants(t *testing.T) {
+	body := func(required ...string) swagger.Operation {
+		return swagger.Operation{RequestBody: &swagger.RequestBody{Content: map[string]swagger.MediaType{
+			"application/json": {Schema: &swagger.Schema{Type: "object", Required: required, Properties: map[string]swagger.Property{
+				"name": {Type: "string"},
+				"tag":  {Type: "string"},
+			}}},
+		}}}
+	}
+	resolver := swagger.NewResolver(&swagger.Spec{})
+
+	request := NewFormatter().formatRequestBodySection(body("name"), resolver)
+	minimal := strings.Index(request, "Minimal example (required fields only)")
+	full := strings.Index(request, "Full example")
+	if minimal < 0 || full < minimal || strings.Count(request, `ac:name="expand"`) != 2 {
+		t.Fatalf("expected minimal and full examples in expand macros:\n%s", request)
+	}
+	if strings.Contains(request[minimal:full], `"tag"`) || !strings.Contains(request[full:], `"tag"`) {
+		t.Errorf("expected the optional field only in the full example:\n%s", request)
+	}
+
+	// Examples whose fields are all required are shown once
+	request = NewFormatter().formatRequestBodySection(body("name", "tag"), resolver)
+	if strings.Contains(request, "expand") || strings.Count(request, "<ac:plain-text-body>") != 1 {
+		t.Errorf("expected a single example:\n%s", request)
+	}
+}
+
+func TestFormatter_SwaggerResponseSchemas(t *testing.T) {
+	spec := &swagger.Spec{
+		Definitions: map[string]swagger.Definition{
+			"Pet": {Type: "object", Properties: map[string]swagger.Property{"name": {Type: "string"}}},
+		},
+	}
+	pet := &swagger.Schema{Ref: "#/definitions/Pet"}
+
+	tests := []struct {
+		name      string
+		formatter *Formatter
+		schema    *swagger.Schema
+		want      []string
+		wantNot   []string
+	}{
+		{
+			name:      "ref",
+			formatter: NewFormatter(),
+			schema:    pet,
+			want:      []string{"<td><code>name</code></td>"},
+			wantNot:   []string{"Array of"},
+		},
+		{
+			name:      "array of ref",
+			formatter: NewFormatter(),
+			schema:    &swagger.Schema{Type: "array", Items: pet},
+			want:      []string{"<p><strong>Type:</strong> Array of <code>Pet</code></p>", "<td><code>name</code></td>"},
+			wantNot:   []string{"No properties defined"},
+		},
+		{
+			name:      "array of ref with model pages",
+			formatter: NewFormatter().WithModelPages("Pets"),
+			schema:    &swagger.Schema{Type: "array", Items: pet},
+			want:      []string{"Array of <code>Pet</code>", `<ri:page ri:content-title="Pets - Pet Model" />`},
+			wantNot:   []string{"<td><code>name</code></td>"},
+		},
+		{
+			name:      "array of scalars",
+			formatter: NewFormatter(),
+			schema:    &swagger.Schema{Type: "array", Items: &swagger.Schema{Type: "string"}},
+			want:      []string{"Array of <code>string</code>"},
+			wantNot:   []string{"No properties defined", "<table>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := swagger.Operation{Responses: swagger.Responses{"200": {Description: "OK", Schema: tt.schema}}}
+			content := tt.formatter.formatResponsesSection(op, swagger.NewResolver(spec))
+			for _, want := range tt.want {
+				if !strings.Contains(content, want) {
+					t.Errorf("expected %q in:\n%s", want, content)
+				}
+			}
+			for _, unwanted := range tt.wantNot {
+				if strings.Contains(content, unwanted) {
+					t.Errorf("unexpected %q in:\n%s", unwanted, content)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatter_HeaderURL(t *testing.T) {
+	spec := &swagger.Spec{
+		Host:     "petstore.io",
+		BasePath: "/v2",
+		Paths:    map[string]swagger.PathItem{"/pets/{id}": {"get": {}}},
+		Webhooks: map[string]swagger.PathItem{"newPet": {"post": {}}},
+	}
+	f := NewFormatter().WithSecurity(spec, "")
+
+	if content := f.formatHeaderSection("/pets/{id}", "get", swagger.Operation{}); !strings.Contains(content,
+		"<p><strong>URL:</strong> <code>https://petstore.io/v2/pets/{id}</code></p>") {
+		t.Errorf("expected the full URL in:\n%s", content)
+	}
+	if content := f.formatHeaderSection("newPet", "post", swagger.Operation{}); strings.Contains(content, "URL:") {
+		t.Errorf("expected no URL on a webhook page:\n%s", content)
+	}
+	if content := NewFormatter().formatHeaderSection("/pets/{id}", "get", swagger.Operation{}); strings.Contains(content, "URL:") {
+		t.Errorf("expected no URL without a spec:\n%s", content)
+	}
+}
+
+func TestFormatter_RecursiveSchema(t *testing.T) {
+	spec := &swagger.Spec{
+		Definitions: map[string]swagger.Definition{
+			"Category": {
+				Type: "object",
+				Properties: map[string]swagger.Property{
+					"name":     {Type: "string"},
+					"children": {Type: "array", Items: &swagger.Schema{Ref: "#/definitions/Category"}},
+				},
+			},
+		},
+	}
+	resolver := swagger.NewResolver(spec)
+
+	resolved, err := resolver.ResolveSchema(&swagger.Schema{Ref: "#/definitions/Category"})
+	if err != nil {
+		t.Fatalf("ResolveSchema() error = %v", err)
+	}
+	content := NewFormatter().formatSchemaTable(resolved)
+
+	want := "<code>array[Category] (recursive reference)</code>"
+	if !strings.Contains(content, want) {
+		t.Errorf("expected %q in:\n%s", want, content)
+	}
+}
+
+func TestFormatter_InsertBanner(t *testing.T) {
+	f := NewFormatter()
+	op := swagger.Operation{Summary: "Get Pet", Responses: map[string]swagger.Response{"200": {Description: "OK"}}}
+	content, err := f.FormatEndpointPage("/pets/{id}", "get", op, swagger.NewResolver(&swagger.Spec{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := f.InsertBanner(content, "<p>banner</p>")
+	if !strings.Contains(got, "</h2>\n<p>banner</p>") {
+		t.Errorf("banner not below the heading in:\n%s", got)
+	}
+	if got := f.InsertBanner("<p>custom</p>", "<p>banner</p>"); got != "<p>banner</p><p>custom</p>" {
+		t.Errorf("InsertBanner() without heading = %q", got)
+	}
+}
+
+func TestFormatter_ReplaceVersionLinks(t *testing.T) {
+	f := NewFormatter()
+	v2 := []VersionLink{{Label: "v2", Title: "Get Pet (v2)"}}
+	page := func(links string) string {
+		return "<h2>Get Pet</h2>" + links + "<p>body</p>"
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		links   []VersionLink
+		want    string
+	}{
+		{
+			name:    "link added",
+			content: page(strings.TrimSuffix(f.FormatVersionLinks(nil), "\n")),
+			links:   v2,
+			want:    page(strings.TrimSuffix(f.FormatVersionLinks(v2), "\n")),
+		},
+		{
+			name: "same links stored by Confluence",
+			content: page(storedAnchor(VersionsStartMarker, "1f3a") +
+				`<p><strong>Other versions:</strong> <ac:link><ri:page ri:space-key="API" ri:content-title="Get Pet (v2)" />` +
+				`<ac:plain-text-link-body><![CDATA[v2]]></ac:plain-text-link-body></ac:link></p>` +
+				storedAnchor(VersionsEndMarker, "9c2e")),
+			links: v2,
+		},
+		{
+			name:    "no version markers",
+			content: page(""),
+			links:   v2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.want
+			if want == "" {
+				want = tt.content
+			}
+			if got := f.ReplaceVersionLinks(tt.content, tt.links); got != want {
+				t.Errorf("ReplaceVersionLinks() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkFormatter_FormatEndpointPage(b *testing.B) {
+	for _, properties := range []int{10, 100} {
+		b.Run(fmt.Sprintf("properties=%d", properties), func(b *testing.B) {
+			op, resolver := benchmarkEndpoint(properties)
+			f := NewFormatter()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := f.FormatEndpointPage("/tenants/{tenant}/orders", "post", op, resolver); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatter_RequestBodyContentTypesAreStable(t *testing.T) {
+	schema := func(field string) *swagger.Schema {
+		return &swagger.Schema{Type: "object", Properties: map[string]swagger.Property{field: {Type: "string"}}}
+	}
+	op := swagger.Operation{
+		Summary: "Create Pet",
+		RequestBody: &swagger.RequestBody{Content: map[string]swagger.MediaType{
+			"application/json":     {Schema: schema("json")},
+			"application/xml":      {Schema: schema("xml")},
+			"text/plain":           {Schema: schema("text")},
+			"application/vnd.pet":  {Schema: schema("vnd")},
+			"application/x-ndjson": {Schema: schema("ndjson")},
+		}},
+		Responses: map[string]swagger.Response{"201": {Description: "Created"}},
+	}
+	resolver := swagger.NewResolver(&swagger.Spec{})
+
+	f := NewFormatter()
+	first, err := f.FormatEndpointPage("/pets", "post", op, resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 50; i++ {
+		content, err := f.FormatEndpointPage("/pets", "post", op, resolver)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if content != first {
+			t.Fatalf("render %d differs from the first:\n%s\n---\n%s", i+2, content, first)
+		}
+	}
+	if strings.Index(first, "application/json") > strings.Index(first, "application/xml") {
+		t.Errorf("content types not in sorted order in:\n%s", first)
+	}
+}
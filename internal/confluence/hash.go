@@ -0,0 +1,55 @@
+package confluence
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// ContentHashProperty is the content property holding the hash of the
+// storage body last written to a page
+const ContentHashProperty = "swagfluence-content-hash"
+
+// pageExpand is the expansion requested when looking pages up, so the
+// content hash and labels come with the page instead of needing requests of
+// their own
+const pageExpand = "version,body.storage,metadata.labels,metadata.properties." + ContentHashProperty
+
+// contentHash returns the hash recorded for storage format content
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// recordedHash returns the content hash stored on a page and the version of
+// its property, or "" and 0 when the page has none
+func recordedHash(page *Page) (string, int) {
+	if page.Metadata == nil {
+		return "", 0
+	}
+	property, ok := page.Metadata.Properties[ContentHashProperty]
+	if !ok || property.Version == nil {
+		return "", 0
+	}
+	var hash string
+	if err := json.Unmarshal(property.Value, &hash); err != nil {
+		return "", 0
+	}
+	return hash, property.Version.Number
+}
+
+// recordHash stores the content hash of a written page and mirrors it in
+// the page metadata, so later lookups and runs see it
+func (c *ConfluenceClient) recordHash(ctx context.Context, page *Page, hash string, version int) error {
+	if err := c.SetPageProperty(ctx, page.ID, ContentHashProperty, hash, version); err != nil {
+		return fmt.Errorf("failed to record content hash: %w", err)
+	}
+
+	raw, _ := json.Marshal(hash)
+	page.Metadata = &Metadata{Properties: map[string]contentProperty{
+		ContentHashProperty: {Key: ContentHashProperty, Value: raw, Version: &Version{Number: version + 1}},
+	}}
+	return nil
+}
@@ -0,0 +1,71 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+func TestClient_SkipsUnchangedContent(t *testing.T) {
+	content := "<p>pet</p>"
+	var writes []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprintf(w, `{"results": [{"id": "7", "title": "Get Pet", "version": {"number": 3},
+				"body": {"storage": {"value": %q, "representation": "storage"}},
+				"metadata": {"properties": {%q: {"key": %q, "value": %q, "version": {"number": 2}}}}}]}`,
+				content, ContentHashProperty, ContentHashProperty, contentHash(content))
+			return
+		}
+		if r.Method == http.MethodPut && r.URL.Path == "/rest/api/content/7/property/"+ContentHashProperty {
+			var property contentProperty
+			if err := json.NewDecoder(r.Body).Decode(&property); err != nil {
+				t.Errorf("failed to decode property: %v", err)
+			}
+			writes = append(writes, fmt.Sprintf("property v%d", property.Version.Number))
+		} else {
+			writes = append(writes, r.Method+" "+r.URL.Path)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(config.ConfluenceConfig{
+		BaseURL:  server.URL,
+		Username: "user",
+		APIToken: "token",
+		SpaceKey: "TEST",
+		Enabled:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if _, err := client.CreateOrUpdatePage(ctx, "Get Pet", content, ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(writes) != 0 {
+		t.Errorf("unchanged page was written: %v", writes)
+	}
+	if change := client.(*ConfluenceClient).LastChange(); change != ChangeUnchanged {
+		t.Errorf("LastChange() = %q, want %q", change, ChangeUnchanged)
+	}
+
+	if _, err := client.CreateOrUpdatePage(ctx, "Get Pet", "<p>changed</p>", ""); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"PUT /rest/api/content/7", "property v3"}
+	if fmt.Sprint(writes) != fmt.Sprint(want) {
+		t.Errorf("writes = %v, want %v", writes, want)
+	}
+	if change := client.(*ConfluenceClient).LastChange(); change != ChangeUpdate {
+		t.Errorf("LastChange() = %q, want %q", change, ChangeUpdate)
+	}
+}
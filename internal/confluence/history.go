@@ -0,0 +1,113 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+)
+
+// Version messages
+const (
+	// VersionPlaceholder is replaced by the API version in version messages
+	VersionPlaceholder = "{version}"
+	// DefaultVersionMessage is the version message of pages written by
+	// SwagFluence unless configured otherwise
+	DefaultVersionMessage = "API version " + VersionPlaceholder
+)
+
+// versionMessage returns the version message for pages written in this run
+func (c *ConfluenceClient) versionMessage() string {
+	if c.apiVersion == "" {
+		return ""
+	}
+	return strings.ReplaceAll(c.messageTemplate(), VersionPlaceholder, c.apiVersion)
+}
+
+// messageTemplate returns the configured version message
+func (c *ConfluenceClient) messageTemplate() string {
+	if c.cfg.VersionMessage != "" {
+		return c.cfg.VersionMessage
+	}
+	return DefaultVersionMessage
+}
+
+// updateVersion returns the version of a page update, a minor edit that
+// doesn't notify watchers when so configured
+func (c *ConfluenceClient) updateVersion(number int) *Version {
+	return &Version{Number: number, Message: c.versionMessage(), MinorEdit: c.cfg.MinorEdit}
+}
+
+// renderedVersion returns the API version an existing page was rendered
+// from, recovered from a version message written with template or the
+// default one, falling back to its Confluence revision for older pages
+func renderedVersion(page *Page, template string) string {
+	if page.Version == nil {
+		return ""
+	}
+	for _, tmpl := range []string{template, DefaultVersionMessage} {
+		prefix, suffix, _ := strings.Cut(tmpl, VersionPlaceholder)
+		rest, ok := strings.CutPrefix(page.Version.Message, prefix)
+		if !ok {
+			continue
+		}
+		if version, ok := strings.CutSuffix(rest, suffix); ok && version != "" {
+			return version
+		}
+	}
+	return "rev " + strconv.Itoa(page.Version.Number)
+}
+
+// archivePage copies the current rendering of a page into the history
+// subtree under parentPageID. Pages rendered from the version being
+// published are left alone, so re-syncs of one release create no snapshots.
+func (c *ConfluenceClient) archivePage(ctx context.Context, existing *Page, parentPageID string) error {
+	version := renderedVersion(existing, c.messageTemplate())
+	if version == "" || c.apiVersion == "" || version == c.apiVersion {
+		return nil
+	}
+
+	historyID, err := c.historyPage(ctx, parentPageID)
+	if err != nil {
+		return err
+	}
+
+	title := fmt.Sprintf("%s (%s)", existing.Title, version)
+	content := fmt.Sprintf(`<ac:structured-macro ac:name="info">
+<ac:rich-text-body><p>Snapshot of <ac:link><ri:page ri:content-title="%s" /></ac:link> as documented for API version %s.</p></ac:rich-text-body>
+</ac:structured-macro>
+%s`, html.EscapeString(existing.Title), html.EscapeString(version), existing.Body.Storage.Value)
+
+	if _, err := c.publishPage(ctx, title, content, historyID, false); err != nil {
+		return fmt.Errorf("failed to create snapshot %q: %w", title, err)
+	}
+	return nil
+}
+
+// historyPage returns the "History" page under parentPageID, creating it
+// on first use
+func (c *ConfluenceClient) historyPage(ctx context.Context, parentPageID string) (string, error) {
+	if id, ok := c.historyPageIDs[parentPageID]; ok {
+		return id, nil
+	}
+
+	apiTitle := c.apiTitle
+	if apiTitle == "" {
+		apiTitle = c.cfg.SpaceKey
+	}
+	title := fmt.Sprintf("%s - History", apiTitle)
+	content := `<p>Snapshots of the endpoint pages as they were documented for earlier API versions.</p>
+<p><ac:structured-macro ac:name="children">
+<ac:parameter ac:name="all">true</ac:parameter>
+<ac:parameter ac:name="sort">title</ac:parameter>
+</ac:structured-macro></p>`
+
+	id, err := c.publishPage(ctx, title, content, parentPageID, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to create history page: %w", err)
+	}
+
+	c.historyPageIDs[parentPageID] = id
+	return id, nil
+}
@@ -0,0 +1,45 @@
+package confluence
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+func TestRenderedVersion(t *testing.T) {
+	custom := "Generated from spec v{version} by CI"
+
+	tests := []struct {
+		name     string
+		page     *Page
+		template string
+		want     string
+	}{
+		{"no version", &Page{}, DefaultVersionMessage, ""},
+		{"recorded API version", &Page{Version: &Version{Number: 4, Message: "API version 1.2.0"}}, DefaultVersionMessage, "1.2.0"},
+		{"older page", &Page{Version: &Version{Number: 4, Message: "edited by hand"}}, DefaultVersionMessage, "rev 4"},
+		{"custom message", &Page{Version: &Version{Number: 4, Message: "Generated from spec v1.4.2 by CI"}}, custom, "1.4.2"},
+		{"default message before customizing", &Page{Version: &Version{Number: 4, Message: "API version 1.2.0"}}, custom, "1.2.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderedVersion(tt.page, tt.template); got != tt.want {
+				t.Errorf("renderedVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_UpdateVersion(t *testing.T) {
+	c := &ConfluenceClient{
+		cfg:        config.ConfluenceConfig{VersionMessage: "Generated from spec v{version}", MinorEdit: true},
+		apiVersion: "1.4.2",
+	}
+
+	want := &Version{Number: 5, Message: "Generated from spec v1.4.2", MinorEdit: true}
+	if got := c.updateVersion(5); !reflect.DeepEqual(got, want) {
+		t.Errorf("updateVersion() = %+v, want %+v", got, want)
+	}
+}
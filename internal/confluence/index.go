@@ -0,0 +1,99 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// indexPageSize is the number of pages fetched per descendant request
+const indexPageSize = 100
+
+// loadPageIndex fetches every page below rootID in one paginated query and
+// indexes it by title, so publishing doesn't look up each page separately
+func (c *ConfluenceClient) loadPageIndex(ctx context.Context, rootID string) error {
+	if !c.cfg.Enabled || rootID == "" {
+		return nil
+	}
+	if c.pageIndex == nil {
+		c.pageIndex = make(map[string]*Page)
+	}
+
+	for start := 0; ; start += indexPageSize {
+		apiURL := fmt.Sprintf("%s/rest/api/content/%s/descendant/page?expand=%s&limit=%d&start=%d",
+			c.cfg.BaseURL, rootID, pageExpand, indexPageSize, start)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		c.authorize(req)
+
+		resp, err := c.do(req)
+		if err != nil {
+			return fmt.Errorf("failed to list pages: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(bodyBytes))
+		}
+
+		var result SearchResponse
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		for i := range result.Results {
+			page := result.Results[i]
+			c.pageIndex[page.Title] = &page
+		}
+
+		if len(result.Results) < indexPageSize {
+			return nil
+		}
+	}
+}
+
+// lookupPage returns the existing page with the title. Pages recorded by
+// previous runs are used as is; otherwise the subtree index is loaded on
+// first use, with a title search for pages outside it. cached reports
+// whether the page came from the state file and may be stale.
+func (c *ConfluenceClient) lookupPage(ctx context.Context, title string) (page *Page, cached bool, err error) {
+	if page, ok := c.state[title]; ok {
+		return page, true, nil
+	}
+
+	if c.indexRoot != "" && c.pageIndex == nil && !c.cfg.LowMemory {
+		if err := c.loadPageIndex(ctx, c.indexRoot); err != nil {
+			return nil, false, fmt.Errorf("failed to index existing pages: %w", err)
+		}
+	}
+	if page, ok := c.pageIndex[title]; ok {
+		return page, false, nil
+	}
+
+	page, err = c.findPageByTitle(ctx, title)
+	return page, false, err
+}
+
+// rememberPage records a page written in this run, so later lookups and
+// runs see its new version and content
+func (c *ConfluenceClient) rememberPage(page *Page) {
+	if page.ID == "" {
+		return
+	}
+	stored := *page
+	if c.pageIndex != nil {
+		c.pageIndex[page.Title] = &stored
+	}
+	if c.state != nil {
+		c.state[page.Title] = &stored
+	}
+}
@@ -0,0 +1,88 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+func TestClient_PageIndex(t *testing.T) {
+	searches := 0
+	updates := make(map[string]int)
+	created := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/content":
+			searches++
+			w.Write([]byte(`{"results": []}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/content/100/descendant/page":
+			w.Write([]byte(`{"results": [{"id": "7", "title": "Get Pet", "version": {"number": 3},
+				"body": {"storage": {"value": "<p>old</p>", "representation": "storage"}}}]}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/property"):
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/content":
+			created++
+			if created == 1 {
+				w.Write([]byte(`{"id": "100"}`))
+			} else {
+				w.Write([]byte(`{"id": "200"}`))
+			}
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/rest/api/content/"):
+			var page Page
+			if err := json.NewDecoder(r.Body).Decode(&page); err != nil {
+				t.Errorf("failed to decode update: %v", err)
+				return
+			}
+			updates[page.ID] = page.Version.Number
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(config.ConfluenceConfig{
+		BaseURL:  server.URL,
+		Username: "user",
+		APIToken: "token",
+		SpaceKey: "TEST",
+		Enabled:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	parentID, err := client.CreateParentPage(ctx, "Pets")
+	if err != nil || parentID != "100" {
+		t.Fatalf("CreateParentPage() = %q, %v", parentID, err)
+	}
+
+	// Indexed page: updated without a title search
+	if _, err := client.CreateOrUpdatePage(ctx, "Get Pet", "<p>new</p>", parentID); err != nil {
+		t.Fatal(err)
+	}
+	// New page: searched once, then served from the index
+	for i := 0; i < 2; i++ {
+		if _, err := client.CreateOrUpdatePage(ctx, "Add Pet", fmt.Sprintf("<p>add %d</p>", i), parentID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if searches != 2 {
+		t.Errorf("title searches = %d, want 2 (parent page and the new page)", searches)
+	}
+	if updates["7"] != 4 {
+		t.Errorf("Get Pet updated to version %d, want 4", updates["7"])
+	}
+	if updates["200"] != 2 {
+		t.Errorf("Add Pet updated to version %d, want 2", updates["200"])
+	}
+}
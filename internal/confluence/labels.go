@@ -0,0 +1,95 @@
+package confluence
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxLabelLength is the longest label Confluence accepts
+const maxLabelLength = 255
+
+// Labels applied to generated pages when ManagedLabels is set
+const (
+	// ManagedLabel marks every generated page
+	ManagedLabel = "swagfluence"
+	// EndpointLabel marks generated endpoint pages, the only pages pruning
+	// removes
+	EndpointLabel = "swagfluence-endpoint"
+)
+
+// SanitizeLabel converts free text such as an OpenAPI tag into a valid
+// Confluence label: lower case, with whitespace and reserved characters
+// replaced by dashes. It returns "" when nothing usable remains.
+func SanitizeLabel(text string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			sb.WriteRune(r)
+			dash = false
+		} else if !dash && sb.Len() > 0 {
+			sb.WriteRune('-')
+			dash = true
+		}
+	}
+
+	label := strings.TrimSuffix(sb.String(), "-")
+	if len(label) > maxLabelLength {
+		label = strings.TrimSuffix(label[:maxLabelLength], "-")
+	}
+	return label
+}
+
+// TagLabels returns the sanitized, de-duplicated labels for a set of tags
+func TagLabels(tags []string) []string {
+	var labels []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		label := SanitizeLabel(tag)
+		if label == "" || seen[label] {
+			continue
+		}
+		seen[label] = true
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// HasLabel reports whether a page looked up with its labels carries label
+func (p *Page) HasLabel(label string) bool {
+	if p.Metadata == nil || p.Metadata.Labels == nil {
+		return false
+	}
+	for _, l := range p.Metadata.Labels.Results {
+		if l.Name == label {
+			return true
+		}
+	}
+	return false
+}
+
+// IsGeneratedEndpoint reports whether a page is a generated endpoint page,
+// by its endpoint label or, for pages published without managed labels, by
+// its notes markers
+func IsGeneratedEndpoint(page *Page) bool {
+	return page.HasLabel(EndpointLabel) || IsEndpointPage(page.Body.Storage.Value)
+}
+
+// pageLabels returns the labels for a page about to be published: the
+// configured labels and, with ManagedLabels, the managed label, the API
+// name and, for endpoint pages, the endpoint label
+func (c *ConfluenceClient) pageLabels(content string) []string {
+	if !c.cfg.ManagedLabels {
+		return c.cfg.Labels
+	}
+
+	labels := append([]string{}, c.cfg.Labels...)
+	labels = append(labels, ManagedLabel)
+	if apiLabel := SanitizeLabel(c.apiTitle); apiLabel != "" {
+		labels = append(labels, apiLabel)
+	}
+	if IsEndpointPage(content) {
+		labels = append(labels, EndpointLabel)
+	}
+	return labels
+}
@@ -0,0 +1,153 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+func TestSanitizeLabel(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"pet", "pet"},
+		{"Pet Store", "pet-store"},
+		{"  Users & Accounts (v2) ", "users-accounts-v2"},
+		{"billing:invoices", "billing-invoices"},
+		{"snake_case", "snake_case"},
+		{"Überweisung", "überweisung"},
+		{"!!!", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := SanitizeLabel(tt.input); got != tt.want {
+				t.Errorf("SanitizeLabel(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagLabels(t *testing.T) {
+	got := TagLabels([]string{"Pet Store", "pet-store", "!!", "Orders"})
+	want := []string{"pet-store", "orders"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TagLabels() = %v, want %v", got, want)
+	}
+}
+
+func TestClient_PageLabels(t *testing.T) {
+	endpoint := "<h2>Get Pet</h2>\n" + anchorMacro(ManualStartMarker)
+
+	tests := []struct {
+		name    string
+		managed bool
+		content string
+		want    []string
+	}{
+		{"unmanaged", false, endpoint, []string{"docs"}},
+		{"managed page", true, "<p>Models</p>", []string{"docs", ManagedLabel, "pet-store"}},
+		{"managed endpoint page", true, endpoint, []string{"docs", ManagedLabel, "pet-store", EndpointLabel}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ConfluenceClient{
+				cfg:      config.ConfluenceConfig{Labels: []string{"docs"}, ManagedLabels: tt.managed},
+				apiTitle: "Pet Store",
+			}
+			if got := c.pageLabels(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pageLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsGeneratedEndpoint(t *testing.T) {
+	labeled := Page{Metadata: &Metadata{Labels: &LabelResults{Results: []Label{{Prefix: "global", Name: EndpointLabel}}}}}
+	marked := Page{Body: Body{Storage: Storage{Value: storedAnchor(ManualStartMarker, "7b0e")}}}
+	other := Page{
+		Body:     Body{Storage: Storage{Value: "<p>Hand-written</p>"}},
+		Metadata: &Metadata{Labels: &LabelResults{Results: []Label{{Prefix: "global", Name: ManagedLabel}}}},
+	}
+
+	if !IsGeneratedEndpoint(&labeled) {
+		t.Error("page with the endpoint label not recognized")
+	}
+	if !IsGeneratedEndpoint(&marked) {
+		t.Error("page with notes markers not recognized")
+	}
+	if IsGeneratedEndpoint(&other) {
+		t.Error("page without endpoint label or markers recognized")
+	}
+}
+
+func TestClient_AddLabels(t *testing.T) {
+	tests := []struct {
+		name     string
+		labels   []string
+		dryRun   bool
+		status   int
+		wantPost bool
+		wantErr  string
+	}{
+		{name: "posts global labels", labels: []string{"pets", ManagedLabel}, status: http.StatusOK, wantPost: true},
+		{name: "rejected", labels: []string{"pets"}, status: http.StatusForbidden, wantPost: true,
+			wantErr: "failed to add labels pets: unexpected status 403: not allowed"},
+		{name: "dry run", labels: []string{"pets"}, dryRun: true},
+		{name: "no labels"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posted []Label
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/rest/api/content/7/label" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&posted); err != nil {
+					t.Errorf("failed to decode labels: %v", err)
+				}
+				w.WriteHeader(tt.status)
+				if tt.status != http.StatusOK {
+					w.Write([]byte("not allowed"))
+				}
+			}))
+			defer server.Close()
+
+			c, err := NewClient(config.ConfluenceConfig{BaseURL: server.URL, SpaceKey: "TEST", Enabled: true, DryRun: tt.dryRun})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = c.(*ConfluenceClient).AddLabels(context.Background(), "7", tt.labels)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("AddLabels() error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Errorf("AddLabels() error = %v", err)
+			}
+
+			if (posted != nil) != tt.wantPost {
+				t.Fatalf("labels posted = %v, want %v", posted != nil, tt.wantPost)
+			}
+			if !tt.wantPost {
+				return
+			}
+			want := make([]Label, 0, len(tt.labels))
+			for _, name := range tt.labels {
+				want = append(want, Label{Prefix: "global", Name: name})
+			}
+			if !reflect.DeepEqual(posted, want) {
+				t.Errorf("posted labels = %+v, want %+v", posted, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package confluence
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// ModelsPageTitle returns the title of the page grouping the model pages
+func ModelsPageTitle(scope string) string {
+	return fmt.Sprintf("%s - Models", scope)
+}
+
+// ModelPageTitle returns the title of the shared page for a component schema
+func ModelPageTitle(scope, name string) string {
+	return fmt.Sprintf("%s - %s Model", scope, name)
+}
+
+// WithModelPages returns a copy of the formatter that includes referenced
+// component schemas from their model pages instead of repeating the table
+// on every endpoint page. scope prefixes the model page titles.
+func (f *Formatter) WithModelPages(scope string) *Formatter {
+	shared := *f
+	shared.modelScope = scope
+	return &shared
+}
+
+// formatSchema formats a schema table, or an excerpt include of the model
+// page when the schema references a shared component. Arrays name their
+// item type and format the items.
+func (f *Formatter) formatSchema(schema, resolved *swagger.Schema) string {
+	if resolved.Type == "array" && resolved.Items != nil && len(resolved.Properties) == 0 {
+		return f.formatArraySchema(schema, resolved)
+	}
+	if f.modelScope == "" || schema.Ref == "" || (len(resolved.Properties) == 0 && !resolved.HasVariants()) {
+		return f.formatSchemaTable(resolved)
+	}
+
+	title := ModelPageTitle(f.modelScope, swagger.ExtractRefName(schema.Ref))
+
+	var sb strings.Builder
+	sb.WriteString("<ac:structured-macro ac:name=\"excerpt-include\">\n")
+	sb.WriteString("<ac:parameter ac:name=\"nopanel\">true</ac:parameter>\n")
+	sb.WriteString(fmt.Sprintf("<ac:parameter ac:name=\"\"><ac:link><ri:page ri:content-title=\"%s\" /></ac:link></ac:parameter>\n",
+		html.EscapeString(title)))
+	sb.WriteString("</ac:structured-macro>\n")
+	sb.WriteString("<p>Model: ")
+	sb.WriteString(modelPageLink(title, swagger.ExtractRefName(schema.Ref)))
+	sb.WriteString("</p>\n")
+	return sb.String()
+}
+
+// formatArraySchema formats an array schema: its item type, followed by the
+// items' table unless they are scalars or a recursive reference
+func (f *Formatter) formatArraySchema(schema, resolved *swagger.Schema) string {
+	// The raw items still name a referenced component
+	items := schema.Items
+	if items == nil {
+		items = resolved.Items
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<p><strong>Type:</strong> Array of ")
+	writeCode(&sb, itemsName(items, resolved.Items))
+	sb.WriteString("</p>\n")
+
+	if resolved.Items.Recursive || (resolved.Items.Type != "array" &&
+		len(resolved.Items.Properties) == 0 && !resolved.Items.HasVariants()) {
+		return sb.String()
+	}
+	sb.WriteString(f.formatSchema(items, resolved.Items))
+	return sb.String()
+}
+
+// itemsName names the item type of an array: the referenced component, the
+// title or the type
+func itemsName(items, resolved *swagger.Schema) string {
+	switch {
+	case items.Ref != "":
+		return swagger.ExtractRefName(items.Ref)
+	case resolved.Title != "":
+		return resolved.Title
+	case resolved.Type != "":
+		return resolved.Type
+	default:
+		return "object"
+	}
+}
+
+// modelLink returns a line break and a link to the model page of the
+// component a property refers to, or "" when model pages are off or the
+// property refers to none
+func (f *Formatter) modelLink(prop swagger.Property) string {
+	ref := prop.Ref
+	if ref == "" && prop.Items != nil {
+		ref = prop.Items.Ref
+	}
+	if f.modelScope == "" || ref == "" {
+		return ""
+	}
+	name := swagger.ExtractRefName(ref)
+	return "<br/>" + modelPageLink(ModelPageTitle(f.modelScope, name), name)
+}
+
+// modelPageLink formats a link to a model page, labeled with the model name
+func modelPageLink(title, name string) string {
+	return fmt.Sprintf("<ac:link><ri:page ri:content-title=\"%s\" /><ac:plain-text-link-body>%s</ac:plain-text-link-body></ac:link>",
+		html.EscapeString(title), cdata(name))
+}
+
+// FormatModelsPage generates the page grouping the model pages
+func (f *Formatter) FormatModelsPage(scope string) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("<h1>%s Models</h1>\n", html.EscapeString(scope)))
+	sb.WriteString("<p>Schemas shared by the endpoint pages. Each model is documented once and included where it is used.</p>\n")
+	sb.WriteString("<p><ac:structured-macro ac:name=\"children\">\n")
+	sb.WriteString("<ac:parameter ac:name=\"all\">true</ac:parameter>\n")
+	sb.WriteString("</ac:structured-macro></p>\n")
+
+	return sb.String()
+}
+
+// FormatModelPage generates the page of one model. The schema table sits in
+// an excerpt macro so endpoint pages can include it. usedBy are the titles
+// of the endpoint pages referring to the model, which are linked below it.
+func (f *Formatter) FormatModelPage(name string, schema *swagger.Schema, usedBy []string) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("<h2>%s</h2>\n", html.EscapeString(name)))
+	sb.WriteString("<ac:structured-macro ac:name=\"excerpt\">\n")
+	sb.WriteString("<ac:parameter ac:name=\"atlassian-macro-output-type\">BLOCK</ac:parameter>\n")
+	sb.WriteString("<ac:rich-text-body>\n")
+	sb.WriteString(f.formatSchemaTable(schema))
+	sb.WriteString("</ac:rich-text-body>\n")
+	sb.WriteString("</ac:structured-macro>\n")
+
+	if len(usedBy) > 0 {
+		sb.WriteString("<h3>Used By</h3>\n<ul>\n")
+		for _, title := range usedBy {
+			sb.WriteString("<li>")
+			sb.WriteString(pageLink(title))
+			sb.WriteString("</li>\n")
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,100 @@
+package confluence
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func TestFormatter_WithModelPages(t *testing.T) {
+	spec := &swagger.Spec{
+		Definitions: map[string]swagger.Definition{
+			"Pet": {Type: "object", Properties: map[string]swagger.Property{"name": {Type: "string"}}},
+		},
+	}
+	resolver := swagger.NewResolver(spec)
+
+	op := swagger.Operation{
+		Parameters: []swagger.Parameter{
+			{Name: "body", In: "body", Schema: &swagger.Schema{Ref: "#/definitions/Pet"}},
+		},
+		Responses: swagger.Responses{
+			"200": {Description: "ok", Schema: &swagger.Schema{
+				Type:       "object",
+				Properties: map[string]swagger.Property{"count": {Type: "integer"}},
+			}},
+		},
+	}
+
+	base := NewFormatter()
+	shared := base.WithModelPages("Pets v2")
+
+	content, err := shared.FormatEndpointPage("/pets", "post", op, resolver)
+	if err != nil {
+		t.Fatalf("FormatEndpointPage() error = %v", err)
+	}
+	if !strings.Contains(content, `<ri:page ri:content-title="Pets v2 - Pet Model" />`) {
+		t.Errorf("expected an excerpt include of the Pet model page:\n%s", content)
+	}
+	if strings.Contains(content, "<td><code>name") {
+		t.Errorf("shared model table repeated on the endpoint page:\n%s", content)
+	}
+	if !strings.Contains(content, "count") {
+		t.Errorf("inline response schema should still be rendered:\n%s", content)
+	}
+
+	// The original formatter is unchanged
+	content, err = base.FormatEndpointPage("/pets", "post", op, resolver)
+	if err != nil {
+		t.Fatalf("FormatEndpointPage() error = %v", err)
+	}
+	if strings.Contains(content, "excerpt-include") {
+		t.Errorf("base formatter should render tables inline:\n%s", content)
+	}
+
+	schema, _ := resolver.ResolveSchema(&swagger.Schema{Ref: "#/definitions/Pet"})
+	model := base.FormatModelPage("Pet", schema, nil)
+	if !strings.Contains(model, `ac:name="excerpt"`) || !strings.Contains(model, "<td><code>name") {
+		t.Errorf("model page should wrap the schema table in an excerpt:\n%s", model)
+	}
+}
+
+func TestFormatter_ModelCrossLinks(t *testing.T) {
+	spec := &swagger.Spec{
+		Definitions: map[string]swagger.Definition{
+			"Pet": {Type: "object", Properties: map[string]swagger.Property{
+				"owner": {Ref: "#/definitions/Owner"},
+				"tags":  {Type: "array", Items: &swagger.Schema{Ref: "#/definitions/Tag"}},
+			}},
+			"Owner": {Type: "object", Properties: map[string]swagger.Property{"name": {Type: "string"}}},
+			"Tag":   {Type: "object", Properties: map[string]swagger.Property{"label": {Type: "string"}}},
+		},
+	}
+	resolver := swagger.NewResolver(spec)
+	shared := NewFormatter().WithModelPages("Pets")
+
+	op := swagger.Operation{
+		Responses: swagger.Responses{"200": {Description: "ok", Schema: &swagger.Schema{Ref: "#/definitions/Pet"}}},
+	}
+	content, err := shared.FormatEndpointPage("/pets/{id}", "get", op, resolver)
+	if err != nil {
+		t.Fatalf("FormatEndpointPage() error = %v", err)
+	}
+	link := `<p>Model: <ac:link><ri:page ri:content-title="Pets - Pet Model" /><ac:plain-text-link-body><![CDATA[Pet]]></ac:plain-text-link-body></ac:link></p>`
+	if !strings.Contains(content, link) {
+		t.Errorf("expected a link to the Pet model page:\n%s", content)
+	}
+
+	schema, _ := resolver.ResolveSchema(&swagger.Schema{Ref: "#/definitions/Pet"})
+	model := shared.FormatModelPage("Pet", schema, []string{"Get Pet"})
+	for _, want := range []string{
+		`<ri:page ri:content-title="Pets - Owner Model" />`,
+		"<h3>Used By</h3>",
+		`<li><ac:link><ri:page ri:content-title="Get Pet" /></ac:link></li>`,
+	} {
+		if !strings.Contains(model, want) {
+			t.Errorf("expected %q in:\n%s", want, model)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// PublishOperationPage creates or updates the page of one operation. With a
+// state file, the page written for the operation under the same parent is
+// recorded by ID, so a later run updates it in place even when its title
+// changed, rather than creating a second page.
+func (c *ConfluenceClient) PublishOperationPage(ctx context.Context, operation, title, content, parentPageID string) (string, error) {
+	if !c.cfg.Enabled || c.operations == nil {
+		return c.CreateOrUpdatePage(ctx, title, content, parentPageID)
+	}
+
+	key := parentPageID + " " + operation
+	if pageID, ok := c.operations[key]; ok {
+		if err := c.adoptPage(ctx, pageID, title); err != nil {
+			return "", fmt.Errorf("failed to find page of %s: %w", operation, err)
+		}
+	}
+
+	pageID, err := c.CreateOrUpdatePage(ctx, title, content, parentPageID)
+	if err != nil {
+		return "", err
+	}
+	if pageID != "" {
+		c.operations[key] = pageID
+	}
+	return pageID, nil
+}
+
+// adoptPage records the page with the ID under a new title, so the next
+// publish of title renames it. The page is fetched again since renames are
+// rare and its recorded version may be stale. Nothing changes when the
+// title is already known or the page no longer exists.
+func (c *ConfluenceClient) adoptPage(ctx context.Context, pageID, title string) error {
+	if _, ok := c.state[title]; ok {
+		return nil
+	}
+
+	page, err := c.getPage(ctx, pageID)
+	if err != nil || page == nil {
+		return err
+	}
+	for oldTitle, recorded := range c.state {
+		if recorded.ID == pageID {
+			delete(c.state, oldTitle)
+			delete(c.pageIndex, oldTitle)
+		}
+	}
+
+	// The old title stays on the page so publishing writes the new one even
+	// when the content is unchanged
+	fmt.Printf("↻ Renaming page: %s -> %s\n", page.Title, title)
+	c.state[title] = page
+	return nil
+}
+
+// getPage fetches a page by ID, including its current storage body. It
+// returns nil when the page doesn't exist.
+func (c *ConfluenceClient) getPage(ctx context.Context, pageID string) (*Page, error) {
+	apiURL := fmt.Sprintf("%s/rest/api/content/%s?expand=%s", c.cfg.BaseURL, pageID, pageExpand)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.authorize(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var page Page
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &page, nil
+}
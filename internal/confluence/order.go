@@ -0,0 +1,83 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+)
+
+// OrderPages positions the pages below parentPageID so that pageIDs appear in
+// the page tree in the given order. Pages already in order are not moved,
+// and other children of the parent keep their place.
+func (c *ConfluenceClient) OrderPages(ctx context.Context, parentPageID string, pageIDs []string) error {
+	if !c.cfg.Enabled || c.cfg.DryRun || parentPageID == "" || len(pageIDs) < 2 {
+		return nil
+	}
+
+	children, err := c.ChildPages(ctx, parentPageID)
+	if err != nil {
+		return err
+	}
+
+	// The current order of the pages being ordered; pages missing from the
+	// listing are appended as if they were last
+	current := make([]string, 0, len(pageIDs))
+	for _, child := range children {
+		if slices.Contains(pageIDs, child.ID) {
+			current = append(current, child.ID)
+		}
+	}
+	for _, pageID := range pageIDs {
+		if !slices.Contains(current, pageID) {
+			current = append(current, pageID)
+		}
+	}
+
+	// Each page must directly follow the one before it
+	moved := 0
+	for i := 1; i < len(pageIDs); i++ {
+		pageID, previous := pageIDs[i], pageIDs[i-1]
+		at := slices.Index(current, previous)
+		if at+1 < len(current) && current[at+1] == pageID {
+			continue
+		}
+		if err := c.movePageAfter(ctx, pageID, previous); err != nil {
+			return err
+		}
+		current = slices.DeleteFunc(current, func(id string) bool { return id == pageID })
+		current = slices.Insert(current, slices.Index(current, previous)+1, pageID)
+		moved++
+	}
+	if moved > 0 {
+		fmt.Printf("✓ Reordered %d pages\n", moved)
+	}
+	return nil
+}
+
+// movePageAfter moves a page directly after targetID, below the same parent
+func (c *ConfluenceClient) movePageAfter(ctx context.Context, pageID, targetID string) error {
+	apiURL := fmt.Sprintf("%s/rest/api/content/%s/move/after/%s", c.cfg.BaseURL, pageID, targetID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.authorize(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to move page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to move page %s after %s: unexpected status %d: %s",
+			pageID, targetID, resp.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+func TestClient_OrderPages(t *testing.T) {
+	tests := []struct {
+		name     string
+		children []string
+		pageIDs  []string
+		want     []string
+	}{
+		{name: "in order", children: []string{"1", "auth", "2", "3"}, pageIDs: []string{"1", "2", "3"}},
+		{name: "last page first", children: []string{"3", "1", "2"}, pageIDs: []string{"1", "2", "3"}, want: []string{"3 after 2"}},
+		{name: "swapped", children: []string{"2", "1", "3"}, pageIDs: []string{"1", "2", "3"}, want: []string{"2 after 1"}},
+		{name: "new page", children: []string{"1", "3"}, pageIDs: []string{"1", "2", "3"}, want: []string{"2 after 1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var moves []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/rest/api/content/100/child/page":
+					var results []string
+					for _, id := range tt.children {
+						results = append(results, fmt.Sprintf(`{"id": %q, "title": "Page %s"}`, id, id))
+					}
+					fmt.Fprintf(w, `{"results": [%s]}`, strings.Join(results, ","))
+				case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/rest/api/content/"):
+					// /rest/api/content/{id}/move/after/{target}
+					parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/rest/api/content/"), "/")
+					moves = append(moves, parts[0]+" "+parts[2]+" "+parts[3])
+					w.Write([]byte(`{}`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+			}))
+			defer server.Close()
+
+			c, err := NewClient(config.ConfluenceConfig{BaseURL: server.URL, SpaceKey: "TEST", Enabled: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := c.(*ConfluenceClient).OrderPages(context.Background(), "100", tt.pageIDs); err != nil {
+				t.Fatalf("OrderPages() error = %v", err)
+			}
+			if !slices.Equal(moves, tt.want) {
+				t.Errorf("moves = %q, want %q", moves, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package confluence
+
+import (
+	"html"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// PagePropertiesID identifies the page properties of endpoint pages, so the
+// report on the API page ignores page properties written by hand
+const PagePropertiesID = "swagfluence-endpoint"
+
+// propertyHeadings are the page property rows of endpoint pages, in order
+var propertyHeadings = []string{"Method", "Path", "Tags", "Deprecated", "Authentication"}
+
+// formatPagePropertiesSection formats the page properties of an endpoint page:
+// a details macro that the Page Properties Report on the API page collects
+// into a filterable catalog
+func (f *Formatter) formatPagePropertiesSection(path, method string, op swagger.Operation) string {
+	values := []string{
+		f.methodBadge(method),
+		"<code>" + html.EscapeString(path) + "</code>",
+		html.EscapeString(strings.Join(op.Tags, ", ")),
+		yesNo(op.Deprecated),
+		html.EscapeString(f.authenticationSummary(op)),
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<ac:structured-macro ac:name=\"details\">\n")
+	sb.WriteString("<ac:parameter ac:name=\"id\">" + PagePropertiesID + "</ac:parameter>\n")
+	sb.WriteString("<ac:rich-text-body>\n<table>\n<tbody>\n")
+	// The report takes the first cell of each row as the key
+	for i, heading := range propertyHeadings {
+		sb.WriteString("<tr><td><strong>" + heading + "</strong></td><td>" + values[i] + "</td></tr>\n")
+	}
+	sb.WriteString("</tbody>\n</table>\n</ac:rich-text-body>\n</ac:structured-macro>\n")
+	return sb.String()
+}
+
+// authenticationSummary names the security schemes an operation accepts,
+// alternatives separated by "or". It is empty when the spec documents no
+// authentication.
+func (f *Formatter) authenticationSummary(op swagger.Operation) string {
+	if f.spec == nil {
+		return ""
+	}
+	requirements := f.spec.SecurityFor(op)
+	if len(requirements) == 0 {
+		if op.Security == nil {
+			return ""
+		}
+		return "None"
+	}
+
+	alternatives := make([]string, 0, len(requirements))
+	for _, requirement := range requirements {
+		if len(requirement) == 0 {
+			alternatives = append(alternatives, "None")
+			continue
+		}
+		alternatives = append(alternatives, strings.Join(requirement.SchemeNames(), " and "))
+	}
+	return strings.Join(alternatives, " or ")
+}
+
+// formatPropertiesReport formats a Page Properties Report macro listing the
+// page properties of every endpoint page below the current page
+func formatPropertiesReport() string {
+	var sb strings.Builder
+	sb.WriteString("<h2>API Catalog</h2>\n")
+	sb.WriteString("<ac:structured-macro ac:name=\"detailssummary\">\n")
+	sb.WriteString("<ac:parameter ac:name=\"id\">" + PagePropertiesID + "</ac:parameter>\n")
+	sb.WriteString("<ac:parameter ac:name=\"headings\">" + strings.Join(propertyHeadings, ",") + "</ac:parameter>\n")
+	sb.WriteString("<ac:parameter ac:name=\"sortBy\">Path</ac:parameter>\n")
+	sb.WriteString("<ac:parameter ac:name=\"cql\">ancestor = currentContent()</ac:parameter>\n")
+	sb.WriteString("</ac:structured-macro>\n")
+	return sb.String()
+}
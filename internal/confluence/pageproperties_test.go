@@ -0,0 +1,79 @@
+package confluence
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func TestFormatter_PageProperties(t *testing.T) {
+	spec := &swagger.Spec{
+		Components: &swagger.Components{SecuritySchemes: map[string]swagger.SecurityScheme{
+			"apiKey": {Type: "apiKey", Name: "X-API-Key", In: "header"},
+			"oauth":  {Type: "oauth2"},
+		}},
+		Security: []swagger.SecurityRequirement{{"apiKey": {}}},
+	}
+
+	tests := []struct {
+		name string
+		op   swagger.Operation
+		want []string
+	}{
+		{
+			name: "default requirement",
+			op:   swagger.Operation{Tags: []string{"pets", "store"}},
+			want: []string{
+				"<td><strong>Path</strong></td><td><code>/pets</code></td>",
+				"<td><strong>Tags</strong></td><td>pets, store</td>",
+				"<td><strong>Deprecated</strong></td><td>No</td>",
+				"<td><strong>Authentication</strong></td><td>apiKey</td>",
+			},
+		},
+		{
+			name: "alternatives",
+			op:   swagger.Operation{Deprecated: true, Security: []swagger.SecurityRequirement{{"oauth": {"read"}}, {"apiKey": {}}}},
+			want: []string{
+				"<td><strong>Deprecated</strong></td><td>Yes</td>",
+				"<td><strong>Authentication</strong></td><td>oauth or apiKey</td>",
+			},
+		},
+		{
+			name: "public",
+			op:   swagger.Operation{Security: []swagger.SecurityRequirement{}},
+			want: []string{"<td><strong>Authentication</strong></td><td>None</td>"},
+		},
+	}
+
+	formatter := NewFormatter().WithSecurity(spec, "")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := formatter.FormatEndpointPage("/pets", "get", tt.op, swagger.NewResolver(spec))
+			if err != nil {
+				t.Fatalf("FormatEndpointPage() error = %v", err)
+			}
+			if !strings.Contains(content, `<ac:parameter ac:name="id">swagfluence-endpoint</ac:parameter>`) {
+				t.Errorf("expected a details macro:\n%s", content)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(content, want) {
+					t.Errorf("expected %q in:\n%s", want, content)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatter_PropertiesReport(t *testing.T) {
+	f := NewFormatter()
+	for name, content := range map[string]string{
+		"parent page":  f.FormatParentPage("Pets"),
+		"version page": f.FormatVersionPage("Pets", "v2", "2.0.0"),
+	} {
+		if !strings.Contains(content, `<ac:structured-macro ac:name="detailssummary">`) ||
+			!strings.Contains(content, `<ac:parameter ac:name="cql">ancestor = currentContent()</ac:parameter>`) {
+			t.Errorf("%s: expected a page properties report:\n%s", name, content)
+		}
+	}
+}
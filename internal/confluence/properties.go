@@ -0,0 +1,110 @@
+package confluence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// contentProperty is a Confluence content property
+type contentProperty struct {
+	Key     string          `json:"key"`
+	Value   json.RawMessage `json:"value"`
+	Version *Version        `json:"version,omitempty"`
+}
+
+// GetPageProperty decodes a content property of a page into value. It
+// returns the property version, or 0 when the property does not exist.
+func (c *ConfluenceClient) GetPageProperty(ctx context.Context, pageID, key string, value interface{}) (int, error) {
+	if !c.cfg.Enabled || pageID == "" {
+		return 0, nil
+	}
+
+	apiURL := fmt.Sprintf("%s/rest/api/content/%s/property/%s", c.cfg.BaseURL, pageID, url.PathEscape(key))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.authorize(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get property %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return 0, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var property contentProperty
+	if err := json.NewDecoder(resp.Body).Decode(&property); err != nil {
+		return 0, fmt.Errorf("failed to decode property %s: %w", key, err)
+	}
+	if err := json.Unmarshal(property.Value, value); err != nil {
+		return 0, fmt.Errorf("failed to decode property %s value: %w", key, err)
+	}
+
+	if property.Version == nil {
+		return 1, nil
+	}
+	return property.Version.Number, nil
+}
+
+// SetPageProperty creates a content property (version 0) or replaces the
+// given version of an existing one
+func (c *ConfluenceClient) SetPageProperty(ctx context.Context, pageID, key string, value interface{}, version int) error {
+	if !c.cfg.Enabled || c.cfg.DryRun || pageID == "" {
+		return nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal property %s: %w", key, err)
+	}
+
+	property := contentProperty{Key: key, Value: raw}
+	method := http.MethodPost
+	apiURL := fmt.Sprintf("%s/rest/api/content/%s/property", c.cfg.BaseURL, pageID)
+	if version > 0 {
+		method = http.MethodPut
+		apiURL += "/" + url.PathEscape(key)
+		property.Version = &Version{Number: version + 1}
+	}
+
+	body, err := json.Marshal(property)
+	if err != nil {
+		return fmt.Errorf("failed to marshal property %s: %w", key, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.authorize(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to set property %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to set property %s: unexpected status %d: %s", key, resp.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
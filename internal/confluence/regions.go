@@ -0,0 +1,133 @@
+package confluence
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// layoutTagPattern matches the page layout wrappers emitted by the Formatter
+var layoutTagPattern = regexp.MustCompile(`</?ac:layout(-section|-cell)?[^>]*>\n?`)
+
+// Marker names delimiting the hand-written notes section, the generated
+// region and the version links of a page.
+// Confluence drops HTML comments from storage format, so markers are
+// emitted as invisible anchor macros; comment markers are still honored
+// for pages edited through the API.
+const (
+	ManualStartMarker    = "swagfluence-manual-start"
+	ManualEndMarker      = "swagfluence-manual-end"
+	GeneratedStartMarker = "swagfluence-generated-start"
+	GeneratedEndMarker   = "swagfluence-generated-end"
+	VersionsStartMarker  = "swagfluence-versions-start"
+	VersionsEndMarker    = "swagfluence-versions-end"
+)
+
+// Update modes controlling how much of an existing page SwagFluence owns
+const (
+	// UpdateModeFull replaces the whole page body
+	UpdateModeFull = "full"
+	// UpdateModeRegion replaces only the delimited generated region
+	UpdateModeRegion = "region"
+)
+
+// anchorMacro renders an invisible anchor macro used as a region marker
+func anchorMacro(name string) string {
+	return fmt.Sprintf("<ac:structured-macro ac:name=\"anchor\">"+
+		"<ac:parameter ac:name=\"\">%s</ac:parameter>"+
+		"</ac:structured-macro>", name)
+}
+
+// commentMarker renders the comment form of a region marker
+func commentMarker(name string) string {
+	return fmt.Sprintf("<!-- %s -->", strings.ReplaceAll(name, "-", ":"))
+}
+
+// markerPatterns match the anchor and comment forms of each marker.
+// Confluence rewrites stored macros, adding attributes such as
+// ac:schema-version and ac:macro-id, so anchors are matched by their
+// parameter value rather than by the exact markup written.
+var markerPatterns = func() map[string]*regexp.Regexp {
+	patterns := make(map[string]*regexp.Regexp)
+	for _, name := range []string{ManualStartMarker, ManualEndMarker, GeneratedStartMarker, GeneratedEndMarker, VersionsStartMarker, VersionsEndMarker} {
+		patterns[name] = regexp.MustCompile(
+			`<ac:structured-macro\b[^>]*\bac:name="anchor"[^>]*>\s*` +
+				`<ac:parameter\b[^>]*\bac:name=""[^>]*>\s*` + regexp.QuoteMeta(name) + `\s*</ac:parameter>\s*` +
+				`</ac:structured-macro>` +
+				`|<!--\s*` + regexp.QuoteMeta(strings.ReplaceAll(name, "-", ":")) + `\s*-->`)
+	}
+	return patterns
+}()
+
+// findMarker returns the byte offsets of the first marker named name in
+// content, in either form
+func findMarker(content, name string) ([]int, bool) {
+	loc := markerPatterns[name].FindStringIndex(content)
+	return loc, loc != nil
+}
+
+// IsEndpointPage reports whether page content is a generated endpoint page,
+// judging by the notes markers only endpoint pages carry. Other generated
+// pages, such as the models or authentication pages, are not matched.
+func IsEndpointPage(content string) bool {
+	_, ok := findMarker(content, ManualStartMarker)
+	return ok
+}
+
+// findRegion returns the byte offsets of the content between the start and
+// end markers
+func findRegion(content, startName, endName string) (int, int, bool) {
+	startLoc, ok := findMarker(content, startName)
+	if !ok {
+		return 0, 0, false
+	}
+	start := startLoc[1]
+
+	endLoc, ok := findMarker(content[start:], endName)
+	if !ok {
+		return 0, 0, false
+	}
+	return start, start + endLoc[0], true
+}
+
+// PreserveManualSection copies the notes section of the existing page body
+// into the regenerated content so hand-written notes survive re-syncs
+func PreserveManualSection(existing, generated string) string {
+	oldStart, oldEnd, ok := findRegion(existing, ManualStartMarker, ManualEndMarker)
+	if !ok {
+		return generated
+	}
+
+	newStart, newEnd, ok := findRegion(generated, ManualStartMarker, ManualEndMarker)
+	if !ok {
+		return generated
+	}
+
+	return generated[:newStart] + existing[oldStart:oldEnd] + generated[newEnd:]
+}
+
+// ReplaceGeneratedRegion swaps the generated region of a human-owned page
+// for freshly generated content, leaving everything outside the markers
+// untouched. Pages without markers get the region appended at the end; a
+// page whose end marker was deleted has everything after its start marker
+// replaced, so the region is never appended twice. Layout wrappers are
+// dropped because Confluence layouts cannot nest.
+func ReplaceGeneratedRegion(existing, generated string) string {
+	generated = layoutTagPattern.ReplaceAllString(generated, "")
+
+	start, end, ok := findRegion(existing, GeneratedStartMarker, GeneratedEndMarker)
+	if !ok {
+		startLoc, marked := findMarker(existing, GeneratedStartMarker)
+		if !marked {
+			return existing + wrapGenerated(generated)
+		}
+		return existing[:startLoc[1]] + "\n" + generated + anchorMacro(GeneratedEndMarker) + "\n"
+	}
+
+	return existing[:start] + "\n" + generated + existing[end:]
+}
+
+// wrapGenerated surrounds content with the generated region markers
+func wrapGenerated(content string) string {
+	return anchorMacro(GeneratedStartMarker) + "\n" + content + anchorMacro(GeneratedEndMarker) + "\n"
+}
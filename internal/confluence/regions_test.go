@@ -0,0 +1,113 @@
+package confluence
+
+import (
+	"strings"
+	"testing"
+)
+
+// storedAnchor renders a marker the way Confluence returns it after
+// storing a page, with the attributes it adds to every macro
+func storedAnchor(name, macroID string) string {
+	return `<ac:structured-macro ac:name="anchor" ac:schema-version="1" ac:macro-id="` + macroID + `">` +
+		`<ac:parameter ac:name="">` + name + `</ac:parameter></ac:structured-macro>`
+}
+
+func TestPreserveManualSection(t *testing.T) {
+	generated := "<h3>Notes</h3>" + anchorMacro(ManualStartMarker) + "<p>placeholder</p>" +
+		anchorMacro(ManualEndMarker) + "<p>footer v2</p>"
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{
+			name: "anchor markers",
+			existing: "<p>old</p>" + anchorMacro(ManualStartMarker) + "<p>keep me</p>" +
+				anchorMacro(ManualEndMarker),
+			want: "<p>keep me</p>",
+		},
+		{
+			name: "markers normalized by Confluence",
+			existing: "<p>old</p>" + storedAnchor(ManualStartMarker, "1f3a") + "<p>hand written</p>" +
+				storedAnchor(ManualEndMarker, "9c2e"),
+			want: "<p>hand written</p>",
+		},
+		{
+			name:     "comment markers",
+			existing: "<!-- swagfluence:manual:start --><p>from comments</p><!-- swagfluence:manual:end -->",
+			want:     "<p>from comments</p>",
+		},
+		{
+			name:     "no markers on existing page",
+			existing: "<p>legacy page</p>",
+			want:     "<p>placeholder</p>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PreserveManualSection(tt.existing, generated)
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("expected %q in result, got %q", tt.want, got)
+			}
+			if !strings.HasSuffix(got, "<p>footer v2</p>") {
+				t.Errorf("expected regenerated content outside the notes, got %q", got)
+			}
+		})
+	}
+}
+
+func TestReplaceGeneratedRegion(t *testing.T) {
+	generated := "<ac:layout>\n<ac:layout-section ac:type=\"single\">\n<ac:layout-cell>\n" +
+		"<h2>GET /users</h2>\n</ac:layout-cell>\n</ac:layout-section>\n</ac:layout>\n"
+
+	t.Run("replaces existing region", func(t *testing.T) {
+		existing := "<p>Team intro</p>" + wrapGenerated("<h2>old</h2>\n") + "<p>Team outro</p>"
+
+		got := ReplaceGeneratedRegion(existing, generated)
+
+		if !strings.HasPrefix(got, "<p>Team intro</p>") || !strings.HasSuffix(got, "<p>Team outro</p>") {
+			t.Errorf("expected human content to be kept, got %q", got)
+		}
+		if strings.Contains(got, "<h2>old</h2>") || !strings.Contains(got, "<h2>GET /users</h2>") {
+			t.Errorf("expected generated region to be replaced, got %q", got)
+		}
+		if strings.Contains(got, "ac:layout") {
+			t.Errorf("expected layout wrappers to be stripped, got %q", got)
+		}
+	})
+
+	t.Run("replaces region with normalized markers", func(t *testing.T) {
+		existing := "<p>Team intro</p>" + storedAnchor(GeneratedStartMarker, "a1") + "\n<h2>old</h2>\n" +
+			storedAnchor(GeneratedEndMarker, "b2") + "\n<p>Team outro</p>"
+
+		got := ReplaceGeneratedRegion(existing, generated)
+		got = ReplaceGeneratedRegion(got, generated)
+
+		if strings.Contains(got, "<h2>old</h2>") || strings.Count(got, "<h2>GET /users</h2>") != 1 {
+			t.Errorf("expected one copy of the generated region, got %q", got)
+		}
+		if !strings.HasPrefix(got, "<p>Team intro</p>") || !strings.HasSuffix(got, "<p>Team outro</p>") {
+			t.Errorf("expected human content to be kept, got %q", got)
+		}
+	})
+
+	t.Run("missing end marker", func(t *testing.T) {
+		existing := "<p>Team intro</p>" + storedAnchor(GeneratedStartMarker, "a1") + "\n<h2>old</h2>\n"
+
+		got := ReplaceGeneratedRegion(existing, generated)
+
+		if strings.Contains(got, "<h2>old</h2>") || strings.Count(got, GeneratedStartMarker) != 1 {
+			t.Errorf("expected the region after the start marker to be replaced, got %q", got)
+		}
+	})
+
+	t.Run("appends region to unmarked page", func(t *testing.T) {
+		got := ReplaceGeneratedRegion("<p>Team intro</p>", generated)
+
+		if !strings.HasPrefix(got, "<p>Team intro</p>"+anchorMacro(GeneratedStartMarker)) {
+			t.Errorf("expected region appended after human content, got %q", got)
+		}
+	})
+}
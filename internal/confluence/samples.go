@@ -0,0 +1,64 @@
+package confluence
+
+import (
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/snippet"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// SamplesNone in the samples setting leaves code samples out
+const SamplesNone = "none"
+
+// WithSamples returns a copy of the formatter whose endpoint pages carry
+// code samples from the given generators, in order. Custom generators can
+// be mixed with the built-in ones from snippet.Lookup.
+func (f *Formatter) WithSamples(generators ...snippet.Generator) *Formatter {
+	sampled := *f
+	sampled.samples = generators
+	return &sampled
+}
+
+// formatSamplesSection formats code calling the endpoint for each
+// configured generator, or returns "" when there are none. The first sample
+// is shown; further languages are collapsed in expand macros to keep the
+// page short.
+func (f *Formatter) formatSamplesSection(path, method string, op swagger.Operation, resolver *swagger.Resolver) string {
+	if len(f.samples) == 0 {
+		return ""
+	}
+
+	spec := f.spec
+	if spec == nil {
+		spec = &swagger.Spec{}
+	}
+	req := snippet.BuildRequest(spec, path, method, op, resolver, f.exampleGen)
+
+	var sb strings.Builder
+	sb.WriteString("<h3>Code Samples</h3>\n")
+	for i, generator := range f.samples {
+		if i > 0 {
+			sb.WriteString("<ac:structured-macro ac:name=\"expand\">\n")
+			sb.WriteString("<ac:parameter ac:name=\"title\">")
+			writeText(&sb, generator.Title)
+			sb.WriteString("</ac:parameter>\n")
+			sb.WriteString("<ac:rich-text-body>\n")
+		}
+		sb.WriteString("<ac:structured-macro ac:name=\"code\">\n")
+		sb.WriteString("<ac:parameter ac:name=\"language\">")
+		writeText(&sb, generator.Syntax)
+		sb.WriteString("</ac:parameter>\n")
+		sb.WriteString("<ac:parameter ac:name=\"title\">")
+		writeText(&sb, generator.Title)
+		sb.WriteString("</ac:parameter>\n")
+		sb.WriteString("<ac:plain-text-body>")
+		sb.WriteString(cdata(generator.Render(req)))
+		sb.WriteString("</ac:plain-text-body>\n")
+		sb.WriteString("</ac:structured-macro>\n")
+		if i > 0 {
+			sb.WriteString("</ac:rich-text-body>\n")
+			sb.WriteString("</ac:structured-macro>\n")
+		}
+	}
+	return sb.String()
+}
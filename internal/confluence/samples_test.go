@@ -0,0 +1,93 @@
+package confluence
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/snippet"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func TestFormatter_Samples(t *testing.T) {
+	spec := &swagger.Spec{Servers: []swagger.Server{{URL: "https://pets.example.com/v1"}}}
+	op := swagger.Operation{
+		Parameters: []swagger.Parameter{{Name: "petId", In: "path", Required: true, Type: "integer"}},
+	}
+
+	tests := []struct {
+		name    string
+		samples []string
+		want    []string
+		notWant []string
+		wantErr bool
+	}{
+		{
+			name:    "curl",
+			samples: []string{"curl"},
+			want:    []string{"<h3>Code Samples</h3>", "<ac:parameter ac:name=\"title\">curl</ac:parameter>", "curl -X GET 'https://pets.example.com/v1/pets/1'"},
+			notWant: []string{"HTTPie"},
+		},
+		{
+			name:    "curl and httpie",
+			samples: []string{"curl", "httpie"},
+			want:    []string{"curl -X GET", "<ac:parameter ac:name=\"title\">HTTPie</ac:parameter>", "http GET 'https://pets.example.com/v1/pets/1'"},
+		},
+		{
+			name:    "client languages are collapsed",
+			samples: []string{"python", "go"},
+			want: []string{
+				"<ac:parameter ac:name=\"language\">python</ac:parameter>",
+				"<ac:structured-macro ac:name=\"expand\">\n<ac:parameter ac:name=\"title\">Go</ac:parameter>",
+				`http.NewRequest("GET", "https://pets.example.com/v1/pets/1", nil)`,
+			},
+		},
+		{name: "none", samples: []string{"none"}, notWant: []string{"Code Samples"}},
+		{name: "unknown language", samples: []string{"cobol"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFormatterWithConfig(config.TemplateConfig{Samples: tt.samples})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewFormatterWithConfig() error = %v", err)
+			}
+
+			content, err := f.WithSecurity(spec, "").FormatEndpointPage("/pets/{petId}", "get", op, swagger.NewResolver(spec))
+			if err != nil {
+				t.Fatalf("FormatEndpointPage() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(content, want) {
+					t.Errorf("expected %q in:\n%s", want, content)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(content, notWant) {
+					t.Errorf("unexpected %q in:\n%s", notWant, content)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatter_WithSamples(t *testing.T) {
+	custom := snippet.Generator{
+		Language: "wget",
+		Title:    "wget",
+		Syntax:   "bash",
+		Render:   func(req snippet.Request) string { return "wget " + req.URL },
+	}
+
+	content := NewFormatter().WithSamples(custom).formatSamplesSection("/health", "get", swagger.Operation{}, swagger.NewResolver(&swagger.Spec{}))
+
+	if !strings.Contains(content, "wget https://api.example.com/health") {
+		t.Errorf("expected custom sample in:\n%s", content)
+	}
+}
@@ -0,0 +1,216 @@
+package confluence
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// AuthenticationPageTitle returns the title of the page describing the
+// security schemes
+func AuthenticationPageTitle(scope string) string {
+	return fmt.Sprintf("%s - Authentication", scope)
+}
+
+// WithSecurity returns a copy of the formatter that adds an Authentication
+// section to endpoint pages, based on the security schemes and default
+// requirement of spec. A non-empty pageTitle links the section to the
+// Authentication page.
+func (f *Formatter) WithSecurity(spec *swagger.Spec, pageTitle string) *Formatter {
+	secured := *f
+	secured.spec = spec
+	secured.authPageTitle = pageTitle
+	return &secured
+}
+
+// formatAuthenticationSection formats the requirements of one operation
+func (f *Formatter) formatAuthenticationSection(op swagger.Operation) string {
+	if f.spec == nil {
+		return ""
+	}
+
+	requirements := f.spec.SecurityFor(op)
+	if len(requirements) == 0 && op.Security == nil {
+		// The spec documents no authentication at all
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<h3>Authentication</h3>\n")
+	if len(requirements) == 0 {
+		sb.WriteString("<p>No authentication required.</p>\n")
+		return sb.String()
+	}
+
+	f.writeRequirements(&sb, requirements, f.spec.SecuritySchemes())
+	if f.authPageTitle != "" {
+		sb.WriteString("<p>See ")
+		sb.WriteString(pageLink(f.authPageTitle))
+		sb.WriteString(" for how to obtain credentials.</p>\n")
+	}
+	return sb.String()
+}
+
+// writeRequirements writes alternative security requirements as a list
+func (f *Formatter) writeRequirements(sb *strings.Builder, requirements []swagger.SecurityRequirement, schemes map[string]swagger.SecurityScheme) {
+	if len(requirements) == 1 {
+		sb.WriteString("<p>Requires:</p>\n")
+	} else {
+		sb.WriteString("<p>Requires one of:</p>\n")
+	}
+
+	sb.WriteString("<ul>\n")
+	for _, requirement := range requirements {
+		sb.WriteString("<li>")
+		if len(requirement) == 0 {
+			sb.WriteString("No authentication")
+		}
+		for i, name := range requirement.SchemeNames() {
+			if i > 0 {
+				sb.WriteString(" <em>and</em> ")
+			}
+			sb.WriteString("<strong>")
+			sb.WriteString(html.EscapeString(name))
+			sb.WriteString("</strong> (")
+			sb.WriteString(describeScheme(schemes[name]))
+			sb.WriteString(")")
+			if scopes := requirement[name]; len(scopes) > 0 {
+				sb.WriteString(" with scopes ")
+				for j, scope := range scopes {
+					if j > 0 {
+						sb.WriteString(", ")
+					}
+					sb.WriteString("<code>")
+					sb.WriteString(html.EscapeString(scope))
+					sb.WriteString("</code>")
+				}
+			}
+		}
+		sb.WriteString("</li>\n")
+	}
+	sb.WriteString("</ul>\n")
+}
+
+// FormatAuthenticationPage generates the page describing every security
+// scheme of the spec: API keys, HTTP authentication and OAuth2 flows with
+// their scopes
+func (f *Formatter) FormatAuthenticationPage(spec *swagger.Spec) string {
+	schemes := spec.SecuritySchemes()
+
+	var sb strings.Builder
+	sb.WriteString("<h1>Authentication</h1>\n")
+	if len(spec.Security) > 0 {
+		sb.WriteString("<h2>Default Requirement</h2>\n")
+		f.writeRequirements(&sb, spec.Security, schemes)
+		sb.WriteString("<p><em>Endpoints may override the default; each endpoint page lists its own requirement.</em></p>\n")
+	}
+
+	for _, name := range spec.SecuritySchemeNames() {
+		scheme := schemes[name]
+
+		sb.WriteString(fmt.Sprintf("<h2>%s</h2>\n", html.EscapeString(name)))
+		if scheme.Description != "" {
+			sb.WriteString(MarkdownToStorage(scheme.Description))
+		}
+
+		sb.WriteString("<table>\n")
+		writeDetailRow(&sb, "Type", describeScheme(scheme))
+		switch scheme.Type {
+		case "apiKey":
+			writeDetailRow(&sb, "Parameter", "<code>"+html.EscapeString(scheme.Name)+"</code>")
+			writeDetailRow(&sb, "Location", html.EscapeString(scheme.In))
+		case "http":
+			writeDetailRow(&sb, "Scheme", "<code>"+html.EscapeString(scheme.Scheme)+"</code>")
+			if scheme.BearerFormat != "" {
+				writeDetailRow(&sb, "Bearer format", html.EscapeString(scheme.BearerFormat))
+			}
+		case "openIdConnect":
+			writeDetailRow(&sb, "Discovery URL", urlCell(scheme.OpenIDConnectURL))
+		}
+		sb.WriteString("</table>\n")
+
+		for _, flow := range scheme.OAuthFlows() {
+			writeOAuthFlow(&sb, flow)
+		}
+	}
+
+	return sb.String()
+}
+
+// writeOAuthFlow writes the endpoints and scopes of one OAuth2 flow
+func writeOAuthFlow(sb *strings.Builder, flow swagger.NamedOAuthFlow) {
+	sb.WriteString(fmt.Sprintf("<h3>%s flow</h3>\n", html.EscapeString(flow.Name)))
+
+	sb.WriteString("<table>\n")
+	if flow.AuthorizationURL != "" {
+		writeDetailRow(sb, "Authorization URL", urlCell(flow.AuthorizationURL))
+	}
+	if flow.TokenURL != "" {
+		writeDetailRow(sb, "Token URL", urlCell(flow.TokenURL))
+	}
+	if flow.RefreshURL != "" {
+		writeDetailRow(sb, "Refresh URL", urlCell(flow.RefreshURL))
+	}
+	sb.WriteString("</table>\n")
+
+	if len(flow.Scopes) == 0 {
+		return
+	}
+	sb.WriteString("<table>\n")
+	sb.WriteString("<tr><th>Scope</th><th>Description</th></tr>\n")
+	for _, scope := range swagger.SortedKeys(flow.Scopes) {
+		sb.WriteString(fmt.Sprintf("<tr><td><code>%s</code></td><td>%s</td></tr>\n",
+			html.EscapeString(scope), markdownCell(flow.Scopes[scope])))
+	}
+	sb.WriteString("</table>\n")
+}
+
+// describeScheme summarizes a security scheme in a few words
+func describeScheme(scheme swagger.SecurityScheme) string {
+	switch scheme.Type {
+	case "apiKey":
+		return fmt.Sprintf("API key in %s <code>%s</code>", html.EscapeString(scheme.In), html.EscapeString(scheme.Name))
+	case "basic":
+		return "HTTP Basic"
+	case "http":
+		switch strings.ToLower(scheme.Scheme) {
+		case "basic":
+			return "HTTP Basic"
+		case "bearer":
+			if scheme.BearerFormat != "" {
+				return fmt.Sprintf("Bearer token (%s)", html.EscapeString(scheme.BearerFormat))
+			}
+			return "Bearer token"
+		}
+		return fmt.Sprintf("HTTP %s", html.EscapeString(scheme.Scheme))
+	case "oauth2":
+		return "OAuth 2.0"
+	case "openIdConnect":
+		return "OpenID Connect"
+	case "":
+		return "undefined scheme"
+	}
+	return html.EscapeString(scheme.Type)
+}
+
+// writeDetailRow writes a two-column header/value table row
+func writeDetailRow(sb *strings.Builder, name, value string) {
+	sb.WriteString("<tr><th>")
+	sb.WriteString(name)
+	sb.WriteString("</th><td>")
+	sb.WriteString(value)
+	sb.WriteString("</td></tr>\n")
+}
+
+// urlCell formats a URL as a link
+func urlCell(url string) string {
+	escaped := html.EscapeString(url)
+	return fmt.Sprintf("<a href=\"%s\">%s</a>", escaped, escaped)
+}
+
+// pageLink formats a link to another page in the space
+func pageLink(title string) string {
+	return fmt.Sprintf("<ac:link><ri:page ri:content-title=\"%s\" /></ac:link>", html.EscapeString(title))
+}
@@ -0,0 +1,104 @@
+package confluence
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func TestFormatter_AuthenticationSection(t *testing.T) {
+	spec := &swagger.Spec{
+		Components: &swagger.Components{
+			SecuritySchemes: map[string]swagger.SecurityScheme{
+				"bearer": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+				"oauth": {Type: "oauth2", Flows: &swagger.OAuthFlows{
+					ClientCredentials: &swagger.OAuthFlow{
+						TokenURL: "https://auth.example.com/token",
+						Scopes:   map[string]string{"orders:write": "Create orders"},
+					},
+				}},
+			},
+		},
+		Security: []swagger.SecurityRequirement{{"bearer": {}}},
+	}
+
+	tests := []struct {
+		name      string
+		formatter *Formatter
+		op        swagger.Operation
+		want      []string
+		wantEmpty bool
+	}{
+		{
+			name:      "default requirement",
+			formatter: NewFormatter().WithSecurity(spec, "Orders - Authentication"),
+			want:      []string{"<h3>Authentication</h3>", "<strong>bearer</strong> (Bearer token (JWT))", `ri:content-title="Orders - Authentication"`},
+		},
+		{
+			name:      "operation override with scopes",
+			formatter: NewFormatter().WithSecurity(spec, ""),
+			op:        swagger.Operation{Security: []swagger.SecurityRequirement{{"oauth": {"orders:write"}}, {"bearer": {}}}},
+			want:      []string{"Requires one of:", "(OAuth 2.0) with scopes <code>orders:write</code>"},
+		},
+		{
+			name:      "explicitly public",
+			formatter: NewFormatter().WithSecurity(spec, ""),
+			op:        swagger.Operation{Security: []swagger.SecurityRequirement{}},
+			want:      []string{"No authentication required."},
+		},
+		{
+			name:      "without security",
+			formatter: NewFormatter(),
+			wantEmpty: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := tt.formatter.formatAuthenticationSection(tt.op)
+			if tt.wantEmpty && content != "" {
+				t.Errorf("expected no section, got:\n%s", content)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(content, want) {
+					t.Errorf("expected %q in:\n%s", want, content)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatter_FormatAuthenticationPage(t *testing.T) {
+	spec := &swagger.Spec{
+		SecurityDefinitions: map[string]swagger.SecurityScheme{
+			"api_key": {Type: "apiKey", Name: "X-API-Key", In: "header", Description: "Issued in the portal"},
+			"petstore_auth": {
+				Type:             "oauth2",
+				Flow:             "implicit",
+				AuthorizationURL: "https://auth.example.com/authorize",
+				Scopes:           map[string]string{"write:pets": "Modify pets", "read:pets": "Read pets"},
+			},
+		},
+	}
+
+	content := NewFormatter().FormatAuthenticationPage(spec)
+
+	for _, want := range []string{
+		"<h2>api_key</h2>",
+		"<p>Issued in the portal</p>",
+		"API key in header <code>X-API-Key</code>",
+		"<h3>implicit flow</h3>",
+		`<a href="https://auth.example.com/authorize">`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in:\n%s", want, content)
+		}
+	}
+	if strings.Index(content, "read:pets") > strings.Index(content, "write:pets") {
+		t.Error("expected scopes in sorted order")
+	}
+	if strings.Contains(content, "Default Requirement") {
+		t.Error("expected no default requirement without spec-wide security")
+	}
+}
@@ -0,0 +1,102 @@
+package confluence
+
+import (
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// formatOperationURLs formats the full URL of a path on each server it is
+// served from, labeled with the server description when there are several
+func formatOperationURLs(servers []swagger.Server, path string) string {
+	switch len(servers) {
+	case 0:
+		return ""
+	case 1:
+		var sb strings.Builder
+		sb.WriteString("<p><strong>URL:</strong> ")
+		writeCode(&sb, servers[0].ResolvedURL()+path)
+		sb.WriteString("</p>\n")
+		return sb.String()
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<p><strong>URLs:</strong></p>\n<ul>\n")
+	for _, server := range servers {
+		sb.WriteString("<li>")
+		writeCode(&sb, server.ResolvedURL()+path)
+		if server.Description != "" {
+			sb.WriteString(" - ")
+			writeText(&sb, server.Description)
+		}
+		sb.WriteString("</li>\n")
+	}
+	sb.WriteString("</ul>\n")
+	return sb.String()
+}
+
+// FormatServers generates the Servers section of the API page: a table of
+// the environments the API is served from, with their variables. It
+// returns "" when the spec documents no servers.
+func (f *Formatter) FormatServers(spec *swagger.Spec) string {
+	if len(spec.Servers) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<h2>Servers</h2>\n")
+	sb.WriteString("<table>\n<tbody>\n")
+	sb.WriteString("<tr><th>URL</th><th>Description</th><th>Variables</th></tr>\n")
+	for _, server := range spec.Servers {
+		sb.WriteString("<tr><td>")
+		writeCode(&sb, server.ResolvedURL())
+		// Show the template too when variables were substituted
+		if len(server.Variables) > 0 {
+			sb.WriteString("<br/>")
+			writeCode(&sb, server.URL)
+		}
+		sb.WriteString("</td><td>")
+		writeText(&sb, server.Description)
+		sb.WriteString("</td><td>")
+		sb.WriteString(formatServerVariables(server))
+		sb.WriteString("</td></tr>\n")
+	}
+	sb.WriteString("</tbody>\n</table>\n")
+	return sb.String()
+}
+
+// formatServerVariables lists the variables of a server URL with their
+// defaults and allowed values
+func formatServerVariables(server swagger.Server) string {
+	names := server.VariableNames()
+	if len(names) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<ul>\n")
+	for _, name := range names {
+		variable := server.Variables[name]
+		sb.WriteString("<li>")
+		writeCode(&sb, name)
+		sb.WriteString(" (default ")
+		writeCode(&sb, variable.Default)
+		sb.WriteString(")")
+		if len(variable.Enum) > 0 {
+			sb.WriteString(": one of ")
+			for i, value := range variable.Enum {
+				if i > 0 {
+					sb.WriteString(", ")
+				}
+				writeCode(&sb, value)
+			}
+		}
+		if variable.Description != "" {
+			sb.WriteString(" - ")
+			writeText(&sb, variable.Description)
+		}
+		sb.WriteString("</li>\n")
+	}
+	sb.WriteString("</ul>\n")
+	return sb.String()
+}
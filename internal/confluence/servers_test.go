@@ -0,0 +1,62 @@
+package confluence
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func TestFormatter_FormatServers(t *testing.T) {
+	spec := &swagger.Spec{Servers: []swagger.Server{
+		{URL: "https://api.example.com/", Description: "Production"},
+		{
+			URL:         "https://{region}.sandbox.example.com",
+			Description: "Sandbox",
+			Variables: map[string]swagger.ServerVariable{
+				"region": {Default: "eu", Enum: []string{"eu", "us"}, Description: "Data residency"},
+			},
+		},
+	}}
+
+	content := NewFormatter().FormatServers(spec)
+	for _, want := range []string{
+		"<h2>Servers</h2>",
+		"<td><code>https://api.example.com</code></td><td>Production</td>",
+		"<code>https://eu.sandbox.example.com</code><br/><code>https://{region}.sandbox.example.com</code>",
+		"<li><code>region</code> (default <code>eu</code>): one of <code>eu</code>, <code>us</code> - Data residency</li>",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in:\n%s", want, content)
+		}
+	}
+
+	if content := NewFormatter().FormatServers(&swagger.Spec{Host: "petstore.io"}); content != "" {
+		t.Errorf("expected no servers section for Swagger 2.0, got:\n%s", content)
+	}
+}
+
+func TestFormatter_HeaderServerURLs(t *testing.T) {
+	spec := &swagger.Spec{
+		Servers: []swagger.Server{{URL: "https://api.example.com", Description: "Production"}, {URL: "https://sandbox.example.com", Description: "Sandbox"}},
+		Paths:   map[string]swagger.PathItem{"/pets": {"get": {}, "delete": {}}},
+	}
+	f := NewFormatter().WithSecurity(spec, "")
+
+	content := f.formatHeaderSection("/pets", "get", swagger.Operation{})
+	for _, want := range []string{
+		"<p><strong>URLs:</strong></p>",
+		"<li><code>https://api.example.com/pets</code> - Production</li>",
+		"<li><code>https://sandbox.example.com/pets</code> - Sandbox</li>",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in:\n%s", want, content)
+		}
+	}
+
+	op := swagger.Operation{Servers: []swagger.Server{{URL: "https://admin.example.com"}}}
+	if content := f.formatHeaderSection("/pets", "delete", op); !strings.Contains(content,
+		"<p><strong>URL:</strong> <code>https://admin.example.com/pets</code></p>") {
+		t.Errorf("expected the operation server in:\n%s", content)
+	}
+}
@@ -0,0 +1,114 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// SpecEmbedCode embeds the attached spec on the API page in a collapsed
+// code macro. Any other embed value names a viewer macro, such as one from
+// an OpenAPI viewer app, that is given the spec as its plain-text body.
+const SpecEmbedCode = "code"
+
+// specContentTypes maps document formats to attachment content types
+var specContentTypes = map[string]string{
+	"json": "application/json",
+	"yaml": "application/yaml",
+	"apib": "text/plain",
+}
+
+// SpecAttachmentName returns the file name the source spec is attached as:
+// the source's own file name when it has the extension of a spec, otherwise
+// openapi with the extension of the format
+func SpecAttachmentName(source, format string) string {
+	name := source
+	if u, err := url.Parse(source); err == nil && u.Host != "" {
+		name = u.Path
+	}
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+
+	switch strings.ToLower(path.Ext(name)) {
+	case ".json", ".yaml", ".yml", ".apib":
+		return name
+	}
+	if format == "" {
+		format = "json"
+	}
+	return "openapi." + format
+}
+
+// SetSpecDocument records the source document of the spec being published.
+// With AttachSpec, the API page links to it and it is attached to the page.
+func (c *ConfluenceClient) SetSpecDocument(name, format string, data []byte) {
+	c.specName, c.specFormat, c.specDocument = name, format, data
+}
+
+// specSection formats the part of the API page offering the source spec,
+// or "" when it is not attached
+func (c *ConfluenceClient) specSection() string {
+	if !c.cfg.AttachSpec || len(c.specDocument) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n<h2>Specification</h2>\n")
+	sb.WriteString(fmt.Sprintf("<p>Download the source of these pages: <ac:link><ri:attachment ri:filename=\"%s\" /></ac:link></p>\n",
+		html.EscapeString(c.specName)))
+
+	switch c.cfg.SpecEmbed {
+	case "":
+	case SpecEmbedCode:
+		language := "yaml"
+		if c.specFormat == "json" {
+			language = "json"
+		}
+		sb.WriteString("<ac:structured-macro ac:name=\"code\">\n")
+		sb.WriteString(fmt.Sprintf("<ac:parameter ac:name=\"language\">%s</ac:parameter>\n", language))
+		sb.WriteString(fmt.Sprintf("<ac:parameter ac:name=\"title\">%s</ac:parameter>\n", html.EscapeString(c.specName)))
+		sb.WriteString("<ac:parameter ac:name=\"collapse\">true</ac:parameter>\n")
+		sb.WriteString(fmt.Sprintf("<ac:plain-text-body>%s</ac:plain-text-body>\n", cdata(string(c.specDocument))))
+		sb.WriteString("</ac:structured-macro>")
+	default:
+		sb.WriteString(fmt.Sprintf("<ac:structured-macro ac:name=\"%s\">\n", html.EscapeString(c.cfg.SpecEmbed)))
+		sb.WriteString(fmt.Sprintf("<ac:plain-text-body>%s</ac:plain-text-body>\n", cdata(string(c.specDocument))))
+		sb.WriteString("</ac:structured-macro>")
+	}
+	return sb.String()
+}
+
+// attachSpec uploads the source document to the API page
+func (c *ConfluenceClient) attachSpec(ctx context.Context, pageID string) error {
+	if !c.cfg.AttachSpec || len(c.specDocument) == 0 {
+		return nil
+	}
+
+	contentType, ok := specContentTypes[c.specFormat]
+	if !ok {
+		contentType = "text/plain"
+	}
+	if err := c.UploadAttachment(ctx, pageID, c.specName, contentType, c.specDocument); err != nil {
+		return fmt.Errorf("failed to attach spec: %w", err)
+	}
+	return nil
+}
+
+// swaggerUISection formats the part of the API page embedding the
+// configured Swagger UI, or "" when there is none
+func (c *ConfluenceClient) swaggerUISection() string {
+	if c.cfg.SwaggerUIURL == "" {
+		return ""
+	}
+	return fmt.Sprintf(`
+<h2>Try It Out</h2>
+<ac:structured-macro ac:name="iframe">
+<ac:parameter ac:name="src"><ri:url ri:value="%s" /></ac:parameter>
+<ac:parameter ac:name="width">100%%</ac:parameter>
+<ac:parameter ac:name="height">800</ac:parameter>
+</ac:structured-macro>
+<p><a href="%s">Open Swagger UI in a new window</a></p>`,
+		html.EscapeString(c.cfg.SwaggerUIURL), html.EscapeString(c.cfg.SwaggerUIURL))
+}
@@ -0,0 +1,129 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+func TestSpecAttachmentName(t *testing.T) {
+	tests := []struct {
+		source string
+		format string
+		want   string
+	}{
+		{"./build/openapi.yaml", "yaml", "openapi.yaml"},
+		{"https://example.com/v2/swagger.json?token=x", "json", "swagger.json"},
+		{"https://example.com/api-docs", "json", "openapi.json"},
+		{"-", "yaml", "openapi.yaml"},
+		{`C:\specs\pets.apib`, "apib", "pets.apib"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			if got := SpecAttachmentName(tt.source, tt.format); got != tt.want {
+				t.Errorf("SpecAttachmentName(%q) = %q, want %q", tt.source, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_CreateParentPageAttachesSpec(t *testing.T) {
+	var content, attached string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"results": []}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/content":
+			var page Page
+			if err := json.NewDecoder(r.Body).Decode(&page); err != nil {
+				t.Error(err)
+			}
+			content = page.Body.Storage.Value
+			w.Write([]byte(`{"id": "100"}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/rest/api/content/100/child/attachment":
+			file, header, err := r.FormFile("file")
+			if err != nil {
+				t.Fatal(err)
+			}
+			file.Close()
+			attached = header.Filename + " " + header.Header.Get("Content-Type")
+			w.Write([]byte(`{}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(config.ConfluenceConfig{
+		BaseURL:    server.URL,
+		Username:   "user",
+		APIToken:   "token",
+		SpaceKey:   "TEST",
+		Enabled:    true,
+		AttachSpec: true,
+		SpecEmbed:  SpecEmbedCode,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := c.(*ConfluenceClient)
+	client.SetSpecDocument("openapi.yaml", "yaml", []byte("openapi: 3.0.0\n"))
+
+	if _, err := client.CreateParentPage(context.Background(), "Pets"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`<ri:attachment ri:filename="openapi.yaml" />`,
+		`<ac:parameter ac:name="language">yaml</ac:parameter>`,
+		"<ac:plain-text-body><![CDATA[openapi: 3.0.0\n]]></ac:plain-text-body>",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in:\n%s", want, content)
+		}
+	}
+	if attached != "openapi.yaml application/yaml" {
+		t.Errorf("attached %q", attached)
+	}
+}
+
+func TestClient_SwaggerUISection(t *testing.T) {
+	c := &ConfluenceClient{cfg: config.ConfluenceConfig{SwaggerUIURL: "https://docs.example.com/ui/?url=/openapi.json&deepLinking=true"}}
+
+	content := c.parentPageContent("Pets")
+	for _, want := range []string{
+		"<h2>Try It Out</h2>",
+		`<ri:url ri:value="https://docs.example.com/ui/?url=/openapi.json&amp;deepLinking=true" />`,
+		`<ac:parameter ac:name="width">100%</ac:parameter>`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in:\n%s", want, content)
+		}
+	}
+
+	if content := (&ConfluenceClient{}).parentPageContent("Pets"); strings.Contains(content, "iframe") {
+		t.Errorf("unexpected Swagger UI without a URL:\n%s", content)
+	}
+}
+
+func TestClient_SetParentPageContent(t *testing.T) {
+	c := &ConfluenceClient{cfg: config.ConfluenceConfig{SwaggerUIURL: "https://docs.example.com/ui/"}}
+	if content := c.parentPageContent("Pets"); !strings.HasPrefix(content, NewFormatter().FormatParentPage("Pets")) {
+		t.Errorf("expected the default introduction in:\n%s", content)
+	}
+
+	c.SetParentPageContent("<p>Custom</p>")
+	content := c.parentPageContent("Pets")
+	if !strings.HasPrefix(content, "<p>Custom</p>") || strings.Contains(content, "<h1>Pets</h1>") {
+		t.Errorf("expected only the custom introduction in:\n%s", content)
+	}
+	if !strings.Contains(content, "<h2>Try It Out</h2>") {
+		t.Errorf("expected the Swagger UI section after the introduction in:\n%s", content)
+	}
+}
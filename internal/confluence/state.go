@@ -0,0 +1,76 @@
+package confluence
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// errStalePage is returned when a page changed or disappeared since it was
+// recorded in the state file
+var errStalePage = errors.New("page changed since the last run")
+
+// stateFile is the on-disk record of pages written by previous runs
+type stateFile struct {
+	BaseURL  string           `json:"baseUrl"`
+	SpaceKey string           `json:"spaceKey"`
+	Pages    map[string]*Page `json:"pages"`
+	// Operations maps operation keys to the IDs of their endpoint pages
+	Operations map[string]string `json:"operations,omitempty"`
+}
+
+// loadState reads the pages and operation pages recorded for the
+// configured space. A missing file or one written for another space starts
+// an empty state.
+func loadState(path, baseURL, spaceKey string) (map[string]*Page, map[string]string, error) {
+	pages := make(map[string]*Page)
+	operations := make(map[string]string)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return pages, operations, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var state stateFile
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	if state.BaseURL != baseURL || state.SpaceKey != spaceKey {
+		return pages, operations, nil
+	}
+	for title, page := range state.Pages {
+		pages[title] = page
+	}
+	for key, pageID := range state.Operations {
+		operations[key] = pageID
+	}
+	return pages, operations, nil
+}
+
+// SaveState writes the pages published so far to the state file, so the
+// next run can update them without looking them up
+func (c *ConfluenceClient) SaveState() error {
+	if c.cfg.StateFile == "" || !c.cfg.Enabled || c.cfg.DryRun {
+		return nil
+	}
+
+	data, err := json.Marshal(stateFile{
+		BaseURL:  c.cfg.BaseURL,
+		SpaceKey: c.cfg.SpaceKey,
+		Pages:    c.state,
+
+		Operations: c.operations,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	if err := os.WriteFile(c.cfg.StateFile, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
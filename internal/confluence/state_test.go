@@ -0,0 +1,167 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+func TestClient_StateFile(t *testing.T) {
+	lookups := 0
+	remoteVersion := 1
+	var putVersions []int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			lookups++
+			if lookups == 1 {
+				w.Write([]byte(`{"results": []}`))
+				return
+			}
+			json.NewEncoder(w).Encode(SearchResponse{Results: []Page{{
+				ID: "42", Title: "Get Pet", Version: &Version{Number: remoteVersion},
+			}}})
+		case http.MethodPost:
+			w.Write([]byte(`{"id": "42"}`))
+		case http.MethodPut:
+			if strings.HasSuffix(r.URL.Path, "/property/"+ContentHashProperty) {
+				w.Write([]byte(`{}`))
+				return
+			}
+			var page Page
+			if err := json.NewDecoder(r.Body).Decode(&page); err != nil {
+				t.Errorf("failed to decode update: %v", err)
+				return
+			}
+			putVersions = append(putVersions, page.Version.Number)
+			if page.Version.Number != remoteVersion+1 {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			remoteVersion = page.Version.Number
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	cfg := config.ConfluenceConfig{
+		BaseURL:   server.URL,
+		Username:  "user",
+		APIToken:  "token",
+		SpaceKey:  "TEST",
+		StateFile: filepath.Join(t.TempDir(), "state.json"),
+		Enabled:   true,
+	}
+	ctx := context.Background()
+
+	// Every run publishes new content, so no update is skipped as unchanged
+	runs := 0
+	publish := func() {
+		t.Helper()
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		runs++
+		if _, err := client.CreateOrUpdatePage(ctx, "Get Pet", fmt.Sprintf("<p>pet %d</p>", runs), ""); err != nil {
+			t.Fatalf("CreateOrUpdatePage() error = %v", err)
+		}
+		if err := client.(*ConfluenceClient).SaveState(); err != nil {
+			t.Fatalf("SaveState() error = %v", err)
+		}
+	}
+
+	// First run creates the page after a lookup
+	publish()
+	// Second run updates it straight from the state file
+	publish()
+	if lookups != 1 {
+		t.Errorf("lookups after re-run = %d, want 1", lookups)
+	}
+
+	// Someone edits the page: the stale update is retried after a lookup
+	remoteVersion = 5
+	publish()
+	if lookups != 2 {
+		t.Errorf("lookups after remote edit = %d, want 2", lookups)
+	}
+	if want := []int{2, 3, 6}; len(putVersions) != 3 || putVersions[0] != want[0] || putVersions[1] != want[1] || putVersions[2] != want[2] {
+		t.Errorf("update versions = %v, want %v", putVersions, want)
+	}
+}
+
+func TestClient_StateFileRenamesOperationPage(t *testing.T) {
+	var creates int
+	var putTitles []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/content/42":
+			json.NewEncoder(w).Encode(Page{ID: "42", Title: "Get Pet", Version: &Version{Number: 1}})
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"results": []}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/content":
+			creates++
+			w.Write([]byte(`{"id": "42"}`))
+		case r.Method == http.MethodPost:
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/property/"+ContentHashProperty):
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodPut:
+			var page Page
+			if err := json.NewDecoder(r.Body).Decode(&page); err != nil {
+				t.Errorf("failed to decode update: %v", err)
+				return
+			}
+			putTitles = append(putTitles, page.Title)
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	cfg := config.ConfluenceConfig{
+		BaseURL:   server.URL,
+		Username:  "user",
+		APIToken:  "token",
+		SpaceKey:  "TEST",
+		StateFile: filepath.Join(t.TempDir(), "state.json"),
+		Enabled:   true,
+	}
+	ctx := context.Background()
+
+	publish := func(title string) string {
+		t.Helper()
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pageID, err := client.(*ConfluenceClient).PublishOperationPage(ctx, "getPet", title, "<p>pet</p>", "1")
+		if err != nil {
+			t.Fatalf("PublishOperationPage() error = %v", err)
+		}
+		if err := client.(*ConfluenceClient).SaveState(); err != nil {
+			t.Fatalf("SaveState() error = %v", err)
+		}
+		return pageID
+	}
+
+	publish("Get Pet")
+	// The title template changed: the same page is renamed, not duplicated
+	if pageID := publish("Fetch Pet"); pageID != "42" {
+		t.Errorf("page ID after rename = %q, want 42", pageID)
+	}
+	if creates != 1 {
+		t.Errorf("creates = %d, want 1", creates)
+	}
+	if len(putTitles) != 1 || putTitles[0] != "Fetch Pet" {
+		t.Errorf("updated titles = %v, want [Fetch Pet]", putTitles)
+	}
+}
@@ -0,0 +1,67 @@
+package confluence
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// UntaggedGroup heads the endpoints without tags in the endpoint summary
+const UntaggedGroup = "Other"
+
+// EndpointSummary is one endpoint listed on the API page
+type EndpointSummary struct {
+	// Title is the title of the endpoint page
+	Title   string
+	Method  string
+	Path    string
+	Summary string
+	Tags    []string
+}
+
+// FormatEndpointSummary generates tables listing every endpoint with its
+// method, path, summary and a link to its page, so readers can scan the API
+// without opening each page. Endpoints are grouped by their first tag, in
+// the order the tags first appear; untagged endpoints come last.
+func (f *Formatter) FormatEndpointSummary(endpoints []EndpointSummary) string {
+	if len(endpoints) == 0 {
+		return ""
+	}
+
+	var groups []string
+	byGroup := make(map[string][]EndpointSummary)
+	tagged := false
+	for _, endpoint := range endpoints {
+		group := UntaggedGroup
+		if len(endpoint.Tags) > 0 {
+			group = endpoint.Tags[0]
+			tagged = true
+		}
+		if _, ok := byGroup[group]; !ok && group != UntaggedGroup {
+			groups = append(groups, group)
+		}
+		byGroup[group] = append(byGroup[group], endpoint)
+	}
+	if _, ok := byGroup[UntaggedGroup]; ok {
+		groups = append(groups, UntaggedGroup)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<h2>Endpoints</h2>\n")
+	for _, group := range groups {
+		// A heading only helps when there is more than one kind of endpoint
+		if tagged {
+			sb.WriteString(fmt.Sprintf("<h3>%s</h3>\n", html.EscapeString(group)))
+		}
+		sb.WriteString("<table>\n<tbody>\n")
+		sb.WriteString("<tr><th>Method</th><th>Path</th><th>Summary</th><th>Page</th></tr>\n")
+		for _, endpoint := range byGroup[group] {
+			sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td><code>%s</code></td><td>%s</td><td>%s</td></tr>\n",
+				f.methodBadge(endpoint.Method), html.EscapeString(endpoint.Path),
+				html.EscapeString(endpoint.Summary), modelPageLink(endpoint.Title, endpoint.Title)))
+		}
+		sb.WriteString("</tbody>\n</table>\n")
+	}
+
+	return sb.String()
+}
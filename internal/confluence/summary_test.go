@@ -0,0 +1,68 @@
+package confluence
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatter_FormatEndpointSummary(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints []EndpointSummary
+		want      []string
+		wantNot   []string
+	}{
+		{
+			name:      "no endpoints",
+			endpoints: nil,
+			wantNot:   []string{"<h2>"},
+		},
+		{
+			name: "untagged",
+			endpoints: []EndpointSummary{
+				{Title: "List Pets", Method: "get", Path: "/pets", Summary: "List <all> pets"},
+			},
+			want: []string{
+				"<h2>Endpoints</h2>",
+				`<ac:parameter ac:name="title">GET</ac:parameter>`,
+				"<code>/pets</code>",
+				"List &lt;all&gt; pets",
+				`<ri:page ri:content-title="List Pets" />`,
+			},
+			wantNot: []string{"<h3>"},
+		},
+		{
+			name: "grouped by first tag",
+			endpoints: []EndpointSummary{
+				{Title: "Create Pet", Method: "post", Path: "/pets", Tags: []string{"pets", "admin"}},
+				{Title: "Health", Method: "get", Path: "/health"},
+				{Title: "Get Store", Method: "get", Path: "/store", Tags: []string{"store"}},
+			},
+			want:    []string{"<h3>pets</h3>", "<h3>store</h3>", "<h3>Other</h3>"},
+			wantNot: []string{"<h3>admin</h3>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := NewFormatter().FormatEndpointSummary(tt.endpoints)
+			for _, want := range tt.want {
+				if !strings.Contains(content, want) {
+					t.Errorf("expected %q in:\n%s", want, content)
+				}
+			}
+			for _, unwanted := range tt.wantNot {
+				if strings.Contains(content, unwanted) {
+					t.Errorf("unexpected %q in:\n%s", unwanted, content)
+				}
+			}
+		})
+	}
+
+	// Untagged endpoints follow the tags, which keep their first appearance order
+	content := NewFormatter().FormatEndpointSummary(tests[2].endpoints)
+	pets, store, other := strings.Index(content, "<h3>pets"), strings.Index(content, "<h3>store"), strings.Index(content, "<h3>Other")
+	if !(pets < store && store < other) {
+		t.Errorf("groups out of order:\n%s", content)
+	}
+}
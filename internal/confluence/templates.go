@@ -0,0 +1,164 @@
+package confluence
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// BlockNames lists the named blocks of the endpoint page layout that can be
+// overridden individually
+var BlockNames = []string{"properties", "header", "authentication", "requestBody", "parameters", "samples", "responses", "notes", "footer"}
+
+// defaultPageTemplate is the endpoint page layout. Each section is a named
+// block that renders the pre-formatted default markup.
+const defaultPageTemplate = `<ac:layout>
+<ac:layout-section ac:type="single">
+<ac:layout-cell>
+{{block "properties" .}}{{.Properties}}{{end}}` +
+	`{{block "header" .}}{{.Header}}{{end}}` +
+	`{{block "authentication" .}}{{.Authentication}}{{end}}` +
+	`{{block "requestBody" .}}{{.RequestBody}}{{end}}` +
+	`{{block "parameters" .}}{{.Parameters}}{{end}}` +
+	`{{block "samples" .}}{{.Samples}}{{end}}` +
+	`{{block "responses" .}}{{.Responses}}{{end}}` +
+	`{{block "notes" .}}{{.Notes}}{{end}}` +
+	`{{block "footer" .}}{{end}}` +
+	`</ac:layout-cell>
+</ac:layout-section>
+</ac:layout>
+`
+
+// EndpointData is passed to the endpoint page templates. Each section's
+// default markup is rendered, and its schemas resolved, only when a template
+// uses it.
+type EndpointData struct {
+	Path      string
+	Method    string
+	Operation swagger.Operation
+
+	formatter *Formatter
+	resolver  *swagger.Resolver
+}
+
+// Properties returns the page properties collected by the API catalog
+func (d EndpointData) Properties() string {
+	return d.formatter.formatPagePropertiesSection(d.Path, d.Method, d.Operation)
+}
+
+// Header returns the default heading, description and operation details
+func (d EndpointData) Header() string {
+	return d.formatter.formatHeaderSection(d.Path, d.Method, d.Operation)
+}
+
+// Authentication returns the security requirements section
+func (d EndpointData) Authentication() string {
+	return d.formatter.formatAuthenticationSection(d.Operation)
+}
+
+// RequestBody returns the default request body section
+func (d EndpointData) RequestBody() string {
+	return d.formatter.formatRequestBodySection(d.Operation, d.resolver)
+}
+
+// Parameters returns the default parameters table
+func (d EndpointData) Parameters() string {
+	return d.formatter.formatParametersSection(d.Operation.Parameters)
+}
+
+// Samples returns the code samples section
+func (d EndpointData) Samples() string {
+	return d.formatter.formatSamplesSection(d.Path, d.Method, d.Operation, d.resolver)
+}
+
+// Responses returns the default responses section
+func (d EndpointData) Responses() string {
+	return d.formatter.formatResponsesSection(d.Operation, d.resolver)
+}
+
+// Notes returns the hand-written notes section, preserved across re-syncs
+func (d EndpointData) Notes() string {
+	return d.formatter.formatNotesSection()
+}
+
+// templateFuncs are available to custom templates
+var templateFuncs = template.FuncMap{
+	"escape": html.EscapeString,
+	"upper":  strings.ToUpper,
+	"join":   strings.Join,
+}
+
+// loadPageTemplate builds the endpoint page template from the default
+// layout, an optional full page override and block overrides
+func loadPageTemplate(cfg config.TemplateConfig) (*template.Template, error) {
+	tmpl, err := template.New("page").Funcs(templateFuncs).Parse(defaultPageTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse default template: %w", err)
+	}
+
+	if cfg.Page != "" {
+		data, err := os.ReadFile(cfg.Page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", cfg.Page, err)
+		}
+		// Replace the layout; the default blocks stay available to it
+		if _, err := tmpl.New("page").Parse(string(data)); err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", cfg.Page, err)
+		}
+	}
+
+	for _, path := range cfg.Blocks {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", path, err)
+		}
+		if err := checkBlockNames(path, string(data)); err != nil {
+			return nil, err
+		}
+		if _, err := tmpl.New(path).Parse(string(data)); err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+		}
+	}
+
+	return tmpl, nil
+}
+
+// checkBlockNames rejects block files that define unknown blocks, which
+// would otherwise be silently ignored
+func checkBlockNames(path, text string) error {
+	parsed, err := template.New(path).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+
+	defined := 0
+	for _, t := range parsed.Templates() {
+		if t.Name() == path {
+			continue
+		}
+		if !isBlockName(t.Name()) {
+			return fmt.Errorf("template %s defines unknown block %q (expected one of %s)",
+				path, t.Name(), strings.Join(BlockNames, ", "))
+		}
+		defined++
+	}
+	if defined == 0 {
+		return fmt.Errorf("template %s defines no blocks; use {{define \"<block>\"}}...{{end}}", path)
+	}
+	return nil
+}
+
+// isBlockName reports whether name is an overridable block
+func isBlockName(name string) bool {
+	for _, block := range BlockNames {
+		if block == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package confluence
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func writeTemplate(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFormatEndpointPage_Templates(t *testing.T) {
+	op := swagger.Operation{
+		Summary:     "Get pet",
+		OperationID: "getPet",
+		Parameters: []swagger.Parameter{
+			{Name: "petId", In: "path", Required: true, Type: "integer"},
+		},
+	}
+	resolver := swagger.NewResolver(&swagger.Spec{})
+
+	tests := []struct {
+		name    string
+		cfg     func(t *testing.T) config.TemplateConfig
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name: "default layout",
+			cfg:  func(t *testing.T) config.TemplateConfig { return config.TemplateConfig{} },
+			want: []string{"<ac:layout>\n", "</h2>\n", "<code>getPet</code>", "petId", "<h3>Notes</h3>", "</ac:layout>\n"},
+		},
+		{
+			name: "footer block",
+			cfg: func(t *testing.T) config.TemplateConfig {
+				return config.TemplateConfig{Blocks: []string{
+					writeTemplate(t, "footer.tmpl", `{{define "footer"}}<p>Owned by {{escape "Pets & Co"}}</p>{{end}}`),
+				}}
+			},
+			want: []string{"petId", "<h3>Notes</h3>", "<p>Owned by Pets &amp; Co</p></ac:layout-cell>"},
+		},
+		{
+			name: "parameters block uses operation data",
+			cfg: func(t *testing.T) config.TemplateConfig {
+				return config.TemplateConfig{Blocks: []string{
+					writeTemplate(t, "params.tmpl", `{{define "parameters"}}<p>{{len .Operation.Parameters}} params for {{.Method}}</p>{{end}}`),
+				}}
+			},
+			want:    []string{"<p>1 params for GET</p>", "<code>getPet</code>"},
+			notWant: []string{"<th>"},
+		},
+		{
+			name: "full page override keeps default blocks",
+			cfg: func(t *testing.T) config.TemplateConfig {
+				return config.TemplateConfig{Page: writeTemplate(t, "page.tmpl", `<h1>{{.Operation.Summary}}</h1>{{template "header" .}}`)}
+			},
+			want:    []string{"<h1>Get pet</h1><h2>", "<code>getPet</code>"},
+			notWant: []string{"<ac:layout>", "Notes"},
+		},
+		{
+			name: "unknown block",
+			cfg: func(t *testing.T) config.TemplateConfig {
+				return config.TemplateConfig{Blocks: []string{writeTemplate(t, "x.tmpl", `{{define "sidebar"}}x{{end}}`)}}
+			},
+			wantErr: `unknown block "sidebar"`,
+		},
+		{
+			name: "block file without define",
+			cfg: func(t *testing.T) config.TemplateConfig {
+				return config.TemplateConfig{Blocks: []string{writeTemplate(t, "x.tmpl", `<p>footer</p>`)}}
+			},
+			wantErr: "defines no blocks",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFormatterWithConfig(tt.cfg(t))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewFormatterWithConfig() error = %v", err)
+			}
+
+			content, err := f.FormatEndpointPage("/pets/{petId}", "get", op, resolver)
+			if err != nil {
+				t.Fatalf("FormatEndpointPage() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(content, want) {
+					t.Errorf("content missing %q:\n%s", want, content)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(content, notWant) {
+					t.Errorf("content contains %q:\n%s", notWant, content)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatEndpointPage_LazySections(t *testing.T) {
+	op, resolver := benchmarkEndpoint(100)
+	headerOnly, err := NewFormatterWithConfig(config.TemplateConfig{
+		Page: writeTemplate(t, "page.tmpl", `{{template "header" .}}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	allocs := func(f *Formatter) float64 {
+		return testing.AllocsPerRun(10, func() {
+			if _, err := f.FormatEndpointPage("/tenants/{tenant}/orders", "post", op, resolver); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+	// The request and response schemas are only resolved and rendered by
+	// the sections the template leaves out
+	full, header := allocs(NewFormatter()), allocs(headerOnly)
+	if header*10 > full {
+		t.Errorf("header-only page made %.0f allocations, full page %.0f; want the unused sections skipped", header, full)
+	}
+}
@@ -9,6 +9,20 @@ type Page struct {
 	Body      Body           `json:"body"`
 	Version   *Version       `json:"version,omitempty"`
 	Ancestors []PageAncestor `json:"ancestors,omitempty"`
+	// Metadata holds the content properties expanded by lookups; it is
+	// never sent when writing a page
+	Metadata *Metadata `json:"metadata,omitempty"`
+}
+
+// Metadata holds expanded page metadata
+type Metadata struct {
+	Properties map[string]contentProperty `json:"properties,omitempty"`
+	Labels     *LabelResults              `json:"labels,omitempty"`
+}
+
+// LabelResults holds the labels of an expanded page
+type LabelResults struct {
+	Results []Label `json:"results"`
 }
 
 // PageAncestor represents a parent page
@@ -35,9 +49,19 @@ type Storage struct {
 // Version represents page version
 type Version struct {
 	Number int `json:"number"`
+	// Message records the API version a page version was rendered from
+	Message string `json:"message,omitempty"`
+	// MinorEdit saves the version without notifying watchers
+	MinorEdit bool `json:"minorEdit,omitempty"`
 }
 
 // SearchResponse represents a page search response
 type SearchResponse struct {
 	Results []Page `json:"results"`
 }
+
+// Label represents a page label
+type Label struct {
+	Prefix string `json:"prefix"`
+	Name   string `json:"name"`
+}
@@ -0,0 +1,302 @@
+package confluence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/example"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// API versions of the Confluence REST API
+const (
+	APIv1 = "v1"
+	APIv2 = "v2"
+)
+
+// Body representations accepted by the v2 pages API
+const (
+	representationStorage = "storage"
+	representationADF     = "atlas_doc_format"
+)
+
+// CloudClient publishes pages through the Confluence Cloud v2 API, with
+// endpoint pages written as Atlassian Document Format. Labels, properties,
+// attachments and exports have no v2 equivalent yet and go through the
+// embedded v1 client.
+type CloudClient struct {
+	*ConfluenceClient
+	formatter *ADFFormatter
+	// spaceID is the numeric ID of the configured space, once resolved
+	spaceID string
+}
+
+// v2Page is a page of the v2 pages API
+type v2Page struct {
+	ID       string   `json:"id,omitempty"`
+	Status   string   `json:"status,omitempty"`
+	Title    string   `json:"title"`
+	SpaceID  string   `json:"spaceId,omitempty"`
+	ParentID string   `json:"parentId,omitempty"`
+	Body     *v2Body  `json:"body,omitempty"`
+	Version  *Version `json:"version,omitempty"`
+}
+
+// v2Body is the body of a page written through the v2 API
+type v2Body struct {
+	Representation string `json:"representation"`
+	Value          string `json:"value"`
+}
+
+// v2Results is a page of v2 list results
+type v2Results[T any] struct {
+	Results []T `json:"results"`
+}
+
+// NewCloudClient creates a new Confluence Cloud v2 client
+func NewCloudClient(cfg config.ConfluenceConfig) (*CloudClient, error) {
+	client, err := NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &CloudClient{
+		ConfluenceClient: client.(*ConfluenceClient),
+		formatter:        NewADFFormatter().WithCollapse(cfg.CollapseProperties, cfg.CollapseLines),
+	}, nil
+}
+
+// SetExampleGenerator makes endpoint pages use gen for example bodies
+func (c *CloudClient) SetExampleGenerator(gen *example.Generator) {
+	formatter := *c.formatter
+	formatter.exampleGen = gen
+	c.formatter = &formatter
+}
+
+// PublishEndpoint renders an endpoint as ADF and creates or updates its page
+func (c *CloudClient) PublishEndpoint(ctx context.Context, endpoint swagger.EndpointInfo, resolver *swagger.Resolver, parentPageID string) (string, error) {
+	doc, err := c.formatter.FormatEndpointPage(endpoint.Path, endpoint.Method, endpoint.Operation, resolver)
+	if err != nil {
+		return "", fmt.Errorf("failed to format endpoint page: %w", err)
+	}
+	return c.publish(ctx, endpoint.Title, representationADF, doc.String(), parentPageID)
+}
+
+// CreateOrUpdatePage creates or updates a page with storage format content
+func (c *CloudClient) CreateOrUpdatePage(ctx context.Context, title, content, parentPageID string) (string, error) {
+	return c.publish(ctx, title, representationStorage, content, parentPageID)
+}
+
+// CreateParentPage creates or updates the parent documentation page
+func (c *CloudClient) CreateParentPage(ctx context.Context, apiTitle string) (string, error) {
+	c.apiTitle = apiTitle
+
+	pageID, err := c.CreateOrUpdatePage(ctx, APIPageTitle(apiTitle), c.parentPageContent(apiTitle), c.cfg.ParentPageID)
+	if err != nil {
+		return "", err
+	}
+	if err := c.attachSpec(ctx, pageID); err != nil {
+		return "", err
+	}
+	return pageID, nil
+}
+
+// ResolveParentPage resolves the configured parent page to an ID.
+// A parent given by title is looked up in the space and, if CreateParent
+// is set, created at the space root when missing.
+func (c *CloudClient) ResolveParentPage(ctx context.Context) (string, error) {
+	if !c.cfg.Enabled || c.cfg.ParentPageID != "" || c.cfg.ParentPageTitle == "" {
+		return c.cfg.ParentPageID, nil
+	}
+
+	existing, err := c.findPage(ctx, c.cfg.ParentPageTitle)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up parent page %q: %w", c.cfg.ParentPageTitle, err)
+	}
+
+	var pageID string
+	if existing != nil {
+		pageID = existing.ID
+	} else {
+		if !c.cfg.CreateParent {
+			return "", fmt.Errorf("parent page %q not found in space %s", c.cfg.ParentPageTitle, c.cfg.SpaceKey)
+		}
+		content := fmt.Sprintf("<p>%s</p>\n", html.EscapeString(c.cfg.ParentPageTitle))
+		pageID, err = c.CreateOrUpdatePage(ctx, c.cfg.ParentPageTitle, content, "")
+		if err != nil {
+			return "", fmt.Errorf("failed to create parent page %q: %w", c.cfg.ParentPageTitle, err)
+		}
+	}
+
+	c.cfg.ParentPageID = pageID
+	return pageID, nil
+}
+
+// FindPage returns the ID of the page with the title, or "" if the space
+// has no such page
+func (c *CloudClient) FindPage(ctx context.Context, title string) (string, error) {
+	if !c.cfg.Enabled {
+		return "", nil
+	}
+	page, err := c.findPage(ctx, title)
+	if err != nil || page == nil {
+		return "", err
+	}
+	return page.ID, nil
+}
+
+// publish creates or updates the page with the title
+func (c *CloudClient) publish(ctx context.Context, title, representation, value, parentPageID string) (string, error) {
+	c.lastChange = ""
+	if !c.cfg.Enabled {
+		// Print to console if Confluence is disabled
+		fmt.Printf("\n=== Page: %s ===\n%s\n\n", title, value)
+		return "", nil
+	}
+
+	existing, err := c.findPage(ctx, title)
+	if err != nil {
+		return "", fmt.Errorf("failed to check existing page: %w", err)
+	}
+
+	if c.cfg.DryRun {
+		if existing == nil {
+			fmt.Printf("[dry-run] %s: %s\n", ChangeCreate, title)
+			c.lastChange = ChangeCreate
+			return "", nil
+		}
+		fmt.Printf("[dry-run] %s: %s\n", ChangeUpdate, title)
+		c.lastChange = ChangeUpdate
+		return existing.ID, nil
+	}
+
+	spaceID, err := c.resolveSpaceID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	page := v2Page{
+		Status:   "current",
+		Title:    title,
+		SpaceID:  spaceID,
+		ParentID: parentPageID,
+		Body:     &v2Body{Representation: representation, Value: value},
+	}
+
+	var pageID string
+	if existing != nil {
+		version := 0
+		if existing.Version != nil {
+			version = existing.Version.Number
+		}
+		page.ID = existing.ID
+		page.Version = c.updateVersion(version + 1)
+		pageID, err = c.writePage(ctx, http.MethodPut, fmt.Sprintf("%s/api/v2/pages/%s", c.cfg.BaseURL, existing.ID), &page)
+	} else {
+		pageID, err = c.writePage(ctx, http.MethodPost, fmt.Sprintf("%s/api/v2/pages", c.cfg.BaseURL), &page)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if existing != nil {
+		fmt.Printf("✓ Updated page: %s - %s\n", title, c.PageURL(pageID))
+	} else {
+		fmt.Printf("✓ Created page: %s - %s\n", title, c.PageURL(pageID))
+	}
+
+	if err := c.AddLabels(ctx, pageID, c.pageLabels(value)); err != nil {
+		return "", err
+	}
+
+	c.lastChange = ChangeCreate
+	if existing != nil {
+		c.lastChange = ChangeUpdate
+	}
+
+	return pageID, nil
+}
+
+// writePage sends a page to the v2 API and returns its ID
+func (c *CloudClient) writePage(ctx context.Context, method, apiURL string, page *v2Page) (string, error) {
+	body, err := json.Marshal(page)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal page: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.authorize(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to write page %q: %w", page.Title, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("failed to write page %q: unexpected status %d: %s", page.Title, resp.StatusCode, string(bodyBytes))
+	}
+
+	var result v2Page
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.ID, nil
+}
+
+// findPage finds a page of the space by title. It returns nil when no page
+// matches.
+func (c *CloudClient) findPage(ctx context.Context, title string) (*v2Page, error) {
+	spaceID, err := c.resolveSpaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	apiURL := fmt.Sprintf("%s/api/v2/pages?space-id=%s&title=%s",
+		c.cfg.BaseURL, url.QueryEscape(spaceID), url.QueryEscape(title))
+
+	var result v2Results[v2Page]
+	if err := c.getJSON(ctx, apiURL, &result); err != nil {
+		return nil, fmt.Errorf("failed to search page: %w", err)
+	}
+	if len(result.Results) == 0 {
+		return nil, nil
+	}
+	return &result.Results[0], nil
+}
+
+// resolveSpaceID looks up the numeric ID of the configured space, which the
+// v2 API uses in place of the space key
+func (c *CloudClient) resolveSpaceID(ctx context.Context) (string, error) {
+	if c.spaceID != "" {
+		return c.spaceID, nil
+	}
+
+	apiURL := fmt.Sprintf("%s/api/v2/spaces?keys=%s", c.cfg.BaseURL, url.QueryEscape(c.cfg.SpaceKey))
+
+	var result v2Results[struct {
+		ID string `json:"id"`
+	}]
+	if err := c.getJSON(ctx, apiURL, &result); err != nil {
+		return "", fmt.Errorf("failed to look up space %s: %w", c.cfg.SpaceKey, err)
+	}
+	if len(result.Results) == 0 {
+		return "", fmt.Errorf("space %s not found", c.cfg.SpaceKey)
+	}
+
+	c.spaceID = result.Results[0].ID
+	return c.spaceID, nil
+}
@@ -0,0 +1,142 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func TestADFFormatter_FormatEndpointPage(t *testing.T) {
+	op, resolver := benchmarkEndpoint(4)
+
+	doc, err := NewADFFormatter().FormatEndpointPage("/tenants/{tenant}/orders", "post", op, resolver)
+	if err != nil {
+		t.Fatalf("FormatEndpointPage() error = %v", err)
+	}
+	if doc.Version != 1 || doc.Type != "doc" {
+		t.Errorf("document = version %d type %q, want version 1 type doc", doc.Version, doc.Type)
+	}
+
+	var decoded ADFDocument
+	if err := json.Unmarshal([]byte(doc.String()), &decoded); err != nil {
+		t.Fatalf("document is not valid JSON: %v", err)
+	}
+
+	counts := make(map[string]int)
+	var texts []string
+	var walk func(nodes []ADFNode)
+	walk = func(nodes []ADFNode) {
+		for _, node := range nodes {
+			counts[node.Type]++
+			if node.Type == "text" {
+				if node.Text == "" {
+					t.Error("empty text node")
+				}
+				texts = append(texts, node.Text)
+			}
+			walk(node.Content)
+		}
+	}
+	walk(decoded.Content)
+
+	// Parameters, request body fields and response fields
+	if counts["table"] != 3 {
+		t.Errorf("tables = %d, want 3", counts["table"])
+	}
+	// Minimal and full request examples in expands, and the 201 response
+	// example
+	if counts["codeBlock"] != 3 || counts["expand"] != 2 {
+		t.Errorf("code blocks = %d, expands = %d, want 3 and 2", counts["codeBlock"], counts["expand"])
+	}
+
+	all := strings.Join(texts, "\n")
+	for _, want := range []string{"POST", "/tenants/{tenant}/orders", "createOrder", "orders, checkout", "dryRun", "201 - Created", "400 - Bad request", "field0"} {
+		if !strings.Contains(all, want) {
+			t.Errorf("document is missing %q", want)
+		}
+	}
+	// The body parameter is documented as the request body, not a parameter
+	if strings.Contains(all, "\nbody\n") {
+		t.Error("body parameter listed in the parameters table")
+	}
+}
+
+func TestCloudClient_Publish(t *testing.T) {
+	var created, updated v2Page
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/spaces":
+			if got := r.URL.Query().Get("keys"); got != "TEST" {
+				t.Errorf("space key = %q, want TEST", got)
+			}
+			w.Write([]byte(`{"results": [{"id": "98765"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/pages":
+			if got := r.URL.Query().Get("space-id"); got != "98765" {
+				t.Errorf("space-id = %q, want 98765", got)
+			}
+			if r.URL.Query().Get("title") == "Existing" {
+				w.Write([]byte(`{"results": [{"id": "7", "title": "Existing", "version": {"number": 3}}]}`))
+				return
+			}
+			w.Write([]byte(`{"results": []}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/pages":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(`{"id": "42"}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v2/pages/7":
+			json.NewDecoder(r.Body).Decode(&updated)
+			w.Write([]byte(`{"id": "7"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewCloudClient(config.ConfluenceConfig{
+		BaseURL:  server.URL,
+		Username: "user",
+		APIToken: "token",
+		SpaceKey: "TEST",
+		Enabled:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	op, resolver := benchmarkEndpoint(2)
+	endpoint := swagger.EndpointInfo{Path: "/orders", Method: "post", Operation: op, Title: "Create Order"}
+	pageID, err := client.PublishEndpoint(ctx, endpoint, resolver, "1")
+	if err != nil {
+		t.Fatalf("PublishEndpoint() error = %v", err)
+	}
+	if pageID != "42" {
+		t.Errorf("page ID = %q, want 42", pageID)
+	}
+	if created.SpaceID != "98765" || created.ParentID != "1" || created.Status != "current" {
+		t.Errorf("created page = %+v", created)
+	}
+	if created.Body == nil || created.Body.Representation != representationADF {
+		t.Fatalf("created body = %+v, want ADF", created.Body)
+	}
+	var doc ADFDocument
+	if err := json.Unmarshal([]byte(created.Body.Value), &doc); err != nil || doc.Type != "doc" {
+		t.Errorf("created body is not an ADF document: %v", err)
+	}
+
+	if _, err := client.CreateOrUpdatePage(ctx, "Existing", "<p>hi</p>", "1"); err != nil {
+		t.Fatalf("CreateOrUpdatePage() error = %v", err)
+	}
+	if updated.Version == nil || updated.Version.Number != 4 {
+		t.Errorf("updated version = %+v, want 4", updated.Version)
+	}
+	if updated.Body == nil || updated.Body.Representation != representationStorage || updated.Body.Value != "<p>hi</p>" {
+		t.Errorf("updated body = %+v", updated.Body)
+	}
+}
@@ -0,0 +1,86 @@
+package confluence
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// formatVariants formats the oneOf/anyOf alternatives of a schema as
+// labeled sub-tables, with the discriminator mapping when there is one
+func (f *Formatter) formatVariants(schema *swagger.Schema) string {
+	var sb strings.Builder
+
+	if schema.Discriminator != nil && schema.Discriminator.PropertyName != "" {
+		f.writeDiscriminator(&sb, schema.Discriminator)
+	}
+
+	if len(schema.OneOf) > 0 {
+		sb.WriteString("<p><strong>One of</strong> the following variants:</p>\n")
+		f.writeVariantTables(&sb, schema.OneOf)
+	}
+	if len(schema.AnyOf) > 0 {
+		sb.WriteString("<p><strong>Any of</strong> the following variants (one or more may apply):</p>\n")
+		f.writeVariantTables(&sb, schema.AnyOf)
+	}
+
+	return sb.String()
+}
+
+// writeVariantTables writes one labeled schema table per variant
+func (f *Formatter) writeVariantTables(sb *strings.Builder, variants []*swagger.Schema) {
+	for i, variant := range variants {
+		title := variant.Title
+		if title == "" && variant.Ref != "" {
+			title = swagger.ExtractRefName(variant.Ref)
+		}
+		label := fmt.Sprintf("Variant %d", i+1)
+		if title != "" {
+			label += ": " + html.EscapeString(title)
+		}
+		sb.WriteString("<h5>")
+		sb.WriteString(label)
+		sb.WriteString("</h5>\n")
+
+		if variant.Ref != "" {
+			// Nested variants are not resolved; point at the component
+			sb.WriteString("<p>See <code>")
+			sb.WriteString(html.EscapeString(swagger.ExtractRefName(variant.Ref)))
+			sb.WriteString("</code></p>\n")
+			continue
+		}
+
+		if len(variant.Properties) == 0 && !variant.HasVariants() && variant.Type != "" && variant.Type != "object" {
+			// Scalar or array variants have no table to show
+			sb.WriteString("<p><strong>Type:</strong> <code>")
+			sb.WriteString(html.EscapeString(variant.Type))
+			sb.WriteString("</code></p>\n")
+			continue
+		}
+		sb.WriteString(f.formatSchemaTable(variant))
+	}
+}
+
+// writeDiscriminator writes the property that selects the variant and the
+// values mapped to each variant
+func (f *Formatter) writeDiscriminator(sb *strings.Builder, discriminator *swagger.Discriminator) {
+	sb.WriteString("<p><strong>Discriminator:</strong> <code>")
+	sb.WriteString(html.EscapeString(discriminator.PropertyName))
+	sb.WriteString("</code></p>\n")
+
+	if len(discriminator.Mapping) == 0 {
+		return
+	}
+	sb.WriteString("<table>\n")
+	sb.WriteString("<tr><th>Value</th><th>Variant</th></tr>\n")
+	for _, value := range swagger.SortedKeys(discriminator.Mapping) {
+		sb.WriteString("<tr><td><code>")
+		sb.WriteString(html.EscapeString(value))
+		sb.WriteString("</code></td><td>")
+		sb.WriteString(html.EscapeString(swagger.ExtractRefName(discriminator.Mapping[value])))
+		sb.WriteString("</td></tr>\n")
+	}
+	sb.WriteString("</table>\n")
+}
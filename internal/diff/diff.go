@@ -0,0 +1,333 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// Change kinds
+const (
+	EndpointAdded       = "endpoint-added"
+	EndpointRemoved     = "endpoint-removed"
+	EndpointRenamed     = "endpoint-renamed"
+	ParameterAdded      = "parameter-added"
+	ParameterRemoved    = "parameter-removed"
+	ParameterRequired   = "parameter-required"
+	ResponseAdded       = "response-added"
+	ResponseRemoved     = "response-removed"
+	SchemaAdded         = "schema-added"
+	SchemaRemoved       = "schema-removed"
+	PropertyAdded       = "property-added"
+	PropertyRemoved     = "property-removed"
+	PropertyTypeChanged = "property-type-changed"
+	PropertyRequired    = "property-required"
+)
+
+// Change is a single difference between two specifications
+type Change struct {
+	Kind     string `json:"kind"`
+	Breaking bool   `json:"breaking"`
+	// Method and Path identify the affected endpoint, when there is one
+	Method  string `json:"method,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Schema  string `json:"schema,omitempty"`
+	Message string `json:"message"`
+}
+
+// String formats the change for console output
+func (c Change) String() string {
+	prefix := "    "
+	if c.Breaking {
+		prefix = "[breaking] "
+	}
+	return prefix + c.Message
+}
+
+// Compare returns the changes from old to new, endpoints first and then
+// schemas, each in a stable order
+func Compare(old, new *swagger.Spec) []Change {
+	changes := compareEndpoints(old, new)
+	return append(changes, compareSchemas(definitions(old), definitions(new))...)
+}
+
+// Breaking returns only the breaking changes
+func Breaking(changes []Change) []Change {
+	var breaking []Change
+	for _, change := range changes {
+		if change.Breaking {
+			breaking = append(breaking, change)
+		}
+	}
+	return breaking
+}
+
+type operationKey struct {
+	method string
+	path   string
+}
+
+func compareEndpoints(old, new *swagger.Spec) []Change {
+	oldOps := operations(old)
+	newOps := operations(new)
+
+	var removed, added []operationKey
+	var changes []Change
+
+	for _, key := range sortedOperationKeys(oldOps) {
+		newOp, ok := newOps[key]
+		if !ok {
+			removed = append(removed, key)
+			continue
+		}
+		changes = append(changes, compareOperation(key, oldOps[key], newOp)...)
+	}
+	for _, key := range sortedOperationKeys(newOps) {
+		if _, ok := oldOps[key]; !ok {
+			added = append(added, key)
+		}
+	}
+
+	// An operation that moved to a new path keeps its operationId
+	renamed := make(map[operationKey]bool)
+	for _, oldKey := range removed {
+		id := oldOps[oldKey].OperationID
+		if id == "" {
+			continue
+		}
+		for _, newKey := range added {
+			if !renamed[newKey] && newOps[newKey].OperationID == id {
+				changes = append(changes, Change{
+					Kind:     EndpointRenamed,
+					Breaking: true,
+					Method:   strings.ToUpper(newKey.method),
+					Path:     newKey.path,
+					Message: fmt.Sprintf("%s %s moved to %s %s", strings.ToUpper(oldKey.method),
+						oldKey.path, strings.ToUpper(newKey.method), newKey.path),
+				})
+				renamed[oldKey] = true
+				renamed[newKey] = true
+				break
+			}
+		}
+	}
+
+	for _, key := range removed {
+		if renamed[key] {
+			continue
+		}
+		changes = append(changes, Change{
+			Kind:     EndpointRemoved,
+			Breaking: true,
+			Method:   strings.ToUpper(key.method),
+			Path:     key.path,
+			Message:  fmt.Sprintf("%s %s was removed", strings.ToUpper(key.method), key.path),
+		})
+	}
+	for _, key := range added {
+		if renamed[key] {
+			continue
+		}
+		changes = append(changes, Change{
+			Kind:    EndpointAdded,
+			Method:  strings.ToUpper(key.method),
+			Path:    key.path,
+			Message: fmt.Sprintf("%s %s was added", strings.ToUpper(key.method), key.path),
+		})
+	}
+
+	return changes
+}
+
+func compareOperation(key operationKey, old, new swagger.Operation) []Change {
+	method := strings.ToUpper(key.method)
+	endpoint := fmt.Sprintf("%s %s", method, key.path)
+	change := func(kind string, breaking bool, format string, args ...interface{}) Change {
+		return Change{
+			Kind:     kind,
+			Breaking: breaking,
+			Method:   method,
+			Path:     key.path,
+			Message:  endpoint + ": " + fmt.Sprintf(format, args...),
+		}
+	}
+
+	var changes []Change
+
+	oldParams := parameters(old.Parameters)
+	newParams := parameters(new.Parameters)
+	for _, name := range swagger.SortedKeys(oldParams) {
+		newParam, ok := newParams[name]
+		if !ok {
+			changes = append(changes, change(ParameterRemoved, true, "parameter %s was removed", name))
+			continue
+		}
+		if newParam.Required && !oldParams[name].Required {
+			changes = append(changes, change(ParameterRequired, true, "parameter %s is now required", name))
+		}
+	}
+	for _, name := range swagger.SortedKeys(newParams) {
+		if _, ok := oldParams[name]; !ok {
+			newParam := newParams[name]
+			changes = append(changes, change(ParameterAdded, newParam.Required,
+				"parameter %s was added%s", name, requiredSuffix(newParam.Required)))
+		}
+	}
+
+	for _, code := range swagger.SortedKeys(old.Responses) {
+		if _, ok := new.Responses[code]; !ok {
+			changes = append(changes, change(ResponseRemoved, true, "response %s was removed", code))
+		}
+	}
+	for _, code := range swagger.SortedKeys(new.Responses) {
+		if _, ok := old.Responses[code]; !ok {
+			changes = append(changes, change(ResponseAdded, false, "response %s was added", code))
+		}
+	}
+
+	return changes
+}
+
+func compareSchemas(old, new map[string]swagger.Definition) []Change {
+	var changes []Change
+
+	for _, name := range swagger.SortedKeys(old) {
+		newDef, ok := new[name]
+		if !ok {
+			changes = append(changes, Change{
+				Kind:     SchemaRemoved,
+				Breaking: true,
+				Schema:   name,
+				Message:  fmt.Sprintf("schema %s was removed", name),
+			})
+			continue
+		}
+		changes = append(changes, compareDefinition(name, old[name], newDef)...)
+	}
+	for _, name := range swagger.SortedKeys(new) {
+		if _, ok := old[name]; !ok {
+			changes = append(changes, Change{
+				Kind:    SchemaAdded,
+				Schema:  name,
+				Message: fmt.Sprintf("schema %s was added", name),
+			})
+		}
+	}
+
+	return changes
+}
+
+func compareDefinition(name string, old, new swagger.Definition) []Change {
+	change := func(kind string, breaking bool, format string, args ...interface{}) Change {
+		return Change{
+			Kind:     kind,
+			Breaking: breaking,
+			Schema:   name,
+			Message:  fmt.Sprintf("schema %s: ", name) + fmt.Sprintf(format, args...),
+		}
+	}
+
+	oldRequired := stringSet(old.Required)
+	newRequired := stringSet(new.Required)
+
+	var changes []Change
+	for _, prop := range swagger.SortedKeys(old.Properties) {
+		newProp, ok := new.Properties[prop]
+		if !ok {
+			changes = append(changes, change(PropertyRemoved, true, "property %s was removed", prop))
+			continue
+		}
+		if oldType, newType := propertyType(old.Properties[prop]), propertyType(newProp); oldType != newType {
+			changes = append(changes, change(PropertyTypeChanged, true,
+				"property %s changed type from %s to %s", prop, oldType, newType))
+		}
+		if newRequired[prop] && !oldRequired[prop] {
+			changes = append(changes, change(PropertyRequired, true, "property %s is now required", prop))
+		}
+	}
+	for _, prop := range swagger.SortedKeys(new.Properties) {
+		if _, ok := old.Properties[prop]; !ok {
+			changes = append(changes, change(PropertyAdded, newRequired[prop],
+				"property %s was added%s", prop, requiredSuffix(newRequired[prop])))
+		}
+	}
+
+	return changes
+}
+
+// operations indexes every operation by lower-case method and path
+func operations(spec *swagger.Spec) map[operationKey]swagger.Operation {
+	ops := make(map[operationKey]swagger.Operation)
+	for path, item := range spec.Paths {
+		for method, op := range item {
+			if swagger.IsMethod(method) {
+				ops[operationKey{method: strings.ToLower(method), path: path}] = op
+			}
+		}
+	}
+	return ops
+}
+
+// parameters indexes parameters by location and name
+func parameters(params []swagger.Parameter) map[string]swagger.Parameter {
+	indexed := make(map[string]swagger.Parameter, len(params))
+	for _, param := range params {
+		indexed[fmt.Sprintf("%s (%s)", param.Name, param.In)] = param
+	}
+	return indexed
+}
+
+// definitions returns the component schemas or Swagger 2.0 definitions
+func definitions(spec *swagger.Spec) map[string]swagger.Definition {
+	if spec.Components != nil && len(spec.Components.Schemas) > 0 {
+		return spec.Components.Schemas
+	}
+	return spec.Definitions
+}
+
+func propertyType(prop swagger.Property) string {
+	if prop.Ref != "" {
+		return swagger.ExtractRefName(prop.Ref)
+	}
+	if prop.Type == "array" && prop.Items != nil {
+		if prop.Items.Ref != "" {
+			return "array of " + swagger.ExtractRefName(prop.Items.Ref)
+		}
+		return "array of " + prop.Items.Type
+	}
+	if prop.Type == "" {
+		return "any"
+	}
+	return prop.Type
+}
+
+func requiredSuffix(required bool) string {
+	if required {
+		return " as required"
+	}
+	return ""
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+func sortedOperationKeys(ops map[operationKey]swagger.Operation) []operationKey {
+	keys := make([]operationKey, 0, len(ops))
+	for key := range ops {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].path != keys[j].path {
+			return keys[i].path < keys[j].path
+		}
+		return keys[i].method < keys[j].method
+	})
+	return keys
+}
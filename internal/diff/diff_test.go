@@ -0,0 +1,121 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func TestCompare(t *testing.T) {
+	old := &swagger.Spec{
+		Paths: map[string]swagger.PathItem{
+			"/users": {
+				"get": swagger.Operation{
+					OperationID: "listUsers",
+					Parameters:  []swagger.Parameter{{Name: "limit", In: "query"}},
+					Responses:   swagger.Responses{"200": {}, "404": {}},
+				},
+			},
+			"/users/{id}": {
+				"delete": swagger.Operation{OperationID: "deleteUser"},
+			},
+			"/pets": {
+				"get": swagger.Operation{OperationID: "listPets"},
+			},
+		},
+		Definitions: map[string]swagger.Definition{
+			"User": {Properties: map[string]swagger.Property{
+				"id":   {Type: "integer"},
+				"name": {Type: "string"},
+			}},
+		},
+	}
+	new := &swagger.Spec{
+		Paths: map[string]swagger.PathItem{
+			"/users": {
+				"get": swagger.Operation{
+					OperationID: "listUsers",
+					Parameters:  []swagger.Parameter{{Name: "limit", In: "query", Required: true}},
+					Responses:   swagger.Responses{"200": {}},
+				},
+			},
+			"/animals": {
+				"get": swagger.Operation{OperationID: "listPets"},
+			},
+			"/orders": {
+				"get": swagger.Operation{OperationID: "listOrders"},
+			},
+		},
+		Definitions: map[string]swagger.Definition{
+			"User": {Properties: map[string]swagger.Property{
+				"id":    {Type: "string"},
+				"email": {Type: "string"},
+			}},
+		},
+	}
+
+	tests := []struct {
+		kind     string
+		message  string
+		breaking bool
+	}{
+		{ParameterRequired, "GET /users: parameter limit (query) is now required", true},
+		{ResponseRemoved, "GET /users: response 404 was removed", true},
+		{EndpointRenamed, "GET /pets moved to GET /animals", true},
+		{EndpointRemoved, "DELETE /users/{id} was removed", true},
+		{EndpointAdded, "GET /orders was added", false},
+		{PropertyTypeChanged, "schema User: property id changed type from integer to string", true},
+		{PropertyRemoved, "schema User: property name was removed", true},
+		{PropertyAdded, "schema User: property email was added", false},
+	}
+
+	changes := Compare(old, new)
+	if len(changes) != len(tests) {
+		t.Fatalf("expected %d changes, got %d: %v", len(tests), len(changes), changes)
+	}
+	for i, tt := range tests {
+		got := changes[i]
+		if got.Kind != tt.kind || got.Message != tt.message || got.Breaking != tt.breaking {
+			t.Errorf("change %d = %+v, want kind %s message %q breaking %v", i, got, tt.kind, tt.message, tt.breaking)
+		}
+	}
+
+	if got := len(Breaking(changes)); got != 6 {
+		t.Errorf("Breaking() returned %d changes, want 6", got)
+	}
+}
+
+func TestReport_WriteText(t *testing.T) {
+	old := &swagger.Spec{
+		Info: swagger.Info{Title: "Pets", Version: "1.0"},
+		Paths: map[string]swagger.PathItem{
+			"/pets": {"get": swagger.Operation{}},
+		},
+	}
+	new := &swagger.Spec{
+		Info: swagger.Info{Title: "Pets", Version: "2.0"},
+		Paths: map[string]swagger.PathItem{
+			"/orders": {"get": swagger.Operation{}},
+		},
+	}
+
+	var sb strings.Builder
+	if err := NewReport(old, new).WriteText(&sb); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+
+	want := `Pets 1.0 -> 2.0
+
+Breaking changes (1):
+  - GET /pets was removed
+
+Other changes (1):
+  - GET /orders was added
+
+2 changes, 1 breaking
+`
+	if sb.String() != want {
+		t.Errorf("WriteText() =\n%s\nwant\n%s", sb.String(), want)
+	}
+}
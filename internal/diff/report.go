@@ -0,0 +1,85 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// SpecInfo identifies one side of a comparison
+type SpecInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Report is a comparison of two specifications
+type Report struct {
+	Old      SpecInfo `json:"old"`
+	New      SpecInfo `json:"new"`
+	Breaking int      `json:"breaking"`
+	Changes  []Change `json:"changes"`
+}
+
+// NewReport compares two specifications
+func NewReport(old, new *swagger.Spec) *Report {
+	changes := Compare(old, new)
+	if changes == nil {
+		changes = []Change{}
+	}
+
+	return &Report{
+		Old:      SpecInfo{Title: old.Info.Title, Version: old.Info.Version},
+		New:      SpecInfo{Title: new.Info.Title, Version: new.Info.Version},
+		Breaking: len(Breaking(changes)),
+		Changes:  changes,
+	}
+}
+
+// WriteText writes a human-readable report, breaking changes first
+func (r *Report) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "%s %s -> %s\n", r.New.Title, r.Old.Version, r.New.Version)
+
+	if len(r.Changes) == 0 {
+		_, err := fmt.Fprintln(w, "\nNo changes")
+		return err
+	}
+
+	sections := []struct {
+		title    string
+		breaking bool
+	}{
+		{"Breaking changes", true},
+		{"Other changes", false},
+	}
+	for _, section := range sections {
+		var lines []string
+		for _, change := range r.Changes {
+			if change.Breaking == section.breaking {
+				lines = append(lines, change.Message)
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(w, "\n%s (%d):\n", section.title, len(lines))
+		for _, line := range lines {
+			fmt.Fprintf(w, "  - %s\n", line)
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d changes, %d breaking\n", len(r.Changes), r.Breaking)
+	return err
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to encode diff report: %w", err)
+	}
+	return nil
+}
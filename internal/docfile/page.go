@@ -0,0 +1,265 @@
+package docfile
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/example"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+var tagPattern = regexp.MustCompile(`<[^>]+>`)
+
+// EndpointPage is the content of an endpoint page, resolved from the spec
+// and ready for a Format to lay out
+type EndpointPage struct {
+	Title  string
+	Method string
+	Path   string
+	// Summary is set when it adds to the title and description
+	Summary     string
+	Description string
+	OperationID string
+	Tags        []string
+	// Parameters lists the non-body parameters
+	Parameters []Parameter
+	// Request is nil when the operation takes no body
+	Request   *RequestBody
+	Responses []Response
+}
+
+// Parameter is a row of the parameters table
+type Parameter struct {
+	Name        string
+	In          string
+	Type        string
+	Format      string
+	Required    bool
+	Description string
+}
+
+// RequestBody is the request body section of an endpoint page
+type RequestBody struct {
+	ContentType string
+	// Schema is nil when the schema could not be resolved
+	Schema *SchemaTable
+	// Minimal is the example with only required fields, empty when it is
+	// the same as Full
+	Minimal string
+	Full    string
+}
+
+// Response is the section of one response code
+type Response struct {
+	Code        string
+	Description string
+	// Schema is nil when the response has no body
+	Schema  *SchemaTable
+	Example string
+}
+
+// SchemaTable describes a resolved schema: its properties, or the variants
+// of a oneOf/anyOf schema
+type SchemaTable struct {
+	// ArrayOf names the item type when the schema is an array
+	ArrayOf  string
+	Fields   []Field
+	Variants []VariantGroup
+}
+
+// Field is a row of a schema table
+type Field struct {
+	Name     string
+	Property swagger.Property
+	Required bool
+}
+
+// VariantGroup lists the variants of a oneOf or anyOf schema
+type VariantGroup struct {
+	// Label is "One of" or "Any of"
+	Label    string
+	Variants []Variant
+}
+
+// Variant is one schema of a VariantGroup. Type is empty for objects.
+type Variant struct {
+	Name string
+	Type string
+}
+
+// NewEndpointPage resolves the content of an endpoint page
+func NewEndpointPage(endpoint swagger.EndpointInfo, resolver *swagger.Resolver, gen *example.Generator) *EndpointPage {
+	gen = gen.ForOperation(endpoint.Operation)
+	op := endpoint.Operation
+	page := &EndpointPage{
+		Title:       endpoint.Title,
+		Method:      strings.ToUpper(endpoint.Method),
+		Path:        endpoint.Path,
+		Description: op.Description,
+		OperationID: op.OperationID,
+		Tags:        op.Tags,
+	}
+	if op.Summary != endpoint.Title && op.Summary != op.Description {
+		page.Summary = op.Summary
+	}
+
+	for _, param := range op.Parameters {
+		if param.In == "body" {
+			continue
+		}
+		paramType := param.Type
+		if paramType == "" && param.Schema != nil {
+			paramType = param.Schema.Type
+		}
+		page.Parameters = append(page.Parameters, Parameter{
+			Name:        param.Name,
+			In:          param.In,
+			Type:        paramType,
+			Format:      param.Format,
+			Required:    param.Required,
+			Description: param.Description,
+		})
+	}
+
+	if schema, contentType := RequestSchema(op); schema != nil {
+		page.Request = &RequestBody{ContentType: contentType}
+		if resolved, err := resolver.ResolveSchema(schema); err == nil && resolved != nil {
+			page.Request.Schema = NewSchemaTable(schema, resolved)
+			page.Request.Minimal, page.Request.Full = gen.GenerateRequestVariants(resolved)
+		}
+	}
+
+	for _, code := range op.ResponseCodes() {
+		response := op.Responses[code]
+		section := Response{Code: code, Description: response.Description}
+		schema := ResponseSchema(response)
+		if resolved, err := resolver.ResolveSchema(schema); err == nil && resolved != nil {
+			section.Schema = NewSchemaTable(schema, resolved)
+			if exampleJSON := gen.ForResponse().GenerateExampleJSON(resolved); exampleJSON != "null" {
+				section.Example = exampleJSON
+			}
+		}
+		page.Responses = append(page.Responses, section)
+	}
+
+	return page
+}
+
+// NewSchemaTable describes a resolved schema. raw is the schema before
+// resolution, which still names referenced array items.
+func NewSchemaTable(raw, schema *swagger.Schema) *SchemaTable {
+	table := &SchemaTable{}
+	if schema.Type == "array" && schema.Items != nil && len(schema.Properties) == 0 {
+		items := schema.Items
+		if raw.Items != nil {
+			items = raw.Items
+		}
+		table.ArrayOf = ItemName(items)
+		if len(schema.Items.Properties) > 0 {
+			schema = schema.Items
+		}
+	}
+
+	for _, name := range schema.PropertyNames() {
+		table.Fields = append(table.Fields, Field{
+			Name:     name,
+			Property: schema.Properties[name],
+			Required: slices.Contains(schema.Required, name),
+		})
+	}
+
+	for _, group := range []struct {
+		label    string
+		variants []*swagger.Schema
+	}{{"One of", schema.OneOf}, {"Any of", schema.AnyOf}} {
+		if len(group.variants) == 0 {
+			continue
+		}
+		variants := VariantGroup{Label: group.label}
+		for i, variant := range group.variants {
+			name := variant.Title
+			if name == "" {
+				name = fmt.Sprintf("Variant %d", i+1)
+			}
+			variantType := variant.Type
+			if variantType == "object" {
+				variantType = ""
+			}
+			variants.Variants = append(variants.Variants, Variant{Name: name, Type: variantType})
+		}
+		table.Variants = append(table.Variants, variants)
+	}
+
+	return table
+}
+
+// RequestSchema returns the request body schema of an operation and its
+// content type, preferring JSON
+func RequestSchema(op swagger.Operation) (*swagger.Schema, string) {
+	if op.RequestBody != nil {
+		if mediaType, ok := op.RequestBody.Content["application/json"]; ok {
+			return mediaType.Schema, "application/json"
+		}
+		contentTypes := make([]string, 0, len(op.RequestBody.Content))
+		for contentType := range op.RequestBody.Content {
+			contentTypes = append(contentTypes, contentType)
+		}
+		sort.Strings(contentTypes)
+		for _, contentType := range contentTypes {
+			return op.RequestBody.Content[contentType].Schema, contentType
+		}
+	}
+	for _, param := range op.Parameters {
+		if param.In == "body" {
+			return param.Schema, ""
+		}
+	}
+	return nil, ""
+}
+
+// ResponseSchema returns the schema of a response, preferring JSON content
+func ResponseSchema(response swagger.Response) *swagger.Schema {
+	if mediaType, ok := response.Content["application/json"]; ok && mediaType.Schema != nil {
+		return mediaType.Schema
+	}
+	contentTypes := make([]string, 0, len(response.Content))
+	for contentType := range response.Content {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+	for _, contentType := range contentTypes {
+		if schema := response.Content[contentType].Schema; schema != nil {
+			return schema
+		}
+	}
+	return response.Schema
+}
+
+// ItemName names the item type of an array
+func ItemName(items *swagger.Schema) string {
+	if items.Ref != "" {
+		return swagger.ExtractRefName(items.Ref)
+	}
+	if items.Title != "" {
+		return items.Title
+	}
+	if items.Type == "" {
+		return "object"
+	}
+	return items.Type
+}
+
+// StorageText reduces Confluence storage markup to plain text paragraphs
+// separated by blank lines
+func StorageText(content string) string {
+	var paragraphs []string
+	for _, line := range strings.Split(tagPattern.ReplaceAllString(content, ""), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			paragraphs = append(paragraphs, line)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n") + "\n"
+}
@@ -0,0 +1,65 @@
+package docfile
+
+import (
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/example"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func TestNewEndpointPage(t *testing.T) {
+	spec := &swagger.Spec{
+		Definitions: map[string]swagger.Definition{
+			"Pet": {
+				Type:          "object",
+				Required:      []string{"name"},
+				Properties:    map[string]swagger.Property{"name": {Type: "string"}, "age": {Type: "integer"}},
+				PropertyOrder: []string{"name", "age"},
+			},
+		},
+	}
+	endpoint := swagger.EndpointInfo{
+		Path:   "/pets",
+		Method: "post",
+		Title:  "Add Pet",
+		Operation: swagger.Operation{
+			Summary: "Add Pet",
+			Parameters: []swagger.Parameter{
+				{Name: "X-Trace", In: "header", Schema: &swagger.Schema{Type: "string"}},
+				{Name: "pet", In: "body", Schema: &swagger.Schema{Ref: "#/definitions/Pet"}},
+			},
+			Responses: swagger.Responses{
+				"200": {Description: "All pets", Schema: &swagger.Schema{Type: "array", Items: &swagger.Schema{Ref: "#/definitions/Pet"}}},
+				"204": {Description: "No content"},
+			},
+		},
+	}
+
+	page := NewEndpointPage(endpoint, swagger.NewResolver(spec), example.NewGenerator())
+
+	if page.Method != "POST" || page.Summary != "" {
+		t.Errorf("method/summary = %q/%q, want POST and no repeated summary", page.Method, page.Summary)
+	}
+	if len(page.Parameters) != 1 || page.Parameters[0].Type != "string" {
+		t.Errorf("parameters = %+v, want the header with its schema type", page.Parameters)
+	}
+	if page.Request == nil || page.Request.Schema == nil || len(page.Request.Schema.Fields) != 2 {
+		t.Fatalf("request = %+v, want the Pet fields", page.Request)
+	}
+	if field := page.Request.Schema.Fields[0]; field.Name != "name" || !field.Required {
+		t.Errorf("first field = %+v, want required name", field)
+	}
+	if page.Request.Full == "" {
+		t.Error("request has no example")
+	}
+
+	if len(page.Responses) != 2 {
+		t.Fatalf("responses = %+v", page.Responses)
+	}
+	if table := page.Responses[0].Schema; table == nil || table.ArrayOf != "Pet" || len(table.Fields) != 2 {
+		t.Errorf("200 schema = %+v, want an array of Pet with its fields", table)
+	}
+	if response := page.Responses[1]; response.Schema != nil || response.Example != "" {
+		t.Errorf("204 = %+v, want no body", response)
+	}
+}
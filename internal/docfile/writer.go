@@ -0,0 +1,262 @@
+// Package docfile writes API documentation as a directory of files, one per
+// page plus an index, in a format such as Markdown, AsciiDoc or HTML
+package docfile
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/internal/example"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// defaultTitle names the index until the API page is created
+const defaultTitle = "API Documentation"
+
+// Entry is a page listed on the index
+type Entry struct {
+	Title string
+	File  string
+	// Method and Path are set for endpoint pages
+	Method string
+	Path   string
+
+	content  string
+	document string
+}
+
+// Content returns the rendered content of the page, before Document wraps
+// it
+func (e Entry) Content() string {
+	return e.content
+}
+
+// Format renders pages in one markup language
+type Format interface {
+	// Ext is the extension of page files, including the dot
+	Ext() string
+	// Endpoint renders the content of an endpoint page
+	Endpoint(page *EndpointPage) string
+	// Page renders the content of a generic page from Confluence storage
+	// markup
+	Page(title, content string) string
+	// Document renders the file of entries[i] around its content. The
+	// neighbouring entries are there for formats that link them.
+	Document(title string, entries []Entry, i int) (string, error)
+	// Index renders the index file linking entries
+	Index(title string, entries []Entry) (string, error)
+}
+
+// Writer is a publisher that renders the parsed spec as files in a
+// directory, one per endpoint plus an index
+type Writer struct {
+	dir        string
+	format     Format
+	title      string
+	entries    []Entry
+	byTitle    map[string]int
+	exampleGen *example.Generator
+}
+
+// NewWriter creates a Writer for dir, creating the directory
+func NewWriter(dir string, format Format) (*Writer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	return &Writer{
+		dir:        dir,
+		format:     format,
+		byTitle:    make(map[string]int),
+		exampleGen: example.NewGenerator(),
+	}, nil
+}
+
+// IndexFile returns the name of the index file
+func (w *Writer) IndexFile() string {
+	return "index" + w.format.Ext()
+}
+
+// ResolveParentPage returns no parent; files are written flat
+func (w *Writer) ResolveParentPage(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+// CreateParentPage names the index after the API and writes it
+func (w *Writer) CreateParentPage(ctx context.Context, apiTitle string) (string, error) {
+	w.title = confluence.APIPageTitle(apiTitle)
+	if err := w.writeIndex(); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+// SetExampleGenerator makes endpoint pages use gen for example bodies
+func (w *Writer) SetExampleGenerator(gen *example.Generator) {
+	w.exampleGen = gen
+}
+
+// PublishEndpoint renders an endpoint and writes it
+func (w *Writer) PublishEndpoint(ctx context.Context, endpoint swagger.EndpointInfo, resolver *swagger.Resolver, parentPageID string) (string, error) {
+	entry := Entry{
+		Title:  endpoint.Title,
+		Method: strings.ToUpper(endpoint.Method),
+		Path:   endpoint.Path,
+	}
+	return w.writePage(entry, w.format.Endpoint(NewEndpointPage(endpoint, resolver, w.exampleGen)))
+}
+
+// CreateOrUpdatePage writes a generic page from Confluence storage markup
+func (w *Writer) CreateOrUpdatePage(ctx context.Context, title, content, parentPageID string) (string, error) {
+	return w.writePage(Entry{Title: title}, w.format.Page(title, content))
+}
+
+// PageURL returns the path of a written page
+func (w *Writer) PageURL(pageID string) string {
+	if pageID == "" {
+		return ""
+	}
+	return filepath.Join(w.dir, pageID)
+}
+
+// writePage writes a page and lists it on the index. The page before it is
+// rewritten when its file changes, for formats linking the next page. The
+// returned ID is the page's file name.
+func (w *Writer) writePage(entry Entry, content string) (string, error) {
+	entry.content = content
+	i, ok := w.byTitle[entry.Title]
+	if ok {
+		entry.File = w.entries[i].File
+		w.entries[i] = entry
+	} else {
+		entry.File = w.uniqueFile(Slug(entry.Title))
+		i = len(w.entries)
+		w.byTitle[entry.Title] = i
+		w.entries = append(w.entries, entry)
+	}
+
+	if err := w.writeFile(i); err != nil {
+		return "", err
+	}
+	if i > 0 {
+		if err := w.writeFile(i - 1); err != nil {
+			return "", err
+		}
+	}
+
+	// The index is rewritten after every page so a failed run still leaves
+	// a consistent directory
+	if err := w.writeIndex(); err != nil {
+		return "", err
+	}
+
+	fmt.Printf("✓ Wrote page: %s - %s\n", entry.Title, filepath.Join(w.dir, entry.File))
+	return entry.File, nil
+}
+
+// writeFile writes the file of entries[i], unless it is unchanged
+func (w *Writer) writeFile(i int) error {
+	document, err := w.format.Document(w.siteTitle(), w.entries, i)
+	if err != nil {
+		return err
+	}
+	entry := &w.entries[i]
+	if document == entry.document {
+		return nil
+	}
+
+	path := filepath.Join(w.dir, entry.File)
+	if err := os.WriteFile(path, []byte(document), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	entry.document = document
+	return nil
+}
+
+func (w *Writer) writeIndex() error {
+	content, err := w.format.Index(w.siteTitle(), w.entries)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(w.dir, w.IndexFile())
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// siteTitle returns the title of the index
+func (w *Writer) siteTitle() string {
+	if w.title == "" {
+		return defaultTitle
+	}
+	return w.title
+}
+
+// uniqueFile returns the file name for a slug, suffixed when another title
+// already produced it
+func (w *Writer) uniqueFile(base string) string {
+	taken := func(candidate string) bool {
+		if candidate == w.IndexFile() {
+			return true
+		}
+		for _, entry := range w.entries {
+			if entry.File == candidate {
+				return true
+			}
+		}
+		return false
+	}
+
+	candidate := base + w.format.Ext()
+	for n := 2; taken(candidate); n++ {
+		candidate = fmt.Sprintf("%s-%d%s", base, n, w.format.Ext())
+	}
+	return candidate
+}
+
+// Slug converts a title to a lower-case, dash-separated file name
+func Slug(title string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+			dash = false
+		} else if !dash && sb.Len() > 0 {
+			sb.WriteRune('-')
+			dash = true
+		}
+	}
+
+	result := strings.TrimSuffix(sb.String(), "-")
+	if result == "" {
+		return "page"
+	}
+	return result
+}
+
+// FileName turns a title into a portable file name, keeping its case and
+// spaces, or returns fallback when nothing of the title is left
+func FileName(title, fallback string) string {
+	var sb strings.Builder
+	for _, r := range title {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == ' ':
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune('_')
+		}
+	}
+
+	result := strings.TrimSpace(sb.String())
+	if result == "" {
+		return fallback
+	}
+	return result
+}
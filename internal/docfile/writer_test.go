@@ -0,0 +1,120 @@
+package docfile
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// textFormat renders pages as plain text, each linking the page after it
+type textFormat struct{}
+
+func (textFormat) Ext() string { return ".txt" }
+
+func (textFormat) Endpoint(page *EndpointPage) string {
+	return page.Method + " " + page.Path
+}
+
+func (textFormat) Page(title, content string) string {
+	return StorageText(content)
+}
+
+func (textFormat) Document(title string, entries []Entry, i int) (string, error) {
+	document := entries[i].Content()
+	if i < len(entries)-1 {
+		document += "\nnext: " + entries[i+1].File
+	}
+	return document, nil
+}
+
+func (textFormat) Index(title string, entries []Entry) (string, error) {
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		files = append(files, entry.File)
+	}
+	return fmt.Sprintf("%s: %s", title, strings.Join(files, ", ")), nil
+}
+
+func TestWriter(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWriter(dir, textFormat{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	resolver := swagger.NewResolver(&swagger.Spec{})
+
+	if _, err := w.CreateParentPage(ctx, "Pets"); err != nil {
+		t.Fatal(err)
+	}
+	steps := []struct {
+		title string
+		write func(title string) (string, error)
+		want  string
+	}{
+		{"Get Pet", func(title string) (string, error) {
+			return w.PublishEndpoint(ctx, swagger.EndpointInfo{Title: title, Method: "get", Path: "/pets/{id}"}, resolver, "")
+		}, "get-pet.txt"},
+		{"Get pet!", func(title string) (string, error) {
+			return w.CreateOrUpdatePage(ctx, title, "<p>Same slug</p>", "")
+		}, "get-pet-2.txt"},
+		{"Index", func(title string) (string, error) {
+			return w.CreateOrUpdatePage(ctx, title, "<p>Not the index</p>", "")
+		}, "index-2.txt"},
+		{"Get Pet", func(title string) (string, error) {
+			return w.PublishEndpoint(ctx, swagger.EndpointInfo{Title: title, Method: "put", Path: "/pets/{id}"}, resolver, "")
+		}, "get-pet.txt"},
+	}
+	for _, step := range steps {
+		file, err := step.write(step.title)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if file != step.want {
+			t.Errorf("write %q = %q, want %q", step.title, file, step.want)
+		}
+	}
+
+	read := func(file string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	if got, want := read("get-pet.txt"), "PUT /pets/{id}\nnext: get-pet-2.txt"; got != want {
+		t.Errorf("get-pet.txt = %q, want %q", got, want)
+	}
+	if got, want := read("get-pet-2.txt"), "Same slug\n\nnext: index-2.txt"; got != want {
+		t.Errorf("get-pet-2.txt = %q, want %q", got, want)
+	}
+	if got, want := read("index.txt"), "Pets - API Documentation: get-pet.txt, get-pet-2.txt, index-2.txt"; got != want {
+		t.Errorf("index.txt = %q, want %q", got, want)
+	}
+	if got := w.PageURL("get-pet.txt"); got != filepath.Join(dir, "get-pet.txt") {
+		t.Errorf("PageURL() = %q", got)
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Get Pet", "Get Pet"},
+		{"Pets/Store: Orders (v2)", "Pets_Store_ Orders _v2_"},
+		{" ", "page"},
+	}
+
+	for _, tt := range tests {
+		if got := FileName(tt.title, "page"); got != tt.want {
+			t.Errorf("FileName(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package example
+
+import (
+	"math"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// allowedValue returns the value a schema declares for examples to use: its
+// default, then its first enum value. ok is false when it declares neither.
+func allowedValue(def interface{}, enum []interface{}) (interface{}, bool) {
+	if def != nil {
+		return def, true
+	}
+	if len(enum) > 0 {
+		return enum[0], true
+	}
+	return nil, false
+}
+
+// numberExample returns zero moved into the range of a numeric property,
+// as an int for integers
+func numberExample(prop swagger.Property, integer bool) interface{} {
+	value := 0.0
+	if minimum := prop.Minimum; minimum != nil && (value < *minimum || (value == *minimum && prop.ExclusiveMinimum)) {
+		value = *minimum
+		if prop.ExclusiveMinimum {
+			value = aboveBound(*minimum, prop.Maximum, integer)
+		}
+	}
+	if maximum := prop.Maximum; maximum != nil && (value > *maximum || (value == *maximum && prop.ExclusiveMaximum)) {
+		value = *maximum
+		if prop.ExclusiveMaximum {
+			value = belowBound(*maximum, prop.Minimum, integer)
+		}
+	}
+	if integer {
+		value = math.Ceil(value)
+	}
+
+	if step := prop.MultipleOf; step != nil && *step > 0 {
+		multiple := math.Ceil(value / *step) * *step
+		if prop.Maximum != nil && multiple > *prop.Maximum {
+			multiple = math.Floor(value / *step) * *step
+		}
+		value = multiple
+	}
+
+	if integer {
+		return int(value)
+	}
+	return value
+}
+
+// aboveBound returns a value just above an exclusive minimum: the next
+// integer, or for numbers halfway to the maximum when there is one
+func aboveBound(minimum float64, maximum *float64, integer bool) float64 {
+	if integer {
+		return math.Floor(minimum) + 1
+	}
+	if maximum != nil {
+		return (minimum + *maximum) / 2
+	}
+	return minimum + 1
+}
+
+// belowBound returns a value just below an exclusive maximum
+func belowBound(maximum float64, minimum *float64, integer bool) float64 {
+	if integer {
+		return math.Ceil(maximum) - 1
+	}
+	if minimum != nil {
+		return (*minimum + maximum) / 2
+	}
+	return maximum - 1
+}
+
+// fitLength pads or cuts an example string to the property's length limits
+func fitLength(value string, prop swagger.Property) string {
+	runes := []rune(value)
+	if prop.MaxLength > 0 && len(runes) > prop.MaxLength {
+		return string(runes[:prop.MaxLength])
+	}
+	if len(runes) < prop.MinLength {
+		return value + strings.Repeat("x", prop.MinLength-len(runes))
+	}
+	return value
+}
+
+// patternExample returns a string matching a regular expression, or false
+// when the pattern is not one that can be generated
+func patternExample(pattern string) (string, bool) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+
+	var sb strings.Builder
+	if !writePattern(&sb, re.Simplify()) {
+		return "", false
+	}
+
+	// Constructs the generator can't satisfy, such as anchors in the
+	// middle, are caught by checking the result
+	value := sb.String()
+	if matched, err := regexp.MatchString(pattern, value); err != nil || !matched {
+		return "", false
+	}
+	return value, true
+}
+
+// writePattern writes a short, readable string matching re
+func writePattern(sb *strings.Builder, re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return true
+	case syntax.OpLiteral:
+		sb.WriteString(string(re.Rune))
+		return true
+	case syntax.OpCharClass:
+		r, ok := classRune(re.Rune)
+		if ok {
+			sb.WriteRune(r)
+		}
+		return ok
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		sb.WriteRune('a')
+		return true
+	case syntax.OpCapture:
+		return writePattern(sb, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !writePattern(sb, sub) {
+				return false
+			}
+		}
+		return true
+	case syntax.OpAlternate:
+		return writePattern(sb, re.Sub[0])
+	case syntax.OpQuest:
+		return true
+	case syntax.OpStar, syntax.OpPlus:
+		// One repetition reads better than none
+		return writePattern(sb, re.Sub[0])
+	case syntax.OpRepeat:
+		for range re.Min {
+			if !writePattern(sb, re.Sub[0]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// classRune picks a readable rune from a character class, given as pairs
+// of inclusive ranges: a lower-case letter, digit or upper-case letter when
+// the class has one, otherwise its first rune
+func classRune(ranges []rune) (rune, bool) {
+	if len(ranges) < 2 {
+		return 0, false
+	}
+	for _, candidate := range []rune{'a', '0', 'A'} {
+		for i := 0; i+1 < len(ranges); i += 2 {
+			if ranges[i] <= candidate && candidate <= ranges[i+1] {
+				return candidate, true
+			}
+		}
+	}
+	for i := 0; i+1 < len(ranges); i += 2 {
+		for r := ranges[i]; r <= ranges[i+1]; r++ {
+			if unicode.IsPrint(r) {
+				return r, true
+			}
+		}
+	}
+	return ranges[0], true
+}
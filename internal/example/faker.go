@@ -0,0 +1,85 @@
+package example
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+var (
+	firstNames = []string{"Alice", "Bruno", "Chloe", "Daniel", "Emma", "Farid", "Grace", "Hiro", "Isabel", "Jonas"}
+	lastNames  = []string{"Anderson", "Becker", "Costa", "Dubois", "Evans", "Fischer", "Garcia", "Huang", "Ivanova", "Jensen"}
+	companies  = []string{"Acme Corporation", "Globex Ltd", "Initech", "Northwind Traders", "Umbrella Systems", "Vandelay Industries"}
+	streets    = []string{"Maple Avenue", "Oak Street", "Harbor Road", "Station Lane", "Mill Street", "Park Boulevard"}
+	cities     = []string{"Amsterdam", "Berlin", "Chicago", "Dublin", "Lisbon", "Melbourne", "Toronto", "Zurich"}
+	countries  = []string{"Australia", "Canada", "Germany", "Ireland", "Netherlands", "Portugal", "Switzerland", "United States"}
+)
+
+// NewFakerGenerator creates a Generator that fills fields named like people,
+// companies, addresses and phone numbers with realistic data. Values are
+// picked by field name and the operation's seed (see ForOperation), so the
+// same spec always produces the same examples.
+func NewFakerGenerator() *Generator {
+	return &Generator{faker: true}
+}
+
+// ForOperation returns a generator seeded from an operation, by its
+// operationId or summary, so operations get different but stable values
+func (g *Generator) ForOperation(op swagger.Operation) *Generator {
+	if !g.faker {
+		return g
+	}
+	seeded := *g
+	seeded.seed = op.OperationID
+	if seeded.seed == "" {
+		seeded.seed = op.Summary
+	}
+	return &seeded
+}
+
+// fakeValue returns a realistic value for a field, or false when its name
+// suggests none
+func (g *Generator) fakeValue(fieldName string) (string, bool) {
+	name := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(fieldName))
+	pick := func(values []string) string {
+		return values[g.pick(fieldName, len(values))]
+	}
+	first, last := firstNames[g.pick(fieldName+"/first", len(firstNames))], lastNames[g.pick(fieldName+"/last", len(lastNames))]
+
+	switch {
+	case strings.Contains(name, "email"):
+		return strings.ToLower(first + "." + last + "@example.com"), true
+	case strings.Contains(name, "username") || strings.Contains(name, "login"):
+		return strings.ToLower(first + "." + last), true
+	case strings.Contains(name, "firstname") || strings.Contains(name, "givenname"):
+		return first, true
+	case strings.Contains(name, "lastname") || strings.Contains(name, "surname") || strings.Contains(name, "familyname"):
+		return last, true
+	case strings.Contains(name, "company") || strings.Contains(name, "organization") || strings.Contains(name, "employer"):
+		return pick(companies), true
+	case strings.Contains(name, "phone") || strings.Contains(name, "mobile") || name == "tel":
+		// 555-01xx numbers are reserved for fiction
+		return fmt.Sprintf("+1-202-555-01%02d", g.pick(fieldName, 100)), true
+	case strings.Contains(name, "street") ||
+		(strings.Contains(name, "address") && !strings.Contains(name, "ipaddress") && !strings.Contains(name, "macaddress")):
+		return fmt.Sprintf("%d %s", 1+g.pick(fieldName+"/number", 999), pick(streets)), true
+	case strings.Contains(name, "city"):
+		return pick(cities), true
+	case strings.Contains(name, "country"):
+		return pick(countries), true
+	case strings.Contains(name, "zip") || strings.Contains(name, "postal"):
+		return fmt.Sprintf("%05d", 10000+g.pick(fieldName, 90000)), true
+	case name == "name" || strings.HasSuffix(name, "fullname") || strings.HasSuffix(name, "displayname"):
+		return first + " " + last, true
+	}
+	return "", false
+}
+
+// pick returns a number below n derived from the seed and key
+func (g *Generator) pick(key string, n int) int {
+	h := fnv.New32a()
+	h.Write([]byte(g.seed + "\x00" + key))
+	return int(h.Sum32() % uint32(n))
+}
@@ -0,0 +1,71 @@
+package example
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func TestFakerGenerator(t *testing.T) {
+	schema := &swagger.Schema{
+		Type: "object",
+		Properties: map[string]swagger.Property{
+			"first_name":   {Type: "string"},
+			"email":        {Type: "string", Format: "email"},
+			"phone":        {Type: "string"},
+			"company":      {Type: "string"},
+			"city":         {Type: "string"},
+			"ipAddress":    {Type: "string", Format: "ipv4"},
+			"billingNotes": {Type: "string"},
+		},
+	}
+	op := swagger.Operation{OperationID: "createCustomer"}
+
+	gen := NewFakerGenerator().ForOperation(op)
+	got, ok := gen.buildExample(schema, nil).(map[string]interface{})
+	if !ok {
+		t.Fatal("expected an object example")
+	}
+
+	patterns := map[string]string{
+		"first_name":   `^[A-Z][a-z]+$`,
+		"email":        `^[a-z]+\.[a-z]+@example\.com$`,
+		"phone":        `^\+1-202-555-01\d\d$`,
+		"company":      `^[A-Z]`,
+		"city":         `^[A-Z][a-z]+$`,
+		"ipAddress":    `^192\.0\.2\.1$`,
+		"billingNotes": `^string$`,
+	}
+	for field, pattern := range patterns {
+		value, _ := got[field].(string)
+		if !regexp.MustCompile(pattern).MatchString(value) {
+			t.Errorf("%s = %q, want a match for %s", field, value, pattern)
+		}
+	}
+
+	// The same operation always gets the same values
+	for i := 0; i < 3; i++ {
+		if again := NewFakerGenerator().ForOperation(op).GenerateExampleJSON(schema); again != gen.GenerateExampleJSON(schema) {
+			t.Fatalf("examples differ between runs:\n%s\n%s", again, gen.GenerateExampleJSON(schema))
+		}
+	}
+}
+
+func TestFakerGenerator_Seeds(t *testing.T) {
+	schema := &swagger.Schema{Type: "object", Properties: map[string]swagger.Property{
+		"name": {Type: "string"}, "street": {Type: "string"}, "phone": {Type: "string"},
+	}}
+
+	seen := make(map[string]bool)
+	for _, id := range []string{"getUser", "createUser", "updateUser", "listUsers"} {
+		seen[NewFakerGenerator().ForOperation(swagger.Operation{OperationID: id}).GenerateExampleJSON(schema)] = true
+	}
+	if len(seen) < 2 {
+		t.Error("expected different operations to get different values")
+	}
+
+	if gen := NewGenerator(); gen.ForOperation(swagger.Operation{OperationID: "getUser"}) != gen {
+		t.Error("expected ForOperation to leave a plain generator alone")
+	}
+}
@@ -0,0 +1,135 @@
+package example
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// Content types whose bodies are form fields rather than JSON
+const (
+	ContentTypeForm      = "application/x-www-form-urlencoded"
+	ContentTypeMultipart = "multipart/form-data"
+)
+
+// FilePlaceholder stands in for the path of a file uploaded by an example
+const FilePlaceholder = "path/to/file"
+
+// FormField is one field of an example form body. The value of a file
+// field is the path of the file to upload.
+type FormField struct {
+	Name  string
+	Value string
+	File  bool
+}
+
+// IsForm reports whether a content type carries form fields
+func IsForm(contentType string) bool {
+	mediaType := baseMediaType(contentType)
+	return mediaType == ContentTypeForm || mediaType == ContentTypeMultipart
+}
+
+// IsMultipart reports whether a content type is multipart/form-data, whose
+// example bodies have no text form
+func IsMultipart(contentType string) bool {
+	return baseMediaType(contentType) == ContentTypeMultipart
+}
+
+// baseMediaType returns a content type without its parameters
+func baseMediaType(contentType string) string {
+	mediaType, _, _ := strings.Cut(strings.ToLower(contentType), ";")
+	return strings.TrimSpace(mediaType)
+}
+
+// GenerateFormExample returns the content type and example fields of an
+// operation whose request is a form: an OpenAPI 3.x form body or Swagger
+// 2.0 formData parameters. The fields are nil for other operations.
+func (g *Generator) GenerateFormExample(op swagger.Operation, resolver *swagger.Resolver) (string, []FormField) {
+	g = g.ForOperation(op).ForRequest()
+	if op.RequestBody != nil && len(op.RequestBody.Content) > 0 {
+		contentType := preferredContentType(op.RequestBody.Content)
+		if !IsForm(contentType) {
+			return contentType, nil
+		}
+		resolved, err := resolver.ResolveSchema(op.RequestBody.Content[contentType].Schema)
+		if err != nil || resolved == nil {
+			return contentType, nil
+		}
+		return contentType, g.formFields(resolved)
+	}
+
+	var fields []FormField
+	hasFile := false
+	for _, param := range op.Parameters {
+		if param.In != "formData" {
+			continue
+		}
+		field := FormField{Name: param.Name, Value: FilePlaceholder, File: param.IsFile()}
+		if !field.File {
+			field.Value = formValue(g.buildPropertyExample(param.Name, swagger.Property{
+				Type: param.Type, Format: param.Format, Example: param.Example,
+				Enum: param.Enum, Default: param.Default,
+			}, nil))
+		}
+		hasFile = hasFile || field.File
+		fields = append(fields, field)
+	}
+	if fields == nil {
+		return "", nil
+	}
+
+	contentType := ContentTypeForm
+	if hasFile {
+		contentType = ContentTypeMultipart
+	}
+	for _, consumes := range op.Consumes {
+		if IsForm(consumes) {
+			contentType = consumes
+			break
+		}
+	}
+	return contentType, fields
+}
+
+// formFields returns example fields for the properties of a form schema
+func (g *Generator) formFields(schema *swagger.Schema) []FormField {
+	fields := make([]FormField, 0, len(schema.Properties))
+	for _, name := range schema.PropertyNames() {
+		prop := schema.Properties[name]
+		if g.skips(name, prop, schema.Required) {
+			continue
+		}
+		if prop.IsFile() {
+			fields = append(fields, FormField{Name: name, Value: FilePlaceholder, File: true})
+			continue
+		}
+		fields = append(fields, FormField{Name: name, Value: formValue(g.buildPropertyExample(name, prop, nil))})
+	}
+	return fields
+}
+
+// formValue formats an example value as a form field: strings as is,
+// anything else as JSON
+func formValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// EncodeForm encodes fields as an application/x-www-form-urlencoded body,
+// in field order
+func EncodeForm(fields []FormField) string {
+	pairs := make([]string, 0, len(fields))
+	for _, field := range fields {
+		pairs = append(pairs, url.QueryEscape(field.Name)+"="+url.QueryEscape(field.Value))
+	}
+	return strings.Join(pairs, "&")
+}
@@ -1,39 +1,192 @@
 package example
 
 import (
+	"bytes"
 	"encoding/json"
-	"fmt"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/ahmadimt/SwagFluence/internal/swagger"
 )
 
 // Generator generates example JSON from schemas
-type Generator struct{}
+type Generator struct {
+	// faker fills fields with realistic data picked by seed
+	faker bool
+	seed  string
+	// direction leaves out readOnly or writeOnly properties; zero keeps
+	// every property
+	direction direction
+	// maxDepth and arrayItems cap the size of examples; zero uses the
+	// defaults
+	maxDepth   int
+	arrayItems int
+	// minimal leaves out properties that aren't required
+	minimal bool
+}
+
+const (
+	// DefaultMaxDepth is how many levels of nested schemas an example
+	// shows below the top-level schema
+	DefaultMaxDepth = 10
+	// DefaultArrayItems is how many items example arrays hold
+	DefaultArrayItems = 1
+)
+
+// WithLimits returns a generator that shows at most maxDepth levels of
+// nesting, replacing deeper schemas with a placeholder, and arrayItems
+// items per array. Values of zero or less keep the defaults.
+func (g *Generator) WithLimits(maxDepth, arrayItems int) *Generator {
+	limited := *g
+	limited.maxDepth = maxDepth
+	limited.arrayItems = arrayItems
+	return &limited
+}
+
+// depthLimit returns the configured or default maximum depth
+func (g *Generator) depthLimit() int {
+	if g.maxDepth > 0 {
+		return g.maxDepth
+	}
+	return DefaultMaxDepth
+}
+
+// direction is the way the example travels
+type direction int
+
+const (
+	anyDirection direction = iota
+	request
+	response
+)
+
+// ForRequest returns a generator for request bodies, which leave out
+// readOnly properties such as server-assigned IDs
+func (g *Generator) ForRequest() *Generator {
+	directed := *g
+	directed.direction = request
+	return &directed
+}
+
+// ForResponse returns a generator for response bodies, which leave out
+// writeOnly properties such as passwords
+func (g *Generator) ForResponse() *Generator {
+	directed := *g
+	directed.direction = response
+	return &directed
+}
+
+// Minimal returns a generator whose objects hold only their required
+// properties, showing the smallest valid payload
+func (g *Generator) Minimal() *Generator {
+	minimal := *g
+	minimal.minimal = true
+	return &minimal
+}
+
+// skips reports whether a property is left out of examples in the
+// generator's direction, or by a minimal generator
+func (g *Generator) skips(name string, prop swagger.Property, required []string) bool {
+	if g.minimal && !slices.Contains(required, name) {
+		return true
+	}
+	return (g.direction == request && prop.ReadOnly) || (g.direction == response && prop.WriteOnly)
+}
 
 // NewGenerator creates a new Generator
 func NewGenerator() *Generator {
 	return &Generator{}
 }
 
+// bufferPool recycles the encode buffers of GenerateExampleJSON, which runs
+// several times for every endpoint page
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
 // GenerateExampleJSON generates example JSON from a schema
 func (g *Generator) GenerateExampleJSON(schema *swagger.Schema) string {
-	example := g.buildExample(schema, 0)
-	bytes, _ := json.MarshalIndent(example, "", "  ")
-	return string(bytes)
+	example := g.buildExample(schema, nil)
+
+	buf := bufferPool.Get().(*bytes.Buffer)
+	defer func() {
+		buf.Reset()
+		bufferPool.Put(buf)
+	}()
+
+	// Encoder output matches json.MarshalIndent plus a trailing newline
+	enc := json.NewEncoder(buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(example); err != nil {
+		return ""
+	}
+	return string(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+}
+
+// formatValues are the example values of the standard string formats.
+// Passwords are masked, and binary data is a placeholder since it has no
+// text form.
+var formatValues = map[string]string{
+	"date":          "2024-01-15",
+	"date-time":     "2024-01-15T10:30:00Z",
+	"time":          "10:30:00Z",
+	"email":         "user@example.com",
+	"uuid":          "123e4567-e89b-12d3-a456-426614174000",
+	"uri":           "https://example.com/resource",
+	"url":           "https://example.com/resource",
+	"uri-reference": "/resource",
+	"hostname":      "api.example.com",
+	"ipv4":          "192.0.2.1",
+	"ipv6":          "2001:db8::1",
+	"byte":          "U3dhZ0ZsdWVuY2U=",
+	"binary":        "<binary>",
+	"password":      "********",
 }
 
-// buildExample recursively builds an example object from a schema
-func (g *Generator) buildExample(schema *swagger.Schema, depth int) interface{} {
-	if schema == nil || depth > 10 { // Prevent infinite recursion
+// buildExample recursively builds an example object from a schema. path
+// holds the enclosing schemas; a schema that is already on it, or one the
+// resolver marked recursive, is shown as a placeholder. So are objects and
+// arrays nested deeper than the depth limit.
+func (g *Generator) buildExample(schema *swagger.Schema, path []*swagger.Schema) interface{} {
+	if schema == nil {
 		return nil
 	}
+	if schema.Recursive || slices.Contains(path, schema) {
+		return recursivePlaceholder(schema)
+	}
+	if len(path) > g.depthLimit() && schema.Example == nil && (schema.Type == "object" || schema.Type == "array" || len(schema.Properties) > 0) {
+		return truncatedPlaceholder(schema)
+	}
+	path = append(path, schema)
+
+	// Values given in the spec beat generated ones
+	if schema.Example != nil {
+		return schema.Example
+	}
+
+	// Variants without shared properties are illustrated by the first one
+	if len(schema.Properties) == 0 {
+		if len(schema.OneOf) > 0 {
+			return g.buildExample(schema.OneOf[0], path)
+		}
+		if len(schema.AnyOf) > 0 {
+			return g.buildExample(schema.AnyOf[0], path)
+		}
+	}
+
+	// Scalars show the value the schema declares
+	if schema.Type != "object" && schema.Type != "array" {
+		if value, ok := allowedValue(schema.Default, schema.Enum); ok {
+			return value
+		}
+	}
 
 	switch schema.Type {
 	case "object":
-		return g.buildObjectExample(schema, depth)
+		return g.buildObjectExample(schema, path)
 	case "array":
-		return g.buildArrayExample(schema, depth)
+		return g.buildArrayExample(schema, path)
 	case "string":
 		return g.buildStringExample(schema)
 	case "integer":
@@ -47,64 +200,110 @@ func (g *Generator) buildExample(schema *swagger.Schema, depth int) interface{}
 	}
 }
 
-func (g *Generator) buildObjectExample(schema *swagger.Schema, depth int) map[string]interface{} {
-	obj := make(map[string]interface{})
+// recursivePlaceholder stands in for a schema that contains itself
+func recursivePlaceholder(schema *swagger.Schema) string {
+	name := schema.Title
+	if schema.Ref != "" {
+		name = swagger.ExtractRefName(schema.Ref)
+	}
+	if name == "" {
+		return "<recursive reference>"
+	}
+	return "<recursive reference: " + name + ">"
+}
+
+// truncatedPlaceholder stands in for a schema nested deeper than the depth
+// limit
+func truncatedPlaceholder(schema *swagger.Schema) string {
+	name := schema.Title
+	if schema.Ref != "" {
+		name = swagger.ExtractRefName(schema.Ref)
+	}
+	if name == "" {
+		return "<truncated>"
+	}
+	return "<truncated: " + name + ">"
+}
+
+func (g *Generator) buildObjectExample(schema *swagger.Schema, path []*swagger.Schema) map[string]interface{} {
+	obj := make(map[string]interface{}, len(schema.Properties))
 
 	if schema.Properties != nil {
 		for name, prop := range schema.Properties {
-			obj[name] = g.buildPropertyExample(name, prop, depth+1)
+			if g.skips(name, prop, schema.Required) {
+				continue
+			}
+			obj[name] = g.buildPropertyExample(name, prop, path)
 		}
 	}
 
 	return obj
 }
 
-func (g *Generator) buildArrayExample(schema *swagger.Schema, depth int) []interface{} {
+func (g *Generator) buildArrayExample(schema *swagger.Schema, path []*swagger.Schema) []interface{} {
 	if schema.Items == nil {
 		return []interface{}{}
 	}
 
-	itemExample := g.buildExample(schema.Items, depth+1)
-	return []interface{}{itemExample}
+	return g.repeatItem(g.buildExample(schema.Items, path), 0, 0)
 }
 
-func (g *Generator) buildStringExample(schema *swagger.Schema) string {
-	// Use example if available
-	if schema.Format == "date" {
-		return "2024-01-15"
+// repeatItem returns an example array holding the item as many times as
+// configured, within the minItems and maxItems of the array when given
+func (g *Generator) repeatItem(item interface{}, minItems, maxItems int) []interface{} {
+	count := DefaultArrayItems
+	if g.arrayItems > 0 {
+		count = g.arrayItems
 	}
-	if schema.Format == "date-time" {
-		return "2024-01-15T10:30:00Z"
+	if count < minItems {
+		count = minItems
 	}
-	if schema.Format == "email" {
-		return "user@example.com"
+	if maxItems > 0 && count > maxItems {
+		count = maxItems
+	}
+
+	items := make([]interface{}, count)
+	for i := range items {
+		items[i] = item
+	}
+	return items
+}
+
+func (g *Generator) buildStringExample(schema *swagger.Schema) string {
+	if value, ok := formatValues[schema.Format]; ok {
+		return value
 	}
 	return "string"
 }
 
-func (g *Generator) buildPropertyExample(fieldName string, prop swagger.Property, depth int) interface{} {
+func (g *Generator) buildPropertyExample(fieldName string, prop swagger.Property, path []*swagger.Schema) interface{} {
 	// Use explicit example if available
 	if prop.Example != nil {
 		return prop.Example
 	}
 
+	if value, ok := allowedValue(prop.Default, prop.Enum); ok {
+		return value
+	}
+
 	// Handle references
 	if prop.Ref != "" {
-		return fmt.Sprintf("<%s>", swagger.ExtractRefName(prop.Ref))
+		return "<" + swagger.ExtractRefName(prop.Ref) + ">"
 	}
 
 	// Handle arrays
 	if prop.Type == "array" && prop.Items != nil {
-		itemExample := g.buildExample(prop.Items, depth+1)
-		return []interface{}{itemExample}
+		return g.repeatItem(g.buildExample(prop.Items, path), prop.MinItems, prop.MaxItems)
 	}
 
 	// Generate default values based on type and field name
 	switch prop.Type {
 	case "string":
 		return g.generateStringValue(fieldName, prop)
-	case "integer", "number":
-		return 0
+	case "integer":
+		return numberExample(prop, true)
+	case "number":
+		return numberExample(prop, false)
 	case "boolean":
 		return false
 	case "object":
@@ -114,20 +313,37 @@ func (g *Generator) buildPropertyExample(fieldName string, prop swagger.Property
 	}
 }
 
+// generateStringValue returns a string matching the property's pattern
+// when one can be generated, otherwise a value suggested by its format or
+// name, fitted to its length limits
 func (g *Generator) generateStringValue(fieldName string, prop swagger.Property) string {
+	if prop.Pattern != "" {
+		if value, ok := patternExample(prop.Pattern); ok {
+			return value
+		}
+	}
+	return fitLength(g.suggestStringValue(fieldName, prop), prop)
+}
+
+// suggestStringValue returns a string suggested by a property's format or
+// name
+func (g *Generator) suggestStringValue(fieldName string, prop swagger.Property) string {
 	fieldLower := strings.ToLower(fieldName)
 
-	if prop.Format == "date" {
-		return "2024-01-15"
+	// Realistic values also replace the format's fixed email address
+	if g.faker && (prop.Format == "" || prop.Format == "email") {
+		if value, ok := g.fakeValue(fieldName); ok {
+			return value
+		}
 	}
-	if prop.Format == "date-time" {
-		return "2024-01-15T10:30:00Z"
+	if value, ok := formatValues[prop.Format]; ok {
+		return value
 	}
-	if prop.Format == "email" || strings.Contains(fieldLower, "email") {
+	if strings.Contains(fieldLower, "email") {
 		return "user@example.com"
 	}
 	if strings.Contains(fieldLower, "name") {
-		return fmt.Sprintf("Sample %s", fieldName)
+		return "Sample " + fieldName
 	}
 	if strings.Contains(fieldLower, "id") {
 		return "123e4567-e89b-12d3-a456-426614174000"
@@ -2,6 +2,9 @@ package example
 
 import (
 	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/ahmadimt/SwagFluence/internal/swagger"
@@ -56,7 +59,7 @@ func TestGenerator_BuildArrayExample(t *testing.T) {
 	}
 
 	gen := NewGenerator()
-	result := gen.buildExample(schema, 0)
+	result := gen.buildExample(schema, nil)
 
 	arr, ok := result.([]interface{})
 	if !ok {
@@ -67,3 +70,282 @@ func TestGenerator_BuildArrayExample(t *testing.T) {
 		t.Errorf("expected 1 item in array, got %d", len(arr))
 	}
 }
+
+func TestGenerator_GenerateExampleJSONMatchesMarshalIndent(t *testing.T) {
+	schema := &swagger.Schema{
+		Type: "object",
+		Properties: map[string]swagger.Property{
+			"owner": {Ref: "#/definitions/User"},
+			"email": {Type: "string"},
+			"tags":  {Type: "array", Items: &swagger.Schema{Type: "string"}},
+		},
+	}
+
+	gen := NewGenerator()
+	want, err := json.MarshalIndent(gen.buildExample(schema, nil), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := gen.GenerateExampleJSON(schema); got != string(want) {
+		t.Errorf("GenerateExampleJSON() = %q, want %q", got, want)
+	}
+}
+
+func TestGenerator_BuildVariantExample(t *testing.T) {
+	schema := &swagger.Schema{
+		OneOf: []*swagger.Schema{
+			{Type: "object", Properties: map[string]swagger.Property{"number": {Type: "string"}}},
+			{Type: "integer"},
+		},
+	}
+
+	result, ok := NewGenerator().buildExample(schema, nil).(map[string]interface{})
+	if !ok {
+		t.Fatal("expected the first variant's object example")
+	}
+	if _, ok := result["number"]; !ok {
+		t.Error("expected 'number' field in variant example")
+	}
+}
+
+func BenchmarkGenerator_GenerateExampleJSON(b *testing.B) {
+	props := make(map[string]swagger.Property, 100)
+	for i := 0; i < 100; i++ {
+		props[fmt.Sprintf("name%d", i)] = swagger.Property{Type: "string"}
+		props[fmt.Sprintf("count%d", i)] = swagger.Property{Type: "integer"}
+		props[fmt.Sprintf("tags%d", i)] = swagger.Property{Type: "array", Items: &swagger.Schema{Type: "string"}}
+	}
+	schema := &swagger.Schema{Type: "object", Properties: props}
+
+	gen := NewGenerator()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		gen.GenerateExampleJSON(schema)
+	}
+}
+
+func TestGenerator_RecursiveSchemas(t *testing.T) {
+	category := &swagger.Schema{Type: "object"}
+	category.Properties = map[string]swagger.Property{
+		"name":     {Type: "string"},
+		"children": {Type: "array", Items: category},
+	}
+
+	tests := []struct {
+		name   string
+		schema *swagger.Schema
+		want   interface{}
+	}{
+		{
+			name:   "self reference",
+			schema: category,
+			want: map[string]interface{}{
+				"name":     "Sample name",
+				"children": []interface{}{"<recursive reference>"},
+			},
+		},
+		{
+			name: "resolver placeholder",
+			schema: &swagger.Schema{
+				Type:  "array",
+				Items: &swagger.Schema{Ref: "#/definitions/Category", Type: "object", Recursive: true},
+			},
+			want: []interface{}{"<recursive reference: Category>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewGenerator().buildExample(tt.schema, nil)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerator_HonorsConstraints(t *testing.T) {
+	bound := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name string
+		prop swagger.Property
+		want interface{}
+	}{
+		{name: "enum", prop: swagger.Property{Type: "string", Enum: []interface{}{"available", "sold"}}, want: "available"},
+		{name: "default", prop: swagger.Property{Type: "integer", Default: 20.0, Enum: []interface{}{10.0, 20.0}}, want: 20.0},
+		{name: "example wins", prop: swagger.Property{Type: "string", Example: "dog", Default: "cat"}, want: "dog"},
+		{name: "minimum", prop: swagger.Property{Type: "integer", Minimum: bound(1)}, want: 1},
+		{name: "exclusive minimum", prop: swagger.Property{Type: "integer", Minimum: bound(0), ExclusiveMinimum: true}, want: 1},
+		{name: "maximum", prop: swagger.Property{Type: "integer", Maximum: bound(-5)}, want: -5},
+		{name: "number range", prop: swagger.Property{Type: "number", Minimum: bound(0.5), Maximum: bound(1)}, want: 0.5},
+		{
+			name: "exclusive number range",
+			prop: swagger.Property{Type: "number", Minimum: bound(0), Maximum: bound(1), ExclusiveMinimum: true},
+			want: 0.5,
+		},
+		{name: "multiple of", prop: swagger.Property{Type: "integer", Minimum: bound(7), MultipleOf: bound(5)}, want: 10},
+		{name: "pattern", prop: swagger.Property{Type: "string", Pattern: `^[A-Z]{3}-\d{4}$`}, want: "AAA-0000"},
+		{name: "unsupported pattern", prop: swagger.Property{Type: "string", Pattern: `^(?:a)\1$`}, want: "string"},
+		{name: "max length", prop: swagger.Property{Type: "string", MaxLength: 3}, want: "str"},
+		{name: "min length", prop: swagger.Property{Type: "string", MinLength: 8}, want: "stringxx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewGenerator().buildPropertyExample("value", tt.prop, nil); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerator_ScalarSchemaValues(t *testing.T) {
+	schema := &swagger.Schema{Type: "array", Items: &swagger.Schema{Type: "string", Enum: []interface{}{"red", "green"}}}
+	if got := NewGenerator().GenerateExampleJSON(schema); got != "[\n  \"red\"\n]" {
+		t.Errorf("GenerateExampleJSON() = %q, want the first enum value", got)
+	}
+}
+
+func TestPatternExample(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{`^[a-z0-9-]+$`, "a"},
+		{`^\+?[1-9]\d{1,14}$`, "10"},
+		{`^(GET|POST)$`, "GET"},
+		{`^#[0-9A-Fa-f]{6}$`, "#aaaaaa"},
+		{`^v\d+\.\d+$`, "v0.0"},
+	}
+
+	for _, tt := range tests {
+		got, ok := patternExample(tt.pattern)
+		if !ok || got != tt.want {
+			t.Errorf("patternExample(%q) = %q, %v, want %q", tt.pattern, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := patternExample(`[`); ok {
+		t.Error("expected an invalid pattern to be rejected")
+	}
+}
+
+func TestGenerator_FormatValues(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"uuid", "123e4567-e89b-12d3-a456-426614174000"},
+		{"uri", "https://example.com/resource"},
+		{"hostname", "api.example.com"},
+		{"ipv4", "192.0.2.1"},
+		{"ipv6", "2001:db8::1"},
+		{"byte", "U3dhZ0ZsdWVuY2U="},
+		{"binary", "<binary>"},
+		{"password", "********"},
+		{"unknown", "string"},
+	}
+
+	gen := NewGenerator()
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := gen.buildPropertyExample("value", swagger.Property{Type: "string", Format: tt.format}, nil); got != tt.want {
+				t.Errorf("property example = %v, want %q", got, tt.want)
+			}
+			if got := gen.buildExample(&swagger.Schema{Type: "string", Format: tt.format}, nil); got != tt.want {
+				t.Errorf("schema example = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerator_SchemaExample(t *testing.T) {
+	spec := &swagger.Spec{Definitions: map[string]swagger.Definition{
+		"Money": {Type: "object", Example: map[string]interface{}{"amount": 10, "currency": "EUR"}, Properties: map[string]swagger.Property{
+			"amount": {Type: "number"}, "currency": {Type: "string"},
+		}},
+	}}
+	resolved, err := swagger.NewResolver(spec).ResolveSchema(&swagger.Schema{Ref: "#/definitions/Money"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "{\n  \"amount\": 10,\n  \"currency\": \"EUR\"\n}"
+	if got := NewGenerator().GenerateExampleJSON(resolved); got != want {
+		t.Errorf("GenerateExampleJSON() = %s, want the schema's example", got)
+	}
+}
+
+func TestGenerator_WithLimits(t *testing.T) {
+	address := &swagger.Schema{Type: "object", Title: "Address", Properties: map[string]swagger.Property{"city": {Type: "string"}}}
+	customer := &swagger.Schema{Type: "object", Properties: map[string]swagger.Property{
+		"tags":      {Type: "array", Items: &swagger.Schema{Type: "string"}},
+		"codes":     {Type: "array", Items: &swagger.Schema{Type: "integer"}, MaxItems: 2},
+		"addresses": {Type: "array", Items: address},
+	}}
+
+	tests := []struct {
+		name   string
+		gen    *Generator
+		schema *swagger.Schema
+		want   interface{}
+	}{
+		{
+			name:   "defaults",
+			gen:    NewGenerator().WithLimits(0, 0),
+			schema: customer,
+			want: map[string]interface{}{
+				"tags":      []interface{}{"string"},
+				"codes":     []interface{}{0},
+				"addresses": []interface{}{map[string]interface{}{"city": "string"}},
+			},
+		},
+		{
+			name:   "array items within maxItems",
+			gen:    NewGenerator().WithLimits(0, 3),
+			schema: &swagger.Schema{Type: "object", Properties: map[string]swagger.Property{"tags": customer.Properties["tags"], "codes": customer.Properties["codes"]}},
+			want: map[string]interface{}{
+				"tags":  []interface{}{"string", "string", "string"},
+				"codes": []interface{}{0, 0},
+			},
+		},
+		{
+			name:   "truncated depth",
+			gen:    NewGenerator().WithLimits(1, 0),
+			schema: &swagger.Schema{Type: "array", Items: &swagger.Schema{Type: "array", Items: address}},
+			want:   []interface{}{[]interface{}{"<truncated: Address>"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.gen.buildExample(tt.schema, nil); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateRequestVariants(t *testing.T) {
+	schema := &swagger.Schema{Type: "object", Required: []string{"name", "owner"}, Properties: map[string]swagger.Property{
+		"id":    {Type: "integer", ReadOnly: true},
+		"name":  {Type: "string"},
+		"tag":   {Type: "string"},
+		"owner": {Type: "object"},
+	}}
+
+	minimal, full := NewGenerator().GenerateRequestVariants(schema)
+	if want := "{\n  \"name\": \"Sample name\",\n  \"owner\": {}\n}"; minimal != want {
+		t.Errorf("minimal = %s, want %s", minimal, want)
+	}
+	if !strings.Contains(full, `"tag"`) || strings.Contains(full, `"id"`) {
+		t.Errorf("full = %s, want every writable field", full)
+	}
+
+	schema.Required = []string{"name", "owner", "tag"}
+	if minimal, _ := NewGenerator().GenerateRequestVariants(schema); minimal != "" {
+		t.Errorf("minimal = %s, want none when every field is required", minimal)
+	}
+}
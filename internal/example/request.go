@@ -0,0 +1,93 @@
+package example
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// GenerateRequestExample returns the content type and example body of an
+// operation's request, preferring a documented example over a generated
+// one. Both are "" when the operation takes no body. Form requests get an
+// encoded body, or none when multipart; see GenerateFormExample.
+func (g *Generator) GenerateRequestExample(op swagger.Operation, resolver *swagger.Resolver) (string, string) {
+	g = g.ForOperation(op).ForRequest()
+	// Form bodies are encoded fields; multipart bodies have no text form
+	if contentType, fields := g.GenerateFormExample(op, resolver); fields != nil {
+		if IsMultipart(contentType) {
+			return contentType, ""
+		}
+		return contentType, EncodeForm(fields)
+	}
+
+	var contentType string
+	var schema *swagger.Schema
+	var recorded interface{}
+
+	if op.RequestBody != nil && len(op.RequestBody.Content) > 0 {
+		contentType = preferredContentType(op.RequestBody.Content)
+		schema = op.RequestBody.Content[contentType].Schema
+		if documented := DocumentedExamples(op.RequestBody.Content[contentType]); len(documented) > 0 {
+			return contentType, documented[0].JSON
+		}
+	}
+
+	for _, param := range op.Parameters {
+		if param.In == "body" && param.Schema != nil {
+			contentType = "application/json"
+			if len(op.Consumes) > 0 {
+				contentType = op.Consumes[0]
+			}
+			schema = param.Schema
+			recorded = param.Example
+			break
+		}
+	}
+
+	// Documented or recorded examples beat generated ones
+	if recorded != nil {
+		if data, err := json.MarshalIndent(recorded, "", "  "); err == nil {
+			return contentType, string(data)
+		}
+	}
+
+	if schema == nil {
+		return contentType, ""
+	}
+
+	resolved, err := resolver.ResolveSchema(schema)
+	if err != nil || resolved == nil {
+		return contentType, ""
+	}
+
+	return contentType, g.GenerateExampleJSON(resolved)
+}
+
+// GenerateRequestVariants returns a minimal example of a resolved request
+// body schema, holding only its required fields, and a full example with
+// every field. minimal is "" when it would be the same as full.
+func (g *Generator) GenerateRequestVariants(schema *swagger.Schema) (minimal, full string) {
+	g = g.ForRequest()
+	full = g.GenerateExampleJSON(schema)
+	minimal = g.Minimal().GenerateExampleJSON(schema)
+	if minimal == full {
+		minimal = ""
+	}
+	return minimal, full
+}
+
+// preferredContentType picks application/json when offered, otherwise the
+// first content type in sorted order
+func preferredContentType(content map[string]swagger.MediaType) string {
+	if _, ok := content["application/json"]; ok {
+		return "application/json"
+	}
+
+	types := make([]string, 0, len(content))
+	for contentType := range content {
+		types = append(types, contentType)
+	}
+	sort.Strings(types)
+	return types[0]
+}
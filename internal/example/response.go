@@ -0,0 +1,130 @@
+package example
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// ResponseExample is the example body of one documented response. Name
+// and Summary are set for the named examples of OpenAPI 3.x.
+type ResponseExample struct {
+	StatusCode  string
+	ContentType string
+	Name        string
+	Summary     string
+	JSON        string
+}
+
+// NamedExample is an example body documented in the spec
+type NamedExample struct {
+	// Name is empty for the single example of a media type
+	Name    string
+	Summary string
+	JSON    string
+}
+
+// DocumentedExamples returns the examples a media type documents: its
+// example, or otherwise each named example whose value is embedded
+func DocumentedExamples(mediaType swagger.MediaType) []NamedExample {
+	if mediaType.Example != nil {
+		return []NamedExample{{JSON: marshalJSON(mediaType.Example)}}
+	}
+
+	var examples []NamedExample
+	for _, name := range mediaType.ExampleNames() {
+		named := mediaType.Examples[name]
+		if named.Value == nil {
+			continue
+		}
+		examples = append(examples, NamedExample{Name: name, Summary: named.Summary, JSON: marshalJSON(named.Value)})
+	}
+	return examples
+}
+
+// marshalJSON formats an example value as indented JSON
+func marshalJSON(value interface{}) string {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// GenerateResponseExamples generates an example body for every documented
+// response that has a schema or an example, in the order the spec lists the
+// status codes. OpenAPI 3 responses get one example per content type,
+// Swagger 2.0 responses one for their schema. Documented examples beat
+// generated ones.
+func (g *Generator) GenerateResponseExamples(op swagger.Operation, resolver *swagger.Resolver) []ResponseExample {
+	g = g.ForOperation(op).ForResponse()
+	var examples []ResponseExample
+
+	for _, code := range op.ResponseCodes() {
+		response := op.Responses[code]
+
+		contentTypes := make([]string, 0, len(response.Content))
+		for contentType := range response.Content {
+			contentTypes = append(contentTypes, contentType)
+		}
+		sort.Strings(contentTypes)
+
+		for _, contentType := range contentTypes {
+			mediaType := response.Content[contentType]
+			if documented := DocumentedExamples(mediaType); len(documented) > 0 {
+				for _, ex := range documented {
+					examples = append(examples, ResponseExample{
+						StatusCode: code, ContentType: contentType, Name: ex.Name, Summary: ex.Summary, JSON: ex.JSON,
+					})
+				}
+				continue
+			}
+			if exampleJSON := g.responseJSON(mediaType.Schema, nil, resolver); exampleJSON != "" {
+				examples = append(examples, ResponseExample{StatusCode: code, ContentType: contentType, JSON: exampleJSON})
+			}
+		}
+
+		if exampleJSON := g.responseJSON(response.Schema, RecordedExample(response.Examples), resolver); exampleJSON != "" {
+			contentType := "application/json"
+			if len(op.Produces) > 0 {
+				contentType = op.Produces[0]
+			}
+			examples = append(examples, ResponseExample{StatusCode: code, ContentType: contentType, JSON: exampleJSON})
+		}
+	}
+
+	return examples
+}
+
+// responseJSON returns the recorded example as JSON, or one generated from
+// the schema. It returns "" when there is neither.
+func (g *Generator) responseJSON(schema *swagger.Schema, recorded interface{}, resolver *swagger.Resolver) string {
+	if recorded != nil {
+		return marshalJSON(recorded)
+	}
+
+	if schema == nil {
+		return ""
+	}
+	resolved, err := resolver.ResolveSchema(schema)
+	if err != nil || resolved == nil {
+		return ""
+	}
+	return g.GenerateExampleJSON(resolved)
+}
+
+// RecordedExample picks the JSON example of a Swagger 2.0 response
+func RecordedExample(examples map[string]interface{}) interface{} {
+	if example, ok := examples["application/json"]; ok {
+		return example
+	}
+	for mimeType, example := range examples {
+		if strings.Contains(mimeType, "json") {
+			return example
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package example
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func TestGenerator_GenerateResponseExamples(t *testing.T) {
+	spec := &swagger.Spec{
+		Definitions: map[string]swagger.Definition{
+			"Pet": {Type: "object", Properties: map[string]swagger.Property{"id": {Type: "integer"}}},
+		},
+	}
+	resolver := swagger.NewResolver(spec)
+
+	tests := []struct {
+		name string
+		op   swagger.Operation
+		want []ResponseExample
+	}{
+		{
+			name: "openapi 3 content",
+			op: swagger.Operation{Responses: swagger.Responses{
+				"200": {Content: map[string]swagger.MediaType{
+					"application/xml":  {Schema: &swagger.Schema{Type: "string"}},
+					"application/json": {Schema: &swagger.Schema{Ref: "#/definitions/Pet"}},
+				}},
+				"404": {Content: map[string]swagger.MediaType{
+					"application/json": {Example: map[string]interface{}{"error": "not found"}},
+				}},
+				"204": {Description: "No content"},
+			}},
+			want: []ResponseExample{
+				{StatusCode: "200", ContentType: "application/json", JSON: "{\n  \"id\": 0\n}"},
+				{StatusCode: "200", ContentType: "application/xml", JSON: `"string"`},
+				{StatusCode: "404", ContentType: "application/json", JSON: "{\n  \"error\": \"not found\"\n}"},
+			},
+		},
+		{
+			name: "swagger 2 schema",
+			op: swagger.Operation{
+				Produces: []string{"application/vnd.pet+json"},
+				Responses: swagger.Responses{
+					"201": {Schema: &swagger.Schema{Ref: "#/definitions/Pet"}},
+					"400": {Schema: &swagger.Schema{Type: "object"}, Examples: map[string]interface{}{"application/json": "bad request"}},
+				},
+			},
+			want: []ResponseExample{
+				{StatusCode: "201", ContentType: "application/vnd.pet+json", JSON: "{\n  \"id\": 0\n}"},
+				{StatusCode: "400", ContentType: "application/vnd.pet+json", JSON: `"bad request"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewGenerator().GenerateResponseExamples(tt.op, resolver)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d examples, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("example %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGenerator_ExampleDirection(t *testing.T) {
+	user := &swagger.Schema{Type: "object", Properties: map[string]swagger.Property{
+		"id":        {Type: "integer", ReadOnly: true},
+		"createdAt": {Type: "string", Format: "date-time", ReadOnly: true},
+		"password":  {Type: "string", Format: "password", WriteOnly: true},
+		"login":     {Type: "string"},
+	}}
+	op := swagger.Operation{
+		RequestBody: &swagger.RequestBody{Content: map[string]swagger.MediaType{"application/json": {Schema: user}}},
+		Responses: swagger.Responses{
+			"201": {Content: map[string]swagger.MediaType{"application/json": {Schema: user}}},
+		},
+	}
+	resolver := swagger.NewResolver(&swagger.Spec{})
+	gen := NewGenerator()
+
+	if _, body := gen.GenerateRequestExample(op, resolver); body != "{\n  \"login\": \"string\",\n  \"password\": \"********\"\n}" {
+		t.Errorf("request example = %s, want no readOnly fields", body)
+	}
+
+	want := "{\n  \"createdAt\": \"2024-01-15T10:30:00Z\",\n  \"id\": 0,\n  \"login\": \"string\"\n}"
+	if examples := gen.GenerateResponseExamples(op, resolver); len(examples) != 1 || examples[0].JSON != want {
+		t.Errorf("response examples = %+v, want no writeOnly fields", examples)
+	}
+
+	if body := gen.GenerateExampleJSON(user); !strings.Contains(body, "id") || !strings.Contains(body, "password") {
+		t.Errorf("undirected example = %s, want every field", body)
+	}
+}
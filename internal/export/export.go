@@ -0,0 +1,138 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/internal/docfile"
+)
+
+// ManifestFile is the name of the manifest written alongside the pages
+const ManifestFile = "manifest.json"
+
+// DefaultDir is used when no export directory is configured
+const DefaultDir = "docs/confluence"
+
+// Manifest describes the exported page hierarchy in publish order
+type Manifest struct {
+	Pages []ManifestPage `json:"pages"`
+}
+
+// ManifestPage is a single exported page. Parent refers to another page's
+// ID, or is empty for pages published under the configured root. The body
+// is in File for exported directories, or in Content for streamed pages.
+type ManifestPage struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	File    string `json:"file,omitempty"`
+	Parent  string `json:"parent,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// Writer is a publisher that writes storage-format page bodies and a
+// manifest to a directory instead of a wiki, so the output can be committed
+// and reviewed before it is published
+type Writer struct {
+	dir      string
+	manifest Manifest
+	byTitle  map[string]int
+}
+
+// NewWriter creates a Writer for dir, replacing any previous manifest
+func NewWriter(dir string) (*Writer, error) {
+	if dir == "" {
+		dir = DefaultDir
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	return &Writer{
+		dir:     dir,
+		byTitle: make(map[string]int),
+	}, nil
+}
+
+// ResolveParentPage returns no parent; the root is chosen at publish time
+func (w *Writer) ResolveParentPage(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+// CreateParentPage writes the API overview page
+func (w *Writer) CreateParentPage(ctx context.Context, apiTitle string) (string, error) {
+	return w.CreateOrUpdatePage(ctx, confluence.APIPageTitle(apiTitle), confluence.DefaultParentPageContent(apiTitle), "")
+}
+
+// CreateOrUpdatePage writes the page body and records it in the manifest.
+// The returned ID is the page's file name without extension.
+func (w *Writer) CreateOrUpdatePage(ctx context.Context, title, content, parentPageID string) (string, error) {
+	page := ManifestPage{Title: title, Parent: parentPageID}
+
+	if i, ok := w.byTitle[title]; ok {
+		page.ID = w.manifest.Pages[i].ID
+		page.File = w.manifest.Pages[i].File
+		w.manifest.Pages[i] = page
+	} else {
+		page.ID = uniqueID(w.manifest.Pages, docfile.Slug(title))
+		page.File = page.ID + ".xml"
+		w.byTitle[title] = len(w.manifest.Pages)
+		w.manifest.Pages = append(w.manifest.Pages, page)
+	}
+
+	path := filepath.Join(w.dir, page.File)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	// The manifest is rewritten after every page so a failed run still
+	// leaves a consistent directory
+	if err := w.writeManifest(); err != nil {
+		return "", err
+	}
+
+	fmt.Printf("✓ Wrote page: %s - %s\n", title, path)
+	return page.ID, nil
+}
+
+// PageURL returns the path of an exported page
+func (w *Writer) PageURL(pageID string) string {
+	if pageID == "" {
+		return ""
+	}
+	return filepath.Join(w.dir, pageID+".xml")
+}
+
+func (w *Writer) writeManifest() error {
+	data, err := json.MarshalIndent(w.manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	path := filepath.Join(w.dir, ManifestFile)
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// uniqueID returns id, suffixed when another of pages already has it
+func uniqueID(pages []ManifestPage, id string) string {
+	taken := func(candidate string) bool {
+		for _, page := range pages {
+			if page.ID == candidate {
+				return true
+			}
+		}
+		return false
+	}
+
+	candidate := id
+	for n := 2; taken(candidate); n++ {
+		candidate = fmt.Sprintf("%s-%d", id, n)
+	}
+	return candidate
+}
@@ -0,0 +1,84 @@
+package export
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+type recordingPublisher struct {
+	parents map[string]string
+}
+
+func (p *recordingPublisher) ResolveParentPage(ctx context.Context) (string, error) {
+	return "root", nil
+}
+
+func (p *recordingPublisher) CreateOrUpdatePage(ctx context.Context, title, content, parentPageID string) (string, error) {
+	p.parents[title] = parentPageID
+	return "id-" + title, nil
+}
+
+func TestWriterAndPublish(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	writer, err := NewWriter(dir)
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+
+	parentID, err := writer.CreateParentPage(ctx, "Pet Store")
+	if err != nil {
+		t.Fatalf("CreateParentPage() error = %v", err)
+	}
+	if parentID != "pet-store-api-documentation" {
+		t.Errorf("parent ID = %q", parentID)
+	}
+	if _, err := writer.CreateOrUpdatePage(ctx, "List Pets", "<p>pets</p>", parentID); err != nil {
+		t.Fatalf("CreateOrUpdatePage() error = %v", err)
+	}
+	if id, _ := writer.CreateOrUpdatePage(ctx, "List pets!", "<p>other</p>", parentID); id != "list-pets-2" {
+		t.Errorf("colliding title got ID %q, want list-pets-2", id)
+	}
+
+	publisher := &recordingPublisher{parents: make(map[string]string)}
+	count, err := Publish(ctx, dir, publisher)
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if count != 3 {
+		t.Errorf("published %d pages, want 3", count)
+	}
+
+	if got := publisher.parents["Pet Store - API Documentation"]; got != "root" {
+		t.Errorf("parent page published under %q, want root", got)
+	}
+	if got := publisher.parents["List Pets"]; got != "id-Pet Store - API Documentation" {
+		t.Errorf("endpoint page published under %q", got)
+	}
+}
+
+func TestWriter_CreateParentPageEscapesTitle(t *testing.T) {
+	dir := t.TempDir()
+	writer, err := NewWriter(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := writer.CreateParentPage(context.Background(), "Pets & <Owners>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(writer.PageURL(id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "<h1>Pets &amp; &lt;Owners&gt;</h1>") {
+		t.Errorf("API title not escaped in:\n%s", content)
+	}
+	if writer.manifest.Pages[0].Title != "Pets & <Owners> - API Documentation" {
+		t.Errorf("title = %q", writer.manifest.Pages[0].Title)
+	}
+}
@@ -0,0 +1,68 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// PagePublisher is the subset of a publisher needed to push exported pages
+type PagePublisher interface {
+	ResolveParentPage(ctx context.Context) (string, error)
+	CreateOrUpdatePage(ctx context.Context, title, content, parentPageID string) (string, error)
+}
+
+// LoadManifest reads the manifest of an export directory
+func LoadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// Publish pushes the pages of an export directory in manifest order,
+// mapping exported parent IDs to the IDs assigned by the publisher
+func Publish(ctx context.Context, dir string, publisher PagePublisher) (int, error) {
+	manifest, err := LoadManifest(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	rootID, err := publisher.ResolveParentPage(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve parent page: %w", err)
+	}
+
+	published := make(map[string]string, len(manifest.Pages))
+	for i, page := range manifest.Pages {
+		parentID := rootID
+		if page.Parent != "" {
+			id, ok := published[page.Parent]
+			if !ok {
+				return i, fmt.Errorf("page %q references parent %q that is not published before it", page.Title, page.Parent)
+			}
+			parentID = id
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, page.File))
+		if err != nil {
+			return i, fmt.Errorf("failed to read page %q: %w", page.Title, err)
+		}
+
+		pageID, err := publisher.CreateOrUpdatePage(ctx, page.Title, string(content), parentID)
+		if err != nil {
+			return i, fmt.Errorf("failed to publish %q: %w", page.Title, err)
+		}
+		published[page.ID] = pageID
+	}
+
+	return len(manifest.Pages), nil
+}
@@ -0,0 +1,71 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/internal/docfile"
+)
+
+// StreamWriter is a publisher that collects the storage-format pages in
+// memory and writes them as one JSON document when the run is finalized,
+// for piping into other tools
+type StreamWriter struct {
+	out      io.Writer
+	manifest Manifest
+	byTitle  map[string]int
+}
+
+// NewStreamWriter creates a StreamWriter writing to out
+func NewStreamWriter(out io.Writer) *StreamWriter {
+	return &StreamWriter{
+		out:     out,
+		byTitle: make(map[string]int),
+	}
+}
+
+// ResolveParentPage returns no parent; pages are nested by their IDs
+func (w *StreamWriter) ResolveParentPage(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+// CreateParentPage records the API overview page
+func (w *StreamWriter) CreateParentPage(ctx context.Context, apiTitle string) (string, error) {
+	return w.CreateOrUpdatePage(ctx, confluence.APIPageTitle(apiTitle), confluence.DefaultParentPageContent(apiTitle), "")
+}
+
+// CreateOrUpdatePage records a page. The returned ID is derived from the
+// title like the IDs of exported files.
+func (w *StreamWriter) CreateOrUpdatePage(ctx context.Context, title, content, parentPageID string) (string, error) {
+	page := ManifestPage{Title: title, Parent: parentPageID, Content: content}
+
+	if i, ok := w.byTitle[title]; ok {
+		page.ID = w.manifest.Pages[i].ID
+		w.manifest.Pages[i] = page
+	} else {
+		page.ID = uniqueID(w.manifest.Pages, docfile.Slug(title))
+		w.byTitle[title] = len(w.manifest.Pages)
+		w.manifest.Pages = append(w.manifest.Pages, page)
+	}
+	return page.ID, nil
+}
+
+// PageURL returns no URL; streamed pages have no location
+func (w *StreamWriter) PageURL(pageID string) string {
+	return ""
+}
+
+// Finalize writes the recorded pages in publish order
+func (w *StreamWriter) Finalize(ctx context.Context) error {
+	data, err := json.MarshalIndent(w.manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal pages: %w", err)
+	}
+	if _, err := w.out.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write pages: %w", err)
+	}
+	return nil
+}
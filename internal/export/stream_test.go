@@ -0,0 +1,44 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestStreamWriter_Finalize(t *testing.T) {
+	var out bytes.Buffer
+	ctx := context.Background()
+	writer := NewStreamWriter(&out)
+
+	parentID, err := writer.CreateParentPage(ctx, "Pet Store")
+	if err != nil {
+		t.Fatalf("CreateParentPage() error = %v", err)
+	}
+	if _, err := writer.CreateOrUpdatePage(ctx, "List Pets", "<p>old</p>", parentID); err != nil {
+		t.Fatalf("CreateOrUpdatePage() error = %v", err)
+	}
+	if _, err := writer.CreateOrUpdatePage(ctx, "List Pets", "<p>pets</p>", parentID); err != nil {
+		t.Fatalf("CreateOrUpdatePage() error = %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("wrote %q before Finalize", out.String())
+	}
+
+	if err := writer.Finalize(ctx); err != nil {
+		t.Fatalf("Finalize() error = %v", err)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(out.Bytes(), &manifest); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+
+	if len(manifest.Pages) != 2 {
+		t.Fatalf("got %d pages, want 2", len(manifest.Pages))
+	}
+	want := ManifestPage{ID: "list-pets", Title: "List Pets", Parent: "pet-store-api-documentation", Content: "<p>pets</p>"}
+	if manifest.Pages[1] != want {
+		t.Errorf("page = %+v, want %+v", manifest.Pages[1], want)
+	}
+}
@@ -0,0 +1,267 @@
+package har
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// RedactedValue replaces sensitive values in recorded payloads
+const RedactedValue = "REDACTED"
+
+// DefaultRedactKeys are field names whose values are always redacted.
+// Matching is case-insensitive and ignores '-' and '_'.
+var DefaultRedactKeys = []string{
+	"password", "secret", "token", "accesstoken", "refreshtoken", "apikey",
+	"authorization", "cookie", "ssn", "creditcard", "cardnumber", "cvv",
+}
+
+// Enricher replaces synthetic examples with sanitized recorded payloads
+type Enricher struct {
+	redact map[string]bool
+}
+
+// NewEnricher creates an Enricher that redacts the default keys plus extra
+func NewEnricher(extra []string) *Enricher {
+	redact := make(map[string]bool)
+	for _, key := range append(DefaultRedactKeys, extra...) {
+		redact[normalizeKey(key)] = true
+	}
+	return &Enricher{redact: redact}
+}
+
+// Apply sets request and response examples on the operations matching the
+// recorded entries. The first recorded payload per operation and status
+// wins. It returns the number of examples set.
+func (e *Enricher) Apply(spec *swagger.Spec, entries []Entry) int {
+	matcher := newPathMatcher(spec)
+	seen := make(map[string]bool)
+	count := 0
+
+	for _, entry := range entries {
+		method := strings.ToLower(entry.Request.Method)
+		path := matcher.match(entry.Request.Path())
+		if path == "" {
+			continue
+		}
+		op, ok := spec.Paths[path][method]
+		if !ok {
+			continue
+		}
+
+		if post := entry.Request.PostData; post != nil {
+			key := fmt.Sprintf("%s %s request", method, path)
+			if value := decodeJSON(post.MimeType, post.Text, ""); value != nil && !seen[key] {
+				if setRequestExample(&op, mediaType(post.MimeType), e.sanitize(value)) {
+					seen[key] = true
+					count++
+				}
+			}
+		}
+
+		content := entry.Response.Content
+		status := strconv.Itoa(entry.Response.Status)
+		key := fmt.Sprintf("%s %s %s", method, path, status)
+		if value := decodeJSON(content.MimeType, content.Text, content.Encoding); value != nil && !seen[key] {
+			if setResponseExample(&op, status, mediaType(content.MimeType), e.sanitize(value)) {
+				seen[key] = true
+				count++
+			}
+		}
+
+		spec.Paths[path][method] = op
+	}
+
+	return count
+}
+
+// sanitize returns a copy of value with sensitive fields redacted
+func (e *Enricher) sanitize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		sanitized := make(map[string]interface{}, len(v))
+		for key, field := range v {
+			if e.redact[normalizeKey(key)] {
+				sanitized[key] = RedactedValue
+			} else {
+				sanitized[key] = e.sanitize(field)
+			}
+		}
+		return sanitized
+	case []interface{}:
+		sanitized := make([]interface{}, len(v))
+		for i, item := range v {
+			sanitized[i] = e.sanitize(item)
+		}
+		return sanitized
+	}
+	return value
+}
+
+func setRequestExample(op *swagger.Operation, mimeType string, value interface{}) bool {
+	if op.RequestBody != nil {
+		if contentType, ok := contentKey(op.RequestBody.Content, mimeType); ok {
+			mt := op.RequestBody.Content[contentType]
+			mt.Example = value
+			op.RequestBody.Content[contentType] = mt
+			return true
+		}
+	}
+
+	// Swagger 2.0 body parameter
+	for i := range op.Parameters {
+		if op.Parameters[i].In == "body" {
+			op.Parameters[i].Example = value
+			return true
+		}
+	}
+	return false
+}
+
+func setResponseExample(op *swagger.Operation, status, mimeType string, value interface{}) bool {
+	response, ok := op.Responses[status]
+	if !ok {
+		return false
+	}
+
+	if contentType, ok := contentKey(response.Content, mimeType); ok {
+		mt := response.Content[contentType]
+		mt.Example = value
+		response.Content[contentType] = mt
+	} else if response.Schema != nil {
+		// Swagger 2.0 keeps examples per MIME type on the response
+		if response.Examples == nil {
+			response.Examples = make(map[string]interface{})
+		}
+		response.Examples[mimeType] = value
+	} else {
+		return false
+	}
+
+	op.Responses[status] = response
+	return true
+}
+
+// contentKey finds the documented content type matching a recorded one,
+// falling back to the only documented type
+func contentKey(content map[string]swagger.MediaType, mimeType string) (string, bool) {
+	for contentType := range content {
+		if mediaType(contentType) == mimeType {
+			return contentType, true
+		}
+	}
+	if len(content) == 1 {
+		for contentType := range content {
+			return contentType, true
+		}
+	}
+	return "", false
+}
+
+// pathMatcher maps recorded URL paths to spec path templates
+type pathMatcher struct {
+	patterns []pathPattern
+}
+
+// pathPattern is a path template below one of its server base paths
+type pathPattern struct {
+	path     string
+	segments []string
+	// literals counts the template segments that aren't parameters
+	literals int
+}
+
+func newPathMatcher(spec *swagger.Spec) *pathMatcher {
+	m := &pathMatcher{}
+	for _, path := range swagger.SortedKeys(spec.Paths) {
+		template := splitPath(path)
+		literals := 0
+		for _, part := range template {
+			if !isParameter(part) {
+				literals++
+			}
+		}
+		for _, base := range basePaths(spec, spec.Paths[path]) {
+			m.patterns = append(m.patterns, pathPattern{
+				path:     path,
+				segments: append(splitPath(base), template...),
+				literals: literals,
+			})
+		}
+	}
+	return m
+}
+
+// match returns the template matching a recorded path as a whole, below
+// one of the template's server base paths. Templates with more literal
+// segments win over parameterized ones.
+func (m *pathMatcher) match(path string) string {
+	segments := splitPath(path)
+	best, bestLiterals := "", -1
+
+	for _, pattern := range m.patterns {
+		if len(pattern.segments) != len(segments) || pattern.literals <= bestLiterals {
+			continue
+		}
+		matched := true
+		for j, part := range pattern.segments {
+			if !isParameter(part) && part != segments[j] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			best, bestLiterals = pattern.path, pattern.literals
+		}
+	}
+
+	return best
+}
+
+// basePaths returns the paths of the servers the operations of a path item
+// are called on, or "" when the spec documents none. Server variables are
+// left as parameters matching any segment.
+func basePaths(spec *swagger.Spec, item swagger.PathItem) []string {
+	seen := make(map[string]bool)
+	var bases []string
+	for _, method := range item.Methods() {
+		servers := spec.ServersFor(item[method])
+		if len(servers) == 0 {
+			servers = []swagger.Server{{}}
+		}
+		for _, server := range servers {
+			base := server.URL
+			if _, rest, ok := strings.Cut(base, "://"); ok {
+				base = ""
+				if i := strings.Index(rest, "/"); i >= 0 {
+					base = rest[i:]
+				}
+			}
+			if !seen[base] {
+				seen[base] = true
+				bases = append(bases, base)
+			}
+		}
+	}
+	return bases
+}
+
+func isParameter(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+func splitPath(path string) []string {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(key))
+}
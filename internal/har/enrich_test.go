@@ -0,0 +1,133 @@
+package har
+
+import (
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func TestEnricher_Apply(t *testing.T) {
+	spec := &swagger.Spec{
+		Servers: []swagger.Server{{URL: "https://api.example.com/v1"}},
+		Paths: map[string]swagger.PathItem{
+			"/users/{id}": {
+				"get": swagger.Operation{
+					Responses: swagger.Responses{
+						"200": {Content: map[string]swagger.MediaType{"application/json": {}}},
+					},
+				},
+			},
+			"/users/me": {
+				"get": swagger.Operation{
+					Responses: swagger.Responses{"200": {Content: map[string]swagger.MediaType{"application/json": {}}}},
+				},
+			},
+			"/login": {
+				"post": swagger.Operation{
+					RequestBody: &swagger.RequestBody{Content: map[string]swagger.MediaType{"application/json": {}}},
+					Responses:   swagger.Responses{"200": {Schema: &swagger.Schema{Type: "object"}}},
+				},
+			},
+		},
+	}
+
+	entries := []Entry{
+		{
+			Request: Request{Method: "GET", URL: "https://api.example.com/v1/users/42"},
+			Response: Response{Status: 200, Content: Content{
+				MimeType: "application/json; charset=utf-8",
+				Text:     `{"id": 42, "name": "Ada"}`,
+			}},
+		},
+		{
+			// A second recording of the same operation is ignored
+			Request: Request{Method: "GET", URL: "https://api.example.com/v1/users/7"},
+			Response: Response{Status: 200, Content: Content{
+				MimeType: "application/json",
+				Text:     `{"id": 7, "name": "Bob"}`,
+			}},
+		},
+		{
+			Request: Request{Method: "GET", URL: "https://api.example.com/v1/users/me"},
+			Response: Response{Status: 200, Content: Content{
+				MimeType: "application/json",
+				Text:     `{"id": 1, "name": "Me"}`,
+			}},
+		},
+		{
+			Request: Request{
+				Method:   "POST",
+				URL:      "https://api.example.com/v1/login",
+				PostData: &PostData{MimeType: "application/json", Text: `{"user": "ada", "Password": "hunter2"}`},
+			},
+			Response: Response{Status: 200, Content: Content{
+				MimeType: "application/json",
+				Text:     `{"access_token": "abc", "expires": 3600, "session": {"id": "s1"}}`,
+			}},
+		},
+	}
+
+	count := NewEnricher([]string{"session-id"}).Apply(spec, entries)
+	if count != 4 {
+		t.Errorf("Apply() = %d, want 4", count)
+	}
+
+	user := spec.Paths["/users/{id}"]["get"].Responses["200"].Content["application/json"].Example.(map[string]interface{})
+	if user["name"] != "Ada" {
+		t.Errorf("/users/{id} example = %v, want the first recording", user)
+	}
+
+	me := spec.Paths["/users/me"]["get"].Responses["200"].Content["application/json"].Example.(map[string]interface{})
+	if me["name"] != "Me" {
+		t.Errorf("/users/me example = %v, want the literal path match", me)
+	}
+
+	login := spec.Paths["/login"]["post"]
+	body := login.RequestBody.Content["application/json"].Example.(map[string]interface{})
+	if body["Password"] != RedactedValue || body["user"] != "ada" {
+		t.Errorf("request example not sanitized: %v", body)
+	}
+
+	resp := login.Responses["200"].Examples["application/json"].(map[string]interface{})
+	if resp["access_token"] != RedactedValue || resp["expires"] != float64(3600) {
+		t.Errorf("response example not sanitized: %v", resp)
+	}
+	if session := resp["session"].(map[string]interface{}); session["id"] != "s1" {
+		t.Errorf("nested non-sensitive field changed: %v", session)
+	}
+}
+
+func TestPathMatcher(t *testing.T) {
+	paths := map[string]swagger.PathItem{
+		"/{id}":       {"get": swagger.Operation{}},
+		"/users/{id}": {"get": swagger.Operation{}},
+		"/users/me":   {"get": swagger.Operation{}},
+	}
+
+	tests := []struct {
+		name string
+		spec *swagger.Spec
+		path string
+		want string
+	}{
+		{"parameter", &swagger.Spec{Servers: []swagger.Server{{URL: "https://api.example.com/v1"}}}, "/v1/users/42", "/users/{id}"},
+		{"literal wins", &swagger.Spec{Servers: []swagger.Server{{URL: "https://api.example.com/v1"}}}, "/v1/users/me", "/users/me"},
+		{"root parameter", &swagger.Spec{Servers: []swagger.Server{{URL: "https://api.example.com/v1"}}}, "/v1/7", "/{id}"},
+		{"longer path", &swagger.Spec{Servers: []swagger.Server{{URL: "https://api.example.com/v1"}}}, "/v1/users/42/orders", ""},
+		{"other base path", &swagger.Spec{Servers: []swagger.Server{{URL: "https://api.example.com/v1"}}}, "/v2/users/42", ""},
+		{"missing base path", &swagger.Spec{Servers: []swagger.Server{{URL: "https://api.example.com/v1"}}}, "/users/42", ""},
+		{"relative server", &swagger.Spec{Servers: []swagger.Server{{URL: "/api"}}}, "/api/users/42", "/users/{id}"},
+		{"server variable", &swagger.Spec{Servers: []swagger.Server{{URL: "https://{region}.example.com/{version}"}}}, "/v3/users/me", "/users/me"},
+		{"swagger 2.0 base path", &swagger.Spec{Host: "api.example.com", BasePath: "/api/"}, "/api/users/42", "/users/{id}"},
+		{"no server", &swagger.Spec{}, "/users/42", "/users/{id}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.spec.Paths = paths
+			if got := newPathMatcher(tt.spec).match(tt.path); got != tt.want {
+				t.Errorf("match(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package har
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// HAR is an HTTP Archive document (http://www.softwareishard.com/blog/har-12-spec/)
+type HAR struct {
+	Log Log `json:"log"`
+}
+
+// Log holds the recorded entries
+type Log struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Entry is a single recorded request/response pair
+type Entry struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is the recorded request
+type Request struct {
+	Method   string    `json:"method"`
+	URL      string    `json:"url"`
+	PostData *PostData `json:"postData,omitempty"`
+}
+
+// PostData is a recorded request body
+type PostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// Response is the recorded response
+type Response struct {
+	Status  int     `json:"status"`
+	Content Content `json:"content"`
+}
+
+// Content is a recorded response body
+type Content struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// Load reads a HAR file
+func Load(path string) (*HAR, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HAR file: %w", err)
+	}
+
+	var doc HAR
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse HAR file: %w", err)
+	}
+	return &doc, nil
+}
+
+// Path returns the URL path of the request
+func (r Request) Path() string {
+	parsed, err := url.Parse(r.URL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Path
+}
+
+// mediaType strips parameters such as charset from a MIME type
+func mediaType(mimeType string) string {
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	return strings.ToLower(strings.TrimSpace(mimeType))
+}
+
+// decodeJSON parses a recorded JSON body, returning nil for anything else
+func decodeJSON(mimeType, text, encoding string) interface{} {
+	if !strings.Contains(mediaType(mimeType), "json") || text == "" || encoding != "" {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,50 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+func TestNew_Proxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Write([]byte("ok"))
+	}))
+	defer proxy.Close()
+
+	client, err := New(config.TLSConfig{Proxy: proxy.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Get("http://confluence.internal/rest/api/content")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if proxied != "http://confluence.internal/rest/api/content" {
+		t.Errorf("proxy received %q", proxied)
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.TLSConfig
+	}{
+		{"proxy without scheme", config.TLSConfig{Proxy: "proxy.internal:3128"}},
+		{"missing CA bundle", config.TLSConfig{CACertFile: "does-not-exist.pem"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.cfg); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Retry defaults
+const (
+	DefaultMaxAttempts = 5
+	DefaultBaseDelay   = time.Second
+	DefaultMaxDelay    = 30 * time.Second
+	// DefaultMaxRetryAfter bounds the wait requested by a server's
+	// Retry-After
+	DefaultMaxRetryAfter = 5 * time.Minute
+)
+
+// Retry re-sends requests rejected with 429 Too Many Requests or 503
+// Service Unavailable. It waits as long as the Retry-After header asks, or
+// otherwise backs off exponentially with jitter.
+type Retry struct {
+	// MaxAttempts is the total number of attempts, including the first
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// MaxRetryAfter caps the wait a server asks for with Retry-After
+	MaxRetryAfter time.Duration
+
+	// sleep waits for d or until ctx is done
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewRetry creates a Retry making at most maxAttempts attempts; zero or less
+// uses DefaultMaxAttempts
+func NewRetry(maxAttempts int) *Retry {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	return &Retry{
+		MaxAttempts:   maxAttempts,
+		BaseDelay:     DefaultBaseDelay,
+		MaxDelay:      DefaultMaxDelay,
+		MaxRetryAfter: DefaultMaxRetryAfter,
+		sleep:         sleep,
+	}
+}
+
+// Do sends the request with client, retrying rate-limited attempts. Request
+// bodies are replayed through GetBody, which http.NewRequest sets for
+// in-memory bodies; requests without it are sent once.
+func (r *Retry) Do(client *http.Client, req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+			req.Body = body
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if !retryable(resp.StatusCode) || attempt >= r.MaxAttempts || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+
+		delay := r.delay(attempt, resp.Header.Get("Retry-After"))
+		// Draining only lets the connection be reused; the body is discarded
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		// Retries are reported on stderr, which is never the output of a run
+		fmt.Fprintf(os.Stderr, "%s %s: status %d, retrying in %s (attempt %d of %d)\n",
+			req.Method, req.URL.Path, resp.StatusCode, delay.Round(time.Millisecond), attempt+1, r.MaxAttempts)
+		if err := r.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryable reports whether a response status is worth retrying
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
+
+// delay returns the wait before the attempt after the given one
+func (r *Retry) delay(attempt int, retryAfter string) time.Duration {
+	if wait, ok := parseRetryAfter(retryAfter, time.Now()); ok {
+		return min(wait, r.MaxRetryAfter)
+	}
+
+	backoff := r.MaxDelay
+	if shift := attempt - 1; shift < 31 {
+		backoff = min(r.BaseDelay<<shift, r.MaxDelay)
+	}
+	// Spread clients that were throttled together over the second half of
+	// the interval
+	half := backoff / 2
+	return half + rand.N(half+1)
+}
+
+// parseRetryAfter parses a Retry-After header in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// interruptKey is the context key of the context KeepRunning detached from
+type interruptKey struct{}
+
+// KeepRunning returns a context that is not canceled with ctx, so requests
+// already sent are finished, while retry waits still end once ctx is
+// canceled
+func KeepRunning(ctx context.Context) context.Context {
+	return context.WithValue(context.WithoutCancel(ctx), interruptKey{}, ctx)
+}
+
+// sleep waits for d or until ctx, or the context it keeps running past, is
+// done
+func sleep(ctx context.Context, d time.Duration) error {
+	// A nil channel never fires when ctx is not from KeepRunning
+	var interrupted <-chan struct{}
+	parent, _ := ctx.Value(interruptKey{}).(context.Context)
+	if parent != nil {
+		interrupted = parent.Done()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-interrupted:
+		return parent.Err()
+	}
+}
@@ -0,0 +1,157 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// noSleep records the waits instead of sleeping
+func noSleep(waits *[]time.Duration) func(context.Context, time.Duration) error {
+	return func(ctx context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return ctx.Err()
+	}
+}
+
+func TestRetry_Do(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []int
+		retryAfter string
+		want       int
+		wantCalls  int
+		wantWaits  []time.Duration
+	}{
+		{name: "success", statuses: []int{200}, want: 200, wantCalls: 1},
+		{name: "retries rate limit", statuses: []int{429, 503, 200}, want: 200, wantCalls: 3},
+		{name: "honors Retry-After", statuses: []int{429, 200}, retryAfter: "7", want: 200, wantCalls: 2,
+			wantWaits: []time.Duration{7 * time.Second}},
+		{name: "gives up after max attempts", statuses: []int{429, 429, 429, 429}, want: 429, wantCalls: 3},
+		{name: "does not retry other errors", statuses: []int{500, 200}, want: 500, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != "payload" {
+					t.Errorf("attempt %d body = %q, want the original body", calls+1, body)
+				}
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.statuses[calls])
+				calls++
+			}))
+			defer server.Close()
+
+			var waits []time.Duration
+			retry := NewRetry(3)
+			retry.sleep = noSleep(&waits)
+
+			req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("payload"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := retry.Do(server.Client(), req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.want || calls != tt.wantCalls {
+				t.Errorf("status %d after %d calls, want %d after %d", resp.StatusCode, calls, tt.want, tt.wantCalls)
+			}
+			if len(waits) != tt.wantCalls-1 {
+				t.Errorf("waited %d times, want %d", len(waits), tt.wantCalls-1)
+			}
+			for i, want := range tt.wantWaits {
+				if waits[i] != want {
+					t.Errorf("wait %d = %s, want %s", i, waits[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestRetry_DelayBackoff(t *testing.T) {
+	retry := NewRetry(10)
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		got := retry.delay(attempt+1, "")
+		if got < want/2 || got > want {
+			t.Errorf("delay after attempt %d = %s, want between %s and %s", attempt+1, got, want/2, want)
+		}
+	}
+	if got := retry.delay(20, ""); got > DefaultMaxDelay {
+		t.Errorf("delay = %s, want at most %s", got, DefaultMaxDelay)
+	}
+}
+
+func TestRetry_DelayRetryAfterCap(t *testing.T) {
+	retry := NewRetry(3)
+	if got := retry.delay(1, "3600"); got != DefaultMaxRetryAfter {
+		t.Errorf("delay = %s, want the default cap %s", got, DefaultMaxRetryAfter)
+	}
+	retry.MaxRetryAfter = 10 * time.Second
+	if got := retry.delay(1, "3600"); got != 10*time.Second {
+		t.Errorf("delay = %s, want the configured cap 10s", got)
+	}
+	if got := retry.delay(1, "4"); got != 4*time.Second {
+		t.Errorf("delay = %s, want the requested 4s", got)
+	}
+}
+
+func TestSleep_Interrupted(t *testing.T) {
+	// Each context is built from a canceled parent and given a short
+	// timeout; only a wait that ignores the parent reaches the timeout
+	tests := []struct {
+		name    string
+		detach  func(parent context.Context) context.Context
+		wantErr error
+	}{
+		{"canceled context", func(parent context.Context) context.Context { return parent }, context.Canceled},
+		{"kept running", KeepRunning, context.Canceled},
+		{"detached", context.WithoutCancel, context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent, cancel := context.WithCancel(context.Background())
+			cancel()
+			ctx, stop := context.WithTimeout(tt.detach(parent), 50*time.Millisecond)
+			defer stop()
+
+			if err := sleep(ctx, time.Minute); !errors.Is(err, tt.wantErr) {
+				t.Errorf("sleep() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"30", 30 * time.Second, true},
+		{"Wed, 01 May 2024 12:00:10 GMT", 10 * time.Second, true},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %s, %v, want %s, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
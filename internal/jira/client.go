@@ -0,0 +1,195 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/httpclient"
+)
+
+// defaultIssueType is used when no issue type is configured
+const defaultIssueType = "Task"
+
+// Client creates issues through the Jira REST API
+type Client struct {
+	cfg        config.JiraConfig
+	httpClient *http.Client
+}
+
+type issueRequest struct {
+	Fields issueFields `json:"fields"`
+}
+
+type issueFields struct {
+	Project     keyRef   `json:"project"`
+	IssueType   nameRef  `json:"issuetype"`
+	Summary     string   `json:"summary"`
+	Description string   `json:"description"`
+	Labels      []string `json:"labels,omitempty"`
+}
+
+type keyRef struct {
+	Key string `json:"key"`
+}
+
+type nameRef struct {
+	Name string `json:"name"`
+}
+
+type issueResponse struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+type searchRequest struct {
+	JQL        string   `json:"jql"`
+	Fields     []string `json:"fields"`
+	MaxResults int      `json:"maxResults"`
+}
+
+type searchResponse struct {
+	Issues []struct {
+		Key    string `json:"key"`
+		Fields struct {
+			Summary string `json:"summary"`
+		} `json:"fields"`
+	} `json:"issues"`
+}
+
+type updateRequest struct {
+	Fields struct {
+		Description string `json:"description"`
+	} `json:"fields"`
+}
+
+// NewClient creates a new Jira client
+func NewClient(cfg config.JiraConfig) (*Client, error) {
+	if cfg.BaseURL == "" || cfg.ProjectKey == "" {
+		return nil, fmt.Errorf("jira integration requires JIRA_BASE_URL and JIRA_PROJECT_KEY")
+	}
+	if cfg.IssueType == "" {
+		cfg.IssueType = defaultIssueType
+	}
+
+	httpClient, err := httpclient.New(cfg.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure jira transport: %w", err)
+	}
+
+	return &Client{
+		cfg:        cfg,
+		httpClient: httpClient,
+	}, nil
+}
+
+// CreateIssue opens an issue in the configured project and returns its key.
+// The description uses Jira wiki markup.
+func (c *Client) CreateIssue(ctx context.Context, summary, description string) (string, error) {
+	body, err := json.Marshal(issueRequest{
+		Fields: issueFields{
+			Project:     keyRef{Key: c.cfg.ProjectKey},
+			IssueType:   nameRef{Name: c.cfg.IssueType},
+			Summary:     summary,
+			Description: description,
+			Labels:      c.cfg.Labels,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal issue: %w", err)
+	}
+
+	var created issueResponse
+	if err := c.do(ctx, http.MethodPost, "/rest/api/2/issue", body, http.StatusCreated, &created); err != nil {
+		return "", fmt.Errorf("failed to create issue: %w", err)
+	}
+
+	return created.Key, nil
+}
+
+// FindOpenIssue returns the key of an unresolved issue in the configured
+// project with exactly this summary, or "" when there is none. Jira's text
+// search is fuzzy, so the summaries it returns are compared again.
+func (c *Client) FindOpenIssue(ctx context.Context, summary string) (string, error) {
+	// Quotes and backslashes cannot be escaped reliably inside a JQL phrase,
+	// and the phrase only narrows the search, so they are dropped
+	phrase := strings.NewReplacer(`"`, " ", `\`, " ").Replace(summary)
+	body, err := json.Marshal(searchRequest{
+		JQL: fmt.Sprintf(`project = "%s" AND statusCategory != Done AND summary ~ "\"%s\"" ORDER BY created DESC`,
+			c.cfg.ProjectKey, phrase),
+		Fields:     []string{"summary"},
+		MaxResults: 50,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal search: %w", err)
+	}
+
+	var found searchResponse
+	if err := c.do(ctx, http.MethodPost, "/rest/api/2/search", body, http.StatusOK, &found); err != nil {
+		return "", fmt.Errorf("failed to search issues: %w", err)
+	}
+	for _, issue := range found.Issues {
+		if issue.Fields.Summary == summary {
+			return issue.Key, nil
+		}
+	}
+	return "", nil
+}
+
+// UpdateIssue replaces the description of an issue
+func (c *Client) UpdateIssue(ctx context.Context, key, description string) error {
+	var update updateRequest
+	update.Fields.Description = description
+	body, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("failed to marshal issue: %w", err)
+	}
+
+	if err := c.do(ctx, http.MethodPut, "/rest/api/2/issue/"+url.PathEscape(key), body, http.StatusNoContent, nil); err != nil {
+		return fmt.Errorf("failed to update issue %s: %w", key, err)
+	}
+	return nil
+}
+
+// do sends a JSON request to the Jira REST API and decodes the response
+// into result, unless it is nil
+func (c *Client) do(ctx context.Context, method, path string, body []byte, wantStatus int, result interface{}) error {
+	apiURL := strings.TrimSuffix(c.cfg.BaseURL, "/") + path
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.SetBasicAuth(c.cfg.Username, c.cfg.APIToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != wantStatus {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// IssueURL returns the browser URL of an issue
+func (c *Client) IssueURL(key string) string {
+	return fmt.Sprintf("%s/browse/%s", strings.TrimSuffix(c.cfg.BaseURL, "/"), key)
+}
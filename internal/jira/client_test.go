@@ -0,0 +1,152 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(config.JiraConfig{
+		BaseURL:    server.URL + "/",
+		Username:   "user",
+		APIToken:   "token",
+		ProjectKey: "API",
+		Labels:     []string{"breaking-change"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestClient_CreateIssue(t *testing.T) {
+	var created issueRequest
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/2/issue" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		if user, token, ok := r.BasicAuth(); !ok || user != "user" || token != "token" {
+			t.Errorf("BasicAuth() = %q, %q, %v", user, token, ok)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+			t.Errorf("failed to decode issue: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "10001", "key": "API-7"}`))
+	})
+
+	key, err := client.CreateIssue(context.Background(), "Breaking API changes in Pets v2", "* removed GET /pets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "API-7" {
+		t.Errorf("CreateIssue() = %q, want API-7", key)
+	}
+
+	fields := created.Fields
+	if fields.Project.Key != "API" || fields.IssueType.Name != defaultIssueType {
+		t.Errorf("project/type = %q/%q, want API/%s", fields.Project.Key, fields.IssueType.Name, defaultIssueType)
+	}
+	if fields.Summary != "Breaking API changes in Pets v2" || fields.Description != "* removed GET /pets" {
+		t.Errorf("summary/description = %q/%q", fields.Summary, fields.Description)
+	}
+	if len(fields.Labels) != 1 || fields.Labels[0] != "breaking-change" {
+		t.Errorf("labels = %v", fields.Labels)
+	}
+}
+
+func TestClient_CreateIssueError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errors": {"issuetype": "invalid"}}`))
+	})
+
+	_, err := client.CreateIssue(context.Background(), "summary", "description")
+	if err == nil || !strings.Contains(err.Error(), "unexpected status 400") {
+		t.Fatalf("CreateIssue() error = %v, want status 400", err)
+	}
+}
+
+func TestClient_FindOpenIssue(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     string
+	}{
+		{
+			name:     "exact summary",
+			response: `{"issues": [{"key": "API-3", "fields": {"summary": "Breaking API changes in Pets v2"}}]}`,
+			want:     "API-3",
+		},
+		{
+			name: "fuzzy matches are skipped",
+			response: `{"issues": [
+				{"key": "API-9", "fields": {"summary": "Breaking API changes in Pets v2.1"}},
+				{"key": "API-4", "fields": {"summary": "Breaking API changes in Pets v2"}}]}`,
+			want: "API-4",
+		},
+		{
+			name:     "no open issue",
+			response: `{"issues": []}`,
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var search searchRequest
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/rest/api/2/search" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&search); err != nil {
+					t.Errorf("failed to decode search: %v", err)
+				}
+				w.Write([]byte(tt.response))
+			})
+
+			key, err := client.FindOpenIssue(context.Background(), "Breaking API changes in Pets v2")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if key != tt.want {
+				t.Errorf("FindOpenIssue() = %q, want %q", key, tt.want)
+			}
+			for _, clause := range []string{`project = "API"`, "statusCategory != Done", `"\"Breaking API changes in Pets v2\""`} {
+				if !strings.Contains(search.JQL, clause) {
+					t.Errorf("JQL %q missing %s", search.JQL, clause)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_UpdateIssue(t *testing.T) {
+	var updated updateRequest
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/rest/api/2/issue/API-3" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+			t.Errorf("failed to decode update: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := client.UpdateIssue(context.Background(), "API-3", "* removed GET /pets"); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Fields.Description != "* removed GET /pets" {
+		t.Errorf("description = %q", updated.Fields.Description)
+	}
+}
@@ -0,0 +1,240 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// Severity levels, ordered from least to most severe
+const (
+	SeverityOff     = "off"
+	SeverityInfo    = "info"
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+var severityRank = map[string]int{
+	SeverityOff:     0,
+	SeverityInfo:    1,
+	SeverityWarning: 2,
+	SeverityError:   3,
+}
+
+// Finding is a single rule violation
+type Finding struct {
+	Rule     string
+	Severity string
+	Location string
+	Message  string
+}
+
+// String formats the finding for console and report output
+func (f Finding) String() string {
+	return fmt.Sprintf("[%s] %s %s: %s", f.Severity, f.Rule, f.Location, f.Message)
+}
+
+// Rule is a documentation quality check
+type Rule struct {
+	Name        string
+	Description string
+	Severity    string
+	Check       func(spec *swagger.Spec) []Finding
+}
+
+// Linter runs the built-in rules with configured severities
+type Linter struct {
+	rules []Rule
+}
+
+// New creates a Linter, applying per-rule severity overrides
+func New(cfg config.LintConfig) (*Linter, error) {
+	rules := DefaultRules()
+	known := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		known[rule.Name] = true
+	}
+
+	for name, severity := range cfg.Rules {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown lint rule %q", name)
+		}
+		if _, ok := severityRank[severity]; !ok {
+			return nil, fmt.Errorf("invalid severity %q for lint rule %s", severity, name)
+		}
+	}
+
+	for i := range rules {
+		if severity, ok := cfg.Rules[rules[i].Name]; ok {
+			rules[i].Severity = severity
+		}
+	}
+
+	return &Linter{rules: rules}, nil
+}
+
+// Run checks the spec against all enabled rules
+func (l *Linter) Run(spec *swagger.Spec) []Finding {
+	var findings []Finding
+	for _, rule := range l.rules {
+		if rule.Severity == SeverityOff {
+			continue
+		}
+		for _, finding := range rule.Check(spec) {
+			finding.Rule = rule.Name
+			finding.Severity = rule.Severity
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// Exceeds reports whether any finding is at or above the given severity.
+// An empty threshold never fails.
+func Exceeds(findings []Finding, threshold string) bool {
+	if threshold == "" || threshold == SeverityOff {
+		return false
+	}
+	for _, finding := range findings {
+		if severityRank[finding.Severity] >= severityRank[threshold] {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidSeverity reports whether s is a known severity
+func ValidSeverity(s string) bool {
+	_, ok := severityRank[s]
+	return ok
+}
+
+// DefaultRules returns the built-in rule set
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			Name:        "operation-description",
+			Description: "Operations should have a summary or description",
+			Severity:    SeverityWarning,
+			Check:       checkOperationDescription,
+		},
+		{
+			Name:        "operation-4xx-response",
+			Description: "Operations should document at least one 4xx response",
+			Severity:    SeverityWarning,
+			Check:       checkOperation4xx,
+		},
+		{
+			Name:        "property-description",
+			Description: "Schema properties should have a description",
+			Severity:    SeverityInfo,
+			Check:       checkPropertyDescription,
+		},
+		{
+			Name:        "property-example",
+			Description: "Schema properties should have an example",
+			Severity:    SeverityInfo,
+			Check:       checkPropertyExample,
+		},
+		{
+			Name:        "property-snake-case",
+			Description: "Schema property names should be snake_case",
+			Severity:    SeverityOff,
+			Check:       checkPropertySnakeCase,
+		},
+	}
+}
+
+func checkOperationDescription(spec *swagger.Spec) []Finding {
+	var findings []Finding
+	eachOperation(spec, func(location string, op swagger.Operation) {
+		if op.Summary == "" && op.Description == "" {
+			findings = append(findings, Finding{Location: location, Message: "missing summary and description"})
+		}
+	})
+	return findings
+}
+
+func checkOperation4xx(spec *swagger.Spec) []Finding {
+	var findings []Finding
+	eachOperation(spec, func(location string, op swagger.Operation) {
+		// A default response doesn't say which client errors to expect
+		for code := range op.Responses {
+			if strings.HasPrefix(code, "4") {
+				return
+			}
+		}
+		findings = append(findings, Finding{Location: location, Message: "no 4xx response documented"})
+	})
+	return findings
+}
+
+func checkPropertyDescription(spec *swagger.Spec) []Finding {
+	var findings []Finding
+	eachProperty(spec, func(location string, _ string, prop swagger.Property) {
+		if prop.Description == "" && prop.Ref == "" {
+			findings = append(findings, Finding{Location: location, Message: "missing description"})
+		}
+	})
+	return findings
+}
+
+func checkPropertyExample(spec *swagger.Spec) []Finding {
+	var findings []Finding
+	eachProperty(spec, func(location string, _ string, prop swagger.Property) {
+		if prop.Example == nil && prop.Ref == "" && prop.Type != "object" && prop.Type != "array" {
+			findings = append(findings, Finding{Location: location, Message: "missing example"})
+		}
+	})
+	return findings
+}
+
+func checkPropertySnakeCase(spec *swagger.Spec) []Finding {
+	var findings []Finding
+	eachProperty(spec, func(location string, name string, _ swagger.Property) {
+		if !isSnakeCase(name) {
+			findings = append(findings, Finding{Location: location, Message: "property name is not snake_case"})
+		}
+	})
+	return findings
+}
+
+// isSnakeCase reports whether name uses only lowercase letters, digits and underscores
+func isSnakeCase(name string) bool {
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// eachOperation calls fn for every operation, in sorted path and method order
+func eachOperation(spec *swagger.Spec, fn func(location string, op swagger.Operation)) {
+	for _, path := range swagger.SortedKeys(spec.Paths) {
+		item := spec.Paths[path]
+		for _, method := range swagger.SortedKeys(item) {
+			if !swagger.IsMethod(method) {
+				continue
+			}
+			fn(fmt.Sprintf("%s %s", strings.ToUpper(method), path), item[method])
+		}
+	}
+}
+
+// eachProperty calls fn for every property of the component schemas
+func eachProperty(spec *swagger.Spec, fn func(location, name string, prop swagger.Property)) {
+	definitions := spec.Definitions
+	if spec.Components != nil && len(spec.Components.Schemas) > 0 {
+		definitions = spec.Components.Schemas
+	}
+
+	for _, schemaName := range swagger.SortedKeys(definitions) {
+		def := definitions[schemaName]
+		for _, propName := range swagger.SortedKeys(def.Properties) {
+			fn(fmt.Sprintf("%s.%s", schemaName, propName), propName, def.Properties[propName])
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package lint
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func TestLinter_Run(t *testing.T) {
+	spec := &swagger.Spec{
+		Paths: map[string]swagger.PathItem{
+			"/users": {
+				"get": swagger.Operation{
+					Summary:   "List users",
+					Responses: swagger.Responses{"200": {}, "404": {}},
+				},
+				"post": swagger.Operation{
+					Responses: swagger.Responses{"201": {}},
+				},
+			},
+			"/users/{id}": {
+				"get": swagger.Operation{
+					Summary:   "Get user",
+					Responses: swagger.Responses{"200": {}, "default": {}},
+				},
+				"delete": swagger.Operation{
+					Summary:   "Delete user",
+					Responses: swagger.Responses{"204": {}, "4XX": {}},
+				},
+			},
+		},
+		Definitions: map[string]swagger.Definition{
+			"User": {
+				Properties: map[string]swagger.Property{
+					"userName": {Type: "string", Description: "Login", Example: "jdoe"},
+				},
+			},
+		},
+	}
+
+	linter, err := New(config.LintConfig{Rules: map[string]string{"property-snake-case": SeverityError}})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	findings := linter.Run(spec)
+
+	want := map[string][]string{
+		"operation-description":  {"POST /users"},
+		"operation-4xx-response": {"POST /users", "GET /users/{id}"},
+		"property-snake-case":    {"User.userName"},
+	}
+	got := make(map[string][]string)
+	for _, finding := range findings {
+		got[finding.Rule] = append(got[finding.Rule], finding.Location)
+	}
+	for rule, locations := range want {
+		slices.Sort(got[rule])
+		slices.Sort(locations)
+		if !slices.Equal(got[rule], locations) {
+			t.Errorf("%s findings = %v, want %v", rule, got[rule], locations)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("unexpected findings %v", findings)
+	}
+
+	if !Exceeds(findings, SeverityError) {
+		t.Error("expected findings to exceed error threshold")
+	}
+}
+
+func TestNew_UnknownRule(t *testing.T) {
+	if _, err := New(config.LintConfig{Rules: map[string]string{"nope": SeverityError}}); err == nil {
+		t.Error("expected error for unknown rule")
+	}
+}
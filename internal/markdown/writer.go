@@ -0,0 +1,23 @@
+package markdown
+
+import (
+	"github.com/ahmadimt/SwagFluence/internal/docfile"
+)
+
+// IndexFile is the name of the index page linking every written page
+const IndexFile = "index" + ext
+
+// DefaultDir is used when no output directory is configured
+const DefaultDir = "docs/api"
+
+const ext = ".md"
+
+// NewWriter creates a publisher that renders the parsed spec as Markdown
+// files in dir, one per endpoint plus an index, for GitHub wikis, MkDocs and
+// similar tools
+func NewWriter(dir string) (*docfile.Writer, error) {
+	if dir == "" {
+		dir = DefaultDir
+	}
+	return docfile.NewWriter(dir, format{})
+}
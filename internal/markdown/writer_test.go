@@ -0,0 +1,89 @@
+package markdown
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func TestWriter_PublishEndpoint(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWriter(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec := &swagger.Spec{
+		Definitions: map[string]swagger.Definition{
+			"Pet": {
+				Type:     "object",
+				Required: []string{"name"},
+				Properties: map[string]swagger.Property{
+					"name": {Type: "string", Description: "Pet | name"},
+					"tags": {Type: "array", Items: &swagger.Schema{Type: "string"}},
+				},
+				PropertyOrder: []string{"name", "tags"},
+			},
+		},
+	}
+	endpoint := swagger.EndpointInfo{
+		Path:   "/pets/{id}",
+		Method: "get",
+		Title:  "Get Pet",
+		Operation: swagger.Operation{
+			Summary:     "Find a pet",
+			OperationID: "getPet",
+			Parameters:  []swagger.Parameter{{Name: "id", In: "path", Type: "integer", Format: "int64", Required: true}},
+			Responses: swagger.Responses{
+				"200": {Description: "The pet", Schema: &swagger.Schema{Ref: "#/definitions/Pet"}},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	if _, err := w.CreateParentPage(ctx, "Pet Store"); err != nil {
+		t.Fatal(err)
+	}
+	file, err := w.PublishEndpoint(ctx, endpoint, swagger.NewResolver(spec), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file != "get-pet.md" {
+		t.Errorf("PublishEndpoint() = %q, want get-pet.md", file)
+	}
+
+	page, err := os.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# Get Pet\n\n`GET /pets/{id}`\n\nFind a pet\n\n",
+		"**Operation ID:** `getPet`",
+		"| `id` | path | `integer` (int64) | yes |  |",
+		"### 200\n\nThe pet\n\n",
+		"| `name` | `string` | yes | Pet \\| name |",
+		"| `tags` | array of `string` | no |  |",
+		"```json\n{",
+	} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("expected %q in:\n%s", want, page)
+		}
+	}
+
+	index, err := os.ReadFile(filepath.Join(dir, IndexFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# Pet Store - API Documentation",
+		"| GET | `/pets/{id}` | [Get Pet](get-pet.md) |",
+	} {
+		if !strings.Contains(string(index), want) {
+			t.Errorf("expected %q in index:\n%s", want, index)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package notion
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/example"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// maxTextLength is the Notion limit for a single rich text object
+const maxTextLength = 2000
+
+var (
+	tagPattern = regexp.MustCompile(`<[^>]+>`)
+	// cdataPattern matches the body of code macros, which may contain
+	// markup of its own
+	cdataPattern    = regexp.MustCompile(`(?s)<!\[CDATA\[(.*?)\]\]>`)
+	languagePattern = regexp.MustCompile(`<ac:parameter ac:name="language">([^<]*)</ac:parameter>`)
+	// parameterPattern matches macro parameters, which are not page text
+	parameterPattern = regexp.MustCompile(`(?s)<ac:parameter[^>]*>.*?</ac:parameter>`)
+)
+
+// codeLanguages maps Confluence code macro languages to Notion's
+var codeLanguages = map[string]string{
+	"bash":       "bash",
+	"shell":      "shell",
+	"sh":         "shell",
+	"json":       "json",
+	"xml":        "xml",
+	"html":       "html",
+	"yaml":       "yaml",
+	"javascript": "javascript",
+	"js":         "javascript",
+	"typescript": "typescript",
+	"python":     "python",
+	"java":       "java",
+	"go":         "go",
+	"csharp":     "c#",
+	"php":        "php",
+	"ruby":       "ruby",
+	"sql":        "sql",
+}
+
+// endpointBlocks renders an endpoint as Notion blocks
+func endpointBlocks(endpoint swagger.EndpointInfo, resolver *swagger.Resolver, gen *example.Generator) []block {
+	gen = gen.ForOperation(endpoint.Operation)
+	op := endpoint.Operation
+	blocks := []block{
+		heading2(fmt.Sprintf("%s %s", strings.ToUpper(endpoint.Method), endpoint.Path)),
+	}
+
+	if op.Description != "" {
+		blocks = append(blocks, paragraph(op.Description))
+	}
+	if op.OperationID != "" {
+		blocks = append(blocks, paragraph("Operation ID: "+op.OperationID))
+	}
+
+	blocks = append(blocks, heading3("Parameters"))
+	hasParams := false
+	for _, param := range op.Parameters {
+		if param.In == "body" {
+			continue
+		}
+		hasParams = true
+		requirement := "optional"
+		if param.Required {
+			requirement = "required"
+		}
+		text := fmt.Sprintf("%s (%s, %s)", param.Name, param.In, requirement)
+		if param.Description != "" {
+			text += " – " + param.Description
+		}
+		blocks = append(blocks, bullet(text))
+	}
+	if !hasParams {
+		blocks = append(blocks, paragraph("This endpoint requires no parameters"))
+	}
+
+	if schema := requestSchema(op); schema != nil {
+		blocks = append(blocks, heading3("Request Body"))
+		if resolved, err := resolver.ResolveSchema(schema); err == nil && resolved != nil {
+			blocks = append(blocks, code(gen.ForRequest().GenerateExampleJSON(resolved), "json"))
+		}
+	}
+
+	if len(op.Responses) > 0 {
+		blocks = append(blocks, heading3("Responses"))
+
+		for _, statusCode := range op.ResponseCodes() {
+			response := op.Responses[statusCode]
+			blocks = append(blocks, bullet(fmt.Sprintf("%s – %s", statusCode, response.Description)))
+
+			schema := response.Schema
+			for _, mediaType := range response.Content {
+				if mediaType.Schema != nil {
+					schema = mediaType.Schema
+					break
+				}
+			}
+			if resolved, err := resolver.ResolveSchema(schema); err == nil && resolved != nil {
+				blocks = append(blocks, code(gen.ForResponse().GenerateExampleJSON(resolved), "json"))
+			}
+		}
+	}
+
+	return blocks
+}
+
+// storageBlocks converts Confluence storage markup to paragraphs of plain
+// text. CDATA sections, the bodies of code macros, become code blocks
+// with their content kept verbatim.
+func storageBlocks(content string) []block {
+	var blocks []block
+	for _, loc := range cdataPattern.FindAllStringSubmatchIndex(content, -1) {
+		markup := content[:loc[0]]
+		blocks = append(blocks, textBlocks(markup)...)
+		blocks = append(blocks, code(content[loc[2]:loc[3]], codeLanguage(markup)))
+		content = content[loc[1]:]
+	}
+	return append(blocks, textBlocks(content)...)
+}
+
+// textBlocks strips the tags and macro parameters from markup and returns
+// a paragraph per line
+func textBlocks(markup string) []block {
+	var blocks []block
+	text := tagPattern.ReplaceAllString(parameterPattern.ReplaceAllString(markup, ""), "")
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(html.UnescapeString(line)); line != "" {
+			blocks = append(blocks, paragraph(line))
+		}
+	}
+	return blocks
+}
+
+// codeLanguage returns the Notion language of the code macro opened last
+// in markup, or "plain text" when it has none Notion knows
+func codeLanguage(markup string) string {
+	matches := languagePattern.FindAllStringSubmatch(markup, -1)
+	if len(matches) == 0 {
+		return "plain text"
+	}
+	if language, ok := codeLanguages[strings.ToLower(matches[len(matches)-1][1])]; ok {
+		return language
+	}
+	return "plain text"
+}
+
+// requestSchema returns the request body schema of an operation, if any
+func requestSchema(op swagger.Operation) *swagger.Schema {
+	if op.RequestBody != nil {
+		if mediaType, ok := op.RequestBody.Content["application/json"]; ok {
+			return mediaType.Schema
+		}
+		for _, mediaType := range op.RequestBody.Content {
+			return mediaType.Schema
+		}
+	}
+	for _, param := range op.Parameters {
+		if param.In == "body" {
+			return param.Schema
+		}
+	}
+	return nil
+}
+
+func heading2(text string) block {
+	return block{Type: "heading_2", Heading2: &textBlock{RichText: texts(text)}}
+}
+
+func heading3(text string) block {
+	return block{Type: "heading_3", Heading3: &textBlock{RichText: texts(text)}}
+}
+
+func paragraph(text string) block {
+	return block{Type: "paragraph", Paragraph: &textBlock{RichText: texts(text)}}
+}
+
+func bullet(text string) block {
+	return block{Type: "bulleted_list_item", BulletedListItem: &textBlock{RichText: texts(text)}}
+}
+
+func code(text, language string) block {
+	return block{Type: "code", Code: &codeBlock{RichText: texts(text), Language: language}}
+}
+
+// texts splits text into rich text objects within the Notion length limit
+func texts(text string) []richText {
+	var result []richText
+	runes := []rune(text)
+	for len(runes) > maxTextLength {
+		result = append(result, richText{Type: "text", Text: textBody{Content: string(runes[:maxTextLength])}})
+		runes = runes[maxTextLength:]
+	}
+	return append(result, richText{Type: "text", Text: textBody{Content: string(runes)}})
+}
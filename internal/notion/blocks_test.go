@@ -0,0 +1,48 @@
+package notion
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStorageBlocks(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []block
+	}{
+		{
+			name:    "paragraphs",
+			content: "<h1>Models</h1>\n<p>Pets &amp; owners</p>",
+			want:    []block{paragraph("Models"), paragraph("Pets & owners")},
+		},
+		{
+			name: "code macro keeps markup in its body",
+			content: `<p>Example</p><ac:structured-macro ac:name="code">` +
+				`<ac:parameter ac:name="language">xml</ac:parameter>` +
+				`<ac:plain-text-body><![CDATA[<pet id="1">a > b</pet>]]></ac:plain-text-body></ac:structured-macro>` +
+				`<p>After</p>`,
+			want: []block{paragraph("Example"), code(`<pet id="1">a > b</pet>`, "xml"), paragraph("After")},
+		},
+		{
+			name: "unknown language",
+			content: `<ac:structured-macro ac:name="code"><ac:parameter ac:name="language">text</ac:parameter>` +
+				`<ac:plain-text-body><![CDATA[GET /pets]]></ac:plain-text-body></ac:structured-macro>`,
+			want: []block{code("GET /pets", "plain text")},
+		},
+		{
+			name: "macro parameters are not text",
+			content: `<ac:structured-macro ac:name="info"><ac:parameter ac:name="title">Note</ac:parameter>` +
+				`<ac:rich-text-body><p>Read only</p></ac:rich-text-body></ac:structured-macro>`,
+			want: []block{paragraph("Read only")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := storageBlocks(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("storageBlocks() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,363 @@
+package notion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/internal/example"
+	"github.com/ahmadimt/SwagFluence/internal/httpclient"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+const (
+	defaultBaseURL = "https://api.notion.com"
+	apiVersion     = "2022-06-28"
+	// maxChildren is the number of blocks Notion accepts per request
+	maxChildren = 100
+)
+
+// Client publishes endpoints to a Notion database, one page per operation
+type Client struct {
+	cfg        config.NotionConfig
+	httpClient *http.Client
+	exampleGen *example.Generator
+}
+
+// NewClient creates a new Notion client
+func NewClient(cfg config.NotionConfig) (*Client, error) {
+	if cfg.Token == "" || (cfg.ParentPageID == "" && cfg.DatabaseID == "") {
+		return nil, fmt.Errorf("notion publisher requires NOTION_TOKEN and NOTION_PARENT_PAGE_ID or NOTION_DATABASE_ID")
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = defaultBaseURL
+	}
+
+	httpClient, err := httpclient.New(cfg.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure notion transport: %w", err)
+	}
+
+	return &Client{
+		cfg:        cfg,
+		httpClient: httpClient,
+		exampleGen: example.NewGenerator(),
+	}, nil
+}
+
+// ResolveParentPage returns the page the endpoint database lives under
+func (c *Client) ResolveParentPage(ctx context.Context) (string, error) {
+	return c.cfg.ParentPageID, nil
+}
+
+// CreateParentPage finds or creates the endpoint database for the API and
+// returns its ID, which later pages use as their parent
+func (c *Client) CreateParentPage(ctx context.Context, apiTitle string) (string, error) {
+	if c.cfg.DatabaseID != "" {
+		return c.cfg.DatabaseID, nil
+	}
+
+	title := confluence.APIPageTitle(apiTitle)
+
+	databaseID, err := c.findDatabase(ctx, title)
+	if err != nil {
+		return "", err
+	}
+	if databaseID != "" {
+		c.cfg.DatabaseID = databaseID
+		return databaseID, nil
+	}
+
+	var created page
+	err = c.do(ctx, http.MethodPost, "/v1/databases", map[string]interface{}{
+		"parent": map[string]string{"type": "page_id", "page_id": c.cfg.ParentPageID},
+		"title":  texts(title),
+		"properties": map[string]interface{}{
+			"Name":   map[string]interface{}{"title": map[string]interface{}{}},
+			"Method": map[string]interface{}{"select": map[string]interface{}{}},
+			"Path":   map[string]interface{}{"rich_text": map[string]interface{}{}},
+			"Tags":   map[string]interface{}{"multi_select": map[string]interface{}{}},
+		},
+	}, &created)
+	if err != nil {
+		return "", fmt.Errorf("failed to create database: %w", err)
+	}
+
+	fmt.Printf("✓ Created database: %s\n", title)
+	c.cfg.DatabaseID = created.ID
+	return created.ID, nil
+}
+
+// findDatabase returns the ID of the database titled exactly title, or ""
+// when there is none. Notion search matches titles loosely, so a search
+// for "Pets" also returns "Pets v2".
+func (c *Client) findDatabase(ctx context.Context, title string) (string, error) {
+	cursor := ""
+	for {
+		request := map[string]interface{}{
+			"query":  title,
+			"filter": map[string]string{"property": "object", "value": "database"},
+		}
+		if cursor != "" {
+			request["start_cursor"] = cursor
+		}
+
+		var search searchResponse
+		if err := c.do(ctx, http.MethodPost, "/v1/search", request, &search); err != nil {
+			return "", fmt.Errorf("failed to search database: %w", err)
+		}
+		for _, database := range search.Results {
+			if database.PlainTitle() == title {
+				return database.ID, nil
+			}
+		}
+
+		if !search.HasMore {
+			return "", nil
+		}
+		cursor = search.NextCursor
+	}
+}
+
+// SetExampleGenerator makes endpoint pages use gen for example bodies
+func (c *Client) SetExampleGenerator(gen *example.Generator) {
+	c.exampleGen = gen
+}
+
+// PublishEndpoint renders an endpoint as blocks and stores it in the
+// database, or below the page parentID names
+func (c *Client) PublishEndpoint(ctx context.Context, endpoint swagger.EndpointInfo, resolver *swagger.Resolver, parentID string) (string, error) {
+	tags := make([]map[string]string, 0, len(endpoint.Operation.Tags))
+	for _, tag := range endpoint.Operation.Tags {
+		// Notion does not allow commas in select options
+		tags = append(tags, map[string]string{"name": strings.ReplaceAll(tag, ",", " ")})
+	}
+
+	properties := map[string]interface{}{
+		"Name":   map[string]interface{}{"title": texts(endpoint.Title)},
+		"Method": map[string]interface{}{"select": map[string]string{"name": strings.ToUpper(endpoint.Method)}},
+		"Path":   map[string]interface{}{"rich_text": texts(endpoint.Path)},
+		"Tags":   map[string]interface{}{"multi_select": tags},
+	}
+
+	return c.upsertPage(ctx, parentID, endpoint.Title, properties,
+		endpointBlocks(endpoint, resolver, c.exampleGen))
+}
+
+// CreateOrUpdatePage stores a generic page in the database, or below the
+// page parentPageID names. Storage markup is reduced to plain text
+// paragraphs.
+func (c *Client) CreateOrUpdatePage(ctx context.Context, title, content, parentPageID string) (string, error) {
+	properties := map[string]interface{}{
+		"Name": map[string]interface{}{"title": texts(title)},
+	}
+	return c.upsertPage(ctx, parentPageID, title, properties, storageBlocks(content))
+}
+
+// PageURL returns the browser URL of a page or database
+func (c *Client) PageURL(pageID string) string {
+	if pageID == "" {
+		return ""
+	}
+	return "https://www.notion.so/" + strings.ReplaceAll(pageID, "-", "")
+}
+
+// upsertPage creates the page or replaces the properties and blocks of an
+// existing page with the same title. Pages go into the endpoint database
+// when parentID is the database; below any other page, such as a version
+// page, they are plain child pages with only a title.
+func (c *Client) upsertPage(ctx context.Context, parentID, title string, properties map[string]interface{}, blocks []block) (string, error) {
+	parent := map[string]string{"type": "database_id", "database_id": parentID}
+	find := c.findDatabasePage
+	if parentID != c.cfg.DatabaseID {
+		parent = map[string]string{"type": "page_id", "page_id": parentID}
+		properties = map[string]interface{}{"title": texts(title)}
+		find = c.findChildPage
+	}
+
+	pageID, err := find(ctx, parentID, title)
+	if err != nil {
+		return "", err
+	}
+
+	if pageID == "" {
+		first, rest := splitBlocks(blocks)
+		var created page
+		err := c.do(ctx, http.MethodPost, "/v1/pages", map[string]interface{}{
+			"parent":     parent,
+			"properties": properties,
+			"children":   first,
+		}, &created)
+		if err != nil {
+			return "", fmt.Errorf("failed to create page: %w", err)
+		}
+		if err := c.appendBlocks(ctx, created.ID, rest); err != nil {
+			return "", err
+		}
+
+		fmt.Printf("✓ Created page: %s\n", title)
+		return created.ID, nil
+	}
+
+	err = c.do(ctx, http.MethodPatch, "/v1/pages/"+pageID, map[string]interface{}{
+		"properties": properties,
+	}, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to update page: %w", err)
+	}
+
+	if err := c.clearBlocks(ctx, pageID); err != nil {
+		return "", err
+	}
+	if err := c.appendBlocks(ctx, pageID, blocks); err != nil {
+		return "", err
+	}
+
+	fmt.Printf("✓ Updated page: %s\n", title)
+	return pageID, nil
+}
+
+// findDatabasePage returns the ID of the database row titled title, or ""
+// when there is none
+func (c *Client) findDatabasePage(ctx context.Context, databaseID, title string) (string, error) {
+	var query queryResponse
+	err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v1/databases/%s/query", databaseID), map[string]interface{}{
+		"filter": map[string]interface{}{
+			"property": "Name",
+			"title":    map[string]string{"equals": title},
+		},
+	}, &query)
+	if err != nil {
+		return "", fmt.Errorf("failed to query database: %w", err)
+	}
+	if len(query.Results) == 0 {
+		return "", nil
+	}
+	return query.Results[0].ID, nil
+}
+
+// findChildPage returns the ID of the child page of pageID titled title,
+// or "" when there is none
+func (c *Client) findChildPage(ctx context.Context, pageID, title string) (string, error) {
+	cursor := ""
+	for {
+		path := fmt.Sprintf("/v1/blocks/%s/children?page_size=100", pageID)
+		if cursor != "" {
+			path += "&start_cursor=" + cursor
+		}
+
+		var children childrenResponse
+		if err := c.do(ctx, http.MethodGet, path, nil, &children); err != nil {
+			return "", fmt.Errorf("failed to list child pages: %w", err)
+		}
+		for _, child := range children.Results {
+			if child.ChildPage != nil && child.ChildPage.Title == title {
+				return child.ID, nil
+			}
+		}
+
+		if !children.HasMore {
+			return "", nil
+		}
+		cursor = children.NextCursor
+	}
+}
+
+// clearBlocks deletes the content blocks of a page. Child pages are kept;
+// deleting their blocks would archive them.
+func (c *Client) clearBlocks(ctx context.Context, pageID string) error {
+	cursor := ""
+	for {
+		path := fmt.Sprintf("/v1/blocks/%s/children?page_size=100", pageID)
+		if cursor != "" {
+			path += "&start_cursor=" + cursor
+		}
+
+		var children childrenResponse
+		if err := c.do(ctx, http.MethodGet, path, nil, &children); err != nil {
+			return fmt.Errorf("failed to list blocks: %w", err)
+		}
+
+		for _, child := range children.Results {
+			if child.ChildPage != nil {
+				continue
+			}
+			if err := c.do(ctx, http.MethodDelete, "/v1/blocks/"+child.ID, nil, nil); err != nil {
+				return fmt.Errorf("failed to delete block: %w", err)
+			}
+		}
+
+		if !children.HasMore {
+			return nil
+		}
+		cursor = children.NextCursor
+	}
+}
+
+// appendBlocks appends blocks to a page in batches
+func (c *Client) appendBlocks(ctx context.Context, pageID string, blocks []block) error {
+	for len(blocks) > 0 {
+		var batch []block
+		batch, blocks = splitBlocks(blocks)
+		err := c.do(ctx, http.MethodPatch, fmt.Sprintf("/v1/blocks/%s/children", pageID),
+			map[string]interface{}{"children": batch}, nil)
+		if err != nil {
+			return fmt.Errorf("failed to append blocks: %w", err)
+		}
+	}
+	return nil
+}
+
+// splitBlocks splits off the first batch of blocks accepted in one request
+func splitBlocks(blocks []block) ([]block, []block) {
+	if len(blocks) <= maxChildren {
+		return blocks, nil
+	}
+	return blocks[:maxChildren], blocks[maxChildren:]
+}
+
+// do sends a request to the Notion API and decodes the response into out
+func (c *Client) do(ctx context.Context, method, path string, payload, out interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.cfg.BaseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+	req.Header.Set("Notion-Version", apiVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(config.NotionConfig{BaseURL: server.URL, Token: "secret", ParentPageID: "root"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// searchResult returns a search response body listing databases by ID and title
+func searchResult(t *testing.T, hasMore bool, databases ...[2]string) []byte {
+	t.Helper()
+	response := map[string]interface{}{"has_more": hasMore, "next_cursor": "next"}
+	results := make([]map[string]interface{}, 0, len(databases))
+	for _, database := range databases {
+		results = append(results, map[string]interface{}{
+			"id":    database[0],
+			"title": []map[string]string{{"plain_text": database[1]}},
+		})
+	}
+	response["results"] = results
+	body, err := json.Marshal(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func TestClient_CreateParentPage(t *testing.T) {
+	tests := []struct {
+		name        string
+		pages       [][]byte
+		wantID      string
+		wantCreated bool
+	}{
+		{
+			name:   "exact title",
+			pages:  [][]byte{searchResult(t, false, [2]string{"db-1", "Pets - API Documentation"})},
+			wantID: "db-1",
+		},
+		{
+			name: "fuzzy matches are skipped",
+			pages: [][]byte{
+				searchResult(t, true, [2]string{"db-2", "Pets v2 - API Documentation"}),
+				searchResult(t, false, [2]string{"db-3", "Petstore - API Documentation"}, [2]string{"db-1", "Pets - API Documentation"}),
+			},
+			wantID: "db-1",
+		},
+		{
+			name:        "no exact match creates the database",
+			pages:       [][]byte{searchResult(t, false, [2]string{"db-2", "Pets v2 - API Documentation"})},
+			wantID:      "db-new",
+			wantCreated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searches := 0
+			created := false
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("Notion-Version") != apiVersion {
+					t.Errorf("missing auth or version headers: %v", r.Header)
+				}
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/v1/search":
+					var search map[string]interface{}
+					if err := json.NewDecoder(r.Body).Decode(&search); err != nil {
+						t.Errorf("failed to decode search: %v", err)
+					}
+					if search["query"] != "Pets - API Documentation" {
+						t.Errorf("query = %v", search["query"])
+					}
+					if searches > 0 && search["start_cursor"] != "next" {
+						t.Errorf("start_cursor = %v, want next", search["start_cursor"])
+					}
+					w.Write(tt.pages[searches])
+					searches++
+				case r.Method == http.MethodPost && r.URL.Path == "/v1/databases":
+					var database struct {
+						Parent map[string]string `json:"parent"`
+						Title  []richText        `json:"title"`
+					}
+					if err := json.NewDecoder(r.Body).Decode(&database); err != nil {
+						t.Errorf("failed to decode database: %v", err)
+					}
+					if database.Parent["page_id"] != "root" || database.Title[0].Text.Content != "Pets - API Documentation" {
+						t.Errorf("database = %+v", database)
+					}
+					created = true
+					w.Write([]byte(`{"id": "db-new"}`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+			})
+
+			id, err := client.CreateParentPage(context.Background(), "Pets")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id != tt.wantID {
+				t.Errorf("CreateParentPage() = %q, want %q", id, tt.wantID)
+			}
+			if created != tt.wantCreated {
+				t.Errorf("database created = %v, want %v", created, tt.wantCreated)
+			}
+			if searches != len(tt.pages) {
+				t.Errorf("searched %d page(s), want %d", searches, len(tt.pages))
+			}
+		})
+	}
+}
+
+func TestClient_CreateOrUpdatePage(t *testing.T) {
+	tests := []struct {
+		name           string
+		parentID       string
+		wantParent     map[string]string
+		wantProperties string
+	}{
+		{
+			name:           "database row",
+			parentID:       "db-1",
+			wantParent:     map[string]string{"type": "database_id", "database_id": "db-1"},
+			wantProperties: `{"Name":{"title":[{"type":"text","text":{"content":"Models"}}]}}`,
+		},
+		{
+			name:           "below a version page",
+			parentID:       "page-v1",
+			wantParent:     map[string]string{"type": "page_id", "page_id": "page-v1"},
+			wantProperties: `{"title":[{"type":"text","text":{"content":"Models"}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var children []block
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/v1/databases/db-1/query":
+					w.Write([]byte(`{"results": []}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/v1/blocks/page-v1/children":
+					w.Write([]byte(`{"results": [{"id": "page-2", "type": "child_page", "child_page": {"title": "Get Pet"}}]}`))
+				case r.Method == http.MethodPost && r.URL.Path == "/v1/pages":
+					var created struct {
+						Parent     map[string]string `json:"parent"`
+						Properties json.RawMessage   `json:"properties"`
+						Children   []block           `json:"children"`
+					}
+					if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+						t.Errorf("failed to decode page: %v", err)
+					}
+					if !reflect.DeepEqual(created.Parent, tt.wantParent) {
+						t.Errorf("parent = %v, want %v", created.Parent, tt.wantParent)
+					}
+					if string(created.Properties) != tt.wantProperties {
+						t.Errorf("properties = %s, want %s", created.Properties, tt.wantProperties)
+					}
+					children = created.Children
+					w.Write([]byte(`{"id": "page-1"}`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+			})
+			client.cfg.DatabaseID = "db-1"
+
+			content := `<p>Pets &amp; owners</p><ac:structured-macro ac:name="code">` +
+				`<ac:parameter ac:name="language">json</ac:parameter>` +
+				`<ac:plain-text-body><![CDATA[{"tag": "<b>"}]]></ac:plain-text-body></ac:structured-macro>`
+			id, err := client.CreateOrUpdatePage(context.Background(), "Models", content, tt.parentID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id != "page-1" {
+				t.Errorf("CreateOrUpdatePage() = %q, want page-1", id)
+			}
+			if len(children) != 2 || children[1].Code == nil || children[1].Code.RichText[0].Text.Content != `{"tag": "<b>"}` {
+				t.Errorf("children = %+v, want a paragraph and the code block", children)
+			}
+		})
+	}
+}
+
+func TestClient_UpdateKeepsChildPages(t *testing.T) {
+	var deleted []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/blocks/root/children":
+			w.Write([]byte(`{"results": [{"id": "page-v1", "type": "child_page", "child_page": {"title": "Pets v1"}}]}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/pages/page-v1":
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/blocks/page-v1/children":
+			w.Write([]byte(`{"results": [{"id": "text-1", "type": "paragraph"}, {"id": "page-2", "type": "child_page", "child_page": {"title": "Get Pet"}}]}`))
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/v1/blocks/"))
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/blocks/page-v1/children":
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+	client.cfg.DatabaseID = "db-1"
+
+	id, err := client.CreateOrUpdatePage(context.Background(), "Pets v1", "<p>Version 1</p>", "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "page-v1" {
+		t.Errorf("CreateOrUpdatePage() = %q, want page-v1", id)
+	}
+	if !reflect.DeepEqual(deleted, []string{"text-1"}) {
+		t.Errorf("deleted blocks = %v, want only the content block", deleted)
+	}
+}
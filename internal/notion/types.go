@@ -0,0 +1,88 @@
+package notion
+
+import "strings"
+
+// richText is a Notion rich text object
+type richText struct {
+	Type string   `json:"type"`
+	Text textBody `json:"text"`
+}
+
+// textBody holds plain text content
+type textBody struct {
+	Content string `json:"content"`
+}
+
+// block is a Notion content block. Only one of the typed fields is set,
+// matching Type.
+type block struct {
+	ID               string     `json:"id,omitempty"`
+	Object           string     `json:"object,omitempty"`
+	Type             string     `json:"type"`
+	Heading2         *textBlock `json:"heading_2,omitempty"`
+	Heading3         *textBlock `json:"heading_3,omitempty"`
+	Paragraph        *textBlock `json:"paragraph,omitempty"`
+	BulletedListItem *textBlock `json:"bulleted_list_item,omitempty"`
+	Code             *codeBlock `json:"code,omitempty"`
+	ChildPage        *childPage `json:"child_page,omitempty"`
+}
+
+// textBlock is the payload of text-like blocks
+type textBlock struct {
+	RichText []richText `json:"rich_text"`
+}
+
+// codeBlock is the payload of a code block
+type codeBlock struct {
+	RichText []richText `json:"rich_text"`
+	Language string     `json:"language"`
+}
+
+// childPage is the payload of a child page block, listed among the
+// children of its parent page
+type childPage struct {
+	Title string `json:"title"`
+}
+
+// page is a Notion page as returned by the API
+type page struct {
+	ID string `json:"id"`
+}
+
+// database is a Notion database as returned by search
+type database struct {
+	ID    string `json:"id"`
+	Title []struct {
+		PlainText string `json:"plain_text"`
+	} `json:"title"`
+}
+
+// PlainTitle returns the title of the database without formatting
+func (d database) PlainTitle() string {
+	var sb strings.Builder
+	for _, text := range d.Title {
+		sb.WriteString(text.PlainText)
+	}
+	return sb.String()
+}
+
+// searchResponse is the result of a database search
+type searchResponse struct {
+	Results    []database `json:"results"`
+	HasMore    bool       `json:"has_more"`
+	NextCursor string     `json:"next_cursor"`
+}
+
+// queryResponse is the result of a database query or search
+type queryResponse struct {
+	Results    []page `json:"results"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// childrenResponse is the result of listing block children
+type childrenResponse struct {
+	Results    []block `json:"results"`
+	HasMore    bool    `json:"has_more"`
+	NextCursor string  `json:"next_cursor"`
+}
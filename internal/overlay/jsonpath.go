@@ -0,0 +1,240 @@
+package overlay
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// segmentKind identifies a JSONPath selector
+type segmentKind int
+
+const (
+	segmentChild segmentKind = iota
+	segmentIndex
+	segmentWildcard
+	segmentDescendant
+)
+
+// segment is a single selector in a JSONPath expression. Descendant
+// segments carry the child name they select ("*" for any).
+type segment struct {
+	kind  segmentKind
+	name  string
+	index int
+}
+
+// node is a value located in the document together with the container
+// holding it, so it can be replaced or removed in place
+type node struct {
+	parent interface{}
+	key    string
+	index  int
+}
+
+// value returns the value at the node
+func (n node) value(root interface{}) interface{} {
+	switch parent := n.parent.(type) {
+	case map[string]interface{}:
+		return parent[n.key]
+	case []interface{}:
+		return parent[n.index]
+	}
+	return root
+}
+
+// set replaces the value at the node; the root cannot be replaced
+func (n node) set(v interface{}) {
+	switch parent := n.parent.(type) {
+	case map[string]interface{}:
+		parent[n.key] = v
+	case []interface{}:
+		parent[n.index] = v
+	}
+}
+
+// parsePath parses the supported JSONPath subset: $, .name, ['name'],
+// [n], .*, [*] and ..name
+func parsePath(path string) ([]segment, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("jsonpath %q must start with $", path)
+	}
+
+	var segments []segment
+	rest := path[1:]
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			name, remaining := readName(rest[2:])
+			if name == "" {
+				return nil, fmt.Errorf("jsonpath %q: expected name after ..", path)
+			}
+			segments = append(segments, segment{kind: segmentDescendant, name: name})
+			rest = remaining
+
+		case strings.HasPrefix(rest, "."):
+			name, remaining := readName(rest[1:])
+			switch name {
+			case "":
+				return nil, fmt.Errorf("jsonpath %q: expected name after .", path)
+			case "*":
+				segments = append(segments, segment{kind: segmentWildcard})
+			default:
+				segments = append(segments, segment{kind: segmentChild, name: name})
+			}
+			rest = remaining
+
+		case strings.HasPrefix(rest, "["):
+			end := closingBracket(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("jsonpath %q: unterminated [", path)
+			}
+			seg, err := parseBracket(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("jsonpath %q: %w", path, err)
+			}
+			segments = append(segments, seg)
+			rest = rest[end+1:]
+
+		default:
+			return nil, fmt.Errorf("jsonpath %q: unexpected %q", path, rest)
+		}
+	}
+
+	return segments, nil
+}
+
+// readName reads a dot-notation member name
+func readName(s string) (string, string) {
+	end := strings.IndexAny(s, ".[")
+	if end < 0 {
+		return s, ""
+	}
+	return s[:end], s[end:]
+}
+
+// closingBracket finds the ] closing a bracket selector, skipping quoted names
+func closingBracket(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote != 0 && s[i] == quote:
+			quote = 0
+		case quote == 0 && (s[i] == '\'' || s[i] == '"'):
+			quote = s[i]
+		case quote == 0 && s[i] == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// parseBracket parses the inside of a bracket selector
+func parseBracket(inner string) (segment, error) {
+	inner = strings.TrimSpace(inner)
+	switch {
+	case inner == "*":
+		return segment{kind: segmentWildcard}, nil
+	case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+		return segment{kind: segmentChild, name: inner[1 : len(inner)-1]}, nil
+	case strings.HasPrefix(inner, "?"):
+		return segment{}, fmt.Errorf("filter expressions are not supported")
+	}
+
+	index, err := strconv.Atoi(inner)
+	if err != nil {
+		return segment{}, fmt.Errorf("unsupported selector [%s]", inner)
+	}
+	return segment{kind: segmentIndex, index: index}, nil
+}
+
+// selectNodes evaluates a parsed path against the document
+func selectNodes(root interface{}, segments []segment) []node {
+	current := []node{{}}
+
+	for _, seg := range segments {
+		var next []node
+		for _, n := range current {
+			value := n.value(root)
+			switch seg.kind {
+			case segmentChild:
+				next = append(next, childNodes(value, seg.name)...)
+			case segmentIndex:
+				if arr, ok := value.([]interface{}); ok {
+					index := seg.index
+					if index < 0 {
+						index += len(arr)
+					}
+					if index >= 0 && index < len(arr) {
+						next = append(next, node{parent: arr, index: index})
+					}
+				}
+			case segmentWildcard:
+				next = append(next, childNodes(value, "*")...)
+			case segmentDescendant:
+				for _, container := range descendants(value) {
+					next = append(next, childNodes(container, seg.name)...)
+				}
+			}
+		}
+		current = next
+	}
+
+	return current
+}
+
+// childNodes returns the named child (or all children for "*") of a container
+func childNodes(value interface{}, name string) []node {
+	switch container := value.(type) {
+	case map[string]interface{}:
+		if name != "*" {
+			if _, ok := container[name]; ok {
+				return []node{{parent: container, key: name}}
+			}
+			return nil
+		}
+		keys := make([]string, 0, len(container))
+		for key := range container {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		nodes := make([]node, 0, len(keys))
+		for _, key := range keys {
+			nodes = append(nodes, node{parent: container, key: key})
+		}
+		return nodes
+
+	case []interface{}:
+		if name != "*" {
+			return nil
+		}
+		nodes := make([]node, 0, len(container))
+		for i := range container {
+			nodes = append(nodes, node{parent: container, index: i})
+		}
+		return nodes
+	}
+	return nil
+}
+
+// descendants returns the value and every container nested inside it
+func descendants(value interface{}) []interface{} {
+	result := []interface{}{value}
+	switch container := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(container))
+		for key := range container {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			result = append(result, descendants(container[key])...)
+		}
+	case []interface{}:
+		for _, item := range container {
+			result = append(result, descendants(item)...)
+		}
+	}
+	return result
+}
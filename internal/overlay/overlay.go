@@ -0,0 +1,163 @@
+package overlay
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Overlay is an OpenAPI Overlay document
+type Overlay struct {
+	Overlay string   `yaml:"overlay"`
+	Info    Info     `yaml:"info"`
+	Actions []Action `yaml:"actions"`
+}
+
+// Info describes the overlay
+type Info struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+// Action is a single overlay modification
+type Action struct {
+	Target      string      `yaml:"target"`
+	Description string      `yaml:"description"`
+	Update      interface{} `yaml:"update"`
+	Remove      bool        `yaml:"remove"`
+}
+
+// removed marks values deleted by an action until the document is compacted
+type removed struct{}
+
+// Load reads an overlay document from a JSON or YAML file
+func Load(path string) (*Overlay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overlay: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse parses an overlay document
+func Parse(data []byte) (*Overlay, error) {
+	var o Overlay
+	if err := yaml.Unmarshal(data, &o); err != nil {
+		return nil, fmt.Errorf("failed to parse overlay: %w", err)
+	}
+	if o.Overlay == "" {
+		return nil, fmt.Errorf("not an overlay document: missing overlay version")
+	}
+	for i, action := range o.Actions {
+		if _, err := parsePath(action.Target); err != nil {
+			return nil, fmt.Errorf("action %d: %w", i+1, err)
+		}
+	}
+	return &o, nil
+}
+
+// Apply applies the overlay actions in order to a decoded JSON document
+func (o *Overlay) Apply(doc interface{}) (interface{}, error) {
+	for i, action := range o.Actions {
+		segments, err := parsePath(action.Target)
+		if err != nil {
+			return nil, fmt.Errorf("action %d: %w", i+1, err)
+		}
+
+		for _, n := range selectNodes(doc, segments) {
+			switch {
+			case action.Remove:
+				if n.parent == nil {
+					return nil, fmt.Errorf("action %d: cannot remove the document root", i+1)
+				}
+				n.set(removed{})
+			case action.Update != nil:
+				merged := merge(n.value(doc), normalize(action.Update))
+				if n.parent == nil {
+					doc = merged
+				} else {
+					n.set(merged)
+				}
+			}
+		}
+
+		doc = compact(doc)
+	}
+
+	return doc, nil
+}
+
+// merge applies an update to a target: objects are merged recursively,
+// arrays get the update appended and anything else is replaced
+func merge(target, update interface{}) interface{} {
+	switch t := target.(type) {
+	case map[string]interface{}:
+		u, ok := update.(map[string]interface{})
+		if !ok {
+			return update
+		}
+		for key, value := range u {
+			if existing, ok := t[key]; ok {
+				t[key] = merge(existing, value)
+			} else {
+				t[key] = value
+			}
+		}
+		return t
+	case []interface{}:
+		if items, ok := update.([]interface{}); ok {
+			return append(t, items...)
+		}
+		return append(t, update)
+	}
+	return update
+}
+
+// compact drops values marked as removed
+func compact(value interface{}) interface{} {
+	switch container := value.(type) {
+	case map[string]interface{}:
+		for key, item := range container {
+			if _, ok := item.(removed); ok {
+				delete(container, key)
+				continue
+			}
+			container[key] = compact(item)
+		}
+	case []interface{}:
+		kept := container[:0]
+		for _, item := range container {
+			if _, ok := item.(removed); !ok {
+				kept = append(kept, compact(item))
+			}
+		}
+		return kept
+	}
+	return value
+}
+
+// normalize converts YAML-decoded values into JSON-compatible ones
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = normalize(item)
+		}
+		return out
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[fmt.Sprint(key)] = normalize(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = normalize(item)
+		}
+		return out
+	}
+	return value
+}
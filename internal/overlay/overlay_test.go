@@ -0,0 +1,61 @@
+package overlay
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOverlay_Apply(t *testing.T) {
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(`{
+		"info": {"title": "Pets", "description": "old"},
+		"paths": {
+			"/pets": {
+				"get": {"summary": "List pets", "tags": ["pets"], "x-internal": true},
+				"post": {"summary": "Create pet", "tags": ["pets"]}
+			}
+		}
+	}`), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := Parse([]byte(`
+overlay: 1.0.0
+info:
+  title: Docs improvements
+  version: 1.0.0
+actions:
+  - target: $.info
+    update:
+      description: Better description
+  - target: "$.paths['/pets'].get.tags"
+    update: listing
+  - target: $..x-internal
+    remove: true
+  - target: $.paths.*.post
+    remove: true
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	result, err := o.Apply(doc)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	got, _ := json.Marshal(result)
+	want := `{"info":{"description":"Better description","title":"Pets"},` +
+		`"paths":{"/pets":{"get":{"summary":"List pets","tags":["pets","listing"]}}}}`
+	if string(got) != want {
+		t.Errorf("Apply() = %s, want %s", got, want)
+	}
+}
+
+func TestParsePath_Unsupported(t *testing.T) {
+	for _, path := range []string{"paths", "$.paths[?(@.get)]", "$.paths['/pets'"} {
+		if _, err := parsePath(path); err == nil {
+			t.Errorf("parsePath(%q) expected error", path)
+		}
+	}
+}
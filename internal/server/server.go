@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// QueueSize is the number of sync requests that can wait while another
+// sync runs; further requests are rejected until one finishes
+const QueueSize = 16
+
+// maxBodySize limits the size of sync request bodies
+const maxBodySize = 64 << 10
+
+// Job is a requested sync. Empty fields use the configured settings.
+type Job struct {
+	// Spec is the URL of the spec to publish
+	Spec string `json:"spec,omitempty"`
+	// Space is the key of the Confluence space to publish to
+	Space string `json:"space,omitempty"`
+}
+
+// SyncFunc publishes the documentation for a job
+type SyncFunc func(ctx context.Context, job Job) error
+
+// Server accepts sync requests over HTTP and runs them one at a time in the
+// background, so webhooks get an answer without waiting for the publish
+type Server struct {
+	secret string
+	sync   SyncFunc
+	jobs   chan Job
+}
+
+// New creates a Server that runs sync for the requests authenticated with
+// secret
+func New(secret string, sync SyncFunc) (*Server, error) {
+	if secret == "" {
+		return nil, errors.New("a webhook secret is required")
+	}
+	return &Server{
+		secret: secret,
+		sync:   sync,
+		jobs:   make(chan Job, QueueSize),
+	}, nil
+}
+
+// Handler returns the HTTP handler serving POST /sync and GET /healthz
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /sync", s.handleSync)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok\n"))
+	})
+	return mux
+}
+
+// handleSync queues a sync. The body is optional; it may name the spec URL
+// and space to publish.
+func (s *Server) handleSync(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		http.Error(w, "invalid or missing secret", http.StatusUnauthorized)
+		return
+	}
+
+	var job Job
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+	if len(strings.TrimSpace(string(body))) > 0 {
+		if err := json.Unmarshal(body, &job); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+	// Local paths would let callers publish any file the server can read
+	if job.Spec != "" && !swagger.IsURL(job.Spec) {
+		http.Error(w, "spec must be an http(s) URL", http.StatusBadRequest)
+		return
+	}
+
+	select {
+	case s.jobs <- job:
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("sync queued\n"))
+	default:
+		http.Error(w, "too many pending syncs", http.StatusServiceUnavailable)
+	}
+}
+
+// authorized reports whether a request carries the secret as a bearer token
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.secret)) == 1
+}
+
+// Work runs the queued syncs one at a time until ctx is done. A failing
+// sync is reported and doesn't stop the server.
+func (s *Server) Work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-s.jobs:
+			if err := s.sync(ctx, job); err != nil {
+				fmt.Printf("Warning: sync failed: %v\n", err)
+			}
+		}
+	}
+}
+
+// ListenAndServe serves the handler on addr and runs the queued syncs until
+// ctx is done, then stops accepting requests and waits for the running sync
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Work(ctx)
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("Listening on %s (POST /sync)\n", addr)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	<-done
+	return nil
+}
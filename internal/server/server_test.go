@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServer_HandleSync(t *testing.T) {
+	tests := []struct {
+		name       string
+		auth       string
+		body       string
+		wantStatus int
+		wantJob    *Job
+	}{
+		{name: "no secret", body: "", wantStatus: http.StatusUnauthorized},
+		{name: "wrong secret", auth: "Bearer nope", wantStatus: http.StatusUnauthorized},
+		{name: "empty body", auth: "Bearer s3cret", wantStatus: http.StatusAccepted, wantJob: &Job{}},
+		{
+			name:       "spec and space",
+			auth:       "Bearer s3cret",
+			body:       `{"spec": "https://example.com/openapi.json", "space": "DOCS"}`,
+			wantStatus: http.StatusAccepted,
+			wantJob:    &Job{Spec: "https://example.com/openapi.json", Space: "DOCS"},
+		},
+		{name: "local path", auth: "Bearer s3cret", body: `{"spec": "/etc/passwd"}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid body", auth: "Bearer s3cret", body: `{`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, err := New("s3cret", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/sync", strings.NewReader(tt.body))
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			select {
+			case job := <-srv.jobs:
+				if tt.wantJob == nil || job != *tt.wantJob {
+					t.Errorf("queued %+v, want %+v", job, tt.wantJob)
+				}
+			default:
+				if tt.wantJob != nil {
+					t.Errorf("nothing queued, want %+v", *tt.wantJob)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_Work(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var got []Job
+	srv, err := New("s3cret", func(ctx context.Context, job Job) error {
+		got = append(got, job)
+		if len(got) == 2 {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv.jobs <- Job{Space: "A"}
+	srv.jobs <- Job{Space: "B"}
+	srv.Work(ctx)
+
+	if len(got) != 2 || got[0].Space != "A" || got[1].Space != "B" {
+		t.Errorf("ran %+v, want the jobs in order", got)
+	}
+}
+
+func TestNew_RequiresSecret(t *testing.T) {
+	if _, err := New("", nil); err == nil {
+		t.Error("expected an error without a secret")
+	}
+}
@@ -0,0 +1,293 @@
+package site
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"regexp"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/docfile"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// macroPattern matches the Confluence macro and resource elements of
+// storage markup, which browsers don't understand
+var macroPattern = regexp.MustCompile(`</?(ac|ri):[^>]*>`)
+
+// layoutData is the data of the page layout
+type layoutData struct {
+	Site  string
+	Title string
+	// Body is the rendered HTML of the page content
+	Body       string
+	Prev, Next *docfile.Entry
+}
+
+// layout wraps page content in a standalone HTML document with a link to
+// the index and to the neighbouring pages
+var layout = template.Must(template.New("page").Funcs(template.FuncMap{
+	"html": func(s string) template.HTML { return template.HTML(s) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 960px; margin: 0 auto; padding: 0 1rem 2rem; color: #172b4d; }
+header { border-bottom: 1px solid #dfe1e6; padding: 1rem 0; }
+header a { font-weight: bold; text-decoration: none; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1rem; }
+th, td { border: 1px solid #dfe1e6; padding: 0.4rem 0.6rem; text-align: left; vertical-align: top; }
+th { background: #f4f5f7; }
+pre { background: #f4f5f7; padding: 0.8rem; overflow-x: auto; }
+.method { font-weight: bold; padding: 0.1rem 0.4rem; border-radius: 3px; background: #deebff; }
+.pager { display: flex; justify-content: space-between; border-top: 1px solid #dfe1e6; padding-top: 1rem; }
+</style>
+</head>
+<body>
+<header><a href="index.html">{{.Site}}</a></header>
+<main>
+{{html .Body}}
+</main>
+{{if or .Prev .Next}}<nav class="pager">
+<span>{{with .Prev}}<a href="{{.File}}">&larr; {{.Title}}</a>{{end}}</span>
+<span>{{with .Next}}<a href="{{.File}}">{{.Title}} &rarr;</a>{{end}}</span>
+</nav>
+{{end}}</body>
+</html>
+`))
+
+// renderLayout renders a complete HTML document
+func renderLayout(data layoutData) (string, error) {
+	var sb strings.Builder
+	if err := layout.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render page %q: %w", data.Title, err)
+	}
+	return sb.String(), nil
+}
+
+// format renders pages as standalone HTML documents
+type format struct{}
+
+// Ext returns the HTML file extension
+func (format) Ext() string {
+	return ext
+}
+
+// Endpoint renders the content of an endpoint page as HTML
+func (format) Endpoint(page *docfile.EndpointPage) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "<h1>%s</h1>\n", html.EscapeString(page.Title))
+	fmt.Fprintf(&sb, "<p><span class=\"method\">%s</span> <code>%s</code></p>\n",
+		page.Method, html.EscapeString(page.Path))
+
+	if page.Summary != "" {
+		writeParagraphs(&sb, page.Summary)
+	}
+	if page.Description != "" {
+		writeParagraphs(&sb, page.Description)
+	}
+	if page.OperationID != "" {
+		fmt.Fprintf(&sb, "<p><strong>Operation ID:</strong> <code>%s</code></p>\n", html.EscapeString(page.OperationID))
+	}
+	if len(page.Tags) > 0 {
+		fmt.Fprintf(&sb, "<p><strong>Tags:</strong> %s</p>\n", html.EscapeString(strings.Join(page.Tags, ", ")))
+	}
+
+	sb.WriteString("<h2>Parameters</h2>\n")
+	writeParameters(&sb, page.Parameters)
+
+	if request := page.Request; request != nil {
+		sb.WriteString("<h2>Request Body</h2>\n")
+		if request.ContentType != "" {
+			fmt.Fprintf(&sb, "<p>Content type: <code>%s</code></p>\n", html.EscapeString(request.ContentType))
+		}
+		if request.Schema != nil {
+			writeSchema(&sb, request.Schema)
+			writeRequestExample(&sb, request.Minimal, request.Full)
+		}
+	}
+
+	if len(page.Responses) > 0 {
+		sb.WriteString("<h2>Responses</h2>\n")
+		for _, response := range page.Responses {
+			fmt.Fprintf(&sb, "<h3>%s</h3>\n", html.EscapeString(response.Code))
+			if response.Description != "" {
+				writeParagraphs(&sb, response.Description)
+			}
+			if response.Schema != nil {
+				writeSchema(&sb, response.Schema)
+				writeExample(&sb, response.Example)
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// Page renders a generic page. Confluence macros are dropped from the
+// storage markup; the remaining XHTML is kept.
+func (format) Page(title, content string) string {
+	return storageHTML(content)
+}
+
+// Document wraps the page content in the layout, linking the pages before
+// and after it
+func (format) Document(title string, entries []docfile.Entry, i int) (string, error) {
+	page := layoutData{Site: title, Title: entries[i].Title, Body: entries[i].Content()}
+	if i > 0 {
+		page.Prev = &entries[i-1]
+	}
+	if i < len(entries)-1 {
+		page.Next = &entries[i+1]
+	}
+	return renderLayout(page)
+}
+
+// Index renders the index page linking every written page
+func (format) Index(title string, entries []docfile.Entry) (string, error) {
+	var sb strings.Builder
+
+	var endpoints, pages []docfile.Entry
+	for _, entry := range entries {
+		if entry.Method != "" {
+			endpoints = append(endpoints, entry)
+		} else {
+			pages = append(pages, entry)
+		}
+	}
+
+	if len(endpoints) > 0 {
+		sb.WriteString("<h2>Endpoints</h2>\n")
+		sb.WriteString("<table>\n<tr><th>Method</th><th>Path</th><th>Page</th></tr>\n")
+		for _, entry := range endpoints {
+			fmt.Fprintf(&sb, "<tr><td><span class=\"method\">%s</span></td><td><code>%s</code></td><td><a href=\"%s\">%s</a></td></tr>\n",
+				entry.Method, html.EscapeString(entry.Path), html.EscapeString(entry.File), html.EscapeString(entry.Title))
+		}
+		sb.WriteString("</table>\n")
+	}
+	if len(pages) > 0 {
+		sb.WriteString("<h2>Pages</h2>\n<ul>\n")
+		for _, entry := range pages {
+			fmt.Fprintf(&sb, "<li><a href=\"%s\">%s</a></li>\n", html.EscapeString(entry.File), html.EscapeString(entry.Title))
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	return renderLayout(layoutData{Site: title, Title: title, Body: sb.String()})
+}
+
+// writeParagraphs writes text as escaped paragraphs, split at blank lines
+func writeParagraphs(sb *strings.Builder, text string) {
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			fmt.Fprintf(sb, "<p>%s</p>\n", html.EscapeString(paragraph))
+		}
+	}
+}
+
+// writeParameters writes the parameters as a table
+func writeParameters(sb *strings.Builder, params []docfile.Parameter) {
+	if len(params) == 0 {
+		sb.WriteString("<p>This endpoint requires no parameters.</p>\n")
+		return
+	}
+
+	sb.WriteString("<table>\n<tr><th>Name</th><th>In</th><th>Type</th><th>Required</th><th>Description</th></tr>\n")
+	for _, param := range params {
+		fmt.Fprintf(sb, "<tr><td><code>%s</code></td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+			html.EscapeString(param.Name), html.EscapeString(param.In), typeName(param.Type, param.Format),
+			yesNo(param.Required), html.EscapeString(param.Description))
+	}
+	sb.WriteString("</table>\n")
+}
+
+// writeSchema writes the properties of a schema as a table, or the variants
+// of a oneOf/anyOf schema
+func writeSchema(sb *strings.Builder, table *docfile.SchemaTable) {
+	if table.ArrayOf != "" {
+		fmt.Fprintf(sb, "<p>Array of <code>%s</code>.</p>\n", html.EscapeString(table.ArrayOf))
+	}
+
+	if len(table.Fields) > 0 {
+		sb.WriteString("<table>\n<tr><th>Field</th><th>Type</th><th>Required</th><th>Description</th></tr>\n")
+		for _, field := range table.Fields {
+			fmt.Fprintf(sb, "<tr><td><code>%s</code></td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+				html.EscapeString(field.Name), propertyType(field.Property), yesNo(field.Required),
+				html.EscapeString(field.Property.Description))
+		}
+		sb.WriteString("</table>\n")
+	}
+
+	for _, group := range table.Variants {
+		fmt.Fprintf(sb, "<p><strong>%s:</strong></p>\n<ul>\n", group.Label)
+		for _, variant := range group.Variants {
+			fmt.Fprintf(sb, "<li>%s", html.EscapeString(variant.Name))
+			if variant.Type != "" {
+				fmt.Fprintf(sb, " (<code>%s</code>)", html.EscapeString(variant.Type))
+			}
+			sb.WriteString("</li>\n")
+		}
+		sb.WriteString("</ul>\n")
+	}
+}
+
+// writeExample writes an example JSON code block
+func writeExample(sb *strings.Builder, exampleJSON string) {
+	if exampleJSON == "" {
+		return
+	}
+	fmt.Fprintf(sb, "<pre><code class=\"language-json\">%s</code></pre>\n", html.EscapeString(exampleJSON))
+}
+
+// writeRequestExample writes a generated request example, as minimal and
+// full bodies in collapsible sections when they differ
+func writeRequestExample(sb *strings.Builder, minimal, full string) {
+	if minimal == "" {
+		writeExample(sb, full)
+		return
+	}
+	fmt.Fprintf(sb, "<details>\n<summary>Minimal example (required fields only)</summary>\n<pre><code class=\"language-json\">%s</code></pre>\n</details>\n", html.EscapeString(minimal))
+	fmt.Fprintf(sb, "<details>\n<summary>Full example</summary>\n<pre><code class=\"language-json\">%s</code></pre>\n</details>\n", html.EscapeString(full))
+}
+
+// storageHTML drops the Confluence macros from storage markup, keeping the
+// XHTML around and inside them
+func storageHTML(content string) string {
+	return macroPattern.ReplaceAllString(content, "")
+}
+
+// propertyType describes the type of a property as HTML
+func propertyType(prop swagger.Property) string {
+	switch {
+	case prop.Ref != "":
+		return "<code>" + html.EscapeString(swagger.ExtractRefName(prop.Ref)) + "</code>"
+	case prop.Type == "array" && prop.Items != nil:
+		return "array of <code>" + html.EscapeString(docfile.ItemName(prop.Items)) + "</code>"
+	default:
+		return typeName(prop.Type, prop.Format)
+	}
+}
+
+// typeName formats a type with its format as HTML, e.g.
+// <code>integer</code> (int64)
+func typeName(typ, format string) string {
+	if typ == "" {
+		return ""
+	}
+	name := "<code>" + html.EscapeString(typ) + "</code>"
+	if format != "" {
+		name += " (" + html.EscapeString(format) + ")"
+	}
+	return name
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
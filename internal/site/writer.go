@@ -0,0 +1,23 @@
+package site
+
+import (
+	"github.com/ahmadimt/SwagFluence/internal/docfile"
+)
+
+// IndexFile is the name of the index page linking every written page
+const IndexFile = "index" + ext
+
+// DefaultDir is used when no output directory is configured
+const DefaultDir = "docs/site"
+
+const ext = ".html"
+
+// NewWriter creates a publisher that renders the parsed spec as a static
+// HTML site in dir: one standalone file per endpoint, an index, and
+// previous/next links between pages
+func NewWriter(dir string) (*docfile.Writer, error) {
+	if dir == "" {
+		dir = DefaultDir
+	}
+	return docfile.NewWriter(dir, format{})
+}
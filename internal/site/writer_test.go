@@ -0,0 +1,120 @@
+package site
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func TestWriter_PublishEndpoint(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWriter(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec := &swagger.Spec{
+		Definitions: map[string]swagger.Definition{
+			"Pet": {
+				Type:     "object",
+				Required: []string{"name"},
+				Properties: map[string]swagger.Property{
+					"name": {Type: "string", Description: "Pet <name>"},
+					"tags": {Type: "array", Items: &swagger.Schema{Type: "string"}},
+				},
+				PropertyOrder: []string{"name", "tags"},
+			},
+		},
+	}
+	endpoints := []swagger.EndpointInfo{
+		{
+			Path:   "/pets/{id}",
+			Method: "get",
+			Title:  "Get Pet",
+			Operation: swagger.Operation{
+				Summary:     "Find a pet",
+				OperationID: "getPet",
+				Parameters:  []swagger.Parameter{{Name: "id", In: "path", Type: "integer", Format: "int64", Required: true}},
+				Responses: swagger.Responses{
+					"200": {Description: "The pet", Schema: &swagger.Schema{Ref: "#/definitions/Pet"}},
+				},
+			},
+		},
+		{Path: "/pets/{id}", Method: "delete", Title: "Delete Pet"},
+	}
+
+	ctx := context.Background()
+	if _, err := w.CreateParentPage(ctx, "Pet Store"); err != nil {
+		t.Fatal(err)
+	}
+	resolver := swagger.NewResolver(spec)
+	for _, endpoint := range endpoints {
+		if _, err := w.PublishEndpoint(ctx, endpoint, resolver, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	read := func(file string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{
+			file: "get-pet.html",
+			want: []string{
+				"<title>Get Pet</title>",
+				`<a href="index.html">Pet Store - API Documentation</a>`,
+				"<h1>Get Pet</h1>",
+				`<span class="method">GET</span> <code>/pets/{id}</code>`,
+				"<p>Find a pet</p>",
+				"<tr><td><code>id</code></td><td>path</td><td><code>integer</code> (int64)</td><td>yes</td><td></td></tr>",
+				"<tr><td><code>name</code></td><td><code>string</code></td><td>yes</td><td>Pet &lt;name&gt;</td></tr>",
+				"<td>array of <code>string</code></td>",
+				`<pre><code class="language-json">{`,
+				`<a href="delete-pet.html">Delete Pet &rarr;</a>`,
+			},
+		},
+		{
+			file: "delete-pet.html",
+			want: []string{`<a href="get-pet.html">&larr; Get Pet</a>`},
+		},
+		{
+			file: IndexFile,
+			want: []string{
+				"<title>Pet Store - API Documentation</title>",
+				`<td><code>/pets/{id}</code></td><td><a href="get-pet.html">Get Pet</a></td>`,
+				`<a href="delete-pet.html">Delete Pet</a>`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			page := read(tt.file)
+			for _, want := range tt.want {
+				if !strings.Contains(page, want) {
+					t.Errorf("expected %q in:\n%s", want, page)
+				}
+			}
+		})
+	}
+}
+
+func TestStorageHTML(t *testing.T) {
+	got := storageHTML(`<ac:structured-macro ac:name="info"><ac:rich-text-body><p>Hi</p></ac:rich-text-body></ac:structured-macro>`)
+	if got != "<p>Hi</p>" {
+		t.Errorf("storageHTML() = %q, want <p>Hi</p>", got)
+	}
+}
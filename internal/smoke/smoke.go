@@ -0,0 +1,144 @@
+package smoke
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/collection"
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/httpclient"
+)
+
+// defaultParamValue fills path and query parameters without a configured value
+const defaultParamValue = "1"
+
+// Result is the outcome of executing one example request
+type Result struct {
+	Method   string `json:"method"`
+	Path     string `json:"path"`
+	URL      string `json:"url,omitempty"`
+	Status   int    `json:"status,omitempty"`
+	Verified bool   `json:"verified"`
+	// Skipped is set for write requests when writes are not allowed
+	Skipped bool   `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Summary describes the result in a few words
+func (r Result) Summary() string {
+	switch {
+	case r.Skipped:
+		return "not executed (write request)"
+	case r.Error != "":
+		return "failed: " + r.Error
+	default:
+		return fmt.Sprintf("HTTP %d", r.Status)
+	}
+}
+
+// Runner executes generated example requests against a live server
+type Runner struct {
+	cfg        config.SmokeConfig
+	httpClient *http.Client
+}
+
+// NewRunner creates a Runner for the configured base URL
+func NewRunner(cfg config.SmokeConfig) (*Runner, error) {
+	if cfg.BaseURL == "" {
+		return nil, fmt.Errorf("smoke tests require a base URL")
+	}
+
+	httpClient, err := httpclient.New(cfg.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure smoke test transport: %w", err)
+	}
+	// Redirects count as working examples; don't follow them off the sandbox
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return &Runner{
+		cfg:        cfg,
+		httpClient: httpClient,
+	}, nil
+}
+
+// Run executes a single request. Only GET requests are sent unless writes
+// are allowed; any 2xx or 3xx status verifies the example.
+func (r *Runner) Run(ctx context.Context, req collection.Request) Result {
+	result := Result{Method: req.Method, Path: req.Path}
+
+	if req.Method != http.MethodGet && !r.cfg.AllowWrites {
+		result.Skipped = true
+		return result
+	}
+
+	result.URL = r.requestURL(req)
+
+	var body io.Reader
+	if req.Body != "" {
+		body = strings.NewReader(req.Body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, result.URL, body)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if req.ContentType != "" && req.Body != "" {
+		httpReq.Header.Set("Content-Type", req.ContentType)
+	}
+	for _, header := range r.cfg.Headers {
+		if name, value, ok := strings.Cut(header, ":"); ok {
+			httpReq.Header.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+		}
+	}
+
+	resp, err := r.httpClient.Do(httpReq)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer resp.Body.Close()
+
+	result.Status = resp.StatusCode
+	// A response cut off mid-body doesn't verify the example
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		result.Error = fmt.Sprintf("failed to read response: %v", err)
+		return result
+	}
+	result.Verified = resp.StatusCode >= 200 && resp.StatusCode < 400
+	return result
+}
+
+// requestURL builds the absolute URL, filling path and query parameters
+func (r *Runner) requestURL(req collection.Request) string {
+	path := req.Path
+	for _, name := range req.PathParams {
+		path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(r.paramValue(name)))
+	}
+
+	query := url.Values{}
+	for _, name := range req.QueryParams {
+		if value, ok := r.cfg.Params[name]; ok {
+			query.Set(name, value)
+		}
+	}
+
+	fullURL := strings.TrimSuffix(r.cfg.BaseURL, "/") + path
+	if len(query) > 0 {
+		fullURL += "?" + query.Encode()
+	}
+	return fullURL
+}
+
+func (r *Runner) paramValue(name string) string {
+	if value, ok := r.cfg.Params[name]; ok {
+		return value
+	}
+	return defaultParamValue
+}
@@ -0,0 +1,80 @@
+package smoke
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/collection"
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+func TestRunner_Run(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.RequestURI() {
+		case "/pets/7?status=sold":
+			w.WriteHeader(http.StatusOK)
+		case "/pets/8":
+			// The connection closes before the announced body is sent
+			w.Header().Set("Content-Length", "100")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id"`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		cfg          config.SmokeConfig
+		req          collection.Request
+		wantVerified bool
+		wantSkipped  bool
+		wantStatus   int
+		wantErr      bool
+	}{
+		{
+			name: "configured parameters",
+			cfg:  config.SmokeConfig{Params: map[string]string{"petId": "7", "status": "sold"}},
+			req: collection.Request{Method: "GET", Path: "/pets/{petId}",
+				PathParams: []string{"petId"}, QueryParams: []string{"status"}},
+			wantVerified: true,
+			wantStatus:   http.StatusOK,
+		},
+		{
+			name:       "default parameters",
+			req:        collection.Request{Method: "GET", Path: "/pets/{petId}", PathParams: []string{"petId"}},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "truncated response",
+			cfg:        config.SmokeConfig{Params: map[string]string{"petId": "8"}},
+			req:        collection.Request{Method: "GET", Path: "/pets/{petId}", PathParams: []string{"petId"}},
+			wantStatus: http.StatusOK,
+			wantErr:    true,
+		},
+		{
+			name:        "writes skipped",
+			req:         collection.Request{Method: "DELETE", Path: "/pets/{petId}"},
+			wantSkipped: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.BaseURL = server.URL
+			runner, err := NewRunner(tt.cfg)
+			if err != nil {
+				t.Fatalf("NewRunner() error = %v", err)
+			}
+
+			result := runner.Run(context.Background(), tt.req)
+			if result.Verified != tt.wantVerified || result.Skipped != tt.wantSkipped || result.Status != tt.wantStatus ||
+				(result.Error != "") != tt.wantErr {
+				t.Errorf("Run() = %+v", result)
+			}
+		})
+	}
+}
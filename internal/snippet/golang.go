@@ -0,0 +1,92 @@
+package snippet
+
+import (
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/example"
+)
+
+// Go renders a request as a Go program using net/http
+func Go(req Request) string {
+	var sb strings.Builder
+
+	hasFile := slices.ContainsFunc(req.Form, func(field example.FormField) bool { return field.File })
+
+	sb.WriteString("package main\n\nimport (\n")
+	if len(req.Form) > 0 {
+		sb.WriteString("\t\"bytes\"\n")
+	}
+	sb.WriteString("\t\"fmt\"\n\t\"io\"\n")
+	if len(req.Form) > 0 {
+		sb.WriteString("\t\"mime/multipart\"\n")
+	}
+	sb.WriteString("\t\"net/http\"\n")
+	if hasFile {
+		sb.WriteString("\t\"os\"\n")
+	}
+	if req.Body != "" {
+		sb.WriteString("\t\"strings\"\n")
+	}
+	sb.WriteString(")\n\nfunc main() {\n")
+
+	body := "nil"
+	if len(req.Form) > 0 {
+		writeGoForm(&sb, req.Form)
+		body = "&body"
+	}
+	if req.Body != "" {
+		literal := "`" + req.Body + "`"
+		if strings.Contains(req.Body, "`") {
+			literal = strconv.Quote(req.Body)
+		}
+		sb.WriteString("\tbody := strings.NewReader(" + literal + ")\n")
+		body = "body"
+	}
+	sb.WriteString("\treq, err := http.NewRequest(" + strconv.Quote(req.Method) + ", " + strconv.Quote(req.URL) + ", " + body + ")\n")
+	sb.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+	if len(req.Form) > 0 {
+		sb.WriteString("\treq.Header.Set(\"Content-Type\", form.FormDataContentType())\n")
+	}
+	for _, header := range req.Headers {
+		sb.WriteString("\treq.Header.Set(" + strconv.Quote(header.Name) + ", " + strconv.Quote(header.Value) + ")\n")
+	}
+	if req.BasicAuth != "" {
+		user, password := credentials(req)
+		sb.WriteString("\treq.SetBasicAuth(" + strconv.Quote(user) + ", " + strconv.Quote(password) + ")\n")
+	}
+
+	sb.WriteString("\n\tresp, err := http.DefaultClient.Do(req)\n")
+	sb.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+	sb.WriteString("\tdefer resp.Body.Close()\n\n")
+	sb.WriteString("\tdata, err := io.ReadAll(resp.Body)\n")
+	sb.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+	sb.WriteString("\tfmt.Println(resp.Status, string(data))\n")
+	sb.WriteString("}")
+
+	return sb.String()
+}
+
+// writeGoForm writes the statements building a multipart body named body
+// from its writer form
+func writeGoForm(sb *strings.Builder, fields []example.FormField) {
+	sb.WriteString("\tvar body bytes.Buffer\n")
+	sb.WriteString("\tform := multipart.NewWriter(&body)\n")
+	for _, field := range fields {
+		if !field.File {
+			sb.WriteString("\tif err := form.WriteField(" + strconv.Quote(field.Name) + ", " + strconv.Quote(field.Value) + "); err != nil {\n\t\tpanic(err)\n\t}\n")
+			continue
+		}
+		// A block per file keeps the variables of several uploads apart
+		sb.WriteString("\t{\n")
+		sb.WriteString("\t\tdata, err := os.ReadFile(" + strconv.Quote(field.Value) + ")\n")
+		sb.WriteString("\t\tif err != nil {\n\t\t\tpanic(err)\n\t\t}\n")
+		sb.WriteString("\t\tpart, err := form.CreateFormFile(" + strconv.Quote(field.Name) + ", " + strconv.Quote(path.Base(field.Value)) + ")\n")
+		sb.WriteString("\t\tif err != nil {\n\t\t\tpanic(err)\n\t\t}\n")
+		sb.WriteString("\t\tif _, err := part.Write(data); err != nil {\n\t\t\tpanic(err)\n\t\t}\n")
+		sb.WriteString("\t}\n")
+	}
+	sb.WriteString("\tif err := form.Close(); err != nil {\n\t\tpanic(err)\n\t}\n")
+}
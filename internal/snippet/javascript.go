@@ -0,0 +1,55 @@
+package snippet
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/example"
+)
+
+// JavaScript renders a request as JavaScript using fetch
+func JavaScript(req Request) string {
+	var sb strings.Builder
+
+	if slices.ContainsFunc(req.Form, func(field example.FormField) bool { return field.File }) {
+		sb.WriteString("import { openAsBlob } from \"node:fs\";\n\n")
+	}
+	if len(req.Form) > 0 {
+		sb.WriteString("const form = new FormData();\n")
+		for _, field := range req.Form {
+			if field.File {
+				sb.WriteString("form.append(" + quote(field.Name) + ", await openAsBlob(" + quote(field.Value) + "));\n")
+				continue
+			}
+			sb.WriteString("form.append(" + quote(field.Name) + ", " + quote(field.Value) + ");\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("const response = await fetch(" + quote(req.URL) + ", {\n")
+	sb.WriteString("  method: " + quote(req.Method) + ",\n")
+	if len(req.Headers) > 0 || req.BasicAuth != "" {
+		sb.WriteString("  headers: {\n")
+		if req.BasicAuth != "" {
+			sb.WriteString("    \"Authorization\": \"Basic \" + btoa(" + quote(req.BasicAuth) + "),\n")
+		}
+		for _, header := range req.Headers {
+			sb.WriteString("    " + quote(header.Name) + ": " + quote(header.Value) + ",\n")
+		}
+		sb.WriteString("  },\n")
+	}
+	if req.Body != "" {
+		// Template literals keep the example body readable
+		body := strings.ReplaceAll(req.Body, `\`, `\\`)
+		body = strings.ReplaceAll(body, "`", "\\`")
+		body = strings.ReplaceAll(body, "${", "\\${")
+		sb.WriteString("  body: `" + body + "`,\n")
+	}
+	if len(req.Form) > 0 {
+		sb.WriteString("  body: form,\n")
+	}
+	sb.WriteString("});\n")
+	sb.WriteString("console.log(response.status, await response.text());")
+
+	return sb.String()
+}
@@ -0,0 +1,62 @@
+package snippet
+
+import (
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/example"
+)
+
+// Python renders a request as a Python script using requests
+func Python(req Request) string {
+	var sb strings.Builder
+	sb.WriteString("import requests\n\n")
+
+	if req.Body != "" {
+		// Triple quotes keep the example body readable
+		body := strings.ReplaceAll(req.Body, `\`, `\\`)
+		body = strings.ReplaceAll(body, `"""`, `\"\"\"`)
+		sb.WriteString("payload = \"\"\"" + body + "\"\"\"\n\n")
+	}
+
+	sb.WriteString("response = requests.request(\n")
+	sb.WriteString("    " + quote(req.Method) + ",\n")
+	sb.WriteString("    " + quote(req.URL) + ",\n")
+	if len(req.Headers) > 0 {
+		sb.WriteString("    headers={\n")
+		for _, header := range req.Headers {
+			sb.WriteString("        " + quote(header.Name) + ": " + quote(header.Value) + ",\n")
+		}
+		sb.WriteString("    },\n")
+	}
+	if req.BasicAuth != "" {
+		user, password := credentials(req)
+		sb.WriteString("    auth=(" + quote(user) + ", " + quote(password) + "),\n")
+	}
+	if req.Body != "" {
+		sb.WriteString("    data=payload,\n")
+	}
+	writePythonForm(&sb, req.Form)
+	sb.WriteString(")\n")
+	sb.WriteString("print(response.status_code, response.text)")
+
+	return sb.String()
+}
+
+// writePythonForm writes the fields of a multipart body as the data and
+// files arguments
+func writePythonForm(sb *strings.Builder, form []example.FormField) {
+	var data, files []string
+	for _, field := range form {
+		if field.File {
+			files = append(files, "        "+quote(field.Name)+": open("+quote(field.Value)+", \"rb\"),\n")
+		} else {
+			data = append(data, "        "+quote(field.Name)+": "+quote(field.Value)+",\n")
+		}
+	}
+	if len(data) > 0 {
+		sb.WriteString("    data={\n" + strings.Join(data, "") + "    },\n")
+	}
+	if len(files) > 0 {
+		sb.WriteString("    files={\n" + strings.Join(files, "") + "    },\n")
+	}
+}
@@ -0,0 +1,55 @@
+package snippet
+
+import "strings"
+
+// Curl renders a request as a curl command
+func Curl(req Request) string {
+	lines := []string{"curl -X " + req.Method + " " + shellQuote(req.URL)}
+	if req.BasicAuth != "" {
+		lines = append(lines, "-u "+shellQuote(req.BasicAuth))
+	}
+	for _, header := range req.Headers {
+		lines = append(lines, "-H "+shellQuote(header.Name+": "+header.Value))
+	}
+	if req.Body != "" {
+		lines = append(lines, "-d "+shellQuote(req.Body))
+	}
+	for _, field := range req.Form {
+		value := field.Value
+		if field.File {
+			value = "@" + value
+		}
+		lines = append(lines, "-F "+shellQuote(field.Name+"="+value))
+	}
+	return strings.Join(lines, " \\\n  ")
+}
+
+// HTTPie renders a request as an HTTPie command
+func HTTPie(req Request) string {
+	lines := []string{"http " + req.Method + " " + shellQuote(req.URL)}
+	if len(req.Form) > 0 {
+		lines[0] = "http --multipart " + req.Method + " " + shellQuote(req.URL)
+	}
+	if req.BasicAuth != "" {
+		lines = append(lines, "-a "+shellQuote(req.BasicAuth))
+	}
+	for _, header := range req.Headers {
+		lines = append(lines, shellQuote(header.Name+":"+header.Value))
+	}
+	if req.Body != "" {
+		lines = append(lines, "--raw "+shellQuote(req.Body))
+	}
+	for _, field := range req.Form {
+		separator := "="
+		if field.File {
+			separator = "@"
+		}
+		lines = append(lines, shellQuote(field.Name+separator+field.Value))
+	}
+	return strings.Join(lines, " \\\n  ")
+}
+
+// shellQuote quotes a string for POSIX shells
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
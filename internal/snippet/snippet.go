@@ -0,0 +1,212 @@
+package snippet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/example"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// Generator renders requests as code in one language
+type Generator struct {
+	// Language names the generator in the samples setting
+	Language string
+	// Title is the display name of the language
+	Title string
+	// Syntax is the code macro language used for highlighting
+	Syntax string
+	Render func(req Request) string
+}
+
+// DefaultGenerators returns the built-in generators
+func DefaultGenerators() []Generator {
+	return []Generator{
+		{Language: "curl", Title: "curl", Syntax: "bash", Render: Curl},
+		{Language: "httpie", Title: "HTTPie", Syntax: "bash", Render: HTTPie},
+		{Language: "python", Title: "Python", Syntax: "python", Render: Python},
+		{Language: "javascript", Title: "JavaScript", Syntax: "javascript", Render: JavaScript},
+		{Language: "go", Title: "Go", Syntax: "go", Render: Go},
+	}
+}
+
+// Languages returns the languages of the built-in generators
+func Languages() []string {
+	generators := DefaultGenerators()
+	languages := make([]string, 0, len(generators))
+	for _, g := range generators {
+		languages = append(languages, g.Language)
+	}
+	return languages
+}
+
+// Lookup returns the built-in generator for a language
+func Lookup(language string) (Generator, error) {
+	for _, g := range DefaultGenerators() {
+		if g.Language == language {
+			return g, nil
+		}
+	}
+	return Generator{}, fmt.Errorf("unknown code sample language %q (expected one of %s)",
+		language, strings.Join(Languages(), ", "))
+}
+
+// DefaultServerURL stands in for the base URL of specs that document none
+const DefaultServerURL = "https://api.example.com"
+
+// Request is an example call of one operation. Credentials are
+// placeholders such as <token>.
+type Request struct {
+	Method string
+	// URL includes the path parameters and the query string
+	URL     string
+	Headers []Header
+	// BasicAuth is the user:password pair of HTTP basic authentication
+	BasicAuth string
+	Body      string
+	// Form holds the fields of a multipart/form-data body, sent instead of
+	// Body. The client sets the Content-Type with its boundary.
+	Form []example.FormField
+}
+
+// Header is a request header
+type Header struct {
+	Name  string
+	Value string
+}
+
+// BuildRequest builds an example call of an operation from the first server
+// it is served from, sample parameter values, the first security requirement that
+// applies and the example request body
+func BuildRequest(spec *swagger.Spec, path, method string, op swagger.Operation, resolver *swagger.Resolver, gen *example.Generator) Request {
+	base := ""
+	if servers := spec.ServersFor(op); len(servers) > 0 {
+		base = servers[0].ResolvedURL()
+	}
+	if !strings.Contains(base, "://") {
+		base = DefaultServerURL + base
+	}
+
+	req := Request{Method: strings.ToUpper(method)}
+	var query, cookies []string
+
+	for _, param := range op.Parameters {
+		value, ok := sampleValue(param)
+		switch param.In {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+param.Name+"}", url.PathEscape(value))
+		case "query":
+			if ok || param.Required {
+				query = append(query, url.QueryEscape(param.Name)+"="+url.QueryEscape(value))
+			}
+		case "header":
+			if ok || param.Required {
+				req.Headers = append(req.Headers, Header{Name: param.Name, Value: value})
+			}
+		case "cookie":
+			if ok || param.Required {
+				cookies = append(cookies, param.Name+"="+value)
+			}
+		}
+	}
+
+	if requirements := spec.SecurityFor(op); len(requirements) > 0 {
+		schemes := spec.SecuritySchemes()
+		for _, name := range requirements[0].SchemeNames() {
+			scheme, ok := schemes[name]
+			if !ok {
+				continue
+			}
+			switch {
+			case scheme.Type == "apiKey" && scheme.In == "query":
+				query = append(query, url.QueryEscape(scheme.Name)+"=<api-key>")
+			case scheme.Type == "apiKey" && scheme.In == "cookie":
+				cookies = append(cookies, scheme.Name+"=<api-key>")
+			case scheme.Type == "apiKey":
+				req.Headers = append(req.Headers, Header{Name: scheme.Name, Value: "<api-key>"})
+			case scheme.Type == "basic", scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+				req.BasicAuth = "<username>:<password>"
+			case scheme.Type == "http" && !strings.EqualFold(scheme.Scheme, "bearer"):
+				// Other HTTP schemes, such as digest, have no simple sample
+			default:
+				// Bearer tokens, OAuth2 and OpenID Connect
+				req.Headers = append(req.Headers, Header{Name: "Authorization", Value: "Bearer <token>"})
+			}
+		}
+	}
+
+	if len(cookies) > 0 {
+		req.Headers = append(req.Headers, Header{Name: "Cookie", Value: strings.Join(cookies, "; ")})
+	}
+
+	contentType, body := gen.GenerateRequestExample(op, resolver)
+	if example.IsMultipart(contentType) {
+		_, req.Form = gen.GenerateFormExample(op, resolver)
+	} else if body != "" {
+		req.Headers = append(req.Headers, Header{Name: "Content-Type", Value: contentType})
+		req.Body = body
+	}
+
+	req.URL = base + path
+	if len(query) > 0 {
+		req.URL += "?" + strings.Join(query, "&")
+	}
+	return req
+}
+
+// sampleValue returns a value for a parameter and whether it was documented
+// as an example, default or allowed value rather than made up from the type
+func sampleValue(param swagger.Parameter) (string, bool) {
+	typ, enum, def := param.Type, param.Enum, param.Default
+	if param.Schema != nil {
+		if typ == "" {
+			typ = param.Schema.Type
+		}
+		if len(enum) == 0 {
+			enum = param.Schema.Enum
+		}
+		if def == nil {
+			def = param.Schema.Default
+		}
+	}
+
+	switch {
+	case param.Example != nil:
+		return fmt.Sprintf("%v", param.Example), true
+	case def != nil:
+		return fmt.Sprintf("%v", def), true
+	case len(enum) > 0:
+		return fmt.Sprintf("%v", enum[0]), true
+	}
+
+	switch typ {
+	case "integer", "number":
+		return "1", false
+	case "boolean":
+		return "true", false
+	default:
+		return "string", false
+	}
+}
+
+// quote returns s as a double-quoted string literal, which Python,
+// JavaScript and Go all accept
+func quote(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return strconv.Quote(s)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// credentials splits the basic authentication pair into user and password
+func credentials(req Request) (string, string) {
+	user, password, _ := strings.Cut(req.BasicAuth, ":")
+	return user, password
+}
@@ -0,0 +1,298 @@
+package snippet
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/example"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+func TestBuildRequest(t *testing.T) {
+	spec := &swagger.Spec{
+		Host:     "petstore.io",
+		BasePath: "/v2",
+		SecurityDefinitions: map[string]swagger.SecurityScheme{
+			"key": {Type: "apiKey", Name: "X-API-Key", In: "header"},
+		},
+		Security: []swagger.SecurityRequirement{{"key": {}}},
+	}
+	op := swagger.Operation{
+		Parameters: []swagger.Parameter{
+			{Name: "petId", In: "path", Required: true, Type: "integer"},
+			{Name: "status", In: "query", Type: "string", Enum: []interface{}{"sold", "pending"}},
+			{Name: "verbose", In: "query", Type: "boolean"},
+			{Name: "body", In: "body", Schema: &swagger.Schema{Type: "object", Properties: map[string]swagger.Property{"name": {Type: "string", Example: "Rex"}}}},
+		},
+	}
+
+	req := BuildRequest(spec, "/pets/{petId}", "put", op, swagger.NewResolver(spec), example.NewGenerator())
+
+	tests := []struct {
+		name     string
+		language string
+		want     string
+	}{
+		{
+			name:     "curl",
+			language: "curl",
+			want: `curl -X PUT 'https://petstore.io/v2/pets/1?status=sold' \
+  -H 'X-API-Key: <api-key>' \
+  -H 'Content-Type: application/json' \
+  -d '{
+  "name": "Rex"
+}'`,
+		},
+		{
+			name:     "httpie",
+			language: "httpie",
+			want: `http PUT 'https://petstore.io/v2/pets/1?status=sold' \
+  'X-API-Key:<api-key>' \
+  'Content-Type:application/json' \
+  --raw '{
+  "name": "Rex"
+}'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Lookup(tt.language)
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+			if got := g.Render(req); got != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Lookup("cobol"); err == nil {
+		t.Error("expected error for unknown language")
+	}
+}
+
+func TestGenerators_Clients(t *testing.T) {
+	req := Request{
+		Method:    "POST",
+		URL:       "https://api.example.com/pets",
+		Headers:   []Header{{Name: "Content-Type", Value: "application/json"}},
+		BasicAuth: "<username>:<password>",
+		Body:      "{\n  \"name\": \"Rex `the` \\\"dog\\\"\"\n}",
+	}
+
+	tests := []struct {
+		language string
+		want     []string
+	}{
+		{
+			language: "python",
+			want: []string{
+				"import requests",
+				`payload = """{`,
+				`    "https://api.example.com/pets",`,
+				`        "Content-Type": "application/json",`,
+				`    auth=("<username>", "<password>"),`,
+				"    data=payload,",
+			},
+		},
+		{
+			language: "javascript",
+			want: []string{
+				`const response = await fetch("https://api.example.com/pets", {`,
+				`  method: "POST",`,
+				`    "Authorization": "Basic " + btoa("<username>:<password>"),`,
+				"Rex \\`the\\`",
+			},
+		},
+		{
+			language: "go",
+			want: []string{
+				`req, err := http.NewRequest("POST", "https://api.example.com/pets", body)`,
+				`req.SetBasicAuth("<username>", "<password>")`,
+				`req.Header.Set("Content-Type", "application/json")`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			g, err := Lookup(tt.language)
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+			got := g.Render(req)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("expected %q in:\n%s", want, got)
+				}
+			}
+			if tt.language == "go" {
+				formatted, err := format.Source([]byte(got))
+				if err != nil {
+					t.Fatalf("generated Go does not parse: %v\n%s", err, got)
+				}
+				if string(formatted) != got+"\n" {
+					t.Errorf("generated Go is not gofmt-formatted:\n%s", got)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildRequest_Auth(t *testing.T) {
+	tests := []struct {
+		name       string
+		scheme     swagger.SecurityScheme
+		wantURL    string
+		wantHeader Header
+		wantBasic  string
+	}{
+		{
+			name:       "bearer",
+			scheme:     swagger.SecurityScheme{Type: "http", Scheme: "bearer"},
+			wantURL:    "https://api.example.com/me",
+			wantHeader: Header{Name: "Authorization", Value: "Bearer <token>"},
+		},
+		{
+			name:       "oauth2",
+			scheme:     swagger.SecurityScheme{Type: "oauth2"},
+			wantURL:    "https://api.example.com/me",
+			wantHeader: Header{Name: "Authorization", Value: "Bearer <token>"},
+		},
+		{
+			name:      "basic",
+			scheme:    swagger.SecurityScheme{Type: "http", Scheme: "basic"},
+			wantURL:   "https://api.example.com/me",
+			wantBasic: "<username>:<password>",
+		},
+		{
+			name:    "query api key",
+			scheme:  swagger.SecurityScheme{Type: "apiKey", Name: "api_key", In: "query"},
+			wantURL: "https://api.example.com/me?api_key=<api-key>",
+		},
+		{
+			name:       "cookie api key",
+			scheme:     swagger.SecurityScheme{Type: "apiKey", Name: "session", In: "cookie"},
+			wantURL:    "https://api.example.com/me",
+			wantHeader: Header{Name: "Cookie", Value: "session=<api-key>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &swagger.Spec{
+				Components: &swagger.Components{SecuritySchemes: map[string]swagger.SecurityScheme{"auth": tt.scheme}},
+				Security:   []swagger.SecurityRequirement{{"auth": {}}},
+			}
+			req := BuildRequest(spec, "/me", "get", swagger.Operation{}, swagger.NewResolver(spec), example.NewGenerator())
+
+			if req.URL != tt.wantURL {
+				t.Errorf("URL = %q, want %q", req.URL, tt.wantURL)
+			}
+			if req.BasicAuth != tt.wantBasic {
+				t.Errorf("BasicAuth = %q, want %q", req.BasicAuth, tt.wantBasic)
+			}
+			if tt.wantHeader.Name != "" && (len(req.Headers) != 1 || req.Headers[0] != tt.wantHeader) {
+				t.Errorf("Headers = %+v, want %+v", req.Headers, tt.wantHeader)
+			}
+		})
+	}
+}
+
+func TestBuildRequest_Forms(t *testing.T) {
+	spec := &swagger.Spec{Host: "petstore.io"}
+	multipart := swagger.Operation{
+		RequestBody: &swagger.RequestBody{Content: map[string]swagger.MediaType{
+			"multipart/form-data": {Schema: &swagger.Schema{
+				Type: "object",
+				Properties: map[string]swagger.Property{
+					"caption": {Type: "string", Example: "Rex at the beach"},
+					"photo":   {Type: "string", Format: "binary"},
+				},
+				PropertyOrder: []string{"caption", "photo"},
+			}},
+		}},
+	}
+	urlencoded := swagger.Operation{
+		Consumes: []string{"application/x-www-form-urlencoded"},
+		Parameters: []swagger.Parameter{
+			{Name: "name", In: "formData", Type: "string", Example: "Rex"},
+			{Name: "age", In: "formData", Type: "integer", Example: 3},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		op       swagger.Operation
+		language string
+		want     []string
+	}{
+		{
+			name:     "multipart curl",
+			op:       multipart,
+			language: "curl",
+			want: []string{`curl -X POST 'https://petstore.io/pets' \
+  -F 'caption=Rex at the beach' \
+  -F 'photo=@path/to/file'`},
+		},
+		{
+			name:     "multipart httpie",
+			op:       multipart,
+			language: "httpie",
+			want:     []string{"http --multipart POST", `'caption=Rex at the beach'`, `'photo@path/to/file'`},
+		},
+		{
+			name:     "multipart python",
+			op:       multipart,
+			language: "python",
+			want:     []string{`"caption": "Rex at the beach",`, `"photo": open("path/to/file", "rb"),`},
+		},
+		{
+			name:     "multipart javascript",
+			op:       multipart,
+			language: "javascript",
+			want:     []string{`form.append("photo", await openAsBlob("path/to/file"));`, "  body: form,"},
+		},
+		{
+			name:     "multipart go",
+			op:       multipart,
+			language: "go",
+			want:     []string{`form.CreateFormFile("photo", "file")`, `req.Header.Set("Content-Type", form.FormDataContentType())`},
+		},
+		{
+			name:     "urlencoded curl",
+			op:       urlencoded,
+			language: "curl",
+			want: []string{`curl -X POST 'https://petstore.io/pets' \
+  -H 'Content-Type: application/x-www-form-urlencoded' \
+  -d 'name=Rex&age=3'`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := BuildRequest(spec, "/pets", "post", tt.op, swagger.NewResolver(spec), example.NewGenerator())
+			g, err := Lookup(tt.language)
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+			got := g.Render(req)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("expected %q in:\n%s", want, got)
+				}
+			}
+			if tt.language == "go" {
+				formatted, err := format.Source([]byte(got))
+				if err != nil {
+					t.Fatalf("generated Go does not parse: %v\n%s", err, got)
+				}
+				if string(formatted) != got+"\n" {
+					t.Errorf("generated Go is not gofmt-formatted:\n%s", got)
+				}
+			}
+		})
+	}
+}
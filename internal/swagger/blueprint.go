@@ -0,0 +1,632 @@
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// API Blueprint (https://apiblueprint.org) is line oriented: Markdown
+// headers introduce groups, resources and actions, and nested "+" lists
+// hold parameters, requests, responses and MSON attributes.
+var (
+	bpHeaderPattern         = regexp.MustCompile(`^(#+)\s*(.*?)\s*#*\s*$`)
+	bpBulletPattern         = regexp.MustCompile(`^(\s*)[+\-*]\s+(.*)$`)
+	bpResourcePattern       = regexp.MustCompile(`^(.*?)\s*\[(/[^\]]*)\]$`)
+	bpActionPattern         = regexp.MustCompile(`^(.*?)\s*\[(GET|POST|PUT|DELETE|PATCH|OPTIONS|HEAD)(?:\s+(\S+))?\]$`)
+	bpEndpointPattern       = regexp.MustCompile(`^(GET|POST|PUT|DELETE|PATCH|OPTIONS|HEAD)\s+(/\S*)$`)
+	bpPayloadPattern        = regexp.MustCompile(`^(Request|Response)(?:\s+([^(\s]+))?(?:\s*\(([^)]*)\))?$`)
+	bpAttributesPattern     = regexp.MustCompile(`^Attributes(?:\s*\(([^)]*)\))?$`)
+	bpStructurePattern      = regexp.MustCompile(`^(.*?)(?:\s*\(([^)]*)\))?$`)
+	bpPropertyPattern       = regexp.MustCompile("^([^:(]+?)(?::\\s*(`[^`]*`|[^(]*?))?\\s*(?:\\(([^)]*)\\))?\\s*(?:(?:-|\\.\\.\\.)\\s*(.*))?$")
+	bpLegacyParamPattern    = regexp.MustCompile("^(\\S+)\\s*\\(([^)]*)\\)\\s*\\.\\.\\.\\s*(.*)$")
+	bpURITemplateVarPattern = regexp.MustCompile(`\{([?&#+]?)([^}]*)\}`)
+)
+
+// bpPayload is a request or response being parsed
+type bpPayload struct {
+	code        string
+	contentType string
+	description []string
+	body        []string
+	schema      *Schema
+	indent      int
+}
+
+// bpAction is an action (operation) being parsed
+type bpAction struct {
+	method    string
+	path      string
+	operation Operation
+	params    []Parameter
+	requests  []*bpPayload
+	responses []*bpPayload
+}
+
+// bpParser holds the state of a blueprint parse
+type bpParser struct {
+	spec Spec
+
+	group          string
+	resourcePath   string
+	resourceParams []Parameter
+	resourceDesc   []string
+	action         *bpAction
+	actions        []*bpAction
+	payload        *bpPayload
+	structure      string
+	inStructures   bool
+
+	// section is the list the current bullets belong to; only its first
+	// level of nested bullets is read
+	section       string
+	sectionIndent int
+	nestedIndent  int
+	// bodyIndent is the indentation of a message body code block, or -1
+	bodyIndent int
+	bodyTarget *[]string
+	apiDesc    []string
+}
+
+// isBlueprint reports whether a document looks like API Blueprint
+func isBlueprint(body []byte) bool {
+	return strings.HasPrefix(strings.TrimSpace(string(body)), "FORMAT: 1A")
+}
+
+// parseBlueprint converts an API Blueprint document into an OpenAPI 3 spec
+func parseBlueprint(body []byte) (*Spec, error) {
+	p := &bpParser{
+		spec: Spec{
+			OpenAPI:    "3.0.0",
+			Paths:      make(map[string]PathItem),
+			Components: &Components{Schemas: make(map[string]Definition)},
+		},
+		bodyIndent: -1,
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(body), "\r\n", "\n"), "\n")
+	for _, line := range lines {
+		p.parseLine(line)
+	}
+	p.finishAction()
+
+	if p.spec.Info.Title == "" {
+		return nil, fmt.Errorf("failed to parse API Blueprint: missing API name header")
+	}
+	p.spec.Info.Description = joinText(p.apiDesc)
+
+	for _, action := range p.actions {
+		path, _ := splitURITemplate(action.path)
+		item := p.spec.Paths[path]
+		if item == nil {
+			item = make(PathItem)
+			p.spec.Paths[path] = item
+			p.spec.PathOrder = append(p.spec.PathOrder, path)
+		}
+		item[action.method] = action.build()
+	}
+
+	if len(p.spec.Components.Schemas) == 0 {
+		p.spec.Components = nil
+	}
+
+	return &p.spec, nil
+}
+
+func (p *bpParser) parseLine(line string) {
+	// Message bodies are indented code blocks
+	if p.bodyIndent >= 0 {
+		indent := indentation(line)
+		if strings.TrimSpace(line) == "" || indent >= p.bodyIndent {
+			*p.bodyTarget = append(*p.bodyTarget, strings.TrimPrefix(line, strings.Repeat(" ", p.bodyIndent)))
+			return
+		}
+		p.bodyIndent = -1
+	}
+
+	if match := bpHeaderPattern.FindStringSubmatch(line); match != nil && !strings.HasPrefix(line, " ") {
+		p.parseHeader(len(match[1]), match[2])
+		return
+	}
+
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+
+	if match := bpBulletPattern.FindStringSubmatch(line); match != nil {
+		p.parseBullet(len(match[1]), strings.TrimSpace(match[2]))
+		return
+	}
+
+	// A payload body may follow the payload bullet directly
+	if p.payload != nil && indentation(line) >= p.payload.indent+8 {
+		p.startBody(p.payload.indent+8, &p.payload.body)
+		p.parseLine(line)
+		return
+	}
+
+	p.parseText(strings.TrimSpace(line))
+}
+
+func (p *bpParser) parseHeader(level int, text string) {
+	p.section = ""
+	p.payload = nil
+
+	switch {
+	case p.spec.Info.Title == "" && level == 1:
+		p.spec.Info.Title = text
+		p.spec.Info.Version = "1.0"
+		return
+	case text == "Data Structures":
+		p.finishAction()
+		p.inStructures = true
+		return
+	case strings.HasPrefix(text, "Group "):
+		p.finishAction()
+		p.inStructures = false
+		p.group = strings.TrimSpace(strings.TrimPrefix(text, "Group "))
+		p.spec.Tags = append(p.spec.Tags, Tag{Name: p.group})
+		p.resourcePath = ""
+		return
+	}
+
+	if p.inStructures {
+		match := bpStructurePattern.FindStringSubmatch(text)
+		p.structure = match[1]
+		p.spec.Components.Schemas[p.structure] = Definition{
+			Type:       "object",
+			Properties: make(map[string]Property),
+		}
+		p.openSection("structure", -1)
+		return
+	}
+
+	if match := bpEndpointPattern.FindStringSubmatch(text); match != nil {
+		p.startResource(match[2])
+		p.startAction(match[1], "", "")
+		return
+	}
+	if match := bpActionPattern.FindStringSubmatch(text); match != nil {
+		p.startAction(match[2], match[1], match[3])
+		return
+	}
+	if match := bpResourcePattern.FindStringSubmatch(text); match != nil {
+		p.startResource(match[2])
+		return
+	}
+}
+
+func (p *bpParser) startResource(uri string) {
+	p.finishAction()
+	p.resourcePath = uri
+	p.resourceParams = nil
+	p.resourceDesc = nil
+}
+
+func (p *bpParser) startAction(method, name, uri string) {
+	p.finishAction()
+	if uri == "" {
+		uri = p.resourcePath
+	}
+
+	action := &bpAction{
+		method: strings.ToLower(method),
+		path:   uri,
+		operation: Operation{
+			Summary:   name,
+			Responses: make(Responses),
+		},
+	}
+	if p.group != "" {
+		action.operation.Tags = []string{p.group}
+	}
+	action.params = append(action.params, p.resourceParams...)
+	p.action = action
+}
+
+// finishAction records the current action
+func (p *bpParser) finishAction() {
+	if p.action == nil {
+		return
+	}
+	if p.action.operation.Description == "" {
+		p.action.operation.Description = joinText(p.resourceDesc)
+	}
+	p.actions = append(p.actions, p.action)
+	p.action = nil
+	p.payload = nil
+}
+
+func (p *bpParser) parseBullet(indent int, text string) {
+	if p.section == "structure" {
+		if p.nestedIndent < 0 {
+			p.nestedIndent = indent
+		}
+		if indent == p.nestedIndent {
+			p.addStructureProperty(text)
+		}
+		return
+	}
+
+	// Nested bullets belong to the open section
+	if p.section != "" && indent > p.sectionIndent {
+		if p.nestedIndent < 0 {
+			p.nestedIndent = indent
+		}
+		if indent != p.nestedIndent {
+			return
+		}
+		switch p.section {
+		case "parameters":
+			p.addParameter(text)
+		case "attributes":
+			if schema := p.payload.schema; schema.Properties != nil {
+				name, prop, required := parseMSONProperty(text)
+				schema.Properties[name] = prop
+				schema.PropertyOrder = append(schema.PropertyOrder, name)
+				if required {
+					schema.Required = append(schema.Required, name)
+				}
+			}
+		case "payload":
+			p.parsePayloadSection(indent, text)
+		}
+		return
+	}
+
+	// Siblings of a closed attributes list still belong to the payload
+	if p.payload != nil && indent > p.payload.indent {
+		p.openSection("payload", p.payload.indent)
+		p.nestedIndent = indent
+		p.parsePayloadSection(indent, text)
+		return
+	}
+
+	p.section = ""
+
+	switch {
+	case text == "Parameters":
+		p.payload = nil
+		p.openSection("parameters", indent)
+	case bpPayloadPattern.MatchString(text) && p.action != nil:
+		match := bpPayloadPattern.FindStringSubmatch(text)
+		payload := &bpPayload{contentType: match[3], indent: indent}
+		if match[1] == "Response" {
+			payload.code = match[2]
+			if payload.code == "" {
+				payload.code = "200"
+			}
+			p.action.responses = append(p.action.responses, payload)
+		} else {
+			p.action.requests = append(p.action.requests, payload)
+		}
+		p.payload = payload
+		p.openSection("payload", indent)
+	case bpAttributesPattern.MatchString(text) && p.action != nil:
+		// Action-level attributes describe the request body
+		payload := &bpPayload{indent: indent}
+		p.action.requests = append(p.action.requests, payload)
+		p.payload = payload
+		p.startAttributes(indent, bpAttributesPattern.FindStringSubmatch(text)[1])
+	}
+}
+
+func (p *bpParser) parsePayloadSection(indent int, text string) {
+	switch {
+	case text == "Body":
+		p.startBody(indent+4, &p.payload.body)
+	case text == "Headers" || text == "Schema":
+		var discard []string
+		p.startBody(indent+4, &discard)
+	case bpAttributesPattern.MatchString(text):
+		p.startAttributes(indent, bpAttributesPattern.FindStringSubmatch(text)[1])
+	}
+}
+
+func (p *bpParser) startAttributes(indent int, typeSpec string) {
+	p.openSection("attributes", indent)
+	p.payload.schema = mSONSchema(typeSpec)
+}
+
+func (p *bpParser) openSection(section string, indent int) {
+	p.section = section
+	p.sectionIndent = indent
+	p.nestedIndent = -1
+}
+
+func (p *bpParser) startBody(indent int, target *[]string) {
+	p.bodyIndent = indent
+	p.bodyTarget = target
+}
+
+func (p *bpParser) addParameter(text string) {
+	param := Parameter{In: "query"}
+
+	if match := bpLegacyParamPattern.FindStringSubmatch(text); match != nil {
+		param.Name = match[1]
+		param.Description = match[3]
+		for _, attr := range strings.Split(match[2], ",") {
+			applyParamAttribute(&param, strings.TrimSpace(attr))
+		}
+	} else {
+		name, prop, required := parseMSONProperty(text)
+		param.Name = name
+		param.Description = prop.Description
+		param.Type = prop.Type
+		param.Required = required
+	}
+	if param.Type == "" {
+		param.Type = "string"
+	}
+
+	path := p.resourcePath
+	if p.action != nil {
+		path = p.action.path
+	}
+	if strings.Contains(path, "{"+param.Name+"}") {
+		param.In = "path"
+		param.Required = true
+	}
+
+	if p.action != nil {
+		p.action.params = append(p.action.params, param)
+	} else {
+		p.resourceParams = append(p.resourceParams, param)
+	}
+}
+
+func (p *bpParser) addStructureProperty(text string) {
+	def := p.spec.Components.Schemas[p.structure]
+	name, prop, required := parseMSONProperty(text)
+	def.Properties[name] = prop
+	def.PropertyOrder = append(def.PropertyOrder, name)
+	if required {
+		def.Required = append(def.Required, name)
+	}
+	p.spec.Components.Schemas[p.structure] = def
+}
+
+func (p *bpParser) parseText(text string) {
+	switch {
+	case p.spec.Info.Title == "":
+		// Metadata such as FORMAT and HOST precedes the API name
+	case p.payload != nil:
+		p.payload.description = append(p.payload.description, text)
+	case p.action != nil:
+		p.action.operation.Description = strings.TrimSpace(p.action.operation.Description + "\n" + text)
+	case p.resourcePath != "":
+		p.resourceDesc = append(p.resourceDesc, text)
+	case p.group == "" && !p.inStructures:
+		p.apiDesc = append(p.apiDesc, text)
+	}
+}
+
+// build converts the parsed action into an operation
+func (a *bpAction) build() Operation {
+	op := a.operation
+
+	path, query := splitURITemplate(a.path)
+	seen := make(map[string]bool)
+	for i := len(a.params) - 1; i >= 0; i-- {
+		// Action parameters override resource parameters of the same name
+		param := a.params[i]
+		if seen[param.Name] {
+			continue
+		}
+		seen[param.Name] = true
+		if strings.Contains(path, "{"+param.Name+"}") {
+			param.In = "path"
+			param.Required = true
+		}
+		op.Parameters = append([]Parameter{param}, op.Parameters...)
+	}
+	for _, name := range query {
+		if !seen[name] {
+			op.Parameters = append(op.Parameters, Parameter{Name: name, In: "query", Type: "string"})
+		}
+	}
+
+	if len(a.requests) > 0 {
+		req := a.requests[0]
+		op.RequestBody = &RequestBody{
+			Description: joinText(req.description),
+			Content:     map[string]MediaType{contentTypeOr(req.contentType): {Schema: req.toSchema()}},
+		}
+	}
+
+	for _, resp := range a.responses {
+		response := Response{Description: joinText(resp.description)}
+		if schema := resp.toSchema(); schema != nil {
+			response.Content = map[string]MediaType{contentTypeOr(resp.contentType): {Schema: schema}}
+		}
+		if response.Description == "" {
+			response.Description = "Response " + resp.code
+		}
+		op.Responses[resp.code] = response
+		op.ResponseOrder = append(op.ResponseOrder, resp.code)
+	}
+
+	return op
+}
+
+// toSchema returns the payload's attribute schema, or one inferred from
+// its JSON body
+func (p *bpPayload) toSchema() *Schema {
+	if p.schema != nil {
+		return p.schema
+	}
+
+	body := strings.TrimSpace(strings.Join(p.body, "\n"))
+	if body == "" {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(body), &value); err != nil {
+		return &Schema{Type: "string"}
+	}
+	return inferSchema(value)
+}
+
+// inferSchema derives a schema, with property examples, from a JSON value
+func inferSchema(value interface{}) *Schema {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		schema := &Schema{Type: "object", Properties: make(map[string]Property)}
+		for key, field := range v {
+			fieldSchema := inferSchema(field)
+			prop := Property{Type: fieldSchema.Type}
+			switch fieldSchema.Type {
+			case "array":
+				prop.Items = fieldSchema.Items
+			case "object":
+			default:
+				prop.Example = field
+			}
+			schema.Properties[key] = prop
+		}
+		return schema
+	case []interface{}:
+		schema := &Schema{Type: "array"}
+		if len(v) > 0 {
+			schema.Items = inferSchema(v[0])
+		}
+		return schema
+	case string:
+		return &Schema{Type: "string"}
+	case float64:
+		if v == float64(int64(v)) {
+			return &Schema{Type: "integer"}
+		}
+		return &Schema{Type: "number"}
+	case bool:
+		return &Schema{Type: "boolean"}
+	}
+	return &Schema{}
+}
+
+// parseMSONProperty parses an MSON property line such as
+// "id: `42` (number, required) - The note ID"
+func parseMSONProperty(text string) (string, Property, bool) {
+	match := bpPropertyPattern.FindStringSubmatch(text)
+	if match == nil {
+		return text, Property{Type: "string"}, false
+	}
+
+	name := strings.Trim(strings.TrimSpace(match[1]), "`")
+	example := strings.Trim(strings.TrimSpace(match[2]), "`")
+	prop := Property{Type: "string", Description: strings.TrimSpace(match[4])}
+	required := false
+
+	for _, attr := range strings.Split(match[3], ",") {
+		attr = strings.TrimSpace(attr)
+		switch attr {
+		case "":
+		case "required":
+			required = true
+		case "optional", "fixed", "nullable", "sample", "default":
+		default:
+			applyMSONType(&prop, attr)
+		}
+	}
+
+	if example != "" {
+		prop.Example = typedExample(prop.Type, example)
+	}
+	return name, prop, required
+}
+
+// applyMSONType maps an MSON type to the property
+func applyMSONType(prop *Property, typeName string) {
+	switch {
+	case typeName == "string" || typeName == "boolean" || typeName == "object":
+		prop.Type = typeName
+	case typeName == "number":
+		prop.Type = "number"
+	case typeName == "enum":
+		prop.Type = "string"
+	case typeName == "array":
+		prop.Type = "array"
+	case strings.HasPrefix(typeName, "array[") && strings.HasSuffix(typeName, "]"):
+		prop.Type = "array"
+		prop.Items = mSONSchema(strings.TrimSuffix(strings.TrimPrefix(typeName, "array["), "]"))
+	default:
+		prop.Type = ""
+		prop.Ref = "#/components/schemas/" + typeName
+	}
+}
+
+// mSONSchema returns the schema for an MSON type reference
+func mSONSchema(typeSpec string) *Schema {
+	typeName := strings.TrimSpace(strings.Split(typeSpec, ",")[0])
+	switch typeName {
+	case "", "object":
+		return &Schema{Type: "object", Properties: make(map[string]Property)}
+	case "string", "number", "boolean":
+		return &Schema{Type: typeName}
+	case "array":
+		return &Schema{Type: "array"}
+	}
+	if strings.HasPrefix(typeName, "array[") {
+		return &Schema{Type: "array", Items: mSONSchema(strings.TrimSuffix(strings.TrimPrefix(typeName, "array["), "]"))}
+	}
+	return &Schema{Ref: "#/components/schemas/" + typeName}
+}
+
+func applyParamAttribute(param *Parameter, attr string) {
+	switch {
+	case attr == "required":
+		param.Required = true
+	case attr == "optional":
+	case strings.HasPrefix(attr, "`"):
+	default:
+		param.Type = attr
+	}
+}
+
+// typedExample converts an example literal to the property type
+func typedExample(typeName, example string) interface{} {
+	switch typeName {
+	case "number":
+		if n, err := strconv.ParseFloat(example, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(example); err == nil {
+			return b
+		}
+	}
+	return example
+}
+
+// splitURITemplate splits "/notes/{id}{?limit,page}" into the path and
+// the query parameter names
+func splitURITemplate(uri string) (string, []string) {
+	var query []string
+	path := bpURITemplateVarPattern.ReplaceAllStringFunc(uri, func(variable string) string {
+		match := bpURITemplateVarPattern.FindStringSubmatch(variable)
+		if match[1] == "?" || match[1] == "&" {
+			query = append(query, strings.Split(match[2], ",")...)
+			return ""
+		}
+		return "{" + match[2] + "}"
+	})
+	return path, query
+}
+
+func contentTypeOr(contentType string) string {
+	if contentType == "" {
+		return "application/json"
+	}
+	return contentType
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func joinText(lines []string) string {
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,94 @@
+package swagger
+
+import "testing"
+
+const testBlueprint = `FORMAT: 1A
+HOST: https://api.example.com
+
+# Notes API
+A simple notes service.
+
+# Group Notes
+
+## Notes Collection [/notes{?limit}]
+
+### List Notes [GET]
++ Parameters
+    + limit: ` + "`10`" + ` (number, optional) - Maximum number of notes
+
++ Response 200 (application/json)
+
+        [{"id": 1, "title": "Buy milk"}]
+
+### Create a Note [POST]
++ Request (application/json)
+    + Attributes (Note)
+
++ Response 201 (application/json)
+    + Body
+
+            {"id": 2, "title": "Walk the dog"}
+
+## Note [/notes/{id}]
++ Parameters
+    + id (required, number, ` + "`1`" + `) ... Note ID
+
+### Delete a Note [DELETE]
++ Response 204
+
+# Data Structures
+
+## Note (object)
++ id: 1 (number, required) - The note ID
++ title: Buy milk (string) - Note text
+    + nested (string)
+`
+
+func TestParseBlueprint(t *testing.T) {
+	parser := NewParser()
+	spec, err := parser.ParseBytes([]byte(testBlueprint), "", "notes.apib")
+	if err != nil {
+		t.Fatalf("ParseBytes() error = %v", err)
+	}
+
+	if spec.Info.Title != "Notes API" || spec.Info.Description != "A simple notes service." {
+		t.Errorf("unexpected info %+v", spec.Info)
+	}
+	if spec.OpenAPI != "3.0.0" {
+		t.Errorf("OpenAPI = %q, want 3.0.0", spec.OpenAPI)
+	}
+
+	list := spec.Paths["/notes"]["get"]
+	if list.Summary != "List Notes" || len(list.Tags) != 1 || list.Tags[0] != "Notes" {
+		t.Errorf("unexpected list operation %+v", list)
+	}
+	if len(list.Parameters) != 1 || list.Parameters[0].Name != "limit" || list.Parameters[0].In != "query" {
+		t.Errorf("unexpected list parameters %+v", list.Parameters)
+	}
+	if schema := list.Responses["200"].Content["application/json"].Schema; schema == nil || schema.Type != "array" ||
+		schema.Items.Properties["title"].Example != "Buy milk" {
+		t.Errorf("unexpected list response schema %+v", schema)
+	}
+
+	create := spec.Paths["/notes"]["post"]
+	if create.RequestBody == nil || create.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/Note" {
+		t.Errorf("unexpected create request body %+v", create.RequestBody)
+	}
+	if schema := create.Responses["201"].Content["application/json"].Schema; schema == nil || schema.Properties["id"].Type != "integer" {
+		t.Errorf("unexpected create response schema %+v", schema)
+	}
+
+	del := spec.Paths["/notes/{id}"]["delete"]
+	if len(del.Parameters) != 1 || del.Parameters[0].In != "path" || del.Parameters[0].Type != "number" ||
+		del.Parameters[0].Description != "Note ID" {
+		t.Errorf("unexpected delete parameters %+v", del.Parameters)
+	}
+	if _, ok := del.Responses["204"]; !ok {
+		t.Errorf("missing 204 response: %+v", del.Responses)
+	}
+
+	note := spec.Components.Schemas["Note"]
+	if len(note.Properties) != 2 || note.Properties["id"].Example != float64(1) || len(note.Required) != 1 {
+		t.Errorf("unexpected Note schema %+v", note)
+	}
+}
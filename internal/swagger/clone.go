@@ -0,0 +1,67 @@
+package swagger
+
+import (
+	"maps"
+	"slices"
+)
+
+// Clone returns a deep copy of the schema, so callers can modify it without
+// affecting the spec or other copies. Example, enum and default values are
+// shared; they are never modified.
+func (s *Schema) Clone() *Schema {
+	if s == nil {
+		return nil
+	}
+
+	clone := *s
+	if s.Properties != nil {
+		clone.Properties = make(map[string]Property, len(s.Properties))
+		for name, prop := range s.Properties {
+			clone.Properties[name] = prop.Clone()
+		}
+	}
+	clone.Required = slices.Clone(s.Required)
+	clone.Items = s.Items.Clone()
+	clone.AllOf = cloneSchemas(s.AllOf)
+	clone.OneOf = cloneSchemas(s.OneOf)
+	clone.AnyOf = cloneSchemas(s.AnyOf)
+	if s.Discriminator != nil {
+		discriminator := *s.Discriminator
+		discriminator.Mapping = maps.Clone(s.Discriminator.Mapping)
+		clone.Discriminator = &discriminator
+	}
+	clone.Enum = slices.Clone(s.Enum)
+	clone.PropertyOrder = slices.Clone(s.PropertyOrder)
+	return &clone
+}
+
+// Clone returns a deep copy of the property
+func (p Property) Clone() Property {
+	p.Items = p.Items.Clone()
+	p.Minimum = cloneFloat(p.Minimum)
+	p.Maximum = cloneFloat(p.Maximum)
+	p.MultipleOf = cloneFloat(p.MultipleOf)
+	p.Enum = slices.Clone(p.Enum)
+	p.Extensions = maps.Clone(p.Extensions)
+	return p
+}
+
+// cloneSchemas deep copies a list of schemas
+func cloneSchemas(schemas []*Schema) []*Schema {
+	if schemas == nil {
+		return nil
+	}
+	clones := make([]*Schema, len(schemas))
+	for i, schema := range schemas {
+		clones[i] = schema.Clone()
+	}
+	return clones
+}
+
+func cloneFloat(f *float64) *float64 {
+	if f == nil {
+		return nil
+	}
+	clone := *f
+	return &clone
+}
@@ -0,0 +1,151 @@
+package swagger
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResolveParameter resolves a parameter that references a reusable
+// parameter in components.parameters or, in Swagger 2.0, parameters
+func (r *Resolver) ResolveParameter(param Parameter) (Parameter, error) {
+	registries := map[string]map[string]Parameter{"#/parameters/": r.spec.Parameters}
+	if r.spec.Components != nil {
+		registries["#/components/parameters/"] = r.spec.Components.Parameters
+	}
+	return resolveComponent(param, "parameter", registries, func(p Parameter) string { return p.Ref })
+}
+
+// ResolveRequestBody resolves a request body that references a reusable
+// request body in components.requestBodies
+func (r *Resolver) ResolveRequestBody(body RequestBody) (RequestBody, error) {
+	registries := map[string]map[string]RequestBody{}
+	if r.spec.Components != nil {
+		registries["#/components/requestBodies/"] = r.spec.Components.RequestBodies
+	}
+	return resolveComponent(body, "request body", registries, func(b RequestBody) string { return b.Ref })
+}
+
+// ResolveResponse resolves a response that references a reusable response
+// in components.responses or, in Swagger 2.0, responses
+func (r *Resolver) ResolveResponse(response Response) (Response, error) {
+	registries := map[string]map[string]Response{"#/responses/": r.spec.Responses}
+	if r.spec.Components != nil {
+		registries["#/components/responses/"] = r.spec.Components.Responses
+	}
+	return resolveComponent(response, "response", registries, func(r Response) string { return r.Ref })
+}
+
+// ResolveExample resolves a named example that references a reusable
+// example in components.examples
+func (r *Resolver) ResolveExample(example Example) (Example, error) {
+	registries := map[string]map[string]Example{}
+	if r.spec.Components != nil {
+		registries["#/components/examples/"] = r.spec.Components.Examples
+	}
+	return resolveComponent(example, "example", registries, func(e Example) string { return e.Ref })
+}
+
+// resolveComponent follows the $refs of an object through the registries,
+// keyed by ref prefix, until it reaches one written inline
+func resolveComponent[T any](object T, kind string, registries map[string]map[string]T, refOf func(T) string) (T, error) {
+	seen := make(map[string]bool)
+	for ref := refOf(object); ref != ""; ref = refOf(object) {
+		if seen[ref] {
+			var zero T
+			return zero, fmt.Errorf("circular $ref: %s", ref)
+		}
+		seen[ref] = true
+
+		found := false
+		for prefix, registry := range registries {
+			if name, ok := strings.CutPrefix(ref, prefix); ok {
+				object, found = registry[name]
+				if !found {
+					var zero T
+					return zero, fmt.Errorf("%s not found: %s", kind, name)
+				}
+				break
+			}
+		}
+		if !found {
+			var zero T
+			return zero, fmt.Errorf("unsupported $ref format: %s", ref)
+		}
+	}
+	return object, nil
+}
+
+// InlineComponents replaces the operation parameters, request bodies and
+// responses that reference reusable components with the components, so
+// everything downstream sees them as if they were written inline
+func InlineComponents(spec *Spec) error {
+	resolver := NewResolver(spec)
+	for _, items := range []map[string]PathItem{spec.Paths, spec.Webhooks} {
+		for path, item := range items {
+			for _, method := range item.Methods() {
+				op := item[method]
+				if err := inlineOperation(resolver, &op); err != nil {
+					return fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+				}
+				item[method] = op
+			}
+		}
+	}
+	return nil
+}
+
+// inlineOperation inlines the component references of one operation
+func inlineOperation(resolver *Resolver, op *Operation) error {
+	for i, param := range op.Parameters {
+		resolved, err := resolver.ResolveParameter(param)
+		if err != nil {
+			return fmt.Errorf("failed to resolve parameter: %w", err)
+		}
+		op.Parameters[i] = resolved
+	}
+
+	if op.RequestBody != nil {
+		resolved, err := resolver.ResolveRequestBody(*op.RequestBody)
+		if err != nil {
+			return fmt.Errorf("failed to resolve request body: %w", err)
+		}
+		if err := inlineExamples(resolver, resolved.Content); err != nil {
+			return fmt.Errorf("failed to resolve request body: %w", err)
+		}
+		op.RequestBody = &resolved
+	}
+
+	for code, response := range op.Responses {
+		resolved, err := resolver.ResolveResponse(response)
+		if err == nil {
+			err = inlineExamples(resolver, resolved.Content)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s response: %w", code, err)
+		}
+		op.Responses[code] = resolved
+	}
+	return nil
+}
+
+// inlineExamples replaces the named examples of each media type that
+// reference reusable examples. Maps shared with components are copied
+// rather than changed.
+func inlineExamples(resolver *Resolver, content map[string]MediaType) error {
+	for contentType, mediaType := range content {
+		if len(mediaType.Examples) == 0 {
+			continue
+		}
+		examples := make(map[string]Example, len(mediaType.Examples))
+		for name, example := range mediaType.Examples {
+			resolved, err := resolver.ResolveExample(example)
+			if err != nil {
+				return fmt.Errorf("failed to resolve example %s: %w", name, err)
+			}
+			examples[name] = resolved
+		}
+		mediaType.Examples = examples
+		content[contentType] = mediaType
+	}
+	return nil
+}
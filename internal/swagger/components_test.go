@@ -0,0 +1,93 @@
+package swagger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParser_InlinesComponents(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{
+			name: "OpenAPI 3",
+			spec: `{
+				"openapi": "3.0.0",
+				"info": {"title": "Pets", "version": "1.0.0"},
+				"paths": {"/pets": {"post": {
+					"parameters": [{"$ref": "#/components/parameters/PageSize"}],
+					"requestBody": {"$ref": "#/components/requestBodies/Pet"},
+					"responses": {"404": {"$ref": "#/components/responses/Missing"}}
+				}}},
+				"components": {
+					"parameters": {"PageSize": {"name": "pageSize", "in": "query"}},
+					"requestBodies": {"Pet": {"description": "A pet", "content": {"application/json": {}}}},
+					"responses": {
+						"Missing": {"$ref": "#/components/responses/NotFound"},
+						"NotFound": {"description": "Not found"}
+					}
+				}
+			}`,
+		},
+		{
+			name: "Swagger 2.0",
+			spec: `{
+				"swagger": "2.0",
+				"info": {"title": "Pets", "version": "1.0.0"},
+				"paths": {"/pets": {"post": {
+					"parameters": [{"$ref": "#/parameters/PageSize"}],
+					"responses": {"404": {"$ref": "#/responses/NotFound"}}
+				}}},
+				"parameters": {"PageSize": {"name": "pageSize", "in": "query"}},
+				"responses": {"NotFound": {"description": "Not found"}}
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := NewParser().ParseBytes([]byte(tt.spec), "", "")
+			if err != nil {
+				t.Fatalf("ParseBytes() error = %v", err)
+			}
+			op := spec.Paths["/pets"]["post"]
+
+			if len(op.Parameters) != 1 || op.Parameters[0].Name != "pageSize" || op.Parameters[0].Ref != "" {
+				t.Errorf("parameters = %+v, want inlined pageSize", op.Parameters)
+			}
+			if response := op.Responses["404"]; response.Description != "Not found" || response.Ref != "" {
+				t.Errorf("404 response = %+v, want inlined NotFound", response)
+			}
+			if spec.OpenAPI != "" && (op.RequestBody == nil || op.RequestBody.Description != "A pet") {
+				t.Errorf("request body = %+v, want inlined Pet", op.RequestBody)
+			}
+		})
+	}
+}
+
+func TestParser_InlinesComponentsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		ref     string
+		wantErr string
+	}{
+		{name: "missing", ref: "#/components/parameters/Missing", wantErr: "parameter not found: Missing"},
+		{name: "circular", ref: "#/components/parameters/Loop", wantErr: "circular $ref"},
+		{name: "wrong registry", ref: "#/components/schemas/Pet", wantErr: "unsupported $ref format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParser().ParseBytes([]byte(`{
+				"openapi": "3.0.0",
+				"info": {"title": "Pets", "version": "1.0.0"},
+				"paths": {"/pets": {"get": {"parameters": [{"$ref": "`+tt.ref+`"}]}}},
+				"components": {"parameters": {"Loop": {"$ref": "#/components/parameters/Loop"}}}
+			}`), "", "")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseBytes() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
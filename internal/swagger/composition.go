@@ -0,0 +1,125 @@
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UnmarshalJSON decodes a discriminator, also accepting the bare property
+// name used by Swagger 2.0
+func (d *Discriminator) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		d.PropertyName = name
+		return nil
+	}
+
+	type plain Discriminator
+	return json.Unmarshal(data, (*plain)(d))
+}
+
+// HasVariants reports whether the schema offers oneOf or anyOf alternatives
+func (s *Schema) HasVariants() bool {
+	return len(s.OneOf) > 0 || len(s.AnyOf) > 0
+}
+
+// mergeAllOf merges the allOf members of a schema into its own properties.
+// Member properties come first, in member order; the schema's own
+// properties follow and win on conflicts.
+func (r *Resolver) mergeAllOf(schema *Schema, seen map[string]bool) (*Schema, error) {
+	if len(schema.AllOf) == 0 {
+		return schema, nil
+	}
+
+	merged := *schema
+	merged.AllOf = nil
+	merged.Properties = make(map[string]Property)
+	merged.PropertyOrder = nil
+	merged.Required = nil
+
+	add := func(part *Schema) {
+		for _, name := range part.PropertyNames() {
+			if _, ok := merged.Properties[name]; !ok {
+				merged.PropertyOrder = append(merged.PropertyOrder, name)
+			}
+			merged.Properties[name] = part.Properties[name]
+		}
+		for _, name := range part.Required {
+			if !isRequired(name, merged.Required) {
+				merged.Required = append(merged.Required, name)
+			}
+		}
+	}
+
+	for i, member := range schema.AllOf {
+		part, err := r.resolveMember(member, seen)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve allOf member %d: %w", i, err)
+		}
+		add(part)
+		if merged.Type == "" {
+			merged.Type = part.Type
+		}
+	}
+	add(schema)
+
+	if merged.Type == "" && len(merged.Properties) > 0 {
+		merged.Type = "object"
+	}
+	return &merged, nil
+}
+
+// resolveMember resolves an allOf member, which may itself be composed
+func (r *Resolver) resolveMember(member *Schema, seen map[string]bool) (*Schema, error) {
+	if member == nil {
+		return &Schema{}, nil
+	}
+	if member.Ref != "" {
+		return r.resolveRefSeen(member.Ref, seen)
+	}
+	return r.mergeAllOf(member, seen)
+}
+
+// resolveVariants resolves the oneOf/anyOf variants of a schema one level
+// deep. Variants referencing components are titled with the component name;
+// their own variants are left for the caller to resolve.
+func (r *Resolver) resolveVariants(variants []*Schema) ([]*Schema, error) {
+	if len(variants) == 0 {
+		return nil, nil
+	}
+
+	resolved := make([]*Schema, 0, len(variants))
+	for i, variant := range variants {
+		if variant == nil {
+			continue
+		}
+		if variant.Ref == "" {
+			merged, err := r.mergeAllOf(variant, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve variant %d: %w", i, err)
+			}
+			resolved = append(resolved, merged.Clone())
+			continue
+		}
+
+		schema, err := r.resolveRef(variant.Ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve variant %d: %w", i, err)
+		}
+		if schema.Title == "" {
+			schema.Title = ExtractRefName(variant.Ref)
+		}
+		resolved = append(resolved, schema)
+	}
+	return resolved, nil
+}
+
+// isRequired reports whether name is in the required list
+func isRequired(name string, required []string) bool {
+	for _, r := range required {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package swagger
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolver_Composition(t *testing.T) {
+	var spec Spec
+	err := json.Unmarshal([]byte(`{
+		"openapi": "3.0.0",
+		"paths": {},
+		"components": {"schemas": {
+			"Pet": {
+				"type": "object",
+				"required": ["name"],
+				"properties": {"name": {"type": "string"}, "petType": {"type": "string"}},
+				"discriminator": {"propertyName": "petType", "mapping": {"cat": "#/components/schemas/Cat"}}
+			},
+			"Cat": {"allOf": [
+				{"$ref": "#/components/schemas/Pet"},
+				{"type": "object", "required": ["lives"], "properties": {"lives": {"type": "integer"}}}
+			]},
+			"Dog": {"title": "Good dog", "allOf": [{"$ref": "#/components/schemas/Pet"}]},
+			"AnyPet": {
+				"oneOf": [{"$ref": "#/components/schemas/Cat"}, {"$ref": "#/components/schemas/Dog"}],
+				"discriminator": {"propertyName": "petType"}
+			},
+			"Loop": {"allOf": [{"$ref": "#/components/schemas/Loop"}]}
+		}}
+	}`), &spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolver := NewResolver(&spec)
+
+	cat, err := resolver.ResolveSchema(&Schema{Ref: "#/components/schemas/Cat"})
+	if err != nil {
+		t.Fatalf("ResolveSchema(Cat) error = %v", err)
+	}
+	if got := cat.PropertyNames(); !reflect.DeepEqual(got, []string{"name", "petType", "lives"}) {
+		t.Errorf("Cat properties = %v", got)
+	}
+	if !reflect.DeepEqual(cat.Required, []string{"name", "lives"}) {
+		t.Errorf("Cat required = %v", cat.Required)
+	}
+	if cat.Type != "object" {
+		t.Errorf("Cat type = %q, want object", cat.Type)
+	}
+
+	anyPet, err := resolver.ResolveSchema(&Schema{Ref: "#/components/schemas/AnyPet"})
+	if err != nil {
+		t.Fatalf("ResolveSchema(AnyPet) error = %v", err)
+	}
+	var titles []string
+	for _, variant := range anyPet.OneOf {
+		titles = append(titles, variant.Title)
+		if _, ok := variant.Properties["name"]; !ok {
+			t.Errorf("variant %s is missing inherited properties", variant.Title)
+		}
+	}
+	if !reflect.DeepEqual(titles, []string{"Cat", "Good dog"}) {
+		t.Errorf("variant titles = %v", titles)
+	}
+	if anyPet.Discriminator == nil || anyPet.Discriminator.PropertyName != "petType" {
+		t.Errorf("discriminator = %+v", anyPet.Discriminator)
+	}
+
+	if _, err := resolver.ResolveSchema(&Schema{Ref: "#/components/schemas/Loop"}); err == nil || !strings.Contains(err.Error(), "circular") {
+		t.Errorf("expected a circular $ref error, got %v", err)
+	}
+}
+
+func TestDiscriminator_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want Discriminator
+	}{
+		{name: "swagger 2.0 property name", data: `"petType"`, want: Discriminator{PropertyName: "petType"}},
+		{
+			name: "openapi 3 object",
+			data: `{"propertyName": "kind", "mapping": {"a": "#/components/schemas/A"}}`,
+			want: Discriminator{PropertyName: "kind", Mapping: map[string]string{"a": "#/components/schemas/A"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Discriminator
+			if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// NullType is the JSON Schema type of the null value, listed in OpenAPI 3.1
+// type arrays in place of the 3.0 nullable keyword
+const NullType = "null"
+
+// schemaType is the type keyword of a schema. OpenAPI 3.1 allows a list of
+// types; "null" in the list makes the schema nullable, and the other types
+// are joined with " | ".
+type schemaType struct {
+	Name     string
+	Nullable bool
+}
+
+// UnmarshalJSON decodes a type name or a list of type names
+func (t *schemaType) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		t.Name, t.Nullable = name, name == NullType
+		if t.Nullable {
+			t.Name = ""
+		}
+		return nil
+	}
+
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings: %w", err)
+	}
+	var types []string
+	for _, name := range names {
+		if name == NullType {
+			t.Nullable = true
+			continue
+		}
+		types = append(types, name)
+	}
+	t.Name = strings.Join(types, " | ")
+	return nil
+}
+
+// applyDialect sets a decoded type and its nullability, and records const,
+// the OpenAPI 3.1 spelling of a single allowed value, as the enum
+func applyDialect(t schemaType, constValue json.RawMessage, typ *string, nullable *bool, enum *[]interface{}) error {
+	*typ = t.Name
+	*nullable = *nullable || t.Nullable
+
+	if len(constValue) == 0 {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(constValue, &value); err != nil {
+		return fmt.Errorf("invalid const: %w", err)
+	}
+	*enum = []interface{}{value}
+	return nil
+}
+
+// applyExclusive decodes an exclusive bound: a flag on the inclusive bound
+// in OpenAPI 3.0, or the bound itself in 3.1
+func applyExclusive(raw json.RawMessage, bound **float64, exclusive *bool) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw, exclusive); err == nil {
+		return nil
+	}
+	var value float64
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return fmt.Errorf("must be a boolean or a number: %w", err)
+	}
+	*bound, *exclusive = &value, true
+	return nil
+}
+
+// removeNullVariants drops the {"type": "null"} variants by which OpenAPI
+// 3.1 makes a reference nullable, marking the schema nullable instead. A
+// single remaining reference is then used directly.
+func removeNullVariants(s *Schema) {
+	for _, variants := range []*[]*Schema{&s.OneOf, &s.AnyOf} {
+		kept := (*variants)[:0]
+		for _, variant := range *variants {
+			if isNullSchema(variant) {
+				s.Nullable = true
+				continue
+			}
+			kept = append(kept, variant)
+		}
+		if len(kept) == 0 {
+			kept = nil
+		}
+		*variants = kept
+	}
+
+	variants := slices.Concat(s.OneOf, s.AnyOf)
+	if s.Nullable && len(variants) == 1 && variants[0].Ref != "" && s.Ref == "" && s.Type == "" && len(s.Properties) == 0 {
+		s.Ref = variants[0].Ref
+		s.OneOf, s.AnyOf = nil, nil
+	}
+}
+
+// isNullSchema reports whether a schema only allows null
+func isNullSchema(s *Schema) bool {
+	return s != nil && s.Nullable && s.Type == "" && s.Ref == "" && len(s.Properties) == 0 &&
+		len(s.AllOf)+len(s.OneOf)+len(s.AnyOf) == 0
+}
@@ -0,0 +1,140 @@
+package swagger
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSchema_UnmarshalDialects(t *testing.T) {
+	tests := []struct {
+		name         string
+		json         string
+		wantType     string
+		wantNullable bool
+		wantEnum     []interface{}
+		wantRef      string
+	}{
+		{name: "3.0 type", json: `{"type": "string"}`, wantType: "string"},
+		{name: "3.0 nullable", json: `{"type": "string", "nullable": true}`, wantType: "string", wantNullable: true},
+		{name: "3.1 type array with null", json: `{"type": ["integer", "null"]}`, wantType: "integer", wantNullable: true},
+		{name: "3.1 type array", json: `{"type": ["string", "integer"]}`, wantType: "string | integer"},
+		{name: "3.1 const", json: `{"type": "string", "const": "pet"}`, wantType: "string", wantEnum: []interface{}{"pet"}},
+		{
+			name:         "3.1 nullable reference",
+			json:         `{"anyOf": [{"$ref": "#/components/schemas/Pet"}, {"type": "null"}]}`,
+			wantNullable: true,
+			wantRef:      "#/components/schemas/Pet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var schema Schema
+			if err := json.Unmarshal([]byte(tt.json), &schema); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if schema.Type != tt.wantType {
+				t.Errorf("Type = %q, want %q", schema.Type, tt.wantType)
+			}
+			if schema.Nullable != tt.wantNullable {
+				t.Errorf("Nullable = %v, want %v", schema.Nullable, tt.wantNullable)
+			}
+			if len(schema.Enum) != len(tt.wantEnum) || (len(tt.wantEnum) > 0 && schema.Enum[0] != tt.wantEnum[0]) {
+				t.Errorf("Enum = %v, want %v", schema.Enum, tt.wantEnum)
+			}
+			if schema.Ref != tt.wantRef {
+				t.Errorf("Ref = %q, want %q", schema.Ref, tt.wantRef)
+			}
+			if schema.HasVariants() {
+				t.Errorf("null variants were kept: %+v", schema)
+			}
+		})
+	}
+}
+
+func TestProperty_UnmarshalDialects(t *testing.T) {
+	var schema Schema
+	err := json.Unmarshal([]byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": ["string", "null"]},
+			"kind": {"const": "dog"},
+			"owner": {"oneOf": [{"type": "null"}, {"$ref": "#/components/schemas/Owner"}]}
+		}
+	}`), &schema)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	name := schema.Properties["name"]
+	if name.Type != "string" || !name.Nullable {
+		t.Errorf("name = %+v, want nullable string", name)
+	}
+	kind := schema.Properties["kind"]
+	if len(kind.Enum) != 1 || kind.Enum[0] != "dog" {
+		t.Errorf("kind enum = %v, want [dog]", kind.Enum)
+	}
+	owner := schema.Properties["owner"]
+	if owner.Ref != "#/components/schemas/Owner" || !owner.Nullable {
+		t.Errorf("owner = %+v, want nullable Owner reference", owner)
+	}
+}
+
+func TestProperty_UnmarshalExclusiveBounds(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{name: "3.0 flag", json: `{"type": "integer", "minimum": 0, "exclusiveMinimum": true}`},
+		{name: "3.1 bound", json: `{"type": "integer", "exclusiveMinimum": 0}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var prop Property
+			if err := json.Unmarshal([]byte(tt.json), &prop); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if prop.Minimum == nil || *prop.Minimum != 0 || !prop.ExclusiveMinimum {
+				t.Errorf("got minimum %v exclusive %v, want exclusive 0", prop.Minimum, prop.ExclusiveMinimum)
+			}
+			if prop.Maximum != nil || prop.ExclusiveMaximum {
+				t.Errorf("got maximum %v exclusive %v, want none", prop.Maximum, prop.ExclusiveMaximum)
+			}
+		})
+	}
+}
+
+func TestParser_EndpointsWebhooks(t *testing.T) {
+	spec, err := NewParser().ParseBytes([]byte(`{
+		"openapi": "3.1.0",
+		"info": {"title": "Pets", "version": "1.0.0"},
+		"paths": {"/pets": {"get": {"summary": "List pets"}}},
+		"webhooks": {
+			"petAdopted": {"post": {"summary": "Pet adopted"}},
+			"newPet": {"post": {"summary": "New pet"}}
+		}
+	}`), "", "")
+	if err != nil {
+		t.Fatalf("ParseBytes() error = %v", err)
+	}
+
+	parser := NewParser()
+	endpoints := parser.ExtractEndpoints(spec)
+	if got := parser.CountEndpoints(spec); got != 3 {
+		t.Errorf("CountEndpoints() = %d, want 3", got)
+	}
+	want := []struct {
+		path    string
+		webhook bool
+	}{{"/pets", false}, {"petAdopted", true}, {"newPet", true}}
+	if len(endpoints) != len(want) {
+		t.Fatalf("got %d endpoints, want %d", len(endpoints), len(want))
+	}
+	for i, w := range want {
+		if endpoints[i].Path != w.path || endpoints[i].Webhook != w.webhook {
+			t.Errorf("endpoint %d = %s (webhook %v), want %s (webhook %v)",
+				i, endpoints[i].Path, endpoints[i].Webhook, w.path, w.webhook)
+		}
+	}
+}
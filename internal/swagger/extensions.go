@@ -0,0 +1,197 @@
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Vendor extensions marking operations, parameters and properties that are
+// left out of the published documentation
+const (
+	ExtensionInternal = "x-internal"
+	ExtensionHidden   = "x-hidden"
+)
+
+// Extensions holds the vendor extensions ("x-" members) of a spec object
+type Extensions map[string]interface{}
+
+// Hidden reports whether the object is marked x-internal or x-hidden
+func (e Extensions) Hidden() bool {
+	for _, key := range []string{ExtensionInternal, ExtensionHidden} {
+		if hidden, ok := e[key].(bool); ok && hidden {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeExtensions returns the vendor extensions of a JSON object, or nil
+// when it has none
+func decodeExtensions(data []byte) (Extensions, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	var extensions Extensions
+	for key, raw := range members {
+		if !strings.HasPrefix(key, "x-") {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+		if extensions == nil {
+			extensions = make(Extensions)
+		}
+		extensions[key] = value
+	}
+	return extensions, nil
+}
+
+// UnmarshalJSON decodes the parameter and its vendor extensions
+func (p *Parameter) UnmarshalJSON(data []byte) error {
+	type plain Parameter
+	if err := json.Unmarshal(data, (*plain)(p)); err != nil {
+		return err
+	}
+
+	var err error
+	p.Extensions, err = decodeExtensions(data)
+	return err
+}
+
+// UnmarshalJSON decodes the property in either OpenAPI dialect and its
+// vendor extensions
+func (p *Property) UnmarshalJSON(data []byte) error {
+	type plain Property
+	decoded := struct {
+		*plain
+		Type             schemaType      `json:"type"`
+		Const            json.RawMessage `json:"const"`
+		ExclusiveMinimum json.RawMessage `json:"exclusiveMinimum"`
+		ExclusiveMaximum json.RawMessage `json:"exclusiveMaximum"`
+		// OneOf and AnyOf are only read for the nullable references of
+		// OpenAPI 3.1
+		OneOf []*Schema `json:"oneOf"`
+		AnyOf []*Schema `json:"anyOf"`
+	}{plain: (*plain)(p)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if err := applyDialect(decoded.Type, decoded.Const, &p.Type, &p.Nullable, &p.Enum); err != nil {
+		return err
+	}
+	if err := applyExclusive(decoded.ExclusiveMinimum, &p.Minimum, &p.ExclusiveMinimum); err != nil {
+		return fmt.Errorf("invalid exclusiveMinimum: %w", err)
+	}
+	if err := applyExclusive(decoded.ExclusiveMaximum, &p.Maximum, &p.ExclusiveMaximum); err != nil {
+		return fmt.Errorf("invalid exclusiveMaximum: %w", err)
+	}
+	if p.Ref == "" && p.Type == "" {
+		variants := Schema{OneOf: decoded.OneOf, AnyOf: decoded.AnyOf}
+		removeNullVariants(&variants)
+		if variants.Ref != "" {
+			p.Ref, p.Nullable = variants.Ref, true
+		}
+	}
+
+	var err error
+	p.Extensions, err = decodeExtensions(data)
+	return err
+}
+
+// RemoveHidden drops the operations, parameters and properties marked
+// x-internal or x-hidden from the spec, including from required lists, so
+// nothing downstream documents them. Paths and webhooks left without
+// operations are dropped too.
+func RemoveHidden(spec *Spec) {
+	for _, items := range []map[string]PathItem{spec.Paths, spec.Webhooks} {
+		for path, item := range items {
+			for _, method := range item.Methods() {
+				op := item[method]
+				if op.Extensions.Hidden() {
+					delete(item, method)
+					continue
+				}
+				removeHiddenFromOperation(&op)
+				item[method] = op
+			}
+			if len(item.Methods()) == 0 {
+				delete(items, path)
+			}
+		}
+	}
+
+	for name, definition := range spec.Definitions {
+		definition.Required = removeHiddenProperties(definition.Properties, definition.Required)
+		removeHiddenFromSchemas(definition.AllOf, definition.OneOf, definition.AnyOf)
+		spec.Definitions[name] = definition
+	}
+	if spec.Components != nil {
+		for name, definition := range spec.Components.Schemas {
+			definition.Required = removeHiddenProperties(definition.Properties, definition.Required)
+			removeHiddenFromSchemas(definition.AllOf, definition.OneOf, definition.AnyOf)
+			spec.Components.Schemas[name] = definition
+		}
+	}
+}
+
+// removeHiddenFromOperation drops hidden parameters and the hidden
+// properties of inline body schemas
+func removeHiddenFromOperation(op *Operation) {
+	op.Parameters = slices.DeleteFunc(op.Parameters, func(param Parameter) bool {
+		return param.Extensions.Hidden()
+	})
+	for _, param := range op.Parameters {
+		removeHiddenFromSchema(param.Schema)
+	}
+	if op.RequestBody != nil {
+		for _, mediaType := range op.RequestBody.Content {
+			removeHiddenFromSchema(mediaType.Schema)
+		}
+	}
+	for _, response := range op.Responses {
+		removeHiddenFromSchema(response.Schema)
+		for _, mediaType := range response.Content {
+			removeHiddenFromSchema(mediaType.Schema)
+		}
+	}
+}
+
+// removeHiddenFromSchemas drops hidden properties from each schema list
+func removeHiddenFromSchemas(lists ...[]*Schema) {
+	for _, schemas := range lists {
+		for _, schema := range schemas {
+			removeHiddenFromSchema(schema)
+		}
+	}
+}
+
+// removeHiddenFromSchema drops hidden properties from a schema and the
+// schemas nested in it
+func removeHiddenFromSchema(schema *Schema) {
+	if schema == nil {
+		return
+	}
+	schema.Required = removeHiddenProperties(schema.Properties, schema.Required)
+	removeHiddenFromSchema(schema.Items)
+	removeHiddenFromSchemas(schema.AllOf, schema.OneOf, schema.AnyOf)
+}
+
+// removeHiddenProperties deletes hidden properties, recursing into the
+// rest, and returns required without the deleted names
+func removeHiddenProperties(properties map[string]Property, required []string) []string {
+	for name, prop := range properties {
+		if prop.Extensions.Hidden() {
+			delete(properties, name)
+			required = slices.DeleteFunc(required, func(r string) bool { return r == name })
+			continue
+		}
+		removeHiddenFromSchema(prop.Items)
+	}
+	return required
+}
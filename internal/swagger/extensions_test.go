@@ -0,0 +1,109 @@
+package swagger
+
+import (
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+const hiddenSpec = `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/pets": {
+      "get": {
+        "summary": "List Pets",
+        "parameters": [
+          {"name": "limit", "in": "query"},
+          {"name": "debug", "in": "query", "x-internal": true}
+        ],
+        "responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {
+          "type": "object",
+          "required": ["id", "shard"],
+          "properties": {"id": {"type": "string"}, "shard": {"type": "integer", "x-hidden": true}}
+        }}}}}
+      },
+      "delete": {"summary": "Purge Pets", "x-internal": true, "responses": {}}
+    },
+    "/admin": {
+      "post": {"summary": "Admin", "x-hidden": true, "x-owner": "ops", "responses": {}}
+    }
+  },
+  "components": {"schemas": {"Pet": {
+    "type": "object",
+    "required": ["name", "costPrice"],
+    "properties": {
+      "name": {"type": "string", "x-internal": false},
+      "costPrice": {"type": "number", "x-internal": true},
+      "tags": {"type": "array", "items": {"type": "object", "properties": {
+        "label": {"type": "string"},
+        "source": {"type": "string", "x-internal": true}
+      }}}
+    }
+  }}}
+}`
+
+func TestParser_RemovesHidden(t *testing.T) {
+	parser, err := NewParserWithConfig(config.SpecConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec, err := parser.ParseBytes([]byte(hiddenSpec), "", "spec.json")
+	if err != nil {
+		t.Fatalf("ParseBytes() error = %v", err)
+	}
+
+	if _, ok := spec.Paths["/admin"]; ok {
+		t.Error("path with only hidden operations was kept")
+	}
+	if _, ok := spec.Paths["/pets"]["delete"]; ok {
+		t.Error("x-internal operation was kept")
+	}
+
+	op := spec.Paths["/pets"]["get"]
+	if len(op.Parameters) != 1 || op.Parameters[0].Name != "limit" {
+		t.Errorf("parameters = %+v, want only limit", op.Parameters)
+	}
+	schema := op.Responses["200"].Content["application/json"].Schema
+	if _, ok := schema.Properties["shard"]; ok {
+		t.Error("x-hidden response property was kept")
+	}
+	if len(schema.Required) != 1 || schema.Required[0] != "id" {
+		t.Errorf("required = %v, want [id]", schema.Required)
+	}
+
+	pet := spec.Components.Schemas["Pet"]
+	if _, ok := pet.Properties["costPrice"]; ok {
+		t.Error("x-internal component property was kept")
+	}
+	if _, ok := pet.Properties["name"]; !ok {
+		t.Error("property with x-internal: false was removed")
+	}
+	if _, ok := pet.Properties["tags"].Items.Properties["source"]; ok {
+		t.Error("x-internal nested property was kept")
+	}
+	if len(pet.Required) != 1 || pet.Required[0] != "name" {
+		t.Errorf("required = %v, want [name]", pet.Required)
+	}
+}
+
+func TestParser_IncludeHidden(t *testing.T) {
+	parser, err := NewParserWithConfig(config.SpecConfig{IncludeHidden: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec, err := parser.ParseBytes([]byte(hiddenSpec), "", "spec.json")
+	if err != nil {
+		t.Fatalf("ParseBytes() error = %v", err)
+	}
+
+	admin := spec.Paths["/admin"]["post"]
+	if !admin.Extensions.Hidden() {
+		t.Error("expected the admin operation to be kept and marked hidden")
+	}
+	if owner := admin.Extensions["x-owner"]; owner != "ops" {
+		t.Errorf("x-owner = %v, want ops", owner)
+	}
+	if len(spec.Paths["/pets"]["get"].Parameters) != 2 {
+		t.Error("expected hidden parameters to be kept")
+	}
+}
@@ -0,0 +1,98 @@
+package swagger
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+// Filter selects the endpoints of a spec by tag, path and method. The zero
+// value selects every endpoint.
+type Filter struct {
+	includeTags []string
+	excludeTags []string
+	paths       []*regexp.Regexp
+	methods     []string
+}
+
+// NewFilter creates a filter from the spec settings. Path patterns are
+// globs where "*" matches within one path segment and "**" across segments.
+func NewFilter(cfg config.SpecConfig) (*Filter, error) {
+	f := &Filter{
+		includeTags: cfg.IncludeTags,
+		excludeTags: cfg.ExcludeTags,
+	}
+
+	for _, method := range cfg.Methods {
+		method = strings.ToLower(method)
+		if !slices.Contains(methodOrder, method) {
+			return nil, fmt.Errorf("unknown HTTP method %q", method)
+		}
+		f.methods = append(f.methods, method)
+	}
+
+	for _, pattern := range cfg.IncludePaths {
+		f.paths = append(f.paths, globPattern(pattern))
+	}
+
+	return f, nil
+}
+
+// Match reports whether the endpoint is selected. Excluded tags win over
+// included ones, and untagged operations never match an include list.
+func (f *Filter) Match(path, method string, op Operation) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.methods) > 0 && !slices.Contains(f.methods, strings.ToLower(method)) {
+		return false
+	}
+
+	if len(f.paths) > 0 && !slices.ContainsFunc(f.paths, func(pattern *regexp.Regexp) bool {
+		return pattern.MatchString(path)
+	}) {
+		return false
+	}
+
+	if hasAnyTag(op.Tags, f.excludeTags) {
+		return false
+	}
+	return len(f.includeTags) == 0 || hasAnyTag(op.Tags, f.includeTags)
+}
+
+// hasAnyTag reports whether tags contains any of names, ignoring case
+func hasAnyTag(tags, names []string) bool {
+	for _, tag := range tags {
+		for _, name := range names {
+			if strings.EqualFold(tag, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// globPattern compiles a path glob into an anchored regular expression
+func globPattern(glob string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			sb.WriteString("[^/]*")
+		case glob[i] == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
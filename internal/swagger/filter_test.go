@@ -0,0 +1,69 @@
+package swagger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+func TestParser_FilterEndpoints(t *testing.T) {
+	spec := &Spec{
+		Paths: map[string]PathItem{
+			"/public/pets": {
+				"get":  Operation{Summary: "List Pets", Tags: []string{"Public"}},
+				"post": Operation{Summary: "Create Pet", Tags: []string{"public", "beta"}},
+			},
+			"/public/pets/{id}/photos": {
+				"get": Operation{Summary: "List Photos", Tags: []string{"public"}},
+			},
+			"/internal/stats": {
+				"get": Operation{Summary: "Stats", Tags: []string{"internal"}},
+			},
+			"/health": {
+				"get": Operation{Summary: "Health"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		cfg  config.SpecConfig
+		want []string
+	}{
+		{"no filter", config.SpecConfig{}, []string{"Health", "Stats", "List Pets", "Create Pet", "List Photos"}},
+		{"include tags ignore case", config.SpecConfig{IncludeTags: []string{"PUBLIC"}}, []string{"List Pets", "Create Pet", "List Photos"}},
+		{"exclude wins", config.SpecConfig{IncludeTags: []string{"public"}, ExcludeTags: []string{"beta"}}, []string{"List Pets", "List Photos"}},
+		{"exclude keeps untagged", config.SpecConfig{ExcludeTags: []string{"internal"}}, []string{"Health", "List Pets", "Create Pet", "List Photos"}},
+		{"single segment glob", config.SpecConfig{IncludePaths: []string{"/public/*"}}, []string{"List Pets", "Create Pet"}},
+		{"multi segment glob", config.SpecConfig{IncludePaths: []string{"/public/**"}}, []string{"List Pets", "Create Pet", "List Photos"}},
+		{"methods", config.SpecConfig{Methods: []string{"POST"}}, []string{"Create Pet"}},
+		{"combined", config.SpecConfig{IncludePaths: []string{"/health", "/internal/**"}, Methods: []string{"get"}}, []string{"Health", "Stats"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, err := NewParserWithConfig(tt.cfg)
+			if err != nil {
+				t.Fatalf("NewParserWithConfig() error = %v", err)
+			}
+
+			var got []string
+			for _, endpoint := range parser.ExtractEndpoints(spec) {
+				got = append(got, endpoint.Title)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("endpoints = %v, want %v", got, tt.want)
+			}
+			if count := parser.CountEndpoints(spec); count != len(tt.want) {
+				t.Errorf("CountEndpoints() = %d, want %d", count, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestNewFilter_UnknownMethod(t *testing.T) {
+	if _, err := NewFilter(config.SpecConfig{Methods: []string{"fetch"}}); err == nil {
+		t.Error("expected an error for an unknown method")
+	}
+}
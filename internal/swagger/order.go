@@ -0,0 +1,435 @@
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// methodOrder is the order operations are listed in within a path item, as
+// in the OpenAPI specification
+var methodOrder = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// PathNames returns the spec paths in document order
+func (s *Spec) PathNames() []string {
+	return orderedKeys(s.Paths, s.PathOrder)
+}
+
+// Methods returns the HTTP methods of a path item in specification order
+func (item PathItem) Methods() []string {
+	var methods []string
+	for _, method := range methodOrder {
+		for key := range item {
+			if strings.ToLower(key) == method {
+				methods = append(methods, key)
+			}
+		}
+	}
+	return methods
+}
+
+// ResponseCodes returns the response codes of an operation in document order
+func (o Operation) ResponseCodes() []string {
+	return orderedKeys(o.Responses, o.ResponseOrder)
+}
+
+// PropertyNames returns the schema properties in document order
+func (s *Schema) PropertyNames() []string {
+	return orderedKeys(s.Properties, s.PropertyOrder)
+}
+
+// ExampleNames returns the named examples of a media type in document order
+func (m MediaType) ExampleNames() []string {
+	return orderedKeys(m.Examples, m.ExampleOrder)
+}
+
+// PropertyNames returns the definition properties in document order
+func (d Definition) PropertyNames() []string {
+	return orderedKeys(d.Properties, d.PropertyOrder)
+}
+
+// SortedKeys returns the keys of m in sorted order, for maps whose document
+// order isn't recorded
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// orderedKeys returns the keys of m in the recorded order. Keys missing from
+// the order, e.g. in specs built in code, follow in sorted order.
+func orderedKeys[V any](m map[string]V, order []string) []string {
+	keys := make([]string, 0, len(m))
+	seen := make(map[string]bool, len(m))
+	for _, key := range order {
+		if _, ok := m[key]; ok && !seen[key] {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+
+	var rest []string
+	for key := range m {
+		if !seen[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+
+	return append(keys, rest...)
+}
+
+// UnmarshalJSON decodes the spec and records the path and webhook order
+func (s *Spec) UnmarshalJSON(data []byte) error {
+	type plain Spec
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Paths    json.RawMessage `json:"paths"`
+		Webhooks json.RawMessage `json:"webhooks"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	if s.PathOrder, err = objectKeys(raw.Paths); err != nil {
+		return err
+	}
+	s.WebhookOrder, err = objectKeys(raw.Webhooks)
+	return err
+}
+
+// WebhookNames returns the spec webhooks in document order
+func (s *Spec) WebhookNames() []string {
+	return orderedKeys(s.Webhooks, s.WebhookOrder)
+}
+
+// UnmarshalJSON decodes the operations of a path item. Servers and
+// parameters declared for the whole path are pushed down into its
+// operations: operations without servers of their own get the path's, and
+// path parameters apply unless an operation redefines them. Other fields,
+// such as the path summary and extensions, are ignored.
+func (item *PathItem) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	var shared struct {
+		Servers    []Server    `json:"servers"`
+		Parameters []Parameter `json:"parameters"`
+	}
+	if err := json.Unmarshal(data, &shared); err != nil {
+		return err
+	}
+
+	*item = make(PathItem)
+	for key, value := range fields {
+		if !IsMethod(key) {
+			continue
+		}
+		var op Operation
+		if err := json.Unmarshal(value, &op); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		if op.Servers == nil {
+			op.Servers = shared.Servers
+		}
+		op.Parameters = withPathParameters(shared.Parameters, op.Parameters)
+		(*item)[key] = op
+	}
+	return nil
+}
+
+// IsMethod reports whether a path item key names an operation
+func IsMethod(key string) bool {
+	for _, method := range methodOrder {
+		if strings.ToLower(key) == method {
+			return true
+		}
+	}
+	return false
+}
+
+// withPathParameters returns the parameters of an operation preceded by
+// the path parameters it doesn't redefine by name and location
+func withPathParameters(shared, own []Parameter) []Parameter {
+	if len(shared) == 0 {
+		return own
+	}
+
+	var params []Parameter
+	for _, param := range shared {
+		redefined := false
+		for _, ownParam := range own {
+			if param.Ref != "" && ownParam.Ref == param.Ref ||
+				param.Ref == "" && ownParam.Name == param.Name && ownParam.In == param.In {
+				redefined = true
+				break
+			}
+		}
+		if !redefined {
+			params = append(params, param)
+		}
+	}
+	return append(params, own...)
+}
+
+// UnmarshalJSON decodes the operation and records the response order
+func (o *Operation) UnmarshalJSON(data []byte) error {
+	type plain Operation
+	if err := json.Unmarshal(data, (*plain)(o)); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Responses json.RawMessage `json:"responses"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	if o.ResponseOrder, err = objectKeys(raw.Responses); err != nil {
+		return err
+	}
+	o.Extensions, err = decodeExtensions(data)
+	return err
+}
+
+// UnmarshalJSON decodes the schema in either OpenAPI dialect and records
+// the property order
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type plain Schema
+	decoded := struct {
+		*plain
+		Type  schemaType      `json:"type"`
+		Const json.RawMessage `json:"const"`
+	}{plain: (*plain)(s)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if err := applyDialect(decoded.Type, decoded.Const, &s.Type, &s.Nullable, &s.Enum); err != nil {
+		return err
+	}
+	removeNullVariants(s)
+
+	var err error
+	s.PropertyOrder, err = propertyKeys(data)
+	return err
+}
+
+// UnmarshalJSON decodes the media type and records the order of its named
+// examples
+func (m *MediaType) UnmarshalJSON(data []byte) error {
+	type plain MediaType
+	decoded := struct {
+		*plain
+		Examples json.RawMessage `json:"examples"`
+	}{plain: (*plain)(m)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if len(decoded.Examples) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(decoded.Examples, &m.Examples); err != nil {
+		return err
+	}
+
+	var err error
+	m.ExampleOrder, err = objectKeys(decoded.Examples)
+	return err
+}
+
+// UnmarshalJSON decodes the definition in either OpenAPI dialect and
+// records the property order
+func (d *Definition) UnmarshalJSON(data []byte) error {
+	type plain Definition
+	decoded := struct {
+		*plain
+		Type  schemaType      `json:"type"`
+		Const json.RawMessage `json:"const"`
+	}{plain: (*plain)(d)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if err := applyDialect(decoded.Type, decoded.Const, &d.Type, &d.Nullable, &d.Enum); err != nil {
+		return err
+	}
+
+	var err error
+	d.PropertyOrder, err = propertyKeys(data)
+	return err
+}
+
+// propertyKeys returns the keys of the "properties" member of a schema
+func propertyKeys(data []byte) ([]string, error) {
+	var raw struct {
+		Properties json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	return objectKeys(raw.Properties)
+}
+
+// objectKeys returns the member names of a JSON object in document order.
+// Anything other than an object has no keys.
+func objectKeys(data json.RawMessage) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil || tok != json.Delim('{') {
+		return nil, nil
+	}
+
+	var keys []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object key %v", tok)
+		}
+		keys = append(keys, key)
+
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// keyOrders records the member order of every object in a JSON document,
+// keyed by JSON pointer
+func keyOrders(data []byte) (map[string][]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	orders := make(map[string][]string)
+	if err := recordKeyOrders(dec, "", orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// recordKeyOrders walks the next JSON value, recording object member order
+func recordKeyOrders(dec *json.Decoder, pointer string, orders map[string][]string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		var keys []string
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := keyTok.(string)
+			keys = append(keys, key)
+			if err := recordKeyOrders(dec, pointer+"/"+escapePointer(key), orders); err != nil {
+				return err
+			}
+		}
+		orders[pointer] = keys
+	case json.Delim('['):
+		for i := 0; dec.More(); i++ {
+			if err := recordKeyOrders(dec, pointer+"/"+strconv.Itoa(i), orders); err != nil {
+				return err
+			}
+		}
+	default:
+		return nil
+	}
+
+	// Consume the closing delimiter
+	_, err = dec.Token()
+	return err
+}
+
+// marshalOrdered encodes a decoded JSON document, writing object members in
+// the recorded order. Members added since, e.g. by overlays, follow in
+// sorted order.
+func marshalOrdered(v interface{}, orders map[string][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeOrdered(&buf, v, "", orders); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeOrdered writes one value of marshalOrdered
+func writeOrdered(buf *bytes.Buffer, v interface{}, pointer string, orders map[string][]string) error {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		buf.WriteByte('{')
+		for i, key := range orderedKeys(value, orders[pointer]) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			name, err := json.Marshal(key)
+			if err != nil {
+				return err
+			}
+			buf.Write(name)
+			buf.WriteByte(':')
+			if err := writeOrdered(buf, value[key], pointer+"/"+escapePointer(key), orders); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range value {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeOrdered(buf, item, pointer+"/"+strconv.Itoa(i), orders); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+	return nil
+}
+
+// escapePointer escapes a key for use as a JSON pointer segment
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// SchemaRefs returns the $refs of all component schemas and definitions in
+// sorted order
+func (s *Spec) SchemaRefs() []string {
+	var refs []string
+	if s.Components != nil {
+		for name := range s.Components.Schemas {
+			refs = append(refs, "#/components/schemas/"+name)
+		}
+	}
+	for name := range s.Definitions {
+		refs = append(refs, "#/definitions/"+name)
+	}
+	sort.Strings(refs)
+	return refs
+}
@@ -0,0 +1,158 @@
+package swagger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+const orderedSpecJSON = `{
+	"swagger": "2.0",
+	"info": {"title": "Zoo", "version": "1.0"},
+	"paths": {
+		"/zebras": {
+			"post": {"summary": "Add zebra", "responses": {"201": {"description": "created"}, "400": {"description": "bad"}}},
+			"get": {"summary": "List zebras", "responses": {"200": {"description": "ok"}}}
+		},
+		"/apes": {
+			"get": {"summary": "List apes", "responses": {"404": {"description": "none"}, "200": {"description": "ok"}}}
+		}
+	},
+	"definitions": {
+		"Zebra": {
+			"type": "object",
+			"properties": {
+				"stripes": {"type": "integer"},
+				"name": {"type": "string"},
+				"age": {"type": "integer"}
+			}
+		}
+	}
+}`
+
+const orderedSpecYAML = `swagger: "2.0"
+info:
+  title: Zoo
+  version: "1.0"
+paths:
+  /zebras:
+    post:
+      summary: Add zebra
+      responses:
+        "201": {description: created}
+        "400": {description: bad}
+    get:
+      summary: List zebras
+      responses:
+        "200": {description: ok}
+  /apes:
+    get:
+      summary: List apes
+      responses:
+        "404": {description: none}
+        "200": {description: ok}
+definitions:
+  Zebra:
+    type: object
+    properties:
+      stripes: {type: integer}
+      name: {type: string}
+      age: {type: integer}
+`
+
+func TestParser_PreservesDocumentOrder(t *testing.T) {
+	overlayPath := filepath.Join(t.TempDir(), "overlay.yaml")
+	overlay := `overlay: 1.0.0
+info:
+  title: Descriptions
+  version: 1.0.0
+actions:
+  - target: $.info
+    update:
+      description: Animals
+`
+	if err := os.WriteFile(overlayPath, []byte(overlay), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		cfg    config.SpecConfig
+		body   string
+		format string
+	}{
+		{name: "json", body: orderedSpecJSON, format: "application/json"},
+		{name: "yaml", body: orderedSpecYAML, format: "application/yaml"},
+		{name: "json with overlay", cfg: config.SpecConfig{Overlays: []string{overlayPath}}, body: orderedSpecJSON, format: "application/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, err := NewParserWithConfig(tt.cfg)
+			if err != nil {
+				t.Fatalf("NewParserWithConfig() error = %v", err)
+			}
+			spec, err := parser.ParseBytes([]byte(tt.body), tt.format, "")
+			if err != nil {
+				t.Fatalf("ParseBytes() error = %v", err)
+			}
+
+			var endpoints []string
+			for _, endpoint := range parser.ExtractEndpoints(spec) {
+				endpoints = append(endpoints, endpoint.Method+" "+endpoint.Path)
+			}
+			if got, want := strings.Join(endpoints, ","), "get /zebras,post /zebras,get /apes"; got != want {
+				t.Errorf("endpoints = %s, want %s", got, want)
+			}
+
+			if got := strings.Join(spec.Paths["/apes"]["get"].ResponseCodes(), ","); got != "404,200" {
+				t.Errorf("response codes = %s, want 404,200", got)
+			}
+
+			resolved, err := NewResolver(spec).ResolveSchema(&Schema{Ref: "#/definitions/Zebra"})
+			if err != nil {
+				t.Fatalf("ResolveSchema() error = %v", err)
+			}
+			if got := strings.Join(resolved.PropertyNames(), ","); got != "stripes,name,age" {
+				t.Errorf("properties = %s, want stripes,name,age", got)
+			}
+		})
+	}
+}
+
+func TestOrderedKeys_UnrecordedKeysSorted(t *testing.T) {
+	schema := &Schema{
+		Properties:    map[string]Property{"b": {}, "c": {}, "a": {}, "d": {}},
+		PropertyOrder: []string{"c", "missing", "a"},
+	}
+
+	if got := strings.Join(schema.PropertyNames(), ","); got != "c,a,b,d" {
+		t.Errorf("PropertyNames() = %s, want c,a,b,d", got)
+	}
+}
+
+func TestParser_EndpointsStopsEarly(t *testing.T) {
+	parser := NewParser()
+	spec, err := parser.ParseBytes([]byte(orderedSpecJSON), "application/json", "")
+	if err != nil {
+		t.Fatalf("ParseBytes() error = %v", err)
+	}
+
+	if got := parser.CountEndpoints(spec); got != 3 {
+		t.Errorf("CountEndpoints() = %d, want 3", got)
+	}
+
+	var seen []string
+	for endpoint := range parser.Endpoints(spec) {
+		seen = append(seen, endpoint.Title)
+		if len(seen) == 2 {
+			break
+		}
+	}
+	if got := strings.Join(seen, ","); got != "List zebras,Add zebra" {
+		t.Errorf("endpoints = %s, want List zebras,Add zebra", got)
+	}
+}
@@ -1,34 +1,139 @@
 package swagger
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
+	"os"
+	"slices"
 	"strings"
-	"time"
+	"text/template"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/httpclient"
+	"github.com/ahmadimt/SwagFluence/internal/overlay"
+)
+
+// Supported document formats
+const (
+	FormatJSON      = "json"
+	FormatYAML      = "yaml"
+	FormatBlueprint = "apib"
 )
 
+// StdinSource is the spec source that reads the document from standard input
+const StdinSource = "-"
+
 // Parser handles Swagger/OpenAPI specification parsing
 type Parser struct {
+	cfg        config.SpecConfig
 	httpClient *http.Client
+	overlays   []*overlay.Overlay
+	// filter selects the endpoints that are documented; nil selects all
+	filter *Filter
+	// stdin is read for StdinSource; nil means os.Stdin
+	stdin io.Reader
+	// titleTemplate formats endpoint page titles; nil uses the generated
+	// titles
+	titleTemplate *template.Template
 }
 
 // NewParser creates a new Parser instance
 func NewParser() *Parser {
 	return &Parser{
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: httpclient.DefaultTimeout,
 		},
 	}
 }
 
-// Parse fetches and parses a Swagger/OpenAPI specification from a URL
-func (p *Parser) Parse(ctx context.Context, url string) (*Spec, error) {
+// NewParserWithConfig creates a new Parser honoring format, version and
+// TLS settings
+func NewParserWithConfig(cfg config.SpecConfig) (*Parser, error) {
+	httpClient, err := httpclient.New(cfg.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure spec transport: %w", err)
+	}
+
+	overlays := make([]*overlay.Overlay, 0, len(cfg.Overlays))
+	for _, path := range cfg.Overlays {
+		o, err := overlay.Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load overlay %s: %w", path, err)
+		}
+		overlays = append(overlays, o)
+	}
+
+	filter, err := NewFilter(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint filter: %w", err)
+	}
+
+	parser := &Parser{
+		cfg:        cfg,
+		httpClient: httpClient,
+		overlays:   overlays,
+		filter:     filter,
+	}
+	if cfg.TitleTemplate != "" {
+		if parser.titleTemplate, err = parseTitleTemplate(cfg.TitleTemplate); err != nil {
+			return nil, err
+		}
+	}
+	return parser, nil
+}
+
+// Parse reads and parses a Swagger/OpenAPI specification. The source is an
+// http(s) URL, a local file path or StdinSource.
+func (p *Parser) Parse(ctx context.Context, source string) (*Spec, error) {
+	if source == StdinSource {
+		return p.parseStdin()
+	}
+	if !IsURL(source) {
+		return p.parseFile(source)
+	}
+	return p.parseURL(ctx, source)
+}
+
+// IsURL reports whether a spec source is fetched over HTTP
+func IsURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// parseFile parses a specification stored on disk
+func (p *Parser) parseFile(path string) (*Spec, error) {
+	body, err := os.ReadFile(strings.TrimPrefix(path, "file://"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read swagger file: %w", err)
+	}
+	return p.ParseBytes(body, "", path)
+}
+
+// parseStdin parses a specification piped to standard input
+func (p *Parser) parseStdin() (*Spec, error) {
+	stdin := p.stdin
+	if stdin == nil {
+		stdin = os.Stdin
+	}
+	body, err := io.ReadAll(stdin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read swagger from stdin: %w", err)
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, fmt.Errorf("no swagger document on stdin")
+	}
+	return p.ParseBytes(body, "", "")
+}
+
+// parseURL fetches and parses a specification served over HTTP
+func (p *Parser) parseURL(ctx context.Context, url string) (*Spec, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -49,47 +154,237 @@ func (p *Parser) Parse(ctx context.Context, url string) (*Spec, error) {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
+	return p.ParseBytes(body, resp.Header.Get("Content-Type"), url)
+}
+
+// ParseBytes parses a specification document. The content type and source
+// name are only used as hints when detecting the document format.
+func (p *Parser) ParseBytes(body []byte, contentType, source string) (*Spec, error) {
+	format := p.cfg.Format
+	if format == "" {
+		format = detectFormat(body, contentType, source)
+	}
+	document := body
+
+	switch format {
+	case FormatJSON:
+	case FormatYAML:
+		converted, err := yamlToJSON(body)
+		if err != nil {
+			return nil, err
+		}
+		body = converted
+	case FormatBlueprint:
+		// Blueprints are converted to OpenAPI so overlays apply as usual
+		blueprint, err := parseBlueprint(body)
+		if err != nil {
+			return nil, err
+		}
+		if len(p.overlays) == 0 {
+			// Use the converted spec directly; encoding it loses element order
+			if err := p.applyVersion(blueprint); err != nil {
+				return nil, err
+			}
+			blueprint.Document, blueprint.Format = document, format
+			return blueprint, nil
+		}
+		if body, err = json.Marshal(blueprint); err != nil {
+			return nil, fmt.Errorf("failed to encode blueprint: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported spec format %q (expected json, yaml or apib)", format)
+	}
+
+	if len(p.overlays) > 0 {
+		overlaid, err := p.applyOverlays(body)
+		if err != nil {
+			return nil, err
+		}
+		body = overlaid
+	}
+
 	var spec Spec
 	if err := json.Unmarshal(body, &spec); err != nil {
 		return nil, fmt.Errorf("failed to parse swagger: %w", err)
 	}
 
+	if err := p.applyVersion(&spec); err != nil {
+		return nil, err
+	}
+
+	// Shared parameters, request bodies and responses are inlined once, so
+	// nothing downstream resolves them
+	if err := InlineComponents(&spec); err != nil {
+		return nil, fmt.Errorf("failed to resolve components: %w", err)
+	}
+
+	InheritMediaTypes(&spec)
+
+	if !p.cfg.IncludeHidden {
+		RemoveHidden(&spec)
+	}
+
+	spec.Document, spec.Format = document, format
 	return &spec, nil
 }
 
-// ExtractEndpoints extracts all endpoints from a specification
+// applyOverlays applies the configured overlay documents to the raw JSON spec
+func (p *Parser) applyOverlays(body []byte) ([]byte, error) {
+	// Overlays work on generic maps, so remember the original member order
+	orders, err := keyOrders(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse swagger: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse swagger: %w", err)
+	}
+
+	for _, o := range p.overlays {
+		var err error
+		if doc, err = o.Apply(doc); err != nil {
+			return nil, fmt.Errorf("failed to apply overlay %q: %w", o.Info.Title, err)
+		}
+	}
+
+	overlaid, err := marshalOrdered(doc, orders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode overlaid spec: %w", err)
+	}
+	return overlaid, nil
+}
+
+// applyVersion enforces the configured version override, or verifies that
+// the document declares a version we can detect
+func (p *Parser) applyVersion(spec *Spec) error {
+	switch p.cfg.Version {
+	case "":
+		if spec.Swagger == "" && spec.OpenAPI == "" {
+			return fmt.Errorf("unable to detect spec version: missing swagger/openapi field (use --spec-version)")
+		}
+	case "2", "2.0":
+		spec.Swagger = "2.0"
+		spec.OpenAPI = ""
+	case "3", "3.0":
+		spec.Swagger = ""
+		spec.OpenAPI = "3.0.0"
+	case "3.1":
+		spec.Swagger = ""
+		spec.OpenAPI = "3.1.0"
+	default:
+		return fmt.Errorf("unsupported spec version %q (expected 2, 3 or 3.1)", p.cfg.Version)
+	}
+	return nil
+}
+
+// detectFormat guesses the document format from the Content-Type header,
+// the source extension and finally the first non-blank character
+func detectFormat(body []byte, contentType, source string) string {
+	contentType = strings.ToLower(contentType)
+	switch {
+	case strings.Contains(contentType, "apiblueprint"):
+		return FormatBlueprint
+	case strings.Contains(contentType, "yaml"):
+		return FormatYAML
+	case strings.Contains(contentType, "json"):
+		return FormatJSON
+	}
+
+	lowerSource := strings.ToLower(source)
+	if strings.HasSuffix(lowerSource, ".yaml") || strings.HasSuffix(lowerSource, ".yml") {
+		return FormatYAML
+	}
+	if strings.HasSuffix(lowerSource, ".json") {
+		return FormatJSON
+	}
+	if strings.HasSuffix(lowerSource, ".apib") || isBlueprint(body) {
+		return FormatBlueprint
+	}
+
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+// ExtractEndpoints extracts the endpoints selected by the parser's filter
+// from a specification
 func (p *Parser) ExtractEndpoints(spec *Spec) []EndpointInfo {
-	var endpoints []EndpointInfo
-
-	for path, pathItem := range spec.Paths {
-		for method, operation := range pathItem {
-			if isHTTPMethod(method) {
-				title := generatePageTitle(path, method, operation)
-				endpoints = append(endpoints, EndpointInfo{
-					Path:      path,
-					Method:    method,
-					Operation: operation,
-					Title:     title,
-				})
+	return slices.Collect(p.Endpoints(spec))
+}
+
+// Endpoints yields the selected endpoints of the spec in document order, one
+// at a time, so callers can process very large specs without holding them
+// all. Webhooks follow the paths, named by their webhook name.
+func (p *Parser) Endpoints(spec *Spec) iter.Seq[EndpointInfo] {
+	return func(yield func(EndpointInfo) bool) {
+		titles, _ := p.endpointTitles(spec)
+		for endpoint := range p.selectedEndpoints(spec) {
+			endpoint.Title = titles[keyOf(endpoint)]
+			if !yield(endpoint) {
+				return
 			}
 		}
 	}
+}
 
-	return endpoints
+// selectedEndpoints yields the selected endpoints in document order,
+// without their titles
+func (p *Parser) selectedEndpoints(spec *Spec) iter.Seq[EndpointInfo] {
+	return func(yield func(EndpointInfo) bool) {
+		for _, path := range spec.PathNames() {
+			if !p.yieldOperations(spec, path, spec.Paths[path], false, yield) {
+				return
+			}
+		}
+		for _, name := range spec.WebhookNames() {
+			if !p.yieldOperations(spec, name, spec.Webhooks[name], true, yield) {
+				return
+			}
+		}
+	}
+}
+
+// yieldOperations yields the selected operations of one path item. It
+// returns false once yield asks to stop.
+func (p *Parser) yieldOperations(spec *Spec, path string, pathItem PathItem, webhook bool, yield func(EndpointInfo) bool) bool {
+	for _, method := range pathItem.Methods() {
+		operation := pathItem[method]
+		if !p.filter.Match(path, method, operation) {
+			continue
+		}
+		endpoint := EndpointInfo{
+			Path:      path,
+			Method:    method,
+			Operation: operation,
+			Webhook:   webhook,
+		}
+		if !yield(endpoint) {
+			return false
+		}
+	}
+	return true
 }
 
-// isHTTPMethod checks if a string is a valid HTTP method
-func isHTTPMethod(method string) bool {
-	validMethods := map[string]bool{
-		"get":     true,
-		"post":    true,
-		"put":     true,
-		"delete":  true,
-		"patch":   true,
-		"options": true,
-		"head":    true,
+// CountEndpoints returns the number of selected endpoints and webhooks in
+// the spec
+func (p *Parser) CountEndpoints(spec *Spec) int {
+	count := 0
+	for _, items := range []map[string]PathItem{spec.Paths, spec.Webhooks} {
+		for path, pathItem := range items {
+			for _, method := range pathItem.Methods() {
+				if p.filter.Match(path, method, pathItem[method]) {
+					count++
+				}
+			}
+		}
 	}
-	return validMethods[strings.ToLower(method)]
+	return count
 }
 
 // generatePageTitle generates a page title for an endpoint
@@ -147,4 +442,4 @@ func generateTitleFromPath(path, method string) string {
 	}
 
 	return fmt.Sprintf("%s %s", methodVerb, strings.Join(titleParts, " "))
-}
\ No newline at end of file
+}
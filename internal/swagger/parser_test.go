@@ -4,7 +4,12 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
 )
 
 func TestParser_Parse(t *testing.T) {
@@ -64,6 +69,48 @@ func TestParser_Parse(t *testing.T) {
 	}
 }
 
+func TestParser_ParseLocalSources(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "openapi.yaml")
+	if err := os.WriteFile(yamlPath, []byte("openapi: 3.0.0\ninfo:\n  title: File API\n  version: 1.0.0\npaths: {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		source    string
+		stdin     string
+		wantTitle string
+		wantError bool
+	}{
+		{name: "file path", source: yamlPath, wantTitle: "File API"},
+		{name: "file URL", source: "file://" + yamlPath, wantTitle: "File API"},
+		{name: "missing file", source: filepath.Join(dir, "missing.json"), wantError: true},
+		{
+			name:      "stdin",
+			source:    StdinSource,
+			stdin:     `{"swagger": "2.0", "info": {"title": "Piped API", "version": "1"}, "paths": {}}`,
+			wantTitle: "Piped API",
+		},
+		{name: "empty stdin", source: StdinSource, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewParser()
+			parser.stdin = strings.NewReader(tt.stdin)
+
+			spec, err := parser.Parse(context.Background(), tt.source)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("Parse() error = %v, wantError %v", err, tt.wantError)
+			}
+			if !tt.wantError && spec.Info.Title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", spec.Info.Title, tt.wantTitle)
+			}
+		})
+	}
+}
+
 func TestParser_ExtractEndpoints(t *testing.T) {
 	spec := &Spec{
 		Paths: map[string]PathItem{
@@ -135,3 +182,86 @@ func TestGeneratePageTitle(t *testing.T) {
 		})
 	}
 }
+
+func TestParser_ParseBytesOverrides(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         config.SpecConfig
+		body        string
+		contentType string
+		wantOpenAPI string
+		wantSwagger string
+		wantError   bool
+	}{
+		{
+			name:        "yaml detected from content type",
+			body:        "openapi: 3.0.0\ninfo:\n  title: Test API\n  version: 1.0.0\npaths: {}\n",
+			contentType: "application/x-yaml",
+			wantOpenAPI: "3.0.0",
+		},
+		{
+			name:        "yaml served with wrong content type",
+			cfg:         config.SpecConfig{Format: "yaml"},
+			body:        "swagger: '2.0'\ninfo:\n  title: Test API\n  version: 1.0.0\n",
+			contentType: "application/json",
+			wantSwagger: "2.0",
+		},
+		{
+			name:        "unquoted yaml swagger and info version",
+			body:        "swagger: 2.0\ninfo:\n  title: Test API\n  version: 1.0\npaths: {}\n",
+			contentType: "application/x-yaml",
+			wantSwagger: "2.0",
+		},
+		{
+			name:        "unquoted yaml openapi and integer info version",
+			body:        "openapi: 3.0.3\ninfo:\n  title: Test API\n  version: 2\npaths: {}\n",
+			contentType: "application/x-yaml",
+			wantOpenAPI: "3.0.3",
+		},
+		{
+			name:      "missing version fails detection",
+			body:      `{"info": {"title": "Test API"}}`,
+			wantError: true,
+		},
+		{
+			name:        "missing version with override",
+			cfg:         config.SpecConfig{Version: "3.1"},
+			body:        `{"info": {"title": "Test API"}}`,
+			wantOpenAPI: "3.1.0",
+		},
+		{
+			name:      "unknown format",
+			cfg:       config.SpecConfig{Format: "xml"},
+			body:      `{"swagger": "2.0", "info": {"title": "Test API"}}`,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, err := NewParserWithConfig(tt.cfg)
+			if err != nil {
+				t.Fatalf("NewParserWithConfig() error = %v", err)
+			}
+			spec, err := parser.ParseBytes([]byte(tt.body), tt.contentType, "")
+
+			if (err != nil) != tt.wantError {
+				t.Fatalf("ParseBytes() error = %v, wantError %v", err, tt.wantError)
+			}
+			if tt.wantError {
+				return
+			}
+
+			if spec.Info.Title != "Test API" {
+				t.Errorf("expected title 'Test API', got '%s'", spec.Info.Title)
+			}
+			if spec.OpenAPI != tt.wantOpenAPI || spec.Swagger != tt.wantSwagger {
+				t.Errorf("got openapi=%q swagger=%q, want openapi=%q swagger=%q",
+					spec.OpenAPI, spec.Swagger, tt.wantOpenAPI, tt.wantSwagger)
+			}
+			if string(spec.Document) != tt.body {
+				t.Errorf("Document = %q, want the source document", spec.Document)
+			}
+		})
+	}
+}
@@ -2,56 +2,162 @@ package swagger
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
 )
 
-// Resolver handles $ref resolution in schemas
+// Resolver handles $ref resolution in schemas. Resolved refs are cached
+// per spec, so a component referenced by many endpoints is looked up and
+// resolved once. A Resolver is created for each run, which starts with
+// empty caches.
 type Resolver struct {
 	spec *Spec
+
+	mu    sync.Mutex
+	cache map[string]*Schema
+	// resolved holds fully resolved refs by resolvedKey. Entries are never
+	// handed out; callers get deep copies.
+	resolved map[string]*Schema
 }
 
 // NewResolver creates a new Resolver
 func NewResolver(spec *Spec) *Resolver {
-	return &Resolver{spec: spec}
+	return &Resolver{
+		spec:     spec,
+		cache:    make(map[string]*Schema),
+		resolved: make(map[string]*Schema),
+	}
 }
 
-// ResolveSchema resolves $ref references in a schema
+// ResolveSchema resolves $ref references in a schema, merges allOf members
+// and resolves oneOf/anyOf variants. Array items are resolved all the way
+// down; an items $ref back to an enclosing schema is left as a Recursive
+// placeholder. The spec is never modified: resolved schemas are deep copies
+// that share nothing with the spec or with each other, so callers may
+// modify them and one Resolver can be shared by concurrent conversions.
 func (r *Resolver) ResolveSchema(schema *Schema) (*Schema, error) {
+	return r.resolveSchema(schema, nil)
+}
+
+// resolveSchema is ResolveSchema for a schema nested in the schemas whose
+// refs are in path
+func (r *Resolver) resolveSchema(schema *Schema, path map[string]bool) (*Schema, error) {
 	if schema == nil {
 		return nil, nil
 	}
 
 	if schema.Ref != "" {
-		return r.resolveRef(schema.Ref)
+		if path[schema.Ref] {
+			return &Schema{Ref: schema.Ref, Title: ExtractRefName(schema.Ref), Type: "object", Recursive: true}, nil
+		}
+		return r.resolveRefSchema(schema.Ref, path)
+	}
+
+	merged, err := r.mergeAllOf(schema, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Without allOf members the merged schema is the input itself
+	resolved := merged.Clone()
+	if err := r.resolveNested(resolved, path); err != nil {
+		return nil, err
+	}
+	return r.withVariants(resolved)
+}
+
+// resolveRefSchema fully resolves the schema a $ref points to, memoized by
+// the ref and the enclosing refs, which decide where recursive references
+// are cut off
+func (r *Resolver) resolveRefSchema(ref string, path map[string]bool) (*Schema, error) {
+	key := resolvedKey(ref, path)
+	r.mu.Lock()
+	memo, ok := r.resolved[key]
+	r.mu.Unlock()
+	if ok {
+		return memo.Clone(), nil
+	}
+
+	resolved, err := r.resolveRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.resolveNested(resolved, withRef(path, ref)); err != nil {
+		return nil, err
+	}
+	if resolved, err = r.withVariants(resolved); err != nil {
+		return nil, err
 	}
 
-	// Resolve nested schemas in properties
+	r.mu.Lock()
+	r.resolved[key] = resolved
+	r.mu.Unlock()
+	return resolved.Clone(), nil
+}
+
+// resolvedKey identifies the resolution of ref within the refs of path
+func resolvedKey(ref string, path map[string]bool) string {
+	refs := make([]string, 0, len(path)+1)
+	refs = append(refs, ref)
+	for seenRef := range path {
+		refs = append(refs, seenRef)
+	}
+	slices.Sort(refs[1:])
+	return strings.Join(refs, "\x00")
+}
+
+// resolveNested resolves the properties and array items of a schema in
+// place. The schema must be the caller's own copy.
+func (r *Resolver) resolveNested(schema *Schema, path map[string]bool) error {
 	if len(schema.Properties) > 0 {
-		resolvedProperties := make(map[string]Property)
+		resolvedProperties := make(map[string]Property, len(schema.Properties))
 		for key, prop := range schema.Properties {
-			resolvedProp, err := r.resolveProperty(prop)
+			resolvedProp, err := r.resolveProperty(prop, path)
 			if err != nil {
-				return nil, fmt.Errorf("failed to resolve property %s: %w", key, err)
+				return fmt.Errorf("failed to resolve property %s: %w", key, err)
 			}
 			resolvedProperties[key] = resolvedProp
 		}
 		schema.Properties = resolvedProperties
 	}
 
-	// Resolve array items
 	if schema.Items != nil {
-		resolved, err := r.ResolveSchema(schema.Items)
+		items, err := r.resolveSchema(schema.Items, path)
 		if err != nil {
-			return nil, fmt.Errorf("failed to resolve items: %w", err)
+			return fmt.Errorf("failed to resolve items: %w", err)
 		}
-		schema.Items = resolved
+		schema.Items = items
+	}
+	return nil
+}
+
+// withRef returns a copy of path that also holds ref
+func withRef(path map[string]bool, ref string) map[string]bool {
+	extended := make(map[string]bool, len(path)+1)
+	for seenRef := range path {
+		extended[seenRef] = true
 	}
+	extended[ref] = true
+	return extended
+}
 
-	return schema, nil
+// withVariants resolves the oneOf/anyOf variants of a resolved schema
+func (r *Resolver) withVariants(resolved *Schema) (*Schema, error) {
+	var err error
+	if resolved.OneOf, err = r.resolveVariants(resolved.OneOf); err != nil {
+		return nil, fmt.Errorf("failed to resolve oneOf: %w", err)
+	}
+	if resolved.AnyOf, err = r.resolveVariants(resolved.AnyOf); err != nil {
+		return nil, fmt.Errorf("failed to resolve anyOf: %w", err)
+	}
+	return resolved, nil
 }
 
-// resolveProperty resolves a property, including its references
-func (r *Resolver) resolveProperty(prop Property) (Property, error) {
+// resolveProperty resolves a property, including its references. Object
+// references stay references, taking the type, format and values of the
+// referenced schema where the property leaves them out; array items are
+// resolved.
+func (r *Resolver) resolveProperty(prop Property, path map[string]bool) (Property, error) {
 	if prop.Ref != "" {
 		schema, err := r.resolveRef(prop.Ref)
 		if err != nil {
@@ -59,11 +165,25 @@ func (r *Resolver) resolveProperty(prop Property) (Property, error) {
 		}
 		// Convert schema back to property
 		prop.Type = schema.Type
-	
+		if prop.Format == "" {
+			prop.Format = schema.Format
+		}
+		if prop.Description == "" {
+			prop.Description = schema.Description
+		}
+		if prop.Enum == nil {
+			prop.Enum = schema.Enum
+		}
+		if prop.Default == nil {
+			prop.Default = schema.Default
+		}
+		if prop.Items == nil {
+			prop.Items = schema.Items
+		}
 	}
 
-	if prop.Items != nil && prop.Items.Ref != "" {
-		resolved, err := r.resolveRef(prop.Items.Ref)
+	if prop.Items != nil {
+		resolved, err := r.resolveSchema(prop.Items, path)
 		if err != nil {
 			return prop, err
 		}
@@ -73,37 +193,118 @@ func (r *Resolver) resolveProperty(prop Property) (Property, error) {
 	return prop, nil
 }
 
-// resolveRef resolves a $ref string to a schema
+// resolveRef resolves a $ref string to a schema, using the cache. Callers
+// get their own deep copy of the cached schema, which shares nothing with
+// the cache or the spec.
 func (r *Resolver) resolveRef(ref string) (*Schema, error) {
+	return r.resolveRefSeen(ref, nil)
+}
+
+// resolveRefSeen is resolveRef for a ref reached through the refs in seen
+func (r *Resolver) resolveRefSeen(ref string, seen map[string]bool) (*Schema, error) {
+	r.mu.Lock()
+	cached, ok := r.cache[ref]
+	r.mu.Unlock()
+
+	if !ok {
+		var err error
+		cached, err = r.lookupRef(ref, seen)
+		if err != nil {
+			return nil, err
+		}
+		r.mu.Lock()
+		r.cache[ref] = cached
+		r.mu.Unlock()
+	}
+
+	return cached.Clone(), nil
+}
+
+// lookupRef finds the definition a $ref points to, following definitions
+// that are themselves references and merging allOf members. seen holds the
+// refs being resolved on the way here and guards against reference cycles.
+func (r *Resolver) lookupRef(ref string, seen map[string]bool) (*Schema, error) {
+	if seen[ref] {
+		return nil, fmt.Errorf("circular $ref: %s", ref)
+	}
+
+	def, err := r.definition(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	path := make(map[string]bool, len(seen)+1)
+	for seenRef := range seen {
+		path[seenRef] = true
+	}
+	path[ref] = true
+
+	if def.Ref != "" {
+		return r.lookupRef(def.Ref, path)
+	}
+
+	return r.mergeAllOf(&Schema{
+		Type:          def.Type,
+		Format:        def.Format,
+		Description:   def.Description,
+		Properties:    def.Properties,
+		Items:         def.Items,
+		Enum:          def.Enum,
+		Default:       def.Default,
+		Required:      def.Required,
+		Title:         def.Title,
+		AllOf:         def.AllOf,
+		OneOf:         def.OneOf,
+		AnyOf:         def.AnyOf,
+		Discriminator: def.Discriminator,
+		Nullable:      def.Nullable,
+		Example:       def.Example,
+		PropertyOrder: def.PropertyOrder,
+	}, path)
+}
+
+// definition returns the component schema or definition named by a $ref
+func (r *Resolver) definition(ref string) (Definition, error) {
 	// Handle #/components/schemas/... (OpenAPI 3.x)
 	if strings.HasPrefix(ref, "#/components/schemas/") {
 		name := strings.TrimPrefix(ref, "#/components/schemas/")
 		if r.spec.Components != nil {
 			if def, ok := r.spec.Components.Schemas[name]; ok {
-				return &Schema{
-					Type:       def.Type,
-					Properties: def.Properties,
-					Required:   def.Required,
-				}, nil
+				return def, nil
 			}
 		}
-		return nil, fmt.Errorf("schema not found: %s", name)
+		return Definition{}, fmt.Errorf("schema not found: %s", name)
 	}
 
 	// Handle #/definitions/... (Swagger 2.0)
 	if strings.HasPrefix(ref, "#/definitions/") {
 		name := strings.TrimPrefix(ref, "#/definitions/")
 		if def, ok := r.spec.Definitions[name]; ok {
-			return &Schema{
-				Type:       def.Type,
-				Properties: def.Properties,
-				Required:   def.Required,
-			}, nil
+			return def, nil
 		}
-		return nil, fmt.Errorf("definition not found: %s", name)
+		return Definition{}, fmt.Errorf("definition not found: %s", name)
 	}
 
-	return nil, fmt.Errorf("unsupported $ref format: %s", ref)
+	return Definition{}, fmt.Errorf("unsupported $ref format: %s", ref)
+}
+
+// ResolveHeader resolves a response header that references a reusable
+// header in components.headers
+func (r *Resolver) ResolveHeader(header Header) (Header, error) {
+	if header.Ref == "" {
+		return header, nil
+	}
+	if !strings.HasPrefix(header.Ref, "#/components/headers/") {
+		return Header{}, fmt.Errorf("unsupported $ref format: %s", header.Ref)
+	}
+
+	name := strings.TrimPrefix(header.Ref, "#/components/headers/")
+	if r.spec.Components != nil {
+		if resolved, ok := r.spec.Components.Headers[name]; ok {
+			return resolved, nil
+		}
+	}
+	return Header{}, fmt.Errorf("header not found: %s", name)
 }
 
 // ExtractRefName extracts the name from a $ref string
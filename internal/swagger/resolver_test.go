@@ -1,6 +1,10 @@
 package swagger
 
 import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -81,6 +85,162 @@ func TestResolver_ResolveNestedSchema(t *testing.T) {
 	}
 }
 
+func TestResolver_ResolveSchemaDoesNotModifySpec(t *testing.T) {
+	spec := &Spec{
+		Definitions: map[string]Definition{
+			"Tag": {Type: "object", Properties: map[string]Property{"name": {Type: "string"}}},
+		},
+	}
+	schema := &Schema{
+		Type: "object",
+		Properties: map[string]Property{
+			"tag":  {Ref: "#/definitions/Tag"},
+			"tags": {Type: "array", Items: &Schema{Ref: "#/definitions/Tag"}},
+		},
+		Items: &Schema{Ref: "#/definitions/Tag"},
+	}
+
+	resolver := NewResolver(spec)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := resolver.ResolveSchema(schema); err != nil {
+				t.Errorf("ResolveSchema() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	resolved, err := resolver.ResolveSchema(schema)
+	if err != nil {
+		t.Fatalf("ResolveSchema() error = %v", err)
+	}
+	if resolved == schema {
+		t.Fatal("ResolveSchema() returned the input schema instead of a copy")
+	}
+	if resolved.Properties["tag"].Type != "object" || resolved.Items.Type != "object" {
+		t.Errorf("resolved = %+v, want refs resolved", resolved)
+	}
+	if schema.Properties["tag"].Type != "" || schema.Items.Ref == "" || schema.Properties["tags"].Items.Ref == "" {
+		t.Errorf("input schema was modified: %+v", schema)
+	}
+}
+
+func TestResolver_ResolveRefAliases(t *testing.T) {
+	spec := &Spec{
+		Components: &Components{
+			Schemas: map[string]Definition{
+				"Pet":    {Type: "object", Properties: map[string]Property{"id": {Type: "integer"}}},
+				"Animal": {Ref: "#/components/schemas/Pet"},
+				"Ping":   {Ref: "#/components/schemas/Pong"},
+				"Pong":   {Ref: "#/components/schemas/Ping"},
+			},
+		},
+	}
+	resolver := NewResolver(spec)
+
+	tests := []struct {
+		name     string
+		ref      string
+		wantType string
+		wantErr  bool
+	}{
+		{name: "direct", ref: "#/components/schemas/Pet", wantType: "object"},
+		{name: "alias", ref: "#/components/schemas/Animal", wantType: "object"},
+		{name: "cycle", ref: "#/components/schemas/Ping", wantErr: true},
+		{name: "missing", ref: "#/components/schemas/Nope", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Resolve twice so the second lookup is served from the cache
+			for i := 0; i < 2; i++ {
+				resolved, err := resolver.ResolveSchema(&Schema{Ref: tt.ref})
+				if tt.wantErr {
+					if err == nil {
+						t.Fatalf("ResolveSchema(%s) expected error", tt.ref)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("ResolveSchema(%s) error = %v", tt.ref, err)
+				}
+				if resolved.Type != tt.wantType || len(resolved.Properties) != 1 {
+					t.Errorf("ResolveSchema(%s) = %+v", tt.ref, resolved)
+				}
+				// Callers must not be able to change the cached schema
+				resolved.Type = "changed"
+			}
+		})
+	}
+}
+
+func TestResolver_ResolveRecursiveSchema(t *testing.T) {
+	spec := &Spec{
+		Definitions: map[string]Definition{
+			"Category": {
+				Type: "object",
+				Properties: map[string]Property{
+					"name":     {Type: "string"},
+					"children": {Type: "array", Items: &Schema{Ref: "#/definitions/Category"}},
+				},
+			},
+			"A": {
+				Type:       "object",
+				Properties: map[string]Property{"bs": {Type: "array", Items: &Schema{Ref: "#/definitions/B"}}},
+			},
+			"B": {
+				Type:       "object",
+				Properties: map[string]Property{"as": {Type: "array", Items: &Schema{Ref: "#/definitions/A"}}},
+			},
+		},
+	}
+	resolver := NewResolver(spec)
+
+	tests := []struct {
+		name    string
+		ref     string
+		recurse func(*Schema) *Schema
+		wantRef string
+	}{
+		{
+			name:    "self reference",
+			ref:     "#/definitions/Category",
+			recurse: func(s *Schema) *Schema { return s.Properties["children"].Items },
+			wantRef: "#/definitions/Category",
+		},
+		{
+			name:    "mutual reference",
+			ref:     "#/definitions/A",
+			recurse: func(s *Schema) *Schema { return s.Properties["bs"].Items.Properties["as"].Items },
+			wantRef: "#/definitions/A",
+		},
+		{
+			// B was resolved inside A above; on its own it is cut off at B
+			name:    "mutual reference resolved earlier within another",
+			ref:     "#/definitions/B",
+			recurse: func(s *Schema) *Schema { return s.Properties["as"].Items.Properties["bs"].Items },
+			wantRef: "#/definitions/B",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := resolver.ResolveSchema(&Schema{Ref: tt.ref})
+			if err != nil {
+				t.Fatalf("ResolveSchema(%s) error = %v", tt.ref, err)
+			}
+			placeholder := tt.recurse(resolved)
+			if placeholder == nil || !placeholder.Recursive || placeholder.Ref != tt.wantRef {
+				t.Errorf("expected recursive placeholder for %s, got %+v", tt.wantRef, placeholder)
+			}
+		})
+	}
+}
+
 func TestExtractRefName(t *testing.T) {
 	tests := []struct {
 		ref  string
@@ -108,4 +268,194 @@ func TestExtractRefName(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestResolver_ResolvedSchemasAreIndependent(t *testing.T) {
+	minimum := 1.0
+	spec := &Spec{
+		Components: &Components{
+			Schemas: map[string]Definition{
+				"Tag": {
+					Type:     "object",
+					Required: []string{"name"},
+					Properties: map[string]Property{
+						"name":    {Type: "string", Enum: []interface{}{"a", "b"}},
+						"score":   {Type: "integer", Minimum: &minimum},
+						"aliases": {Type: "array", Items: &Schema{Type: "string"}},
+					},
+					OneOf: []*Schema{{Type: "object", Title: "Short"}},
+				},
+			},
+		},
+	}
+	resolver := NewResolver(spec)
+
+	// Two endpoints reference the same component
+	first, err := resolver.ResolveSchema(&Schema{Ref: "#/components/schemas/Tag"})
+	if err != nil {
+		t.Fatalf("ResolveSchema() error = %v", err)
+	}
+
+	// Rendering one endpoint may adjust its copy in any way
+	first.Required[0] = "changed"
+	first.Required = append(first.Required, "extra")
+	name := first.Properties["name"]
+	name.Enum[0] = "changed"
+	first.Properties["name"] = name
+	*first.Properties["score"].Minimum = 99
+	first.Properties["aliases"].Items.Type = "integer"
+	first.OneOf[0].Title = "Changed"
+	delete(first.Properties, "score")
+
+	second, err := resolver.ResolveSchema(&Schema{Ref: "#/components/schemas/Tag"})
+	if err != nil {
+		t.Fatalf("ResolveSchema() error = %v", err)
+	}
+	for _, schema := range []struct {
+		name string
+		got  *Schema
+	}{
+		{"second resolution", second},
+		{"spec", &Schema{
+			Required:   spec.Components.Schemas["Tag"].Required,
+			Properties: spec.Components.Schemas["Tag"].Properties,
+			OneOf:      spec.Components.Schemas["Tag"].OneOf,
+		}},
+	} {
+		got := schema.got
+		if len(got.Required) != 1 || got.Required[0] != "name" {
+			t.Errorf("%s: required = %v, want [name]", schema.name, got.Required)
+		}
+		if got.Properties["name"].Enum[0] != "a" {
+			t.Errorf("%s: enum = %v, want [a b]", schema.name, got.Properties["name"].Enum)
+		}
+		if score, ok := got.Properties["score"]; !ok || *score.Minimum != 1 {
+			t.Errorf("%s: score = %+v, want minimum 1", schema.name, score)
+		}
+		if got.Properties["aliases"].Items.Type != "string" {
+			t.Errorf("%s: aliases items = %+v, want string", schema.name, got.Properties["aliases"].Items)
+		}
+		if got.OneOf[0].Title != "Short" {
+			t.Errorf("%s: oneOf title = %q, want Short", schema.name, got.OneOf[0].Title)
+		}
+	}
+}
+
+func TestResolver_InlineSchemaIsCopied(t *testing.T) {
+	schema := &Schema{
+		Type:       "object",
+		Required:   []string{"id"},
+		Properties: map[string]Property{"id": {Type: "integer"}},
+		OneOf:      []*Schema{{Type: "string"}},
+	}
+
+	resolved, err := NewResolver(&Spec{}).ResolveSchema(schema)
+	if err != nil {
+		t.Fatalf("ResolveSchema() error = %v", err)
+	}
+	resolved.Required[0] = "changed"
+	resolved.OneOf[0].Type = "integer"
+
+	if schema.Required[0] != "id" || schema.OneOf[0].Type != "string" {
+		t.Errorf("input schema was modified: %+v", schema)
+	}
+}
+
+func TestResolver_MemoizesResolvedRefs(t *testing.T) {
+	spec := &Spec{
+		Definitions: map[string]Definition{
+			"Pet": {Type: "object", Properties: map[string]Property{
+				"tags": {Type: "array", Items: &Schema{Ref: "#/definitions/Tag"}},
+			}},
+			"Tag": {Type: "object", Properties: map[string]Property{"name": {Type: "string"}}},
+		},
+	}
+	resolver := NewResolver(spec)
+
+	var results []*Schema
+	for i := 0; i < 3; i++ {
+		resolved, err := resolver.ResolveSchema(&Schema{Ref: "#/definitions/Pet"})
+		if err != nil {
+			t.Fatalf("ResolveSchema() error = %v", err)
+		}
+		results = append(results, resolved)
+	}
+
+	// Pet, and Tag within Pet
+	if len(resolver.resolved) != 2 {
+		t.Errorf("memoized %d resolutions, want 2", len(resolver.resolved))
+	}
+	if results[0] == results[1] || results[0].Properties["tags"].Items == results[1].Properties["tags"].Items {
+		t.Error("memoized resolutions share schemas")
+	}
+	if results[2].Properties["tags"].Items.Properties["name"].Type != "string" {
+		t.Errorf("resolved = %+v, want tags resolved", results[2])
+	}
+}
+
+func BenchmarkResolver_ResolveSchema(b *testing.B) {
+	// A chain of models, each holding a list of the next
+	definitions := make(map[string]Definition)
+	for i := 0; i < 20; i++ {
+		properties := map[string]Property{"id": {Type: "integer"}, "name": {Type: "string"}}
+		if i < 19 {
+			properties["children"] = Property{Type: "array", Items: &Schema{Ref: fmt.Sprintf("#/definitions/M%d", i+1)}}
+		}
+		definitions[fmt.Sprintf("M%d", i)] = Definition{Type: "object", Properties: properties}
+	}
+	resolver := NewResolver(&Spec{Definitions: definitions})
+	schema := &Schema{Ref: "#/definitions/M0"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := resolver.ResolveSchema(schema); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestResolver_ResolveComponentRefs(t *testing.T) {
+	var spec Spec
+	if err := json.Unmarshal([]byte(`{
+		"openapi": "3.0.0",
+		"components": {"schemas": {
+			"Pet": {"type": "object", "properties": {"name": {"type": "string"}}},
+			"Pets": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}},
+			"Status": {"type": "string", "format": "slug", "description": "Adoption status", "enum": ["available", "sold"], "default": "sold"},
+			"Owner": {"type": "object", "properties": {"status": {"$ref": "#/components/schemas/Status"}, "pets": {"$ref": "#/components/schemas/Pets"}}}
+		}}
+	}`), &spec); err != nil {
+		t.Fatal(err)
+	}
+	resolver := NewResolver(&spec)
+
+	pets, err := resolver.ResolveSchema(&Schema{Ref: "#/components/schemas/Pets"})
+	if err != nil {
+		t.Fatalf("ResolveSchema(Pets) error = %v", err)
+	}
+	if pets.Type != "array" || pets.Items == nil || pets.Items.Properties["name"].Type != "string" {
+		t.Errorf("Pets = %+v, want an array of resolved Pet items", pets)
+	}
+
+	status, err := resolver.ResolveSchema(&Schema{Ref: "#/components/schemas/Status"})
+	if err != nil {
+		t.Fatalf("ResolveSchema(Status) error = %v", err)
+	}
+	if status.Format != "slug" || status.Description != "Adoption status" ||
+		!reflect.DeepEqual(status.Enum, []interface{}{"available", "sold"}) || status.Default != "sold" {
+		t.Errorf("Status = %+v, want its format, description, enum and default", status)
+	}
+
+	owner, err := resolver.ResolveSchema(&Schema{Ref: "#/components/schemas/Owner"})
+	if err != nil {
+		t.Fatalf("ResolveSchema(Owner) error = %v", err)
+	}
+	prop := owner.Properties["status"]
+	if prop.Format != "slug" || prop.Description != "Adoption status" ||
+		!reflect.DeepEqual(prop.Enum, []interface{}{"available", "sold"}) || prop.Default != "sold" {
+		t.Errorf("Owner.status = %+v, want the values of Status", prop)
+	}
+	if items := owner.Properties["pets"].Items; items == nil || items.Properties["name"].Type != "string" {
+		t.Errorf("Owner.pets items = %+v, want resolved Pet items", items)
+	}
+}
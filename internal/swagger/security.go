@@ -0,0 +1,95 @@
+package swagger
+
+import "sort"
+
+// NamedOAuthFlow is an OAuth2 flow with its OpenAPI 3.x flow name
+type NamedOAuthFlow struct {
+	// Name is "implicit", "password", "clientCredentials" or
+	// "authorizationCode"
+	Name string
+	OAuthFlow
+}
+
+// swagger2Flows maps Swagger 2.0 flow names to their OpenAPI 3.x names
+var swagger2Flows = map[string]string{
+	"implicit":    "implicit",
+	"password":    "password",
+	"application": "clientCredentials",
+	"accessCode":  "authorizationCode",
+}
+
+// SecuritySchemes returns the security schemes of either spec version by name
+func (s *Spec) SecuritySchemes() map[string]SecurityScheme {
+	schemes := make(map[string]SecurityScheme)
+	for name, scheme := range s.SecurityDefinitions {
+		schemes[name] = scheme
+	}
+	if s.Components != nil {
+		for name, scheme := range s.Components.SecuritySchemes {
+			schemes[name] = scheme
+		}
+	}
+	return schemes
+}
+
+// SecuritySchemeNames returns the names of the security schemes in sorted
+// order
+func (s *Spec) SecuritySchemeNames() []string {
+	schemes := s.SecuritySchemes()
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SecurityFor returns the requirements that apply to an operation: its own,
+// or the spec-wide default when it declares none. Any one of the returned
+// requirements grants access.
+func (s *Spec) SecurityFor(op Operation) []SecurityRequirement {
+	if op.Security != nil {
+		return op.Security
+	}
+	return s.Security
+}
+
+// SchemeNames returns the schemes of a requirement in sorted order
+func (r SecurityRequirement) SchemeNames() []string {
+	return orderedKeys(r, nil)
+}
+
+// OAuthFlows returns the OAuth2 flows of a scheme in a fixed order, with
+// Swagger 2.0 flows translated to their OpenAPI 3.x names
+func (s SecurityScheme) OAuthFlows() []NamedOAuthFlow {
+	if s.Flows == nil {
+		if s.Flow == "" {
+			return nil
+		}
+		name, ok := swagger2Flows[s.Flow]
+		if !ok {
+			name = s.Flow
+		}
+		return []NamedOAuthFlow{{Name: name, OAuthFlow: OAuthFlow{
+			AuthorizationURL: s.AuthorizationURL,
+			TokenURL:         s.TokenURL,
+			Scopes:           s.Scopes,
+		}}}
+	}
+
+	var flows []NamedOAuthFlow
+	for _, flow := range []struct {
+		name string
+		flow *OAuthFlow
+	}{
+		{"authorizationCode", s.Flows.AuthorizationCode},
+		{"clientCredentials", s.Flows.ClientCredentials},
+		{"implicit", s.Flows.Implicit},
+		{"password", s.Flows.Password},
+	} {
+		if flow.flow != nil {
+			flows = append(flows, NamedOAuthFlow{Name: flow.name, OAuthFlow: *flow.flow})
+		}
+	}
+	return flows
+}
@@ -0,0 +1,94 @@
+package swagger
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSpec_SecurityFor(t *testing.T) {
+	var spec Spec
+	err := json.Unmarshal([]byte(`{
+		"swagger": "2.0",
+		"securityDefinitions": {
+			"api_key": {"type": "apiKey", "name": "X-API-Key", "in": "header"},
+			"petstore_auth": {
+				"type": "oauth2",
+				"flow": "accessCode",
+				"authorizationUrl": "https://auth.example.com/authorize",
+				"tokenUrl": "https://auth.example.com/token",
+				"scopes": {"read:pets": "Read pets"}
+			}
+		},
+		"security": [{"api_key": []}],
+		"paths": {
+			"/pets": {
+				"get": {"responses": {}},
+				"post": {"security": [{"petstore_auth": ["read:pets"]}], "responses": {}},
+				"delete": {"security": [], "responses": {}}
+			}
+		}
+	}`), &spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		method string
+		want   []SecurityRequirement
+	}{
+		{method: "get", want: []SecurityRequirement{{"api_key": {}}}},
+		{method: "post", want: []SecurityRequirement{{"petstore_auth": {"read:pets"}}}},
+		{method: "delete", want: []SecurityRequirement{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			got := spec.SecurityFor(spec.Paths["/pets"][tt.method])
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SecurityFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if names := spec.SecuritySchemeNames(); !reflect.DeepEqual(names, []string{"api_key", "petstore_auth"}) {
+		t.Errorf("SecuritySchemeNames() = %v", names)
+	}
+}
+
+func TestSecurityScheme_OAuthFlows(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme SecurityScheme
+		want   []string
+	}{
+		{
+			name:   "swagger 2.0 flow is renamed",
+			scheme: SecurityScheme{Type: "oauth2", Flow: "application", TokenURL: "https://auth/token"},
+			want:   []string{"clientCredentials"},
+		},
+		{
+			name: "openapi 3 flows in fixed order",
+			scheme: SecurityScheme{Type: "oauth2", Flows: &OAuthFlows{
+				Password:          &OAuthFlow{TokenURL: "https://auth/token"},
+				AuthorizationCode: &OAuthFlow{AuthorizationURL: "https://auth/authorize"},
+			}},
+			want: []string{"authorizationCode", "password"},
+		},
+		{
+			name:   "not oauth",
+			scheme: SecurityScheme{Type: "apiKey"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, flow := range tt.scheme.OAuthFlows() {
+				got = append(got, flow.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OAuthFlows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
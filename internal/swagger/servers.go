@@ -0,0 +1,88 @@
+package swagger
+
+import (
+	"sort"
+	"strings"
+)
+
+// ServerURL returns the base URL of the API without a trailing slash: the
+// first server with its variables set to their defaults (OpenAPI 3.x), or
+// scheme, host and base path (Swagger 2.0). It returns "" when the spec
+// documents neither; a relative server URL is returned as is.
+func (s *Spec) ServerURL() string {
+	if len(s.Servers) > 0 {
+		return s.Servers[0].ResolvedURL()
+	}
+
+	if s.Host == "" {
+		return strings.TrimSuffix(s.BasePath, "/")
+	}
+	scheme := "https"
+	if len(s.Schemes) > 0 {
+		scheme = s.Schemes[0]
+		for _, candidate := range s.Schemes {
+			if candidate == "https" {
+				scheme = candidate
+			}
+		}
+	}
+	return scheme + "://" + s.Host + strings.TrimSuffix(s.BasePath, "/")
+}
+
+// InheritMediaTypes gives Swagger 2.0 operations that declare no consumes or
+// produces the spec-wide defaults, so nothing downstream has to fall back
+// to them
+func InheritMediaTypes(spec *Spec) {
+	if len(spec.Consumes) == 0 && len(spec.Produces) == 0 {
+		return
+	}
+	for _, item := range spec.Paths {
+		for _, method := range item.Methods() {
+			op := item[method]
+			if op.Consumes == nil {
+				op.Consumes = spec.Consumes
+			}
+			if op.Produces == nil {
+				op.Produces = spec.Produces
+			}
+			item[method] = op
+		}
+	}
+}
+
+// ResolvedURL returns the server URL with its variables set to their
+// defaults, without a trailing slash
+func (s Server) ResolvedURL() string {
+	url := s.URL
+	for name, variable := range s.Variables {
+		url = strings.ReplaceAll(url, "{"+name+"}", variable.Default)
+	}
+	return strings.TrimSuffix(url, "/")
+}
+
+// VariableNames returns the names of the server variables in sorted order
+func (s Server) VariableNames() []string {
+	names := make([]string, 0, len(s.Variables))
+	for name := range s.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ServersFor returns the servers an operation is called on: its own or its
+// path's, falling back to the spec's. A Swagger 2.0 spec has one server
+// built from its scheme, host and base path. It returns nil when the spec
+// documents no server.
+func (s *Spec) ServersFor(op Operation) []Server {
+	if len(op.Servers) > 0 {
+		return op.Servers
+	}
+	if len(s.Servers) > 0 {
+		return s.Servers
+	}
+	if url := s.ServerURL(); url != "" {
+		return []Server{{URL: url}}
+	}
+	return nil
+}
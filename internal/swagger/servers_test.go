@@ -0,0 +1,124 @@
+package swagger
+
+import "testing"
+
+func TestSpec_ServerURL(t *testing.T) {
+	tests := []struct {
+		name string
+		spec Spec
+		want string
+	}{
+		{name: "none", spec: Spec{}, want: ""},
+		{
+			name: "openapi 3 server",
+			spec: Spec{Servers: []Server{{URL: "https://api.example.com/v1/"}, {URL: "https://staging.example.com"}}},
+			want: "https://api.example.com/v1",
+		},
+		{
+			name: "server variables",
+			spec: Spec{Servers: []Server{{
+				URL:       "https://{region}.example.com/{version}",
+				Variables: map[string]ServerVariable{"region": {Default: "eu"}, "version": {Default: "v2"}},
+			}}},
+			want: "https://eu.example.com/v2",
+		},
+		{
+			name: "swagger 2 prefers https",
+			spec: Spec{Host: "petstore.io", BasePath: "/api", Schemes: []string{"http", "https"}},
+			want: "https://petstore.io/api",
+		},
+		{
+			name: "swagger 2 scheme",
+			spec: Spec{Host: "localhost:8080", Schemes: []string{"http"}},
+			want: "http://localhost:8080",
+		},
+		{name: "base path only", spec: Spec{BasePath: "/api/"}, want: "/api"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.spec.ServerURL(); got != tt.want {
+				t.Errorf("ServerURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser_InheritsMediaTypes(t *testing.T) {
+	spec, err := NewParser().ParseBytes([]byte(`{
+		"swagger": "2.0",
+		"info": {"title": "Pets", "version": "1.0.0"},
+		"host": "petstore.io",
+		"basePath": "/v2",
+		"consumes": ["application/json"],
+		"produces": ["application/json", "application/xml"],
+		"paths": {"/pets": {
+			"get": {"responses": {"200": {"description": "OK"}}},
+			"post": {"consumes": ["application/x-www-form-urlencoded"], "responses": {"201": {"description": "Created"}}}
+		}}
+	}`), "", "")
+	if err != nil {
+		t.Fatalf("ParseBytes() error = %v", err)
+	}
+
+	get, post := spec.Paths["/pets"]["get"], spec.Paths["/pets"]["post"]
+	if len(get.Consumes) != 1 || get.Consumes[0] != "application/json" || len(get.Produces) != 2 {
+		t.Errorf("get = consumes %v produces %v, want the spec defaults", get.Consumes, get.Produces)
+	}
+	if len(post.Consumes) != 1 || post.Consumes[0] != "application/x-www-form-urlencoded" || len(post.Produces) != 2 {
+		t.Errorf("post = consumes %v produces %v, want its own consumes and the default produces", post.Consumes, post.Produces)
+	}
+	if got := spec.ServersFor(get); len(got) != 1 || got[0].URL != "https://petstore.io/v2" {
+		t.Errorf("ServersFor() = %+v, want https://petstore.io/v2", got)
+	}
+}
+
+func TestParser_PathLevelServersAndParameters(t *testing.T) {
+	spec, err := NewParser().ParseBytes([]byte(`{
+		"openapi": "3.0.0",
+		"info": {"title": "Pets", "version": "1.0.0"},
+		"servers": [{"url": "https://{region}.example.com", "variables": {"region": {"default": "eu", "enum": ["eu", "us"]}}}],
+		"paths": {
+			"/pets/{id}": {
+				"summary": "A pet",
+				"servers": [{"url": "https://pets.example.com"}],
+				"parameters": [
+					{"name": "id", "in": "path", "required": true},
+					{"name": "verbose", "in": "query"}
+				],
+				"get": {"parameters": [{"name": "verbose", "in": "query", "description": "Own"}]},
+				"delete": {"servers": [{"url": "https://admin.example.com"}]}
+			},
+			"/stores": {"get": {}}
+		}
+	}`), "", "")
+	if err != nil {
+		t.Fatalf("ParseBytes() error = %v", err)
+	}
+
+	get, del := spec.Paths["/pets/{id}"]["get"], spec.Paths["/pets/{id}"]["delete"]
+	if len(get.Parameters) != 2 || get.Parameters[0].Name != "id" || get.Parameters[1].Description != "Own" {
+		t.Errorf("get parameters = %+v, want the path's id and its own verbose", get.Parameters)
+	}
+	if len(del.Parameters) != 2 {
+		t.Errorf("delete parameters = %+v, want the path's", del.Parameters)
+	}
+
+	tests := []struct {
+		name string
+		op   Operation
+		want string
+	}{
+		{name: "path servers", op: get, want: "https://pets.example.com"},
+		{name: "operation servers", op: del, want: "https://admin.example.com"},
+		{name: "spec servers", op: spec.Paths["/stores"]["get"], want: "https://eu.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			servers := spec.ServersFor(tt.op)
+			if len(servers) != 1 || servers[0].ResolvedURL() != tt.want {
+				t.Errorf("ServersFor() = %+v, want %s", servers, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package swagger
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+)
+
+// MaxTitleLength is the longest page title Confluence accepts, in characters
+const MaxTitleLength = 255
+
+// TitleData is the data available to page title templates
+type TitleData struct {
+	APITitle   string
+	APIVersion string
+	// Method is upper case, e.g. GET
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	// Tag is the first tag of the operation
+	Tag string
+	// Default is the title generated from the summary, operation ID or path
+	Default string
+}
+
+// parseTitleTemplate compiles a page title template, checking that it can
+// be executed
+func parseTitleTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("title").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid title template: %w", err)
+	}
+	if err := tmpl.Execute(&strings.Builder{}, TitleData{}); err != nil {
+		return nil, fmt.Errorf("invalid title template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// endpointKey identifies an operation among the paths and webhooks
+type endpointKey struct {
+	path    string
+	method  string
+	webhook bool
+}
+
+func keyOf(endpoint EndpointInfo) endpointKey {
+	return endpointKey{path: endpoint.Path, method: endpoint.Method, webhook: endpoint.Webhook}
+}
+
+// endpointTitles returns the page title of every selected endpoint: the
+// title template's output with the configured prefix and suffix, shortened
+// to MaxTitleLength. Endpoints that would share a title are told apart by
+// their method and path. Every page lookup and creation uses these titles,
+// so they agree. The warnings describe the titles that were changed.
+func (p *Parser) endpointTitles(spec *Spec) (map[endpointKey]string, []string) {
+	var endpoints []EndpointInfo
+	bases := make(map[endpointKey]string)
+	titles := make(map[endpointKey]string)
+	counts := make(map[string]int)
+	for endpoint := range p.selectedEndpoints(spec) {
+		key := keyOf(endpoint)
+		bases[key] = p.templateTitle(spec, endpoint.Path, endpoint.Method, endpoint.Operation)
+		titles[key] = p.fitTitle(bases[key], "")
+		counts[titles[key]]++
+		endpoints = append(endpoints, endpoint)
+	}
+
+	var warnings []string
+	for _, endpoint := range endpoints {
+		key := keyOf(endpoint)
+		operation := strings.ToUpper(endpoint.Method) + " " + endpoint.Path
+		title := titles[key]
+		if counts[title] > 1 {
+			titles[key] = p.fitTitle(bases[key], " ("+operation+")")
+			warnings = append(warnings, fmt.Sprintf("%s: title %q is shared with other endpoints, published as %q",
+				operation, title, titles[key]))
+		}
+		if full := p.cfg.TitlePrefix + bases[key] + p.cfg.TitleSuffix; counts[title] == 1 && title != full {
+			warnings = append(warnings, fmt.Sprintf("%s: title is longer than %d characters, published as %q",
+				operation, MaxTitleLength, title))
+		}
+	}
+	return titles, warnings
+}
+
+// TitleWarnings describes the endpoint page titles of the spec that were
+// shortened or disambiguated
+func (p *Parser) TitleWarnings(spec *Spec) []string {
+	_, warnings := p.endpointTitles(spec)
+	return warnings
+}
+
+// fitTitle adds the configured prefix and suffix to a title, with tail
+// before the suffix, shortening the title so the result fits in
+// MaxTitleLength characters
+func (p *Parser) fitTitle(title, tail string) string {
+	head, tail := p.cfg.TitlePrefix, tail+p.cfg.TitleSuffix
+	room := MaxTitleLength - utf8.RuneCountInString(head) - utf8.RuneCountInString(tail)
+	if room < 1 {
+		return truncateTitle(head+title+tail, MaxTitleLength)
+	}
+	return head + truncateTitle(title, room) + tail
+}
+
+// truncateTitle shortens title to at most n characters, ending with an
+// ellipsis and preferring to cut at a word boundary
+func truncateTitle(title string, n int) string {
+	runes := []rune(title)
+	if len(runes) <= n {
+		return title
+	}
+	if n < 1 {
+		return ""
+	}
+
+	cut := runes[:n-1]
+	if i := lastSpace(cut); i > len(cut)/2 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(string(cut), " ") + "…"
+}
+
+// lastSpace returns the index of the last space in runes, or -1
+func lastSpace(runes []rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == ' ' {
+			return i
+		}
+	}
+	return -1
+}
+
+// templateTitle renders the title template for an endpoint, falling back to
+// the generated title
+func (p *Parser) templateTitle(spec *Spec, path, method string, operation Operation) string {
+	title := generatePageTitle(path, method, operation)
+	if p.titleTemplate == nil {
+		return title
+	}
+
+	data := TitleData{
+		APITitle:    spec.Info.Title,
+		APIVersion:  spec.Info.Version,
+		Method:      strings.ToUpper(method),
+		Path:        path,
+		OperationID: operation.OperationID,
+		Summary:     operation.Summary,
+		Default:     title,
+	}
+	if len(operation.Tags) > 0 {
+		data.Tag = operation.Tags[0]
+	}
+
+	var sb strings.Builder
+	if err := p.titleTemplate.Execute(&sb, data); err != nil {
+		return title
+	}
+	// Operations without the fields a template uses keep the default title
+	if rendered := strings.TrimSpace(sb.String()); rendered != "" {
+		return rendered
+	}
+	return title
+}
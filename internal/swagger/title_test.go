@@ -0,0 +1,169 @@
+package swagger
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+func TestParser_TitleTemplate(t *testing.T) {
+	spec := &Spec{
+		Info: Info{Title: "Pet Store", Version: "1.0"},
+		Paths: map[string]PathItem{
+			"/pets/{id}": {"get": Operation{OperationID: "getPet", Summary: "Get a pet", Tags: []string{"pets"}}},
+			"/health":    {"get": Operation{}},
+		},
+	}
+
+	tests := []struct {
+		template string
+		want     map[string]string
+	}{
+		{"", map[string]string{"/pets/{id}": "Get a pet", "/health": "GET Health"}},
+		{"{{.APITitle}} – {{.Method}} {{.Path}}", map[string]string{
+			"/pets/{id}": "Pet Store – GET /pets/{id}",
+			"/health":    "Pet Store – GET /health",
+		}},
+		{"{{.OperationID}}", map[string]string{"/pets/{id}": "getPet", "/health": "GET Health"}},
+		{"{{with .Tag}}{{.}}: {{end}}{{.Default}}", map[string]string{"/pets/{id}": "pets: Get a pet", "/health": "GET Health"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			parser, err := NewParserWithConfig(config.SpecConfig{TitleTemplate: tt.template})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for endpoint := range parser.Endpoints(spec) {
+				if want := tt.want[endpoint.Path]; endpoint.Title != want {
+					t.Errorf("title of %s = %q, want %q", endpoint.Path, endpoint.Title, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParser_InvalidTitleTemplate(t *testing.T) {
+	for _, tmpl := range []string{"{{.Method", "{{.Unknown}}"} {
+		if _, err := NewParserWithConfig(config.SpecConfig{TitleTemplate: tmpl}); err == nil {
+			t.Errorf("expected an error for %q", tmpl)
+		}
+	}
+}
+
+func TestParser_TitlePrefixSuffix(t *testing.T) {
+	spec := &Spec{Paths: map[string]PathItem{"/pets": {"get": Operation{Summary: "List pets"}}}}
+
+	tests := []struct {
+		name string
+		cfg  config.SpecConfig
+		want string
+	}{
+		{name: "prefix", cfg: config.SpecConfig{TitlePrefix: "[Payments] "}, want: "[Payments] List pets"},
+		{name: "suffix", cfg: config.SpecConfig{TitleSuffix: " (Payments)"}, want: "List pets (Payments)"},
+		{
+			name: "with template",
+			cfg:  config.SpecConfig{TitlePrefix: "Payments: ", TitleSuffix: "!", TitleTemplate: "{{.Default}} ({{.Method}})"},
+			want: "Payments: List pets (GET)!",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, err := NewParserWithConfig(tt.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for endpoint := range parser.Endpoints(spec) {
+				if endpoint.Title != tt.want {
+					t.Errorf("title = %q, want %q", endpoint.Title, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParser_DuplicateTitles(t *testing.T) {
+	spec := &Spec{Paths: map[string]PathItem{
+		"/users/{id}":       {"get": Operation{Summary: "Get User"}},
+		"/admin/users/{id}": {"get": Operation{Summary: "Get User"}},
+		"/health":           {"get": Operation{Summary: "Health"}},
+	}}
+
+	parser, err := NewParserWithConfig(config.SpecConfig{TitlePrefix: "[Identity] "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"/users/{id}":       "[Identity] Get User (GET /users/{id})",
+		"/admin/users/{id}": "[Identity] Get User (GET /admin/users/{id})",
+		"/health":           "[Identity] Health",
+	}
+	for endpoint := range parser.Endpoints(spec) {
+		if endpoint.Title != want[endpoint.Path] {
+			t.Errorf("title of %s = %q, want %q", endpoint.Path, endpoint.Title, want[endpoint.Path])
+		}
+	}
+
+	if warnings := parser.TitleWarnings(spec); len(warnings) != 2 || !strings.Contains(warnings[0], "GET /admin/users/{id}") {
+		t.Errorf("TitleWarnings() = %v, want one per duplicated endpoint", warnings)
+	}
+}
+
+func TestParser_LongTitles(t *testing.T) {
+	long := strings.Repeat("word ", 80)
+	spec := &Spec{Paths: map[string]PathItem{
+		"/a": {"get": Operation{Summary: long + "a"}},
+		"/b": {"get": Operation{Summary: long + "b"}},
+		"/c": {"get": Operation{Summary: strings.Repeat("x", 300)}},
+	}}
+
+	parser, err := NewParserWithConfig(config.SpecConfig{TitleSuffix: " (Payments)"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	titles := make(map[string]string)
+	for endpoint := range parser.Endpoints(spec) {
+		titles[endpoint.Path] = endpoint.Title
+		if n := utf8.RuneCountInString(endpoint.Title); n > MaxTitleLength {
+			t.Errorf("title of %s has %d characters, want at most %d", endpoint.Path, n, MaxTitleLength)
+		}
+		if !strings.HasSuffix(endpoint.Title, " (Payments)") {
+			t.Errorf("title of %s = %q, want the suffix kept", endpoint.Path, endpoint.Title)
+		}
+	}
+
+	// Titles that only differ past the limit are told apart
+	if titles["/a"] == titles["/b"] || !strings.Contains(titles["/a"], "… (GET /a)") {
+		t.Errorf("titles = %q and %q, want them disambiguated", titles["/a"], titles["/b"])
+	}
+	if !strings.HasPrefix(titles["/c"], strings.Repeat("x", 200)) || !strings.Contains(titles["/c"], "…") {
+		t.Errorf("title of /c = %q, want it cut mid-word", titles["/c"])
+	}
+
+	warnings := parser.TitleWarnings(spec)
+	if len(warnings) != 3 || !strings.Contains(warnings[2], "longer than 255 characters") {
+		t.Errorf("TitleWarnings() = %v", warnings)
+	}
+}
+
+func TestTruncateTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		n     int
+		want  string
+	}{
+		{"Get a pet", 20, "Get a pet"},
+		{"Get a pet by its identifier", 20, "Get a pet by its…"},
+		{"Getapetbyitsidentifier", 10, "Getapetby…"},
+		{"Ünïcödé títle", 6, "Ünïcö…"},
+	}
+
+	for _, tt := range tests {
+		if got := truncateTitle(tt.title, tt.n); got != tt.want {
+			t.Errorf("truncateTitle(%q, %d) = %q, want %q", tt.title, tt.n, got, tt.want)
+		}
+	}
+}
@@ -9,6 +9,49 @@ type Spec struct {
 	Components  *Components           `json:"components,omitempty"`
 	Definitions map[string]Definition `json:"definitions,omitempty"`
 	Tags        []Tag                 `json:"tags,omitempty"`
+	// Parameters and Responses are the reusable parameters and responses of
+	// Swagger 2.0, referenced as #/parameters/... and #/responses/...
+	Parameters map[string]Parameter `json:"parameters,omitempty"`
+	Responses  map[string]Response  `json:"responses,omitempty"`
+	// SecurityDefinitions are the Swagger 2.0 security schemes
+	SecurityDefinitions map[string]SecurityScheme `json:"securityDefinitions,omitempty"`
+	// Security is the default requirement of operations without their own
+	Security []SecurityRequirement `json:"security,omitempty"`
+	// Servers are the API base URLs (OpenAPI 3.x)
+	Servers []Server `json:"servers,omitempty"`
+	// Host, BasePath and Schemes make up the API base URL (Swagger 2.0)
+	Host     string   `json:"host,omitempty"`
+	BasePath string   `json:"basePath,omitempty"`
+	Schemes  []string `json:"schemes,omitempty"`
+	// Consumes and Produces are the default media types of operations
+	// without their own (Swagger 2.0)
+	Consumes []string `json:"consumes,omitempty"`
+	Produces []string `json:"produces,omitempty"`
+	// PathOrder lists the paths in document order
+	PathOrder []string `json:"-"`
+	// Webhooks are the requests the API sends to subscribers, by name
+	// (OpenAPI 3.1), and WebhookOrder their document order
+	Webhooks     map[string]PathItem `json:"webhooks,omitempty"`
+	WebhookOrder []string            `json:"-"`
+	// Document is the source document as read, before format conversion
+	// and overlays, and Format its format (json, yaml or apib)
+	Document []byte `json:"-"`
+	Format   string `json:"-"`
+}
+
+// Server is a base URL of the API (OpenAPI 3.x). The URL may contain
+// {variables} described in Variables.
+type Server struct {
+	URL         string                    `json:"url"`
+	Description string                    `json:"description,omitempty"`
+	Variables   map[string]ServerVariable `json:"variables,omitempty"`
+}
+
+// ServerVariable is a substitutable part of a server URL
+type ServerVariable struct {
+	Default     string   `json:"default"`
+	Enum        []string `json:"enum,omitempty"`
+	Description string   `json:"description,omitempty"`
 }
 
 // Info contains API metadata
@@ -32,21 +75,49 @@ type Operation struct {
 	Consumes    []string     `json:"consumes,omitempty"`
 	Produces    []string     `json:"produces,omitempty"`
 	Responses   Responses    `json:"responses"`
+	Deprecated  bool         `json:"deprecated,omitempty"`
+	// Security overrides the spec-wide requirement; an empty list means the
+	// operation needs no authentication
+	Security []SecurityRequirement `json:"security,omitempty"`
+	// Servers override the spec-wide servers (OpenAPI 3.x), including those
+	// declared for the operation's path
+	Servers []Server `json:"servers,omitempty"`
+	// ResponseOrder lists the response codes in document order
+	ResponseOrder []string `json:"-"`
+	// Extensions holds the operation's vendor extensions
+	Extensions Extensions `json:"-"`
 }
 
 // Parameter describes a single operation parameter
 type Parameter struct {
-	Name        string  `json:"name"`
-	In          string  `json:"in"`
-	Description string  `json:"description"`
-	Required    bool    `json:"required"`
-	Type        string  `json:"type,omitempty"`
-	Format      string  `json:"format,omitempty"`
-	Schema      *Schema `json:"schema,omitempty"`
+	// Ref points to a reusable parameter; the parser inlines it
+	Ref         string      `json:"$ref,omitempty"`
+	Name        string      `json:"name"`
+	In          string      `json:"in"`
+	Description string      `json:"description"`
+	Required    bool        `json:"required"`
+	Type        string      `json:"type,omitempty"`
+	Format      string      `json:"format,omitempty"`
+	Schema      *Schema     `json:"schema,omitempty"`
+	Example     interface{} `json:"example,omitempty"`
+	// Enum lists the allowed values of a Swagger 2.0 parameter
+	Enum       []interface{} `json:"enum,omitempty"`
+	Default    interface{}   `json:"default,omitempty"`
+	Deprecated bool          `json:"deprecated,omitempty"`
+	// Extensions holds the parameter's vendor extensions
+	Extensions Extensions `json:"-"`
+}
+
+// IsFile reports whether the parameter is a file upload (Swagger 2.0
+// formData)
+func (p Parameter) IsFile() bool {
+	return p.Type == "file" || p.Format == "binary"
 }
 
 // RequestBody describes a single request body
 type RequestBody struct {
+	// Ref points to a reusable request body; the parser inlines it
+	Ref         string               `json:"$ref,omitempty"`
 	Description string               `json:"description"`
 	Required    bool                 `json:"required"`
 	Content     map[string]MediaType `json:"content"`
@@ -54,7 +125,24 @@ type RequestBody struct {
 
 // MediaType describes media type with schema
 type MediaType struct {
-	Schema *Schema `json:"schema"`
+	Schema  *Schema     `json:"schema"`
+	Example interface{} `json:"example,omitempty"`
+	// Examples are named examples (OpenAPI 3.x)
+	Examples map[string]Example `json:"examples,omitempty"`
+	// ExampleOrder lists the named examples in document order
+	ExampleOrder []string `json:"-"`
+}
+
+// Example is a named example of a media type (OpenAPI 3.x)
+type Example struct {
+	// Ref points to a reusable example in components.examples; the parser
+	// inlines it
+	Ref         string      `json:"$ref,omitempty"`
+	Summary     string      `json:"summary,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Value       interface{} `json:"value,omitempty"`
+	// ExternalValue is the URL of an example that can't be embedded
+	ExternalValue string `json:"externalValue,omitempty"`
 }
 
 // Responses is a map of response codes to response objects
@@ -62,19 +150,71 @@ type Responses map[string]Response
 
 // Response describes a single response
 type Response struct {
-	Description string               `json:"description"`
-	Content     map[string]MediaType `json:"content,omitempty"`
-	Schema      *Schema              `json:"schema,omitempty"` // Swagger 2.0
+	// Ref points to a reusable response; the parser inlines it
+	Ref         string                 `json:"$ref,omitempty"`
+	Description string                 `json:"description"`
+	Content     map[string]MediaType   `json:"content,omitempty"`
+	Schema      *Schema                `json:"schema,omitempty"`   // Swagger 2.0
+	Examples    map[string]interface{} `json:"examples,omitempty"` // Swagger 2.0, by MIME type
+	// Headers are the response headers, such as pagination or rate-limit
+	// headers, by name
+	Headers map[string]Header `json:"headers,omitempty"`
+}
+
+// Header describes a response header. OpenAPI 3.x types it with a schema,
+// Swagger 2.0 inline.
+type Header struct {
+	// Ref points to a reusable header in components.headers (OpenAPI 3.x)
+	Ref         string      `json:"$ref,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Deprecated  bool        `json:"deprecated,omitempty"`
+	Schema      *Schema     `json:"schema,omitempty"`
+	Type        string      `json:"type,omitempty"`
+	Format      string      `json:"format,omitempty"`
+	Example     interface{} `json:"example,omitempty"`
 }
 
 // Schema describes a data schema
 type Schema struct {
-	Type       string              `json:"type,omitempty"`
-	Format     string              `json:"format,omitempty"`
-	Ref        string              `json:"$ref,omitempty"`
-	Properties map[string]Property `json:"properties,omitempty"`
-	Required   []string            `json:"required,omitempty"`
-	Items      *Schema             `json:"items,omitempty"`
+	Type        string              `json:"type,omitempty"`
+	Format      string              `json:"format,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Ref         string              `json:"$ref,omitempty"`
+	Properties  map[string]Property `json:"properties,omitempty"`
+	Required    []string            `json:"required,omitempty"`
+	Items       *Schema             `json:"items,omitempty"`
+	// Title labels the schema; resolved variants default to their ref name
+	Title string `json:"title,omitempty"`
+	// AllOf members are merged into the schema when it is resolved
+	AllOf []*Schema `json:"allOf,omitempty"`
+	// OneOf and AnyOf are alternative variants of the schema
+	OneOf         []*Schema      `json:"oneOf,omitempty"`
+	AnyOf         []*Schema      `json:"anyOf,omitempty"`
+	Discriminator *Discriminator `json:"discriminator,omitempty"`
+	// Enum and Default describe the values of a scalar schema, such as
+	// the schema of an OpenAPI 3.x parameter
+	Enum    []interface{} `json:"enum,omitempty"`
+	Default interface{}   `json:"default,omitempty"`
+	// Example is a value of the schema given in the spec, shown instead of
+	// a generated one
+	Example interface{} `json:"example,omitempty"`
+	// Nullable allows null besides values of Type, from the OpenAPI 3.0
+	// keyword or a 3.1 type array including "null"
+	Nullable bool `json:"nullable,omitempty"`
+	// PropertyOrder lists the properties in document order
+	PropertyOrder []string `json:"-"`
+	// Recursive marks a $ref back to a schema that encloses it. The
+	// resolver leaves it unresolved, so renderers show a placeholder
+	// instead of recursing forever.
+	Recursive bool `json:"-"`
+}
+
+// Discriminator names the property that tells variants apart
+type Discriminator struct {
+	PropertyName string `json:"propertyName"`
+	// Mapping maps property values to variant $refs (OpenAPI 3.x)
+	Mapping map[string]string `json:"mapping,omitempty"`
 }
 
 // Property describes a schema property
@@ -87,23 +227,116 @@ type Property struct {
 	Example     interface{} `json:"example,omitempty"`
 	MinLength   int         `json:"minLength,omitempty"`
 	MaxLength   int         `json:"maxLength,omitempty"`
-	Minimum     float64     `json:"minimum,omitempty"`
-	Maximum     float64     `json:"maximum,omitempty"`
-	Pattern     string      `json:"pattern,omitempty"`
-	ReadOnly    bool        `json:"readOnly,omitempty"`
+	// Minimum and Maximum bound numbers, nil when unbounded. The exclusive
+	// flags leave the bound itself out of the range; OpenAPI 3.1 spells
+	// them as the bound instead.
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum bool     `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum bool     `json:"exclusiveMaximum,omitempty"`
+	MultipleOf       *float64 `json:"multipleOf,omitempty"`
+	// MinItems, MaxItems and UniqueItems constrain arrays
+	MinItems    int    `json:"minItems,omitempty"`
+	MaxItems    int    `json:"maxItems,omitempty"`
+	UniqueItems bool   `json:"uniqueItems,omitempty"`
+	Pattern     string `json:"pattern,omitempty"`
+	// ReadOnly properties only appear in responses, WriteOnly ones only in
+	// requests
+	ReadOnly  bool `json:"readOnly,omitempty"`
+	WriteOnly bool `json:"writeOnly,omitempty"`
+	Nullable  bool `json:"nullable,omitempty"`
+	// Enum lists the allowed values
+	Enum       []interface{} `json:"enum,omitempty"`
+	Default    interface{}   `json:"default,omitempty"`
+	Deprecated bool          `json:"deprecated,omitempty"`
+	// Extensions holds the property's vendor extensions
+	Extensions Extensions `json:"-"`
+}
+
+// IsFile reports whether the property is a file upload field of a form
+// body
+func (p Property) IsFile() bool {
+	return p.Type == "file" || (p.Type == "string" && p.Format == "binary")
 }
 
 // Components holds reusable objects (OpenAPI 3.x)
 type Components struct {
-	Schemas map[string]Definition `json:"schemas"`
+	Schemas         map[string]Definition     `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+	Headers         map[string]Header         `json:"headers,omitempty"`
+	Parameters      map[string]Parameter      `json:"parameters,omitempty"`
+	RequestBodies   map[string]RequestBody    `json:"requestBodies,omitempty"`
+	Responses       map[string]Response       `json:"responses,omitempty"`
+	Examples        map[string]Example        `json:"examples,omitempty"`
+}
+
+// SecurityRequirement maps scheme names to the scopes required from each;
+// all schemes of one requirement apply together
+type SecurityRequirement map[string][]string
+
+// SecurityScheme describes an authentication mechanism
+type SecurityScheme struct {
+	// Type is "apiKey", "http", "oauth2", "openIdConnect" or, in Swagger
+	// 2.0, "basic"
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	// Name and In locate the API key ("header", "query" or "cookie")
+	Name string `json:"name,omitempty"`
+	In   string `json:"in,omitempty"`
+	// Scheme and BearerFormat describe HTTP authentication (OpenAPI 3.x)
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	// Flows are the OAuth2 flows (OpenAPI 3.x)
+	Flows *OAuthFlows `json:"flows,omitempty"`
+	// Flow, AuthorizationURL, TokenURL and Scopes describe the single OAuth2
+	// flow of a Swagger 2.0 scheme
+	Flow             string            `json:"flow,omitempty"`
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes,omitempty"`
+	OpenIDConnectURL string            `json:"openIdConnectUrl,omitempty"`
+}
+
+// OAuthFlows holds the OAuth2 flows of a scheme (OpenAPI 3.x)
+type OAuthFlows struct {
+	Implicit          *OAuthFlow `json:"implicit,omitempty"`
+	Password          *OAuthFlow `json:"password,omitempty"`
+	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty"`
+}
+
+// OAuthFlow describes one OAuth2 flow
+type OAuthFlow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	RefreshURL       string            `json:"refreshUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"`
 }
 
 // Definition represents a schema definition
 type Definition struct {
-	Type       string              `json:"type"`
-	Properties map[string]Property `json:"properties"`
-	Required   []string            `json:"required"`
-	Ref        string              `json:"$ref,omitempty"`
+	Type        string              `json:"type"`
+	Format      string              `json:"format,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Properties  map[string]Property `json:"properties"`
+	Required    []string            `json:"required"`
+	Ref         string              `json:"$ref,omitempty"`
+	Title       string              `json:"title,omitempty"`
+	// Items is the item schema of an array definition
+	Items *Schema `json:"items,omitempty"`
+	// Enum and Default describe the values of a scalar definition
+	Enum    []interface{} `json:"enum,omitempty"`
+	Default interface{}   `json:"default,omitempty"`
+	// AllOf, OneOf, AnyOf and Discriminator compose the definition as in
+	// Schema
+	AllOf         []*Schema      `json:"allOf,omitempty"`
+	OneOf         []*Schema      `json:"oneOf,omitempty"`
+	AnyOf         []*Schema      `json:"anyOf,omitempty"`
+	Discriminator *Discriminator `json:"discriminator,omitempty"`
+	Nullable      bool           `json:"nullable,omitempty"`
+	Example       interface{}    `json:"example,omitempty"`
+	// PropertyOrder lists the properties in document order
+	PropertyOrder []string `json:"-"`
 }
 
 // Tag describes an API tag
@@ -118,4 +351,7 @@ type EndpointInfo struct {
 	Method    string
 	Operation Operation
 	Title     string
+	// Webhook marks a request the API sends rather than serves; Path is
+	// then the webhook name
+	Webhook bool
 }
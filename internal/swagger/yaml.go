@@ -0,0 +1,116 @@
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlToJSON converts a YAML document to JSON, keeping mapping keys in
+// document order so the result decodes exactly like a JSON spec would
+func yamlToJSON(data []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("failed to parse yaml: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSONNode(&buf, &node, "", false); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// stringKeys are the spec fields typed as strings. Unquoted YAML such as
+// "version: 1.0" is a number, so their values keep the text as written.
+var stringKeys = map[string]bool{
+	"swagger": true, "openapi": true, "version": true, "title": true,
+	"summary": true, "description": true, "operationId": true, "name": true,
+	"pattern": true, "format": true, "url": true, "basePath": true, "host": true,
+}
+
+// isDataKey reports whether a key holds free-form example data, whose
+// values are kept as typed
+func isDataKey(key string) bool {
+	switch key {
+	case "example", "examples", "default", "enum", "const", "value":
+		return true
+	}
+	return strings.HasPrefix(key, "x-")
+}
+
+// writeJSONNode writes a single YAML node as JSON. key is the mapping key
+// the node is the value of, and data is set within example data.
+func writeJSONNode(buf *bytes.Buffer, node *yaml.Node, key string, data bool) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeJSONNode(buf, node.Content[0], "", data)
+
+	case yaml.AliasNode:
+		return writeJSONNode(buf, node.Alias, key, data)
+
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			name := node.Content[i].Value
+			encoded, err := json.Marshal(name)
+			if err != nil {
+				return fmt.Errorf("failed to encode key: %w", err)
+			}
+			buf.Write(encoded)
+			buf.WriteByte(':')
+			// The default response is a response, not a default value
+			dataKey := isDataKey(name) && key != "responses"
+			if err := writeJSONNode(buf, node.Content[i+1], name, data || dataKey); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONNode(buf, item, "", data); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+
+	case yaml.ScalarNode:
+		if stringKeys[key] && !data && node.ShortTag() != "!!null" {
+			encoded, err := json.Marshal(node.Value)
+			if err != nil {
+				return fmt.Errorf("failed to encode value at line %d: %w", node.Line, err)
+			}
+			buf.Write(encoded)
+			return nil
+		}
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return fmt.Errorf("failed to decode value at line %d: %w", node.Line, err)
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode value at line %d: %w", node.Line, err)
+		}
+		buf.Write(encoded)
+		return nil
+	}
+
+	return fmt.Errorf("unsupported yaml node at line %d", node.Line)
+}
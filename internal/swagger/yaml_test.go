@@ -0,0 +1,44 @@
+package swagger
+
+import "testing"
+
+func TestYAMLToJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{
+			name: "string fields keep their text",
+			yaml: "swagger: 2.0\ninfo:\n  title: 2024\n  version: 1.10\n",
+			want: `{"swagger":"2.0","info":{"title":"2024","version":"1.10"}}`,
+		},
+		{
+			name: "other scalars stay typed",
+			yaml: "minimum: 1.5\nmaxItems: 10\nnullable: true\ndescription: ~\n",
+			want: `{"minimum":1.5,"maxItems":10,"nullable":true,"description":null}`,
+		},
+		{
+			name: "example data stays typed",
+			yaml: "example:\n  version: 1.0\n  name: 7\nenum: [1, 2]\nx-meta:\n  title: 3\n",
+			want: `{"example":{"version":1,"name":7},"enum":[1,2],"x-meta":{"title":3}}`,
+		},
+		{
+			name: "default response is not example data",
+			yaml: "responses:\n  default:\n    description: 500\n",
+			want: `{"responses":{"default":{"description":"500"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := yamlToJSON([]byte(tt.yaml))
+			if err != nil {
+				t.Fatalf("yamlToJSON() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("yamlToJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
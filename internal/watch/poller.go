@@ -0,0 +1,103 @@
+package watch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// Poller detects changes to a spec source between polls. URLs are fetched
+// with conditional requests, so servers that honor ETag or Last-Modified
+// answer unchanged polls without sending the spec again.
+type Poller struct {
+	source     string
+	httpClient *http.Client
+	// etag and lastModified are the validators of the last response
+	etag         string
+	lastModified string
+	// hash is the content hash of the last version seen
+	hash string
+}
+
+// NewPoller creates a Poller for a spec source: an http(s) URL or a local
+// file path
+func NewPoller(source string, httpClient *http.Client) *Poller {
+	return &Poller{source: source, httpClient: httpClient}
+}
+
+// Source returns the polled spec source
+func (p *Poller) Source() string {
+	return p.source
+}
+
+// Changed reports whether the content of the source differs from the last
+// poll. The first poll always reports a change.
+func (p *Poller) Changed(ctx context.Context) (bool, error) {
+	var body []byte
+	var err error
+	if swagger.IsURL(p.source) {
+		body, err = p.fetch(ctx)
+	} else {
+		body, err = os.ReadFile(strings.TrimPrefix(p.source, "file://"))
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to poll %s: %w", p.source, err)
+	}
+	if body == nil {
+		return false, nil
+	}
+
+	sum := sha256.Sum256(body)
+	hash := hex.EncodeToString(sum[:])
+	if hash == p.hash {
+		return false, nil
+	}
+	p.hash = hash
+	return true, nil
+}
+
+// fetch fetches the spec, returning nil when the server reports it is
+// unchanged since the last fetch
+func (p *Poller) fetch(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if p.hash != "" {
+		if p.etag != "" {
+			req.Header.Set("If-None-Match", p.etag)
+		}
+		if p.lastModified != "" {
+			req.Header.Set("If-Modified-Since", p.lastModified)
+		}
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return nil, nil
+	case http.StatusOK:
+	default:
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	p.etag = resp.Header.Get("ETag")
+	p.lastModified = resp.Header.Get("Last-Modified")
+	return body, nil
+}
@@ -0,0 +1,76 @@
+package watch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPoller_ChangedURL(t *testing.T) {
+	spec := `{"openapi": "3.0.0"}`
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := `"` + spec + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fetches++
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(spec))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	poller := NewPoller(server.URL, server.Client())
+	for i, tt := range []struct {
+		spec        string
+		wantChanged bool
+		wantFetches int
+	}{
+		{spec: spec, wantChanged: true, wantFetches: 1},
+		{spec: spec, wantChanged: false, wantFetches: 1},
+		{spec: `{"openapi": "3.1.0"}`, wantChanged: true, wantFetches: 2},
+	} {
+		spec = tt.spec
+		changed, err := poller.Changed(ctx)
+		if err != nil {
+			t.Fatalf("poll %d: Changed() error = %v", i, err)
+		}
+		if changed != tt.wantChanged || fetches != tt.wantFetches {
+			t.Errorf("poll %d: changed = %v after %d fetches, want %v after %d", i, changed, fetches, tt.wantChanged, tt.wantFetches)
+		}
+	}
+}
+
+func TestPoller_ChangedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	poller := NewPoller(path, nil)
+	for i, tt := range []struct {
+		content     string
+		wantChanged bool
+	}{
+		{content: "openapi: 3.0.0\n", wantChanged: true},
+		{content: "openapi: 3.0.0\n", wantChanged: false},
+		{content: "openapi: 3.1.0\n", wantChanged: true},
+	} {
+		write(tt.content)
+		changed, err := poller.Changed(ctx)
+		if err != nil {
+			t.Fatalf("poll %d: Changed() error = %v", i, err)
+		}
+		if changed != tt.wantChanged {
+			t.Errorf("poll %d: changed = %v, want %v", i, changed, tt.wantChanged)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Run polls the sources every interval and calls sync with the sources
+// whose content changed, starting with all of them on the first poll. A
+// failed poll or sync is reported and retried on the next poll, so a
+// temporary outage doesn't end the watch. Run returns when ctx is done.
+func Run(ctx context.Context, pollers []*Poller, interval time.Duration, sync func(ctx context.Context, sources []string) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		var changed []*Poller
+		for _, poller := range pollers {
+			ok, err := poller.Changed(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				fmt.Printf("Warning: %v\n", err)
+				continue
+			}
+			if ok {
+				changed = append(changed, poller)
+			}
+		}
+
+		if len(changed) > 0 {
+			sources := make([]string, 0, len(changed))
+			for _, poller := range changed {
+				sources = append(sources, poller.Source())
+			}
+			if err := sync(ctx, sources); err != nil {
+				// Sync again on the next poll even if the spec is unchanged
+				for _, poller := range changed {
+					poller.hash = ""
+				}
+				fmt.Printf("Warning: sync failed: %v\n", err)
+			}
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		fmt.Printf("Next check in %s\n", interval)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
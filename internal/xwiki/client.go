@@ -0,0 +1,136 @@
+package xwiki
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/internal/httpclient"
+)
+
+// xhtmlSyntax is the XWiki syntax identifier for XHTML page content
+const xhtmlSyntax = "xhtml/1.0"
+
+// Client publishes pages to XWiki through its REST API
+type Client struct {
+	cfg        config.XWikiConfig
+	httpClient *http.Client
+}
+
+// page is the XWiki REST page representation
+type page struct {
+	XMLName xml.Name `xml:"http://www.xwiki.org page"`
+	Title   string   `xml:"title"`
+	Parent  string   `xml:"parent,omitempty"`
+	Syntax  string   `xml:"syntax"`
+	Content string   `xml:"content"`
+}
+
+// NewClient creates a new XWiki client
+func NewClient(cfg config.XWikiConfig) (*Client, error) {
+	if cfg.BaseURL == "" || cfg.Space == "" {
+		return nil, fmt.Errorf("xwiki publisher requires XWIKI_BASE_URL and XWIKI_SPACE")
+	}
+	if cfg.Wiki == "" {
+		cfg.Wiki = "xwiki"
+	}
+
+	httpClient, err := httpclient.New(cfg.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure xwiki transport: %w", err)
+	}
+
+	return &Client{
+		cfg:        cfg,
+		httpClient: httpClient,
+	}, nil
+}
+
+// ResolveParentPage returns the configured parent page reference
+func (c *Client) ResolveParentPage(ctx context.Context) (string, error) {
+	if c.cfg.ParentPage == "" {
+		return "", nil
+	}
+	return c.cfg.Space + "." + c.cfg.ParentPage, nil
+}
+
+// CreateParentPage creates or updates the API overview page
+func (c *Client) CreateParentPage(ctx context.Context, apiTitle string) (string, error) {
+	parent, err := c.ResolveParentPage(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return c.CreateOrUpdatePage(ctx, confluence.APIPageTitle(apiTitle), confluence.ParentPageIntro(apiTitle), parent)
+}
+
+// CreateOrUpdatePage stores a page converted from Confluence storage format
+// to plain XHTML. The returned ID is the XWiki page reference.
+func (c *Client) CreateOrUpdatePage(ctx context.Context, title, content, parentPageID string) (string, error) {
+	name := pageName(title)
+	apiURL := fmt.Sprintf("%s/rest/wikis/%s/spaces/%s/pages/%s",
+		strings.TrimSuffix(c.cfg.BaseURL, "/"), url.PathEscape(c.cfg.Wiki),
+		url.PathEscape(c.cfg.Space), url.PathEscape(name))
+
+	body, err := xml.Marshal(page{
+		Title:   title,
+		Parent:  parentPageID,
+		Syntax:  xhtmlSyntax,
+		Content: toXHTML(content),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal page: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, apiURL, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("Accept", "application/xml")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to store page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		fmt.Printf("✓ Created page: %s\n", title)
+	case http.StatusAccepted, http.StatusOK:
+		fmt.Printf("✓ Updated page: %s\n", title)
+	case http.StatusNotModified:
+		fmt.Printf("✓ Unchanged page: %s\n", title)
+	default:
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return c.cfg.Space + "." + name, nil
+}
+
+// PageURL returns the browser URL of a page reference ("Space.Page")
+func (c *Client) PageURL(pageID string) string {
+	space, name, ok := strings.Cut(pageID, ".")
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s/bin/view/%s/%s", strings.TrimSuffix(c.cfg.BaseURL, "/"),
+		url.PathEscape(space), url.PathEscape(name))
+}
+
+// pageName derives an XWiki page name from a title. Dots separate
+// references in XWiki, so they are replaced.
+func pageName(title string) string {
+	return strings.NewReplacer(".", "_", ":", "_", "\\", "_").Replace(strings.TrimSpace(title))
+}
@@ -0,0 +1,48 @@
+package xwiki
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+)
+
+func TestClient_CreateParentPage(t *testing.T) {
+	var stored page
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.EscapedPath() != "/rest/wikis/xwiki/spaces/API/pages/Pets%20&%20%3COwners%3E%20-%20API%20Documentation" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&stored); err != nil {
+			t.Errorf("failed to decode page: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(config.XWikiConfig{BaseURL: server.URL, Space: "API", ParentPage: "WebHome"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ref, err := client.CreateParentPage(context.Background(), "Pets & <Owners>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref != "API.Pets & <Owners> - API Documentation" {
+		t.Errorf("CreateParentPage() = %q", ref)
+	}
+	if stored.Title != "Pets & <Owners> - API Documentation" || stored.Parent != "API.WebHome" {
+		t.Errorf("title/parent = %q/%q", stored.Title, stored.Parent)
+	}
+	if !strings.Contains(stored.Content, "<h1>Pets &amp; &lt;Owners&gt;</h1>") {
+		t.Errorf("API title not escaped in:\n%s", stored.Content)
+	}
+	if strings.Contains(stored.Content, "ac:") {
+		t.Errorf("Confluence markup left in:\n%s", stored.Content)
+	}
+}
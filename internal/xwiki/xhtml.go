@@ -0,0 +1,28 @@
+package xwiki
+
+import (
+	"html"
+	"regexp"
+)
+
+var (
+	statusMacroPattern = regexp.MustCompile(`(?s)<ac:structured-macro ac:name="status">.*?` +
+		`<ac:parameter ac:name="title">(.*?)</ac:parameter>.*?</ac:structured-macro>`)
+	codeMacroPattern = regexp.MustCompile(`(?s)<ac:structured-macro ac:name="code">.*?` +
+		`<ac:plain-text-body><!\[CDATA\[(.*?)\]\]></ac:plain-text-body>\s*</ac:structured-macro>`)
+	macroPattern     = regexp.MustCompile(`(?s)<ac:structured-macro[^>]*>.*?</ac:structured-macro>`)
+	confluenceTagRef = regexp.MustCompile(`</?(ac|ri):[^>]*>`)
+)
+
+// toXHTML converts Confluence storage format to plain XHTML. Status
+// badges become bold text, code macros become pre blocks and any other
+// Confluence-specific markup is dropped.
+func toXHTML(storage string) string {
+	out := statusMacroPattern.ReplaceAllString(storage, "<strong>[$1]</strong>")
+	out = codeMacroPattern.ReplaceAllStringFunc(out, func(match string) string {
+		code := codeMacroPattern.FindStringSubmatch(match)[1]
+		return "<pre>" + html.EscapeString(code) + "</pre>"
+	})
+	out = macroPattern.ReplaceAllString(out, "")
+	return confluenceTagRef.ReplaceAllString(out, "")
+}
@@ -0,0 +1,19 @@
+package xwiki
+
+import "testing"
+
+func TestToXHTML(t *testing.T) {
+	storage := "<ac:layout><ac:layout-section ac:type=\"single\"><ac:layout-cell>\n" +
+		"<h2><ac:structured-macro ac:name=\"status\"><ac:parameter ac:name=\"colour\">Blue</ac:parameter>" +
+		"<ac:parameter ac:name=\"title\">GET</ac:parameter></ac:structured-macro> /users</h2>\n" +
+		"<ac:structured-macro ac:name=\"code\">\n<ac:parameter ac:name=\"language\">json</ac:parameter>\n" +
+		"<ac:plain-text-body><![CDATA[{\"a\": \"<b>\"}]]></ac:plain-text-body>\n</ac:structured-macro>\n" +
+		"</ac:layout-cell></ac:layout-section></ac:layout>"
+
+	want := "\n<h2><strong>[GET]</strong> /users</h2>\n" +
+		"<pre>{&#34;a&#34;: &#34;&lt;b&gt;&#34;}</pre>\n"
+
+	if got := toXHTML(storage); got != want {
+		t.Errorf("toXHTML() = %q, want %q", got, want)
+	}
+}
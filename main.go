@@ -140,8 +140,6 @@ type EndpointInfo struct {
 	Title     string
 }
 
-var swaggerSpec Swagger // Global variable to hold the full swagger spec
-
 func main() {
 	// Check for CLI argument
 	if len(os.Args) < 2 {
@@ -168,11 +166,12 @@ func main() {
 	}
 
 	fmt.Printf("Fetching Swagger specification from: %s\n", swaggerURL)
-	endpoints, apiTitle, err := parseSwaggerSpec(swaggerURL)
+	spec, endpoints, err := parseSwaggerSpec(swaggerURL)
 	if err != nil {
 		fmt.Printf("Error parsing Swagger: %v\n", err)
 		os.Exit(1)
 	}
+	apiTitle := spec.Info.Title
 
 	fmt.Printf("Found %d endpoints in %s\n\n", len(endpoints), apiTitle)
 
@@ -194,7 +193,7 @@ func main() {
 			fmt.Printf("[%d/%d] Processing: %s %s\n", i+1, len(endpoints),
 				strings.ToUpper(endpoint.Method), endpoint.Path)
 
-			confluenceMarkup := generateOperationTable(endpoint.Path, endpoint.Method, endpoint.Operation)
+			confluenceMarkup := generateOperationTable(spec, endpoint.Path, endpoint.Method, endpoint.Operation)
 
 			pageID, err := createOrUpdateEndpointPage(config, endpoint.Title, confluenceMarkup, parentPageID)
 			if err != nil {
@@ -225,32 +224,35 @@ func main() {
 			fmt.Printf("\n[%d] Page Title: %s\n", i+1, endpoint.Title)
 			fmt.Printf("Endpoint: %s %s\n", strings.ToUpper(endpoint.Method), endpoint.Path)
 			fmt.Println("---")
-			confluenceMarkup := generateOperationTable(endpoint.Path, endpoint.Method, endpoint.Operation)
+			confluenceMarkup := generateOperationTable(spec, endpoint.Path, endpoint.Method, endpoint.Operation)
 			fmt.Println(confluenceMarkup)
 			fmt.Println("=================================")
 		}
 	}
 }
 
-func parseSwaggerSpec(swaggerURL string) ([]EndpointInfo, string, error) {
+// parseSwaggerSpec fetches the spec and lists its endpoints. The returned
+// spec is passed to the renderers so no state is shared between runs.
+func parseSwaggerSpec(swaggerURL string) (*Swagger, []EndpointInfo, error) {
 	resp, err := http.Get(swaggerURL)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to fetch swagger: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch swagger: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to read response: %w", err)
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	if err := json.Unmarshal(body, &swaggerSpec); err != nil {
-		return nil, "", fmt.Errorf("failed to parse swagger: %w", err)
+	var spec Swagger
+	if err := json.Unmarshal(body, &spec); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse swagger: %w", err)
 	}
 
 	var endpoints []EndpointInfo
 
-	for path, pathItem := range swaggerSpec.Paths {
+	for path, pathItem := range spec.Paths {
 		for method, operation := range pathItem {
 			if isHTTPMethod(method) {
 				title := generatePageTitle(path, method, operation)
@@ -264,7 +266,7 @@ func parseSwaggerSpec(swaggerURL string) ([]EndpointInfo, string, error) {
 		}
 	}
 
-	return endpoints, swaggerSpec.Info.Title, nil
+	return &spec, endpoints, nil
 }
 
 func generatePageTitle(path, method string, operation Operation) string {
@@ -328,7 +330,7 @@ func isHTTPMethod(method string) bool {
 	return false
 }
 
-func generateOperationTable(path, method string, operation Operation) string {
+func generateOperationTable(spec *Swagger, path, method string, operation Operation) string {
 	var table strings.Builder
 
 	// Add layout section for full width in Confluence Cloud
@@ -383,7 +385,7 @@ func generateOperationTable(path, method string, operation Operation) string {
 	}
 
 	// REQUEST BODY SECTION (NEW)
-	table.WriteString(generateRequestBodySection(operation))
+	table.WriteString(generateRequestBodySection(spec, operation))
 
 	// PARAMETERS SECTION
 	table.WriteString("<h3>Parameters</h3>\n")
@@ -449,7 +451,7 @@ func generateOperationTable(path, method string, operation Operation) string {
 	return table.String()
 }
 
-func generateRequestBodySection(operation Operation) string {
+func generateRequestBodySection(spec *Swagger, operation Operation) string {
 	var body strings.Builder
 
 	// Check for body parameter (Swagger 2.0)
@@ -490,7 +492,7 @@ func generateRequestBodySection(operation Operation) string {
 		for contentType, mediaType := range operation.RequestBody.Content {
 			body.WriteString(fmt.Sprintf("<p><strong>Content-Type:</strong> <code>%s</code></p>\n", contentType))
 			schemaToUse = &mediaType.Schema
-			body.WriteString(generateSchemaTable(&mediaType.Schema))
+			body.WriteString(generateSchemaTable(spec, &mediaType.Schema))
 		}
 	}
 
@@ -511,19 +513,19 @@ func generateRequestBodySection(operation Operation) string {
 
 		if bodyParam.Schema != nil {
 			schemaToUse = bodyParam.Schema
-			body.WriteString(generateSchemaTable(bodyParam.Schema))
+			body.WriteString(generateSchemaTable(spec, bodyParam.Schema))
 		}
 	}
 
 	// Add Example JSON section
 	if schemaToUse != nil {
-		body.WriteString(generateExampleJSON(schemaToUse))
+		body.WriteString(generateExampleJSON(spec, schemaToUse))
 	}
 
 	return body.String()
 }
 
-func generateSchemaTable(schema *Schema) string {
+func generateSchemaTable(spec *Swagger, schema *Schema) string {
 	if schema == nil {
 		return ""
 	}
@@ -532,7 +534,7 @@ func generateSchemaTable(schema *Schema) string {
 
 	// Resolve reference if present
 	if schema.Ref != "" {
-		resolvedSchema := resolveSchemaRef(schema.Ref)
+		resolvedSchema := resolveSchemaRef(spec, schema.Ref)
 		if resolvedSchema != nil {
 			schema = resolvedSchema
 		} else {
@@ -547,7 +549,7 @@ func generateSchemaTable(schema *Schema) string {
 		table.WriteString("<p><strong>Type:</strong> Array</p>\n")
 		if schema.Items.Ref != "" {
 			table.WriteString(fmt.Sprintf("<p><strong>Items:</strong> %s</p>\n", extractRefName(schema.Items.Ref)))
-			resolvedSchema := resolveSchemaRef(schema.Items.Ref)
+			resolvedSchema := resolveSchemaRef(spec, schema.Items.Ref)
 			if resolvedSchema != nil {
 				schema = resolvedSchema
 			}
@@ -664,7 +666,7 @@ func generateSchemaTable(schema *Schema) string {
 	return table.String()
 }
 
-func resolveSchemaRef(ref string) *Schema {
+func resolveSchemaRef(spec *Swagger, ref string) *Schema {
 	// Extract definition name from $ref
 	// Supports both Swagger 2.0 (#/definitions/Name) and OpenAPI 3.0 (#/components/schemas/Name)
 	parts := strings.Split(ref, "/")
@@ -675,8 +677,8 @@ func resolveSchemaRef(ref string) *Schema {
 	defName := parts[len(parts)-1]
 
 	// Try OpenAPI 3.0 components/schemas first
-	if swaggerSpec.Components != nil && swaggerSpec.Components.Schemas != nil {
-		if def, exists := swaggerSpec.Components.Schemas[defName]; exists {
+	if spec.Components != nil && spec.Components.Schemas != nil {
+		if def, exists := spec.Components.Schemas[defName]; exists {
 			return &Schema{
 				Type:       def.Type,
 				Properties: def.Properties,
@@ -686,7 +688,7 @@ func resolveSchemaRef(ref string) *Schema {
 	}
 
 	// Fall back to Swagger 2.0 definitions
-	if def, exists := swaggerSpec.Definitions[defName]; exists {
+	if def, exists := spec.Definitions[defName]; exists {
 		return &Schema{
 			Type:       def.Type,
 			Properties: def.Properties,
@@ -745,7 +747,7 @@ func getParameterType(param Parameter) string {
 	return ""
 }
 
-func generateExampleJSON(schema *Schema) string {
+func generateExampleJSON(spec *Swagger, schema *Schema) string {
 	var example strings.Builder
 
 	example.WriteString("<h4>Example JSON</h4>\n")
@@ -755,14 +757,14 @@ func generateExampleJSON(schema *Schema) string {
 
 	// Resolve reference if present
 	if schema.Ref != "" {
-		resolvedSchema := resolveSchemaRef(schema.Ref)
+		resolvedSchema := resolveSchemaRef(spec, schema.Ref)
 		if resolvedSchema != nil {
 			schema = resolvedSchema
 		}
 	}
 
 	// Generate JSON from schema
-	jsonStr := buildJSONFromSchema(schema, 0)
+	jsonStr := buildJSONFromSchema(spec, schema, 0)
 	example.WriteString(jsonStr)
 
 	example.WriteString("]]></ac:plain-text-body>\n")
@@ -771,7 +773,7 @@ func generateExampleJSON(schema *Schema) string {
 	return example.String()
 }
 
-func buildJSONFromSchema(schema *Schema, indentLevel int) string {
+func buildJSONFromSchema(spec *Swagger, schema *Schema, indentLevel int) string {
 	if schema == nil {
 		return ""
 	}
@@ -788,14 +790,14 @@ func buildJSONFromSchema(schema *Schema, indentLevel int) string {
 		// Resolve array items if it's a reference
 		itemSchema := schema.Items
 		if itemSchema.Ref != "" {
-			resolvedSchema := resolveSchemaRef(itemSchema.Ref)
+			resolvedSchema := resolveSchemaRef(spec, itemSchema.Ref)
 			if resolvedSchema != nil {
 				itemSchema = resolvedSchema
 			}
 		}
 
 		json.WriteString(nextIndent)
-		json.WriteString(buildJSONFromSchema(itemSchema, indentLevel+1))
+		json.WriteString(buildJSONFromSchema(spec, itemSchema, indentLevel+1))
 		json.WriteString("\n")
 		json.WriteString(indent)
 		json.WriteString("]")
@@ -819,7 +821,7 @@ func buildJSONFromSchema(schema *Schema, indentLevel int) string {
 			json.WriteString(fmt.Sprintf("\"%s\": ", propName))
 
 			// Get example value or generate default
-			exampleValue := getExampleValue(prop, propName)
+			exampleValue := getExampleValue(spec, prop, propName)
 			json.WriteString(exampleValue)
 
 			// Add comma if not last property
@@ -836,7 +838,7 @@ func buildJSONFromSchema(schema *Schema, indentLevel int) string {
 	return json.String()
 }
 
-func getExampleValue(prop Property, fieldName string) string {
+func getExampleValue(spec *Swagger, prop Property, fieldName string) string {
 	// Use example if available
 	if prop.Example != nil {
 		switch v := prop.Example.(type) {
@@ -853,9 +855,9 @@ func getExampleValue(prop Property, fieldName string) string {
 
 	// Handle references
 	if prop.Ref != "" {
-		resolvedSchema := resolveSchemaRef(prop.Ref)
+		resolvedSchema := resolveSchemaRef(spec, prop.Ref)
 		if resolvedSchema != nil {
-			return buildJSONFromSchema(resolvedSchema, 1)
+			return buildJSONFromSchema(spec, resolvedSchema, 1)
 		}
 		return "\"...\""
 	}
@@ -863,9 +865,9 @@ func getExampleValue(prop Property, fieldName string) string {
 	// Handle arrays
 	if prop.Type == "array" && prop.Items != nil {
 		if prop.Items.Ref != "" {
-			resolvedSchema := resolveSchemaRef(prop.Items.Ref)
+			resolvedSchema := resolveSchemaRef(spec, prop.Items.Ref)
 			if resolvedSchema != nil {
-				return fmt.Sprintf("[\n    %s\n  ]", buildJSONFromSchema(resolvedSchema, 2))
+				return fmt.Sprintf("[\n    %s\n  ]", buildJSONFromSchema(spec, resolvedSchema, 2))
 			}
 		}
 		return "[]"
@@ -0,0 +1,68 @@
+package converter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/internal/diff"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// publishChangelog compares the spec with the snapshot the previous run
+// attached to the API page and publishes the differences on a changelog
+// page. The page is left alone when nothing changed, so it keeps showing
+// the last real change.
+func (c *Converter) publishChangelog(ctx context.Context, scope, parentPageID string, spec *swagger.Spec) error {
+	downloader, ok := c.client.(AttachmentDownloader)
+	if !ok || parentPageID == "" {
+		return nil
+	}
+
+	data, err := downloader.DownloadAttachment(ctx, parentPageID, confluence.SpecSnapshotName)
+	if err != nil {
+		return fmt.Errorf("failed to load the previously published spec: %w", err)
+	}
+	if data == nil {
+		fmt.Fprintf(c.progress, "No previously published spec; the changelog starts with the next run\n\n")
+		return nil
+	}
+
+	var previous swagger.Spec
+	if err := json.Unmarshal(data, &previous); err != nil {
+		return fmt.Errorf("failed to parse the previously published spec: %w", err)
+	}
+
+	changes := diff.Compare(&previous, spec)
+	if len(changes) == 0 {
+		fmt.Fprintf(c.progress, "No changes since the previously published spec\n\n")
+		return nil
+	}
+
+	content := c.formatter.FormatChangelogPage(scope, previous.Info.Version, spec.Info.Version, changes)
+	if _, err := c.publishPage(ctx, confluence.ChangelogPageTitle(scope), content, parentPageID); err != nil {
+		return fmt.Errorf("failed to publish changelog: %w", err)
+	}
+
+	fmt.Fprintf(c.progress, "Published changelog: %d changes (%d breaking)\n\n", len(changes), len(diff.Breaking(changes)))
+	return nil
+}
+
+// saveSpecSnapshot attaches the published spec to the API page, for the
+// next run's changelog
+func (c *Converter) saveSpecSnapshot(ctx context.Context, parentPageID string, spec *swagger.Spec) error {
+	uploader, ok := c.client.(AttachmentUploader)
+	if !ok || parentPageID == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("failed to encode spec snapshot: %w", err)
+	}
+	if err := uploader.UploadAttachment(ctx, parentPageID, confluence.SpecSnapshotName, "application/json", data); err != nil {
+		return fmt.Errorf("failed to save spec snapshot: %w", err)
+	}
+	return nil
+}
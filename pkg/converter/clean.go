@@ -0,0 +1,126 @@
+package converter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// Prune modes
+const (
+	PruneDelete  = "delete"
+	PruneArchive = "archive"
+)
+
+// Clean prunes the generated endpoint pages below the API page that no
+// longer match an endpoint of the spec, e.g. after operations were removed.
+// Pages are deleted unless Options.Prune asks to archive them. With dryRun
+// the stale pages are only listed. It returns the titles of the stale pages.
+func (c *Converter) Clean(ctx context.Context, source string, dryRun bool) ([]string, error) {
+	finder, canFind := c.client.(PageFinder)
+	if _, canClean := c.client.(PageCleaner); !canClean || !canFind {
+		return nil, fmt.Errorf("the publisher cannot delete pages")
+	}
+
+	spec, err := c.parser.Parse(ctx, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse swagger: %w", err)
+	}
+
+	// Endpoint pages live below the API page, or its version page
+	parentTitle := confluence.APIPageTitle(spec.Info.Title)
+	if label := c.opts.Versions.Label; label != "" {
+		parentTitle = fmt.Sprintf("%s %s", spec.Info.Title, label)
+	}
+	parentPageID, err := finder.FindPage(ctx, parentTitle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up %q: %w", parentTitle, err)
+	}
+	if parentPageID == "" {
+		return nil, fmt.Errorf("page %q not found; nothing has been published for this spec", parentTitle)
+	}
+
+	return c.prune(ctx, spec, parentPageID, dryRun)
+}
+
+// prune deletes or archives the endpoint pages directly below parentPageID
+// whose endpoints are not in the spec. Only pages recognized as generated
+// endpoint pages, by label or notes markers, are touched.
+func (c *Converter) prune(ctx context.Context, spec *swagger.Spec, parentPageID string, dryRun bool) ([]string, error) {
+	cleaner, ok := c.client.(PageCleaner)
+	if !ok {
+		return nil, nil
+	}
+
+	label := c.opts.Versions.Label
+	current := make(map[string]bool)
+	for endpoint := range c.parser.Endpoints(spec) {
+		title := endpoint.Title
+		if label != "" {
+			title = versionedTitle(title, label)
+		}
+		current[title] = true
+	}
+
+	children, err := cleaner.ChildPages(ctx, parentPageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endpoint pages: %w", err)
+	}
+
+	archive := c.opts.Prune == PruneArchive
+	action := "Deleted"
+	if archive {
+		action = "Archived"
+	}
+
+	var stale []string
+	archivePageID := ""
+	for _, page := range children {
+		if current[page.Title] || !confluence.IsGeneratedEndpoint(&page) {
+			continue
+		}
+		stale = append(stale, page.Title)
+
+		if dryRun {
+			fmt.Fprintf(c.progress, "Would prune (%s): %s\n", c.pruneMode(), page.Title)
+			continue
+		}
+
+		if !archive {
+			if err := cleaner.DeletePage(ctx, page.ID); err != nil {
+				return stale, fmt.Errorf("failed to delete %q: %w", page.Title, err)
+			}
+			fmt.Fprintf(c.progress, "%s: %s\n", action, page.Title)
+			continue
+		}
+
+		// The archive page is only created once there is something to archive
+		if archivePageID == "" {
+			scope := spec.Info.Title
+			if label != "" {
+				scope = fmt.Sprintf("%s %s", spec.Info.Title, label)
+			}
+			archivePageID, err = c.publishPage(ctx, confluence.ArchivePageTitle(scope),
+				c.formatter.FormatArchivePage(scope), parentPageID)
+			if err != nil {
+				return stale, fmt.Errorf("failed to create archive page: %w", err)
+			}
+		}
+		if err := cleaner.MovePage(ctx, page, archivePageID); err != nil {
+			return stale, err
+		}
+		fmt.Fprintf(c.progress, "%s: %s\n", action, page.Title)
+	}
+
+	return stale, nil
+}
+
+// pruneMode returns the configured prune mode, deleting by default
+func (c *Converter) pruneMode() string {
+	if c.opts.Prune == PruneArchive {
+		return PruneArchive
+	}
+	return PruneDelete
+}
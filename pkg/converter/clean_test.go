@@ -0,0 +1,114 @@
+package converter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+)
+
+// endpointPage returns a generated endpoint page recognized by its label
+func endpointPage(title string) confluence.Page {
+	return confluence.Page{ID: title, Title: title, Metadata: &confluence.Metadata{
+		Labels: &confluence.LabelResults{Results: []confluence.Label{{Prefix: "global", Name: confluence.EndpointLabel}}},
+	}}
+}
+
+// markedPage returns an unlabeled endpoint page recognized by its notes
+// markers, as Confluence stores them
+func markedPage(title string) confluence.Page {
+	return confluence.Page{ID: title, Title: title, Body: confluence.Body{Storage: confluence.Storage{
+		Value: `<h3>Notes</h3><ac:structured-macro ac:name="anchor" ac:schema-version="1" ac:macro-id="e1">` +
+			`<ac:parameter ac:name="">` + confluence.ManualStartMarker + `</ac:parameter></ac:structured-macro>`,
+	}}}
+}
+
+func TestConverter_Clean(t *testing.T) {
+	handWritten := confluence.Page{ID: "Team Notes", Title: "Team Notes",
+		Body: confluence.Body{Storage: confluence.Storage{Value: "<p>Our conventions</p>"}}}
+
+	tests := []struct {
+		name        string
+		prune       string
+		label       string
+		dryRun      bool
+		children    []confluence.Page
+		wantStale   []string
+		wantDeleted []string
+		wantMoved   map[string]string
+	}{
+		{
+			name:        "delete",
+			prune:       PruneDelete,
+			children:    []confluence.Page{endpointPage("Get Pet"), endpointPage("Delete Pet"), markedPage("Add Pet"), handWritten},
+			wantStale:   []string{"Delete Pet", "Add Pet"},
+			wantDeleted: []string{"Delete Pet", "Add Pet"},
+		},
+		{
+			name:      "archive",
+			prune:     PruneArchive,
+			children:  []confluence.Page{endpointPage("List Pets"), endpointPage("Delete Pet"), handWritten},
+			wantStale: []string{"Delete Pet"},
+			wantMoved: map[string]string{"Delete Pet": confluence.ArchivePageTitle("Pets")},
+		},
+		{
+			name:      "dry run",
+			prune:     PruneArchive,
+			dryRun:    true,
+			children:  []confluence.Page{endpointPage("Get Pet"), endpointPage("Delete Pet"), markedPage("Add Pet")},
+			wantStale: []string{"Delete Pet", "Add Pet"},
+		},
+		{
+			name:  "version label",
+			prune: PruneDelete,
+			label: "v2",
+			children: []confluence.Page{
+				endpointPage("Get Pet (v2)"), endpointPage("List Pets (v2)"), endpointPage("Delete Pet (v2)"), handWritten,
+			},
+			wantStale:   []string{"Delete Pet (v2)"},
+			wantDeleted: []string{"Delete Pet (v2)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parentTitle := confluence.APIPageTitle("Pets")
+			if tt.label != "" {
+				parentTitle = "Pets " + tt.label
+			}
+			publisher := newFakePublisher()
+			publisher.pages[parentTitle] = ""
+			publisher.children[parentTitle] = tt.children
+
+			c := New(WithPublisher(publisher), WithOptions(Options{Prune: tt.prune, Versions: config.VersionsConfig{Label: tt.label}}))
+			stale, err := c.Clean(context.Background(), specFile(t, testSpec), tt.dryRun)
+			if err != nil {
+				t.Fatalf("Clean() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(stale, tt.wantStale) {
+				t.Errorf("stale = %v, want %v", stale, tt.wantStale)
+			}
+			if !reflect.DeepEqual(publisher.deleted, tt.wantDeleted) {
+				t.Errorf("deleted = %v, want %v", publisher.deleted, tt.wantDeleted)
+			}
+			if len(publisher.moved) > 0 || tt.wantMoved != nil {
+				if !reflect.DeepEqual(publisher.moved, tt.wantMoved) {
+					t.Errorf("moved = %v, want %v", publisher.moved, tt.wantMoved)
+				}
+			}
+			if _, created := publisher.pages[confluence.ArchivePageTitle("Pets")]; created != (tt.wantMoved != nil) {
+				t.Errorf("archive page created = %v, want %v", created, tt.wantMoved != nil)
+			}
+		})
+	}
+}
+
+func TestConverter_CleanUnpublishedSpec(t *testing.T) {
+	c := New(WithPublisher(newFakePublisher()))
+	if _, err := c.Clean(context.Background(), specFile(t, testSpec), false); err == nil {
+		t.Error("Clean() of a spec without an API page succeeded, want an error")
+	}
+}
@@ -2,88 +2,832 @@ package converter
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
 
+	"github.com/ahmadimt/SwagFluence/internal/collection"
 	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/internal/diff"
+	"github.com/ahmadimt/SwagFluence/internal/example"
+	"github.com/ahmadimt/SwagFluence/internal/har"
+	"github.com/ahmadimt/SwagFluence/internal/httpclient"
+	"github.com/ahmadimt/SwagFluence/internal/lint"
+	"github.com/ahmadimt/SwagFluence/internal/smoke"
 	"github.com/ahmadimt/SwagFluence/internal/swagger"
 )
 
-// Converter orchestrates the conversion process
+// Options holds optional behavior for a conversion run
+type Options struct {
+	Collection CollectionConfig
+	Lint       LintConfig
+	// Baseline is the previous spec compared against for breaking changes
+	Baseline string
+	// Issues opens an issue when breaking changes are found; nil disables it
+	Issues   IssueTracker
+	PDF      PDFConfig
+	Examples ExamplesConfig
+	Smoke    SmokeConfig
+	Versions VersionsConfig
+	// TagLabels labels endpoint pages with their operation tags
+	TagLabels bool
+	// OrderPages positions endpoint pages in the page tree in spec order
+	OrderPages bool
+	// SharedModels documents component schemas once on model pages that
+	// endpoint pages include
+	SharedModels bool
+	// Changelog publishes the changes since the previous run on a changelog
+	// page, comparing against a copy of the spec attached to the API page
+	Changelog bool
+	// Formatter renders the storage format pages; nil uses the default layout
+	Formatter *Formatter
+	// Renderer renders the API, endpoint and model pages in place of
+	// Formatter; nil uses Formatter
+	Renderer Renderer
+	// Prune removes endpoint pages of operations no longer in the spec after
+	// publishing: PruneDelete deletes them, PruneArchive moves them below an
+	// archive page; empty keeps them
+	Prune string
+	// DryRun reports the pages that would be pruned without touching them
+	DryRun bool
+	// ContinueOnError records endpoint pages that fail and publishes the
+	// rest, failing the run at the end instead of on the first error
+	ContinueOnError bool
+}
+
+// Converter orchestrates the conversion process. Publishers track the API
+// being published, so parallel conversions need their own Converter and
+// publisher; the parser and resolvers hold no shared state.
 type Converter struct {
 	parser    *swagger.Parser
-	client    confluence.Client
+	client    Publisher
 	formatter *confluence.Formatter
+	opts      Options
+	// sources are the specs converted by Run
+	sources []string
+	// concurrency is the number of endpoint pages rendered in parallel
+	concurrency int
+	// hooks change pages as they are rendered and published; nil has none
+	hooks *Hooks
+	// progress receives the progress messages of runs
+	progress io.Writer
+}
+
+// New creates a new Converter configured by options, e.g.
+//
+//	converter.New(converter.WithSpecSource("openapi.yaml"), converter.WithPublisher(p), converter.WithConcurrency(4))
+func New(options ...Option) *Converter {
+	c := &Converter{}
+	for _, option := range options {
+		option(c)
+	}
+
+	if c.parser == nil {
+		c.parser = swagger.NewParser()
+	}
+	if c.progress == nil {
+		c.progress = os.Stdout
+	}
+	c.formatter = c.opts.Formatter
+	if c.formatter == nil {
+		c.formatter = confluence.NewFormatter()
+	}
+	if c.opts.Examples.Faker || c.opts.Examples.MaxDepth > 0 || c.opts.Examples.ArrayItems > 0 {
+		gen := c.exampleGenerator()
+		c.formatter = c.formatter.WithExamples(gen)
+		if setter, ok := c.client.(ExampleSetter); ok {
+			setter.SetExampleGenerator(gen)
+		}
+	}
+	return c
 }
 
-// New creates a new Converter
-func New(parser *swagger.Parser, client confluence.Client) *Converter {
-	return &Converter{
-		parser:    parser,
-		client:    client,
-		formatter: confluence.NewFormatter(),
+// Run converts the specs added with WithSpecSource in order. A failing spec
+// doesn't stop the others; the returned error joins their errors, and the
+// reports cover every spec.
+func (c *Converter) Run(ctx context.Context) ([]*Report, error) {
+	if len(c.sources) == 0 {
+		return nil, errors.New("no spec source configured")
+	}
+
+	var reports []*Report
+	var errs []error
+	for _, source := range c.sources {
+		report, err := c.Convert(ctx, source)
+		if err != nil {
+			report.Error = err.Error()
+			errs = append(errs, fmt.Errorf("%s: %w", source, err))
+		}
+		reports = append(reports, report)
 	}
+	return reports, errors.Join(errs...)
 }
 
-// Convert performs the full conversion from Swagger to Confluence
-func (c *Converter) Convert(ctx context.Context, swaggerURL string) error {
-	fmt.Printf("Fetching Swagger specification from: %s\n", swaggerURL)
+// Convert performs the full conversion from Swagger to Confluence. The
+// returned report covers the pages processed so far, even on error.
+func (c *Converter) Convert(ctx context.Context, swaggerURL string) (*Report, error) {
+	report := &Report{Source: swaggerURL}
+
+	// Keep what was published even when the run fails, so a re-run is fast
+	if saver, ok := c.client.(StateSaver); ok {
+		defer func() {
+			if err := saver.SaveState(); err != nil {
+				fmt.Fprintf(c.progress, "Warning: %v\n", err)
+				report.Warnings = append(report.Warnings, err.Error())
+			}
+		}()
+	}
+
+	// Resolve the configured parent page up front so a bad title fails fast
+	if c.client != nil {
+		if _, err := c.client.ResolveParentPage(ctx); err != nil {
+			return report, fmt.Errorf("failed to resolve parent page: %w", err)
+		}
+	}
+
+	fmt.Fprintf(c.progress, "Fetching Swagger specification from: %s\n", swaggerURL)
 
 	// Parse Swagger specification
 	spec, err := c.parser.Parse(ctx, swaggerURL)
 	if err != nil {
-		return fmt.Errorf("failed to parse swagger: %w", err)
+		return report, fmt.Errorf("failed to parse swagger: %w", err)
+	}
+
+	fmt.Fprintf(c.progress, "Successfully parsed: %s v%s\n", spec.Info.Title, spec.Info.Version)
+	report.APITitle = spec.Info.Title
+	report.APIVersion = spec.Info.Version
+	if recorder, ok := c.client.(VersionRecorder); ok {
+		recorder.SetAPIVersion(spec.Info.Version)
+	}
+	if recorder, ok := c.client.(SpecRecorder); ok {
+		recorder.SetSpecDocument(confluence.SpecAttachmentName(swaggerURL, spec.Format), spec.Format, spec.Document)
+	}
+
+	// Check documentation quality before anything is published
+	if c.opts.Lint.Enabled {
+		if err := c.lint(spec, report); err != nil {
+			return report, err
+		}
 	}
 
-	fmt.Printf("Successfully parsed: %s v%s\n", spec.Info.Title, spec.Info.Version)
+	// Compare against the previous version before publishing replaces it
+	if c.opts.Baseline != "" {
+		if err := c.compareBaseline(ctx, spec, report); err != nil {
+			return report, err
+		}
+	}
 
-	// Extract endpoints
-	endpoints := c.parser.ExtractEndpoints(spec)
-	fmt.Printf("Found %d endpoints\n\n", len(endpoints))
+	// Replace synthetic examples with recorded traffic
+	if len(c.opts.Examples.HARFiles) > 0 {
+		if err := c.enrichExamples(spec); err != nil {
+			return report, err
+		}
+	}
+
+	// Endpoints are streamed through formatting and publishing one at a
+	// time, so rendered pages are released as soon as they are written
+	total := c.parser.CountEndpoints(spec)
+	report.Endpoints = total
+	fmt.Fprintf(c.progress, "Found %d endpoints\n\n", total)
+	c.titleWarnings(spec, report)
 
 	// Create resolver for $ref resolution
 	resolver := swagger.NewResolver(spec)
 
+	// Export a request collection alongside the docs
+	if c.opts.Collection.Format != "" {
+		if err := c.exportCollection(spec, c.parser.ExtractEndpoints(spec), resolver); err != nil {
+			return report, err
+		}
+	}
+
+	// The API page, or the version page, lists the servers and every
+	// endpoint page. Native publishers title their pages themselves, so the
+	// endpoint links would dangle.
+	_, native := c.client.(EndpointPublisher)
+	summary := c.formatter.FormatServers(spec)
+	if !native {
+		summary += c.endpointSummary(spec)
+	}
+
 	// Create parent page if Confluence is enabled
 	parentPageID := ""
 	if c.client != nil {
+		if setter, ok := c.client.(ParentPageSetter); ok {
+			intro := c.renderer(c.formatter).FormatParentPage(spec.Info.Title)
+			if c.opts.Versions.Label == "" {
+				intro += summary
+			}
+			content, err := c.hooks.runPrePublish(Page{Title: confluence.APIPageTitle(spec.Info.Title)}, intro)
+			if err != nil {
+				return report, err
+			}
+			setter.SetParentPageContent(content)
+		}
 		var err error
 		parentPageID, err = c.client.CreateParentPage(ctx, spec.Info.Title)
 		if err != nil {
-			return fmt.Errorf("failed to create parent page: %w", err)
+			return report, fmt.Errorf("failed to create parent page: %w", err)
 		}
 		if parentPageID != "" {
-			fmt.Printf("Parent page ID: %s\n\n", parentPageID)
+			fmt.Fprintf(c.progress, "Parent page ID: %s\n\n", parentPageID)
+		}
+		report.ParentPageID = parentPageID
+		report.ParentPageURL = c.client.PageURL(parentPageID)
+	}
+
+	// Publish into a per-version subtree
+	if label := c.opts.Versions.Label; label != "" && c.client != nil {
+		title := fmt.Sprintf("%s %s", spec.Info.Title, label)
+		content := c.formatter.FormatVersionPage(spec.Info.Title, label, spec.Info.Version) + summary
+		versionPageID, err := c.publishPage(ctx, title, content, parentPageID)
+		if err != nil {
+			return report, fmt.Errorf("failed to create version page: %w", err)
+		}
+		parentPageID = versionPageID
+	}
+
+	// Pages shared by the endpoints are titled within the published subtree
+	scope := spec.Info.Title
+	if label := c.opts.Versions.Label; label != "" {
+		scope = fmt.Sprintf("%s %s", spec.Info.Title, label)
+	}
+	publishShared := c.client != nil && !native
+
+	// Describe the security schemes once; endpoint pages list what they need
+	authPageTitle := ""
+	if publishShared && len(spec.SecuritySchemes()) > 0 {
+		if err := c.publishAuthentication(ctx, scope, parentPageID, spec); err != nil {
+			return report, err
+		}
+		authPageTitle = confluence.AuthenticationPageTitle(scope)
+	}
+	formatter := c.formatter.WithSecurity(spec, authPageTitle)
+
+	// Document shared schemas once and include them on endpoint pages
+	if c.opts.SharedModels && publishShared {
+		formatter = formatter.WithModelPages(scope)
+		if err := c.publishModels(ctx, formatter, c.renderer(formatter), scope, parentPageID, spec, resolver); err != nil {
+			return report, err
 		}
 	}
 
-	// Process each endpoint
-	successCount := 0
-	for i, endpoint := range endpoints {
-		fmt.Printf("[%d/%d] Processing: %s %s\n", i+1, len(endpoints),
+	// List what changed since the spec published by the previous run
+	if c.opts.Changelog && publishShared {
+		if err := c.publishChangelog(ctx, scope, parentPageID, spec); err != nil {
+			return report, err
+		}
+	}
+
+	// Execute the example requests against a live server
+	var runner *smoke.Runner
+	var smokeExamples *example.Generator
+	if c.opts.Smoke.BaseURL != "" {
+		if runner, err = smoke.NewRunner(c.opts.Smoke); err != nil {
+			return report, err
+		}
+		smokeExamples = c.exampleGenerator()
+	}
+
+	// Render the endpoint pages, unless the publisher renders them itself
+	renderer := c.renderer(formatter)
+	render := func(endpoint *swagger.EndpointInfo) (string, error) {
+		if err := c.hooks.runPreRender(endpoint); err != nil {
+			return "", err
+		}
+		if native {
+			return "", nil
+		}
+		content, err := renderer.FormatEndpointPage(endpoint.Path, endpoint.Method, endpoint.Operation, resolver)
+		if err != nil {
+			return "", err
+		}
+		return c.hooks.runPostRender(Page{Title: endpoint.Title, Endpoint: endpoint}, content)
+	}
+
+	// Process each endpoint. Once the run is interrupted no new pages are
+	// started, but the page in flight is finished so it isn't left
+	// half-done, unless it is waiting to retry a throttled request.
+	i := 0
+	for page := range c.renderPages(c.parser.Endpoints(spec), render) {
+		endpoint := page.endpoint
+		if ctx.Err() != nil {
+			report.Interrupted = true
+			break
+		}
+		pageCtx := httpclient.KeepRunning(ctx)
+		i++
+		base := endpoint.Title
+
+		// Page titles are unique per space, so each version gets its own
+		if label := c.opts.Versions.Label; label != "" && c.client != nil {
+			endpoint.Title = versionedTitle(endpoint.Title, label)
+		}
+
+		fmt.Fprintf(c.progress, "[%d/%d] Processing: %s %s\n", i, total,
 			endpoint.Method, endpoint.Path)
 
-		if err := c.processEndpoint(ctx, resolver, endpoint, parentPageID); err != nil {
-			return fmt.Errorf("failed to process %s %s: %w", endpoint.Method, endpoint.Path, err)
+		result := PageResult{
+			Title:  endpoint.Title,
+			Method: strings.ToUpper(endpoint.Method),
+			Path:   endpoint.Path,
+		}
+
+		banner, err := c.versionLinks(pageCtx, base, c.opts.Versions.Label)
+		if err != nil {
+			if err := c.pageFailed(report, result, err); err != nil {
+				return report, err
+			}
+			continue
+		}
+		if endpoint.Webhook {
+			banner = c.formatter.FormatWebhookNotice(endpoint.Path) + banner
+		}
+		// Webhooks are called by the API, so there is nothing to call back
+		if runner != nil && !endpoint.Webhook {
+			// Built per page so schemas are only resolved for pages being published
+			request := collection.BuildRequests([]swagger.EndpointInfo{endpoint}, resolver, smokeExamples)[0]
+			smokeResult := runner.Run(pageCtx, request)
+			result.Smoke = &smokeResult
+			if !smokeResult.Skipped {
+				banner += c.formatter.FormatVerificationStatus(smokeResult.Verified, smokeResult.Summary())
+			}
+			if !smokeResult.Verified && !smokeResult.Skipped {
+				report.Warnings = append(report.Warnings, fmt.Sprintf("example request %s %s %s",
+					result.Method, endpoint.Path, smokeResult.Summary()))
+			}
+		}
+
+		page.endpoint = endpoint
+		pageID, err := c.processEndpoint(pageCtx, page, renderer, resolver, parentPageID, banner)
+		if err != nil {
+			err = fmt.Errorf("failed to process %s %s: %w", endpoint.Method, endpoint.Path, err)
+			if err := c.pageFailed(report, result, err); err != nil {
+				return report, err
+			}
+			continue
+		}
+
+		if err := c.linkOtherVersions(pageCtx, base); err != nil {
+			err = fmt.Errorf("failed to link %s %s from other versions: %w", endpoint.Method, endpoint.Path, err)
+			if err := c.pageFailed(report, result, err); err != nil {
+				return report, err
+			}
+			continue
 		}
 
-		successCount++
+		if err := c.labelByTags(pageCtx, pageID, endpoint.Operation.Tags); err != nil {
+			err = fmt.Errorf("failed to label %s %s: %w", endpoint.Method, endpoint.Path, err)
+			if err := c.pageFailed(report, result, err); err != nil {
+				return report, err
+			}
+			continue
+		}
+
+		result.PageID = pageID
+		if c.client != nil {
+			result.URL = c.client.PageURL(pageID)
+		}
+		if reporter, ok := c.client.(ChangeReporter); ok {
+			result.Change = reporter.LastChange()
+		}
+		report.Pages = append(report.Pages, result)
 	}
 
-	fmt.Printf("\n=================================\n")
-	fmt.Printf("Summary: %d/%d pages processed successfully\n", successCount, len(endpoints))
+	fmt.Fprintf(c.progress, "\n=================================\n")
+	fmt.Fprintf(c.progress, "Summary: %d/%d pages processed successfully\n", report.Succeeded(), total)
+	if report.Interrupted {
+		fmt.Fprintf(c.progress, "Interrupted: %d pages not processed\n", total-len(report.Pages))
+		fmt.Fprintln(c.progress, "Re-run the same command to resume; published pages are updated in place")
+		fmt.Fprintln(c.progress, "(add --state-file to skip looking them up again)")
+		return report, fmt.Errorf("interrupted after %d of %d pages: %w", len(report.Pages), total, ctx.Err())
+	}
+	// Confluence lists new pages last; keep the tree in spec order
+	if c.opts.OrderPages && parentPageID != "" {
+		if err := c.orderPages(ctx, parentPageID, report); err != nil {
+			return report, fmt.Errorf("failed to order pages: %w", err)
+		}
+	}
+	// Endpoints removed from the spec leave their pages behind
+	if c.opts.Prune != "" && parentPageID != "" {
+		pruned, err := c.prune(ctx, spec, parentPageID, c.opts.DryRun)
+		report.Pruned = pruned
+		if err != nil {
+			return report, fmt.Errorf("failed to prune stale pages: %w", err)
+		}
+		if len(pruned) > 0 && c.opts.DryRun {
+			fmt.Fprintf(c.progress, "%d stale pages would be pruned\n", len(pruned))
+		} else if len(pruned) > 0 {
+			fmt.Fprintf(c.progress, "Pruned %d stale pages\n", len(pruned))
+		}
+	}
+	// Keep this spec for the next run's changelog once its pages are written
+	if c.opts.Changelog && publishShared {
+		if err := c.saveSpecSnapshot(ctx, parentPageID, spec); err != nil {
+			return report, err
+		}
+	}
+	if finalizer, ok := c.client.(Finalizer); ok {
+		if err := finalizer.Finalize(ctx); err != nil {
+			return report, fmt.Errorf("failed to finalize output: %w", err)
+		}
+	}
+	if runner != nil {
+		verified, failing, skipped := report.SmokeCounts()
+		fmt.Fprintf(c.progress, "Example requests: %d verified, %d failing, %d skipped\n",
+			verified, failing, skipped)
+	}
 
+	// Export the published tree for offline sign-off
+	if c.opts.PDF.Output != "" || c.opts.PDF.Attach {
+		if err := c.exportPDF(ctx, report); err != nil {
+			return report, err
+		}
+	}
+
+	// Open an issue once the affected pages exist and can be linked
+	if breaking := diff.Breaking(report.Changes); len(breaking) > 0 && c.opts.Issues != nil {
+		if err := c.openBreakingChangeIssue(ctx, report, breaking); err != nil {
+			return report, err
+		}
+	}
+
+	// Pages that failed under ContinueOnError fail the run once the rest
+	// are published
+	if failed := report.Failed(); len(failed) > 0 {
+		printFailures(c.progress, failed)
+		return report, fmt.Errorf("%d of %d pages failed", len(failed), total)
+	}
+
+	return report, nil
+}
+
+// publishPage publishes a page other than an endpoint page, after the
+// pre-publish hooks
+func (c *Converter) publishPage(ctx context.Context, title, content, parentPageID string) (string, error) {
+	content, err := c.hooks.runPrePublish(Page{Title: title}, content)
+	if err != nil {
+		return "", err
+	}
+	return c.client.CreateOrUpdatePage(ctx, title, content, parentPageID)
+}
+
+// renderer returns the configured Renderer, or formatter when none is set
+func (c *Converter) renderer(formatter *confluence.Formatter) Renderer {
+	if c.opts.Renderer != nil {
+		return c.opts.Renderer
+	}
+	return formatter
+}
+
+// pageFailed records a page that could not be published. It returns err
+// to abort the run, or nil to carry on with the next page when
+// ContinueOnError is set.
+func (c *Converter) pageFailed(report *Report, result PageResult, err error) error {
+	result.Error = err.Error()
+	report.Pages = append(report.Pages, result)
+	if !c.opts.ContinueOnError {
+		return err
+	}
+	fmt.Fprintf(c.progress, "Failed: %v\n", err)
 	return nil
 }
 
-func (c *Converter) processEndpoint(ctx context.Context, resolver *swagger.Resolver, endpoint swagger.EndpointInfo, parentPageID string) error {
-	// Generate Confluence markup
-	content := c.formatter.FormatEndpointPage(endpoint.Path, endpoint.Method, endpoint.Operation, resolver)
+func (c *Converter) processEndpoint(ctx context.Context, page renderedPage, renderer Renderer, resolver *swagger.Resolver, parentPageID, banner string) (string, error) {
+	endpoint := page.endpoint
+
+	// Publishers with native rendering skip the storage format entirely
+	if publisher, ok := c.client.(EndpointPublisher); ok {
+		pageID, err := publisher.PublishEndpoint(ctx, endpoint, resolver, parentPageID)
+		if err != nil {
+			return "", fmt.Errorf("failed to publish endpoint: %w", err)
+		}
+		return pageID, nil
+	}
+
+	// The Confluence markup was rendered ahead of publishing
+	content, err := page.content, page.err
+	if err != nil {
+		return "", err
+	}
+	if banner != "" {
+		content = insertBanner(renderer, content, banner)
+	}
+	if content, err = c.hooks.runPrePublish(Page{Title: endpoint.Title, Endpoint: &endpoint}, content); err != nil {
+		return "", err
+	}
+	// Without a publisher the page is only rendered
+	if c.client == nil {
+		return "", nil
+	}
 
 	// Create/update page
-	_, err := c.client.CreateOrUpdatePage(ctx, endpoint.Title, content, parentPageID)
+	var pageID string
+	if publisher, ok := c.client.(OperationPublisher); ok {
+		pageID, err = publisher.PublishOperationPage(ctx, operationKey(endpoint), endpoint.Title, content, parentPageID)
+	} else {
+		pageID, err = c.client.CreateOrUpdatePage(ctx, endpoint.Title, content, parentPageID)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create/update page: %w", err)
+	}
+
+	return pageID, nil
+}
+
+// operationKey identifies an endpoint across runs by its operationId, or by
+// method and path when it has none
+func operationKey(endpoint swagger.EndpointInfo) string {
+	if endpoint.Operation.OperationID != "" {
+		return endpoint.Operation.OperationID
+	}
+	return strings.ToUpper(endpoint.Method) + " " + endpoint.Path
+}
+
+// labelByTags applies the operation's tags as page labels
+func (c *Converter) labelByTags(ctx context.Context, pageID string, tags []string) error {
+	labeler, ok := c.client.(Labeler)
+	if !ok || !c.opts.TagLabels || pageID == "" {
+		return nil
+	}
+	return labeler.AddLabels(ctx, pageID, confluence.TagLabels(tags))
+}
+
+// insertBanner places the banner of an endpoint page where the renderer
+// lays it out, or at the top of the page
+func insertBanner(renderer Renderer, content, banner string) string {
+	if r, ok := renderer.(BannerRenderer); ok {
+		return r.InsertBanner(content, banner)
+	}
+	return banner + content
+}
+
+// linkedVersions returns the labels of the versions cross-linked by this
+// run, starting with its own, or nil when versions aren't linked
+func (c *Converter) linkedVersions() []string {
+	label := c.opts.Versions.Label
+	if label == "" || len(c.opts.Versions.Linked) == 0 {
+		return nil
+	}
+	labels := []string{label}
+	for _, other := range c.opts.Versions.Linked {
+		if !slices.Contains(labels, other) {
+			labels = append(labels, other)
+		}
+	}
+	return labels
+}
+
+// findVersionLinks returns links from the page of the endpoint titled base
+// in version label to its pages in the other linked versions, skipping
+// versions where the page does not exist
+func (c *Converter) findVersionLinks(ctx context.Context, base, label string) ([]confluence.VersionLink, error) {
+	finder, canFind := c.client.(PageFinder)
+
+	var links []confluence.VersionLink
+	for _, other := range c.linkedVersions() {
+		if other == label {
+			continue
+		}
+		otherTitle := versionedTitle(base, other)
+		if canFind {
+			pageID, err := finder.FindPage(ctx, otherTitle)
+			if err != nil {
+				return nil, fmt.Errorf("failed to look up %q: %w", otherTitle, err)
+			}
+			if pageID == "" {
+				continue
+			}
+		}
+		links = append(links, confluence.VersionLink{Label: other, Title: otherTitle})
+	}
+	return links, nil
+}
+
+// versionLinks formats the links from the page of the endpoint titled base
+// in version label to the other linked versions, or "" when versions
+// aren't linked
+func (c *Converter) versionLinks(ctx context.Context, base, label string) (string, error) {
+	if c.linkedVersions() == nil {
+		return "", nil
+	}
+	links, err := c.findVersionLinks(ctx, base, label)
+	if err != nil {
+		return "", err
+	}
+	return c.formatter.FormatVersionLinks(links), nil
+}
+
+// linkOtherVersions updates the version links on the pages of the endpoint
+// titled base in the other linked versions, so pages published earlier
+// link to the one just published without being republished
+func (c *Converter) linkOtherVersions(ctx context.Context, base string) error {
+	editor, ok := c.client.(PageEditor)
+	labels := c.linkedVersions()
+	if !ok || labels == nil {
+		return nil
+	}
+
+	for _, other := range labels[1:] {
+		links, err := c.findVersionLinks(ctx, base, other)
+		if err != nil {
+			return err
+		}
+		title := versionedTitle(base, other)
+		err = editor.EditPage(ctx, title, func(content string) string {
+			return c.formatter.ReplaceVersionLinks(content, links)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update the version links of %q: %w", title, err)
+		}
+	}
+	return nil
+}
+
+// orderPages positions the published endpoint pages in the order they were
+// published, which is the order of the spec
+func (c *Converter) orderPages(ctx context.Context, parentPageID string, report *Report) error {
+	orderer, ok := c.client.(PageOrderer)
+	if !ok {
+		return nil
+	}
+	var pageIDs []string
+	for _, page := range report.Pages {
+		if page.PageID != "" && page.Error == "" {
+			pageIDs = append(pageIDs, page.PageID)
+		}
+	}
+	return orderer.OrderPages(ctx, parentPageID, pageIDs)
+}
+
+// endpointSummary formats the table of endpoints listed on the API page,
+// titled as the endpoint pages will be
+func (c *Converter) endpointSummary(spec *swagger.Spec) string {
+	var endpoints []confluence.EndpointSummary
+	for endpoint := range c.parser.Endpoints(spec) {
+		title := endpoint.Title
+		if label := c.opts.Versions.Label; label != "" {
+			title = versionedTitle(title, label)
+		}
+		endpoints = append(endpoints, confluence.EndpointSummary{
+			Title:   title,
+			Method:  endpoint.Method,
+			Path:    endpoint.Path,
+			Summary: endpoint.Operation.Summary,
+			Tags:    endpoint.Operation.Tags,
+		})
+	}
+	return c.formatter.FormatEndpointSummary(endpoints)
+}
+
+// exampleGenerator returns the generator of example bodies, producing
+// realistic data in faker mode and capped to the configured size
+func (c *Converter) exampleGenerator() *example.Generator {
+	gen := example.NewGenerator()
+	if c.opts.Examples.Faker {
+		gen = example.NewFakerGenerator()
+	}
+	return gen.WithLimits(c.opts.Examples.MaxDepth, c.opts.Examples.ArrayItems)
+}
+
+// titleWarnings reports the endpoint page titles that were shortened or
+// disambiguated
+func (c *Converter) titleWarnings(spec *swagger.Spec, report *Report) {
+	for _, warning := range c.parser.TitleWarnings(spec) {
+		fmt.Fprintf(c.progress, "Warning: %s\n", warning)
+		report.Warnings = append(report.Warnings, warning)
+	}
+}
+
+// versionedTitle returns the title of a page within a version subtree
+func versionedTitle(title, label string) string {
+	return fmt.Sprintf("%s (%s)", title, label)
+}
+
+// lint runs the documentation rules and records findings as report warnings
+func (c *Converter) lint(spec *swagger.Spec, report *Report) error {
+	linter, err := lint.New(c.opts.Lint)
 	if err != nil {
-		return fmt.Errorf("failed to create/update page: %w", err)
+		return fmt.Errorf("failed to configure lint: %w", err)
+	}
+
+	findings := linter.Run(spec)
+	fmt.Fprintf(c.progress, "Lint: %d findings\n", len(findings))
+	for _, finding := range findings {
+		fmt.Fprintf(c.progress, "  %s\n", finding)
+		report.Warnings = append(report.Warnings, finding.String())
 	}
+	fmt.Fprintln(c.progress)
 
+	if lint.Exceeds(findings, c.opts.Lint.FailOn) {
+		return fmt.Errorf("lint failed: findings at or above %s severity", c.opts.Lint.FailOn)
+	}
+	return nil
+}
+
+// enrichExamples applies sanitized payloads from the configured HAR files
+func (c *Converter) enrichExamples(spec *swagger.Spec) error {
+	enricher := har.NewEnricher(c.opts.Examples.Redact)
+	for _, path := range c.opts.Examples.HARFiles {
+		doc, err := har.Load(path)
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", path, err)
+		}
+		count := enricher.Apply(spec, doc.Log.Entries)
+		fmt.Fprintf(c.progress, "Applied %d recorded examples from %s\n", count, path)
+	}
+	fmt.Fprintln(c.progress)
 	return nil
-}
\ No newline at end of file
+}
+
+// compareBaseline records the changes from the baseline spec and warns about
+// breaking ones
+func (c *Converter) compareBaseline(ctx context.Context, spec *swagger.Spec, report *Report) error {
+	baseline, err := c.parser.Parse(ctx, c.opts.Baseline)
+	if err != nil {
+		return fmt.Errorf("failed to parse baseline spec: %w", err)
+	}
+
+	report.Changes = diff.Compare(baseline, spec)
+	breaking := diff.Breaking(report.Changes)
+	fmt.Fprintf(c.progress, "Changes since %s: %d (%d breaking)\n", baseline.Info.Version, len(report.Changes), len(breaking))
+	for _, change := range report.Changes {
+		fmt.Fprintf(c.progress, "  %s\n", change)
+	}
+	for _, change := range breaking {
+		report.Warnings = append(report.Warnings, "breaking change: "+change.Message)
+	}
+	fmt.Fprintln(c.progress)
+
+	return nil
+}
+
+// openBreakingChangeIssue files one issue summarizing the breaking changes
+// and linking the affected pages, or updates the one already open
+func (c *Converter) openBreakingChangeIssue(ctx context.Context, report *Report, breaking []diff.Change) error {
+	pageURLs := make(map[string]string, len(report.Pages))
+	for _, page := range report.Pages {
+		pageURLs[page.Method+" "+page.Path] = page.URL
+	}
+
+	var description strings.Builder
+	fmt.Fprintf(&description, "SwagFluence detected %d breaking change(s) in %s v%s.\n\n",
+		len(breaking), report.APITitle, report.APIVersion)
+	for _, change := range breaking {
+		if pageURL := pageURLs[change.Method+" "+change.Path]; pageURL != "" {
+			fmt.Fprintf(&description, "* %s ([documentation|%s])\n", change.Message, pageURL)
+		} else {
+			fmt.Fprintf(&description, "* %s\n", change.Message)
+		}
+	}
+	if report.ParentPageURL != "" {
+		fmt.Fprintf(&description, "\nAPI documentation: %s\n", report.ParentPageURL)
+	}
+
+	// Republishing the same version must not file the issue again, so an
+	// issue still open from an earlier run is updated instead
+	summary := fmt.Sprintf("Breaking API changes in %s v%s", report.APITitle, report.APIVersion)
+	key, err := c.opts.Issues.FindOpenIssue(ctx, summary)
+	if err != nil {
+		return fmt.Errorf("failed to look up breaking change issue: %w", err)
+	}
+	if key != "" {
+		if err := c.opts.Issues.UpdateIssue(ctx, key, description.String()); err != nil {
+			return fmt.Errorf("failed to update breaking change issue: %w", err)
+		}
+		fmt.Fprintf(c.progress, "✓ Updated Jira issue: %s\n", key)
+	} else {
+		key, err = c.opts.Issues.CreateIssue(ctx, summary, description.String())
+		if err != nil {
+			return fmt.Errorf("failed to open breaking change issue: %w", err)
+		}
+		fmt.Fprintf(c.progress, "✓ Created Jira issue: %s\n", key)
+	}
+
+	report.IssueKey = key
+	report.IssueURL = c.opts.Issues.IssueURL(key)
+	return nil
+}
+
+// exportCollection writes the configured request collection
+func (c *Converter) exportCollection(spec *swagger.Spec, endpoints []swagger.EndpointInfo, resolver *swagger.Resolver) error {
+	output := c.opts.Collection.Output
+	if output == "" {
+		output = collection.DefaultOutput(c.opts.Collection.Format)
+	}
+
+	// Webhooks are sent by the API, not requests clients can make
+	endpoints = slices.DeleteFunc(endpoints, func(endpoint swagger.EndpointInfo) bool {
+		return endpoint.Webhook
+	})
+	requests := collection.BuildRequests(endpoints, resolver, c.exampleGenerator())
+	if err := collection.Write(c.opts.Collection.Format, output, spec.Info.Title, requests); err != nil {
+		return fmt.Errorf("failed to export %s collection: %w", c.opts.Collection.Format, err)
+	}
+
+	fmt.Fprintf(c.progress, "Wrote %s collection: %s\n\n", c.opts.Collection.Format, output)
+	return nil
+}
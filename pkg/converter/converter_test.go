@@ -0,0 +1,290 @@
+package converter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/diff"
+)
+
+// fakeTracker records the issues opened and updated by a run
+type fakeTracker struct {
+	open    map[string]string
+	created []string
+	updated map[string]string
+}
+
+func (f *fakeTracker) CreateIssue(ctx context.Context, summary, description string) (string, error) {
+	f.created = append(f.created, summary)
+	return "API-100", nil
+}
+
+func (f *fakeTracker) FindOpenIssue(ctx context.Context, summary string) (string, error) {
+	return f.open[summary], nil
+}
+
+func (f *fakeTracker) UpdateIssue(ctx context.Context, key, description string) error {
+	f.updated[key] = description
+	return nil
+}
+
+func (f *fakeTracker) IssueURL(key string) string {
+	return "https://jira.example.com/browse/" + key
+}
+
+func TestConverter_BreakingChangeIssue(t *testing.T) {
+	const summary = "Breaking API changes in Pets v2"
+	breaking := []diff.Change{{Kind: "endpoint-removed", Breaking: true, Method: "DELETE", Path: "/pets/{id}", Message: "removed DELETE /pets/{id}"}}
+
+	tests := []struct {
+		name        string
+		open        map[string]string
+		wantKey     string
+		wantCreated int
+	}{
+		{"first run files an issue", nil, "API-100", 1},
+		{"rerun updates the open issue", map[string]string{summary: "API-7"}, "API-7", 0},
+		{"other versions are not reused", map[string]string{"Breaking API changes in Pets v1": "API-3"}, "API-100", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := &fakeTracker{open: tt.open, updated: map[string]string{}}
+			c := New(WithPublisher(newFakePublisher()), WithOptions(Options{Issues: tracker}))
+			report := &Report{APITitle: "Pets", APIVersion: "2"}
+
+			if err := c.openBreakingChangeIssue(context.Background(), report, breaking); err != nil {
+				t.Fatal(err)
+			}
+			if report.IssueKey != tt.wantKey || report.IssueURL != tracker.IssueURL(tt.wantKey) {
+				t.Errorf("issue = %s %s, want %s", report.IssueKey, report.IssueURL, tt.wantKey)
+			}
+			if len(tracker.created) != tt.wantCreated {
+				t.Errorf("created %v, want %d issue(s)", tracker.created, tt.wantCreated)
+			}
+			if tt.wantCreated == 0 && !strings.Contains(tracker.updated[tt.wantKey], "removed DELETE /pets/{id}") {
+				t.Errorf("updated = %v, want the description of %s replaced", tracker.updated, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestConverter_VersionLinks(t *testing.T) {
+	publisher := newFakePublisher()
+	publish := func(label, linked string) {
+		t.Helper()
+		c := New(
+			WithSpecSource(specFile(t, testSpec)),
+			WithPublisher(publisher),
+			WithProgress(io.Discard),
+			WithOptions(Options{Versions: VersionsConfig{Label: label, Linked: []string{linked}}}),
+		)
+		if _, err := c.Run(context.Background()); err != nil {
+			t.Fatalf("Run(%s) error = %v", label, err)
+		}
+	}
+	links := func(title string) []string {
+		var titles []string
+		for _, match := range regexp.MustCompile(`ri:content-title="([^"]*)"`).FindAllStringSubmatch(publisher.pages[title], -1) {
+			titles = append(titles, match[1])
+		}
+		return titles
+	}
+
+	publish("v1", "v2")
+	if got := links("Get Pet (v1)"); got != nil {
+		t.Errorf("v1 links before v2 is published = %v, want none", got)
+	}
+
+	publish("v2", "v1")
+	if got := links("Get Pet (v2)"); !slices.Equal(got, []string{"Get Pet (v1)"}) {
+		t.Errorf("v2 links = %v", got)
+	}
+	if got := links("Get Pet (v1)"); !slices.Equal(got, []string{"Get Pet (v2)"}) {
+		t.Errorf("v1 links after v2 is published = %v, want the v2 page", got)
+	}
+	if page := publisher.pages["Get Pet (v2)"]; !regexp.MustCompile(`</h2>\n<ac:structured-macro ac:name="anchor">`).MatchString(page) {
+		t.Errorf("version links not below the heading in:\n%s", page)
+	}
+}
+
+func TestConverter_ContinueOnError(t *testing.T) {
+	tests := []struct {
+		name            string
+		continueOnError bool
+		wantPublished   []string
+		wantTable       bool
+	}{
+		{"stops at the first failure", false, nil, false},
+		{"publishes the remaining pages", true, []string{"Get Pet"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := newFakePublisher()
+			publisher.fail = map[string]error{"List Pets": errors.New("permission denied")}
+			var progress strings.Builder
+			c := New(
+				WithSpecSource(specFile(t, testSpec)),
+				WithPublisher(publisher),
+				WithProgress(&progress),
+				WithOptions(Options{ContinueOnError: tt.continueOnError}),
+			)
+
+			reports, err := c.Run(context.Background())
+			if err == nil {
+				t.Fatal("Run() error = nil, want the failed page to fail the run")
+			}
+			if _, ok := publisher.pages["Get Pet"]; ok != (tt.wantPublished != nil) {
+				t.Errorf("Get Pet published = %v, want %v", ok, tt.wantPublished != nil)
+			}
+
+			report := reports[0]
+			failed := report.Failed()
+			if len(failed) != 1 || failed[0].Title != "List Pets" || !strings.Contains(failed[0].Error, "permission denied") {
+				t.Errorf("Failed() = %+v, want List Pets", failed)
+			}
+			if report.Succeeded() != len(tt.wantPublished) {
+				t.Errorf("Succeeded() = %d, want %d", report.Succeeded(), len(tt.wantPublished))
+			}
+
+			table := regexp.MustCompile(`1 pages failed:\nPAGE +ENDPOINT +ERROR\nList Pets +GET /pets +.*permission denied`)
+			if table.MatchString(progress.String()) != tt.wantTable {
+				t.Errorf("failure table printed = %v, want %v in:\n%s", !tt.wantTable, tt.wantTable, progress.String())
+			}
+			if tt.continueOnError && !strings.HasSuffix(err.Error(), ": 1 of 2 pages failed") {
+				t.Errorf("Run() error = %v", err)
+			}
+		})
+	}
+}
+
+// cancelingPublisher cancels the run once the page titled after is
+// published
+type cancelingPublisher struct {
+	*fakePublisher
+	after  string
+	cancel context.CancelFunc
+}
+
+func (p *cancelingPublisher) CreateOrUpdatePage(ctx context.Context, title, content, parentPageID string) (string, error) {
+	if title == p.after {
+		p.cancel()
+	}
+	return p.fakePublisher.CreateOrUpdatePage(ctx, title, content, parentPageID)
+}
+
+func TestConverter_Interrupted(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	publisher := &cancelingPublisher{fakePublisher: newFakePublisher(), after: "List Pets", cancel: cancel}
+	var progress strings.Builder
+	c := New(
+		WithSpecSource(specFile(t, testSpec)),
+		WithPublisher(publisher),
+		WithProgress(&progress),
+		WithOptions(Options{Smoke: SmokeConfig{BaseURL: server.URL, Params: map[string]string{"id": "1"}}}),
+	)
+
+	reports, err := c.Run(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() error = %v, want it to wrap context.Canceled", err)
+	}
+	report := reports[0]
+	if !report.Interrupted || len(report.Pages) != 1 || report.Pages[0].Title != "List Pets" {
+		t.Errorf("report = interrupted %v, pages %+v; want List Pets only", report.Interrupted, report.Pages)
+	}
+	if _, ok := publisher.pages["Get Pet"]; ok {
+		t.Error("Get Pet published after the run was interrupted")
+	}
+	if !strings.Contains(progress.String(), "Summary: 1/2 pages processed successfully\nInterrupted: 1 pages not processed") {
+		t.Errorf("partial summary missing from:\n%s", progress.String())
+	}
+	// Example requests are built per page, so none is sent for pages
+	// never reached
+	if !slices.Equal(requests, []string{"GET /pets"}) {
+		t.Errorf("example requests = %v, want only the published page's", requests)
+	}
+}
+
+func TestConverter_NoPublisher(t *testing.T) {
+	hooks := &Hooks{}
+	var rendered []string
+	hooks.PrePublish(func(page Page, content string) (string, error) {
+		rendered = append(rendered, page.Title)
+		return content, nil
+	})
+	c := New(WithSpecSource(specFile(t, testSpec)), WithHooks(hooks), WithProgress(io.Discard))
+
+	reports, err := c.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !slices.Equal(rendered, []string{"List Pets", "Get Pet"}) {
+		t.Errorf("rendered pages = %v, want both endpoints", rendered)
+	}
+	report := reports[0]
+	if report.Succeeded() != 2 || report.ParentPageURL != "" {
+		t.Errorf("report = %d pages, parent URL %q; want 2 unpublished pages", report.Succeeded(), report.ParentPageURL)
+	}
+	for _, page := range report.Pages {
+		if page.PageID != "" || page.URL != "" {
+			t.Errorf("page %s = ID %q, URL %q; want none", page.Title, page.PageID, page.URL)
+		}
+	}
+}
+
+func TestConverter_SmokeSummary(t *testing.T) {
+	const spec = `{
+		"openapi": "3.1.0",
+		"info": {"title": "Pets", "version": "1.0.0"},
+		"paths": {
+			"/pets": {
+				"get": {"summary": "List Pets", "responses": {"200": {"description": "OK"}}},
+				"post": {"summary": "Create Pet", "responses": {"201": {"description": "Created"}}}
+			},
+			"/broken": {"get": {"summary": "Broken", "responses": {"200": {"description": "OK"}}}}
+		},
+		"webhooks": {
+			"newPet": {"post": {"summary": "New Pet", "responses": {"200": {"description": "OK"}}}}
+		}
+	}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	var progress strings.Builder
+	c := New(
+		WithSpecSource(specFile(t, spec)),
+		WithPublisher(newFakePublisher()),
+		WithProgress(&progress),
+		WithOptions(Options{Smoke: SmokeConfig{BaseURL: server.URL}}),
+	)
+	reports, err := c.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if verified, failing, skipped := reports[0].SmokeCounts(); verified != 1 || failing != 1 || skipped != 1 {
+		t.Errorf("SmokeCounts() = %d, %d, %d; want 1 verified, 1 failing, 1 skipped", verified, failing, skipped)
+	}
+	if !strings.Contains(progress.String(), "Example requests: 1 verified, 1 failing, 1 skipped\n") {
+		t.Errorf("example request summary missing from:\n%s", progress.String())
+	}
+}
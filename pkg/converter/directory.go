@@ -0,0 +1,75 @@
+package converter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+)
+
+// PublishDirectory creates or refreshes a landing page listing every API
+// published into the space. Publishers that can store page properties keep
+// the entries of APIs published by earlier runs.
+func (c *Converter) PublishDirectory(ctx context.Context, title string, reports []*Report) error {
+	if c.client == nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	var updates []confluence.DirectoryEntry
+	for _, report := range reports {
+		if report == nil || report.APITitle == "" {
+			continue
+		}
+		updates = append(updates, confluence.DirectoryEntry{
+			Title:     report.APITitle,
+			Version:   report.APIVersion,
+			Endpoints: len(report.Pages),
+			SyncedAt:  now,
+			URL:       report.ParentPageURL,
+		})
+	}
+
+	store, canStore := c.client.(PropertyStore)
+	finder, canFind := c.client.(PageFinder)
+
+	var existing []confluence.DirectoryEntry
+	propertyVersion := 0
+	if canStore && canFind {
+		pageID, err := finder.FindPage(ctx, title)
+		if err != nil {
+			return fmt.Errorf("failed to look up directory page: %w", err)
+		}
+		propertyVersion, err = store.GetPageProperty(ctx, pageID, confluence.DirectoryProperty, &existing)
+		if err != nil {
+			return fmt.Errorf("failed to read directory entries: %w", err)
+		}
+	}
+	entries := confluence.MergeDirectory(existing, updates)
+
+	// The directory spans APIs, so it isn't tied to any one API version
+	if recorder, ok := c.client.(VersionRecorder); ok {
+		recorder.SetAPIVersion("")
+	}
+
+	rootID, err := c.client.ResolveParentPage(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to resolve parent page: %w", err)
+	}
+
+	content := c.formatter.FormatDirectoryPage(title, entries)
+	pageID, err := c.publishPage(ctx, title, content, rootID)
+	if err != nil {
+		return fmt.Errorf("failed to publish directory page: %w", err)
+	}
+
+	if canStore {
+		if err := store.SetPageProperty(ctx, pageID, confluence.DirectoryProperty, entries, propertyVersion); err != nil {
+			return fmt.Errorf("failed to store directory entries: %w", err)
+		}
+	}
+
+	fmt.Fprintf(c.progress, "Directory page %q lists %d APIs\n", title, len(entries))
+	return nil
+}
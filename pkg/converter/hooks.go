@@ -0,0 +1,162 @@
+package converter
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/pkg/openapi"
+)
+
+// Redacted replaces the text matched by the configured redact patterns
+const Redacted = "[REDACTED]"
+
+// HooksConfig configures the built-in hooks created by NewHooks
+type HooksConfig = config.HooksConfig
+
+// markupPattern matches the CDATA sections and tags of storage markup
+var markupPattern = regexp.MustCompile(`(?s)<!\[CDATA\[(.*?)\]\]>|<[^>]*>`)
+
+// Page describes the page a content hook is called for
+type Page struct {
+	Title string
+	// Endpoint is the endpoint documented by an endpoint page, nil for the
+	// other pages
+	Endpoint *openapi.EndpointInfo
+}
+
+// RenderHook is called before an endpoint page is rendered and may change
+// the endpoint, e.g. its title or description
+type RenderHook func(endpoint *openapi.EndpointInfo) error
+
+// ContentHook returns the storage format content of a page, changed
+type ContentHook func(page Page, content string) (string, error)
+
+// Hooks is a registry of functions that change pages as they pass through
+// a run. Pre-render hooks see each endpoint before its page is rendered,
+// post-render hooks see the rendered endpoint pages, and pre-publish hooks
+// see every page the converter publishes, right before it is sent. Hooks
+// run in registration order. With WithConcurrency, render hooks may run on
+// several goroutines at once.
+type Hooks struct {
+	preRender  []RenderHook
+	postRender []ContentHook
+	prePublish []ContentHook
+}
+
+// NewHooks creates the hooks configured under hooks: a banner above and a
+// footer below every page, and text redacted from every page
+func NewHooks(cfg HooksConfig) (*Hooks, error) {
+	hooks := &Hooks{}
+
+	for _, pattern := range cfg.Redact {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+		}
+		hooks.PrePublish(func(page Page, content string) (string, error) {
+			return redactText(re, content), nil
+		})
+	}
+	if cfg.Banner != "" {
+		banner := fmt.Sprintf("<ac:structured-macro ac:name=\"note\">\n<ac:rich-text-body>\n<p>%s</p>\n"+
+			"</ac:rich-text-body>\n</ac:structured-macro>\n", html.EscapeString(cfg.Banner))
+		hooks.PrePublish(func(page Page, content string) (string, error) {
+			return banner + content, nil
+		})
+	}
+	if cfg.Footer != "" {
+		footer := fmt.Sprintf("\n<p><em>%s</em></p>", html.EscapeString(cfg.Footer))
+		hooks.PrePublish(func(page Page, content string) (string, error) {
+			return content + footer, nil
+		})
+	}
+	return hooks, nil
+}
+
+// PreRender registers a hook called before each endpoint page is rendered
+func (h *Hooks) PreRender(hook RenderHook) {
+	h.preRender = append(h.preRender, hook)
+}
+
+// PostRender registers a hook called with each rendered endpoint page
+func (h *Hooks) PostRender(hook ContentHook) {
+	h.postRender = append(h.postRender, hook)
+}
+
+// PrePublish registers a hook called with every page before it is published
+func (h *Hooks) PrePublish(hook ContentHook) {
+	h.prePublish = append(h.prePublish, hook)
+}
+
+// runPreRender runs the pre-render hooks on endpoint
+func (h *Hooks) runPreRender(endpoint *openapi.EndpointInfo) error {
+	if h == nil {
+		return nil
+	}
+	for _, hook := range h.preRender {
+		if err := hook(endpoint); err != nil {
+			return fmt.Errorf("pre-render hook failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// runPostRender runs the post-render hooks on the content of page
+func (h *Hooks) runPostRender(page Page, content string) (string, error) {
+	if h == nil {
+		return content, nil
+	}
+	return runContentHooks(h.postRender, "post-render", page, content)
+}
+
+// runPrePublish runs the pre-publish hooks on the content of page
+func (h *Hooks) runPrePublish(page Page, content string) (string, error) {
+	if h == nil {
+		return content, nil
+	}
+	return runContentHooks(h.prePublish, "pre-publish", page, content)
+}
+
+// redactText replaces the matches of re in the text of storage markup: the
+// text between tags, unescaped while matching, and the content of CDATA
+// sections such as code macros. Tags, macro names and attributes are left
+// alone, so a pattern can't break the markup.
+func redactText(re *regexp.Regexp, content string) string {
+	var sb strings.Builder
+	redact := func(text string) {
+		unescaped := html.UnescapeString(text)
+		if !re.MatchString(unescaped) {
+			sb.WriteString(text)
+			return
+		}
+		sb.WriteString(html.EscapeString(re.ReplaceAllLiteralString(unescaped, Redacted)))
+	}
+
+	last := 0
+	for _, loc := range markupPattern.FindAllStringSubmatchIndex(content, -1) {
+		redact(content[last:loc[0]])
+		if loc[2] >= 0 {
+			sb.WriteString("<![CDATA[")
+			sb.WriteString(re.ReplaceAllLiteralString(content[loc[2]:loc[3]], Redacted))
+			sb.WriteString("]]>")
+		} else {
+			sb.WriteString(content[loc[0]:loc[1]])
+		}
+		last = loc[1]
+	}
+	redact(content[last:])
+	return sb.String()
+}
+
+func runContentHooks(hooks []ContentHook, stage string, page Page, content string) (string, error) {
+	for _, hook := range hooks {
+		var err error
+		if content, err = hook(page, content); err != nil {
+			return "", fmt.Errorf("%s hook failed for %q: %w", stage, page.Title, err)
+		}
+	}
+	return content, nil
+}
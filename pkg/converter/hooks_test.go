@@ -0,0 +1,98 @@
+package converter_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/pkg/converter"
+	"github.com/ahmadimt/SwagFluence/pkg/openapi"
+)
+
+func TestHooks_Order(t *testing.T) {
+	var calls []string
+	hooks := &converter.Hooks{}
+	hooks.PreRender(func(endpoint *openapi.EndpointInfo) error {
+		calls = append(calls, "pre-render "+endpoint.Title)
+		endpoint.Title = "Fetch Pet"
+		return nil
+	})
+	hooks.PostRender(func(page converter.Page, content string) (string, error) {
+		calls = append(calls, "post-render "+page.Title)
+		return content + "<p>rendered</p>", nil
+	})
+	for _, name := range []string{"first", "second"} {
+		hooks.PrePublish(func(page converter.Page, content string) (string, error) {
+			if page.Endpoint != nil {
+				calls = append(calls, "pre-publish "+name)
+			}
+			return content + "<p>" + name + "</p>", nil
+		})
+	}
+
+	publisher := &pagePublisher{}
+	conv := converter.New(converter.WithSpecSource(writeSpec(t, petSpec)), converter.WithPublisher(publisher),
+		converter.WithHooks(hooks))
+	if _, err := conv.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := "pre-render Get Pet, post-render Fetch Pet, pre-publish first, pre-publish second"
+	if got := strings.Join(calls, ", "); got != want {
+		t.Errorf("hook calls = %s, want %s", got, want)
+	}
+	page, ok := publisher.pages["Fetch Pet"]
+	if !ok || !strings.HasSuffix(page, "<p>rendered</p><p>first</p><p>second</p>") {
+		t.Errorf("expected the retitled page changed by every hook in order, got %v", keys(publisher.pages))
+	}
+}
+
+func TestNewHooks(t *testing.T) {
+	hooks, err := converter.NewHooks(converter.HooksConfig{
+		Banner: "Internal & confidential",
+		Footer: "Generated from openapi.yaml",
+		Redact: []string{`sk_live_\w+`, `code`},
+	})
+	if err != nil {
+		t.Fatalf("NewHooks() error = %v", err)
+	}
+
+	publisher := &pagePublisher{}
+	conv := converter.New(converter.WithSpecSource(writeSpec(t, petSpec)), converter.WithPublisher(publisher),
+		converter.WithRenderer(keyRenderer{}), converter.WithHooks(hooks))
+	if _, err := conv.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	page := publisher.pages["Get Pet"]
+	if !strings.HasPrefix(page, `<ac:structured-macro ac:name="note">`) || !strings.Contains(page, "<p>Internal &amp; confidential</p>") {
+		t.Errorf("expected the escaped banner first, got %q", page)
+	}
+	if !strings.HasSuffix(page, "<p><em>Generated from openapi.yaml</em></p>") {
+		t.Errorf("expected the footer last, got %q", page)
+	}
+	if strings.Contains(page, "sk_live_") || strings.Count(page, converter.Redacted) != 3 {
+		t.Errorf("expected the key redacted in text and code, and the word code in text, got %q", page)
+	}
+	// Macro names are markup, not text, so the code macro survives
+	if !strings.Contains(page, `<ac:structured-macro ac:name="code"><ac:plain-text-body><![CDATA[curl`) {
+		t.Errorf("expected macro names left alone, got %q", page)
+	}
+}
+
+func TestHooks_InvalidRedactPattern(t *testing.T) {
+	if _, err := converter.NewHooks(converter.HooksConfig{Redact: []string{"("}}); err == nil {
+		t.Error("NewHooks() with an invalid pattern succeeded, want an error")
+	}
+}
+
+// keyRenderer renders endpoint pages that leak an API key in text and in a
+// code macro
+type keyRenderer struct {
+	plainRenderer
+}
+
+func (keyRenderer) FormatEndpointPage(path, method string, op openapi.Operation, resolver *openapi.Resolver) (string, error) {
+	return "<p>Use key sk_live_abc123, see code</p>\n" +
+		`<ac:structured-macro ac:name="code"><ac:plain-text-body><![CDATA[curl -H "Key: sk_live_abc123"]]></ac:plain-text-body></ac:structured-macro>`, nil
+}
@@ -0,0 +1,100 @@
+package converter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// publishModels publishes one page per component schema under a models page,
+// for endpoint pages to include. Each model page links the models it refers
+// to and the endpoint pages that use it.
+func (c *Converter) publishModels(ctx context.Context, formatter *confluence.Formatter, renderer Renderer, scope, parentPageID string, spec *swagger.Spec, resolver *swagger.Resolver) error {
+	refs := spec.SchemaRefs()
+	if len(refs) == 0 {
+		return nil
+	}
+
+	modelsPageID, err := c.publishPage(ctx, confluence.ModelsPageTitle(scope),
+		formatter.FormatModelsPage(scope), parentPageID)
+	if err != nil {
+		return fmt.Errorf("failed to create models page: %w", err)
+	}
+
+	usedBy := make(map[string][]string)
+	for endpoint := range c.parser.Endpoints(spec) {
+		title := endpoint.Title
+		if label := c.opts.Versions.Label; label != "" {
+			title = versionedTitle(title, label)
+		}
+		for _, ref := range operationRefs(endpoint.Operation) {
+			usedBy[ref] = append(usedBy[ref], title)
+		}
+	}
+
+	for _, ref := range refs {
+		schema, err := resolver.ResolveSchema(&swagger.Schema{Ref: ref})
+		if err != nil {
+			return fmt.Errorf("failed to resolve model %s: %w", ref, err)
+		}
+
+		name := swagger.ExtractRefName(ref)
+		content := renderer.FormatModelPage(name, schema, usedBy[ref])
+		if _, err := c.publishPage(ctx, confluence.ModelPageTitle(scope, name), content, modelsPageID); err != nil {
+			return fmt.Errorf("failed to publish model %s: %w", name, err)
+		}
+	}
+
+	fmt.Fprintf(c.progress, "Published %d model pages\n\n", len(refs))
+	return nil
+}
+
+// operationRefs returns the component schemas an operation's parameters,
+// request body and responses refer to directly or as array items, each once
+func operationRefs(op swagger.Operation) []string {
+	var refs []string
+	seen := make(map[string]bool)
+	add := func(schema *swagger.Schema) {
+		if schema == nil {
+			return
+		}
+		ref := schema.Ref
+		if ref == "" && schema.Items != nil {
+			ref = schema.Items.Ref
+		}
+		if ref != "" && !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	for _, param := range op.Parameters {
+		add(param.Schema)
+	}
+	if op.RequestBody != nil {
+		for _, contentType := range sortedContentTypes(op.RequestBody.Content) {
+			add(op.RequestBody.Content[contentType].Schema)
+		}
+	}
+	for _, code := range op.ResponseCodes() {
+		response := op.Responses[code]
+		add(response.Schema)
+		for _, contentType := range sortedContentTypes(response.Content) {
+			add(response.Content[contentType].Schema)
+		}
+	}
+	return refs
+}
+
+// sortedContentTypes returns the content types of a body in sorted order
+func sortedContentTypes(content map[string]swagger.MediaType) []string {
+	types := make([]string, 0, len(content))
+	for contentType := range content {
+		types = append(types, contentType)
+	}
+	sort.Strings(types)
+	return types
+}
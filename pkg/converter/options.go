@@ -0,0 +1,97 @@
+package converter
+
+import (
+	"io"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/pkg/openapi"
+)
+
+// Settings of the optional features in Options, as read from the config
+// file
+type (
+	CollectionConfig = config.CollectionConfig
+	LintConfig       = config.LintConfig
+	PDFConfig        = config.PDFConfig
+	ExamplesConfig   = config.ExamplesConfig
+	SmokeConfig      = config.SmokeConfig
+	VersionsConfig   = config.VersionsConfig
+	TLSConfig        = config.TLSConfig
+)
+
+// Formatter renders pages in Confluence storage format with the default
+// layout
+type Formatter = confluence.Formatter
+
+// NewFormatter creates a Formatter using the default page layout
+func NewFormatter() *Formatter {
+	return confluence.NewFormatter()
+}
+
+// Option configures a Converter created with New
+type Option func(*Converter)
+
+// WithSpecSource adds a spec converted by Run: an http(s) URL, a local file
+// path, or - for stdin. Repeat it to convert several specs in order.
+func WithSpecSource(source string) Option {
+	return func(c *Converter) {
+		c.sources = append(c.sources, source)
+	}
+}
+
+// WithPublisher sets the backend the pages are published to. Without one,
+// pages are rendered but not published.
+func WithPublisher(publisher Publisher) Option {
+	return func(c *Converter) {
+		c.client = publisher
+	}
+}
+
+// WithConcurrency renders up to n endpoint pages in parallel ahead of the
+// page being published. Pages are still published one at a time, in spec
+// order; n below 2 renders each page when it is published.
+func WithConcurrency(n int) Option {
+	return func(c *Converter) {
+		c.concurrency = n
+	}
+}
+
+// WithRenderer sets the Renderer of the API, endpoint and model pages
+func WithRenderer(renderer Renderer) Option {
+	return func(c *Converter) {
+		c.opts.Renderer = renderer
+	}
+}
+
+// WithHooks sets the hooks changing pages as they are rendered and
+// published
+func WithHooks(hooks *Hooks) Option {
+	return func(c *Converter) {
+		c.hooks = hooks
+	}
+}
+
+// WithParser sets the parser reading the specs; the default parser uses
+// the default spec settings
+func WithParser(parser *openapi.Parser) Option {
+	return func(c *Converter) {
+		c.parser = parser
+	}
+}
+
+// WithProgress writes the progress messages of runs to w instead of
+// standard output
+func WithProgress(w io.Writer) Option {
+	return func(c *Converter) {
+		c.progress = w
+	}
+}
+
+// WithOptions sets the optional behavior of conversion runs. It replaces
+// the Renderer set by an earlier WithRenderer.
+func WithOptions(opts Options) Option {
+	return func(c *Converter) {
+		c.opts = opts
+	}
+}
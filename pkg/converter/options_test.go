@@ -0,0 +1,94 @@
+package converter_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/pkg/converter"
+	"github.com/ahmadimt/SwagFluence/pkg/openapi"
+)
+
+func TestNew_Options(t *testing.T) {
+	publisher := &pagePublisher{}
+	conv := converter.New(
+		converter.WithParser(openapi.NewParser()),
+		converter.WithSpecSource(writeSpec(t, petSpec)),
+		converter.WithPublisher(publisher),
+		converter.WithConcurrency(2),
+		converter.WithOptions(converter.Options{
+			Formatter: converter.NewFormatter(),
+			Versions:  converter.VersionsConfig{Label: "v1"},
+			Examples:  converter.ExamplesConfig{ArrayItems: 2},
+		}),
+	)
+
+	reports, err := conv.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(reports) != 1 || reports[0].APITitle != "Pets" || reports[0].Succeeded() != 1 {
+		t.Fatalf("reports = %+v, want one successful page of Pets", reports)
+	}
+
+	if _, ok := publisher.pages["Pets v1"]; !ok {
+		t.Errorf("expected the version page, got %v", keys(publisher.pages))
+	}
+	if page := publisher.pages["Get Pet (v1)"]; !strings.Contains(page, "/pets/{id}") {
+		t.Errorf("expected the versioned endpoint page, got %v", keys(publisher.pages))
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	// Standard output must stay clean for publishers that write pages to it
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	var progress bytes.Buffer
+	conv := converter.New(
+		converter.WithSpecSource(writeSpec(t, petSpec)),
+		converter.WithPublisher(&pagePublisher{}),
+		converter.WithProgress(&progress),
+	)
+	_, runErr := conv.Run(context.Background())
+	w.Close()
+	os.Stdout = stdout
+	written, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if runErr != nil {
+		t.Fatalf("Run() error = %v", runErr)
+	}
+	if len(written) > 0 {
+		t.Errorf("wrote to standard output:\n%s", written)
+	}
+	for _, want := range []string{"Found 1 endpoints", "Summary: 1/1 pages processed successfully"} {
+		if !strings.Contains(progress.String(), want) {
+			t.Errorf("expected %q in progress:\n%s", want, progress.String())
+		}
+	}
+}
+
+func TestConverter_RunWithoutSources(t *testing.T) {
+	if _, err := converter.New().Run(context.Background()); err == nil {
+		t.Error("Run() without a spec source succeeded, want an error")
+	}
+}
+
+func keys(pages map[string]string) []string {
+	var titles []string
+	for title := range pages {
+		titles = append(titles, title)
+	}
+	return titles
+}
@@ -0,0 +1,78 @@
+package converter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/internal/docfile"
+)
+
+// pdfContentType is the MIME type of exported documents
+const pdfContentType = "application/pdf"
+
+// exportPDF exports the parent page and every published endpoint page,
+// writing the files to the output directory and optionally attaching them
+// to the parent page
+func (c *Converter) exportPDF(ctx context.Context, report *Report) error {
+	exporter, ok := c.client.(PDFExporter)
+	if !ok {
+		return fmt.Errorf("publisher does not support PDF export")
+	}
+
+	var uploader AttachmentUploader
+	if c.opts.PDF.Attach {
+		if uploader, ok = c.client.(AttachmentUploader); !ok {
+			return fmt.Errorf("publisher does not support attachments")
+		}
+	}
+
+	type exportPage struct {
+		id    string
+		title string
+	}
+	pages := []exportPage{{id: report.ParentPageID, title: confluence.APIPageTitle(report.APITitle)}}
+	for _, page := range report.Pages {
+		pages = append(pages, exportPage{id: page.PageID, title: page.Title})
+	}
+
+	if c.opts.PDF.Output != "" {
+		if err := os.MkdirAll(c.opts.PDF.Output, 0o755); err != nil {
+			return fmt.Errorf("failed to create PDF directory: %w", err)
+		}
+	}
+
+	for _, page := range pages {
+		if page.id == "" {
+			continue
+		}
+
+		data, err := exporter.ExportPDF(ctx, page.id)
+		if err != nil {
+			return fmt.Errorf("failed to export %q to PDF: %w", page.title, err)
+		}
+		if data == nil {
+			continue
+		}
+
+		fileName := docfile.FileName(page.title, "page") + ".pdf"
+		if c.opts.PDF.Output != "" {
+			path := filepath.Join(c.opts.PDF.Output, fileName)
+			if err := os.WriteFile(path, data, 0o644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+			report.PDFFiles = append(report.PDFFiles, path)
+			fmt.Fprintf(c.progress, "✓ Exported PDF: %s\n", path)
+		}
+
+		if uploader != nil && report.ParentPageID != "" {
+			if err := uploader.UploadAttachment(ctx, report.ParentPageID, fileName, pdfContentType, data); err != nil {
+				return fmt.Errorf("failed to attach %s: %w", fileName, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package converter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/config"
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+)
+
+func TestConverter_ExportPDF(t *testing.T) {
+	attached := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/spaces/flyingpdf/pdfpageexport.action":
+			w.Write([]byte("%PDF-1.4 page " + r.URL.Query().Get("pageId")))
+		case r.Method == http.MethodPut && r.URL.Path == "/rest/api/content/100/child/attachment":
+			file, header, err := r.FormFile("file")
+			if err != nil {
+				t.Errorf("failed to read attachment: %v", err)
+				return
+			}
+			data, _ := io.ReadAll(file)
+			attached[header.Filename] = string(data)
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client, err := confluence.NewClient(config.ConfluenceConfig{BaseURL: server.URL, SpaceKey: "TEST", Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := t.TempDir()
+	c := New(WithPublisher(client.(Publisher)), WithProgress(io.Discard),
+		WithOptions(Options{PDF: PDFConfig{Output: output, Attach: true}}))
+	report := &Report{
+		APITitle:     "Pets/Store",
+		ParentPageID: "100",
+		Pages: []PageResult{
+			{Title: "Get Pet (v1)", PageID: "7"},
+			{Title: "Failed Pet", Error: "permission denied"},
+		},
+	}
+
+	if err := c.exportPDF(context.Background(), report); err != nil {
+		t.Fatalf("exportPDF() error = %v", err)
+	}
+
+	want := map[string]string{
+		"Pets_Store - API Documentation.pdf": "%PDF-1.4 page 100",
+		"Get Pet _v1_.pdf":                   "%PDF-1.4 page 7",
+	}
+	if !reflect.DeepEqual(attached, want) {
+		t.Errorf("attached = %v, want %v", attached, want)
+	}
+	if len(report.PDFFiles) != len(want) {
+		t.Errorf("PDFFiles = %v", report.PDFFiles)
+	}
+	for name, content := range want {
+		data, err := os.ReadFile(filepath.Join(output, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != content {
+			t.Errorf("%s = %q, want %q", name, data, content)
+		}
+	}
+}
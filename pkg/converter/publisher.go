@@ -0,0 +1,171 @@
+package converter
+
+import (
+	"context"
+
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/internal/example"
+	"github.com/ahmadimt/SwagFluence/pkg/openapi"
+)
+
+// Publisher delivers rendered pages to a documentation backend. The
+// Confluence client is the default implementation; other wikis plug in
+// by implementing the same methods.
+type Publisher interface {
+	// ResolveParentPage returns the configured root page ID, if any
+	ResolveParentPage(ctx context.Context) (string, error)
+	// CreateParentPage creates or updates the API overview page
+	CreateParentPage(ctx context.Context, apiTitle string) (string, error)
+	// CreateOrUpdatePage creates or updates a page under parentPageID
+	CreateOrUpdatePage(ctx context.Context, title, content, parentPageID string) (string, error)
+	// PageURL returns the browser URL of a published page, or "" if unknown
+	PageURL(pageID string) string
+}
+
+// EndpointPublisher is implemented by publishers that render endpoints
+// themselves (e.g. as native blocks) instead of accepting storage markup
+type EndpointPublisher interface {
+	PublishEndpoint(ctx context.Context, endpoint openapi.EndpointInfo, resolver *openapi.Resolver, parentPageID string) (string, error)
+}
+
+// OperationPublisher is implemented by publishers that track endpoint pages
+// by operation, so pages are updated in place when their title changes
+type OperationPublisher interface {
+	PublishOperationPage(ctx context.Context, operation, title, content, parentPageID string) (string, error)
+}
+
+// VersionRecorder is implemented by publishers that record which API
+// version pages were rendered from
+type VersionRecorder interface {
+	SetAPIVersion(version string)
+}
+
+// Finalizer is implemented by publishers that complete their output once
+// every page of a run has been published
+type Finalizer interface {
+	Finalize(ctx context.Context) error
+}
+
+// ParentPageSetter is implemented by publishers whose API page content can
+// be replaced by the Renderer's
+type ParentPageSetter interface {
+	SetParentPageContent(content string)
+}
+
+// PageOrderer is implemented by publishers that can position pages in the
+// page tree
+type PageOrderer interface {
+	// OrderPages positions the pages below parentPageID in the given order
+	OrderPages(ctx context.Context, parentPageID string, pageIDs []string) error
+}
+
+// ChangeReporter is implemented by publishers that report whether the last
+// page published was created, updated or left unchanged
+type ChangeReporter interface {
+	LastChange() string
+}
+
+// SpecRecorder is implemented by publishers that offer the source spec
+// document alongside the pages
+type SpecRecorder interface {
+	SetSpecDocument(name, format string, data []byte)
+}
+
+// ExampleGenerator generates the example bodies of endpoint pages
+type ExampleGenerator = example.Generator
+
+// ExampleSetter is implemented by publishers that render endpoint pages,
+// and so example bodies, themselves
+type ExampleSetter interface {
+	SetExampleGenerator(gen *ExampleGenerator)
+}
+
+// StateSaver is implemented by publishers that persist lookup state
+// between runs
+type StateSaver interface {
+	SaveState() error
+}
+
+// Labeler is implemented by publishers that can label published pages
+type Labeler interface {
+	AddLabels(ctx context.Context, pageID string, labels []string) error
+}
+
+// PageFinder is implemented by publishers that can look up a page by title
+type PageFinder interface {
+	// FindPage returns the ID of the page with the title, or "" if none exists
+	FindPage(ctx context.Context, title string) (string, error)
+}
+
+// PageEditor is implemented by publishers that can change part of a
+// published page without regenerating it
+type PageEditor interface {
+	// EditPage rewrites the content of the page with the title through
+	// edit, leaving missing pages alone
+	EditPage(ctx context.Context, title string, edit func(content string) string) error
+}
+
+// PropertyStore is implemented by publishers that can keep structured data
+// with a page
+type PropertyStore interface {
+	// GetPageProperty decodes a page property into value and returns its
+	// version, or 0 when the property does not exist
+	GetPageProperty(ctx context.Context, pageID, key string, value interface{}) (int, error)
+	// SetPageProperty stores value, replacing the given version
+	SetPageProperty(ctx context.Context, pageID, key string, value interface{}, version int) error
+}
+
+// PDFExporter is implemented by publishers that can render a published page
+// to PDF
+type PDFExporter interface {
+	ExportPDF(ctx context.Context, pageID string) ([]byte, error)
+}
+
+// AttachmentUploader is implemented by publishers that can attach files to
+// a published page
+type AttachmentUploader interface {
+	UploadAttachment(ctx context.Context, pageID, fileName, contentType string, data []byte) error
+}
+
+// AttachmentDownloader is implemented by publishers that can read back a
+// file attached to a published page
+type AttachmentDownloader interface {
+	// DownloadAttachment returns the attachment, or nil if the page has none
+	// with that name
+	DownloadAttachment(ctx context.Context, pageID, fileName string) ([]byte, error)
+}
+
+// IssueTracker opens issues about breaking changes between spec versions
+type IssueTracker interface {
+	// CreateIssue opens an issue and returns its key
+	CreateIssue(ctx context.Context, summary, description string) (string, error)
+	// FindOpenIssue returns the key of an unresolved issue with exactly this
+	// summary, or "" when there is none
+	FindOpenIssue(ctx context.Context, summary string) (string, error)
+	// UpdateIssue replaces the description of an issue
+	UpdateIssue(ctx context.Context, key, description string) error
+	// IssueURL returns the browser URL of an issue
+	IssueURL(key string) string
+}
+
+// WikiPage is a page listed by a PageCleaner, with its content and labels
+type WikiPage = confluence.Page
+
+// Parts of a WikiPage
+type (
+	WikiPageBody     = confluence.Body
+	WikiPageStorage  = confluence.Storage
+	WikiPageMetadata = confluence.Metadata
+	WikiPageLabels   = confluence.LabelResults
+	WikiPageLabel    = confluence.Label
+)
+
+// PageCleaner is implemented by publishers that can list, delete and move
+// pages
+type PageCleaner interface {
+	// ChildPages returns the pages directly below pageID with their content
+	ChildPages(ctx context.Context, pageID string) ([]WikiPage, error)
+	DeletePage(ctx context.Context, pageID string) error
+	// MovePage moves a page below parentPageID, keeping its content
+	MovePage(ctx context.Context, page WikiPage, parentPageID string) error
+}
@@ -0,0 +1,113 @@
+package converter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+)
+
+// testSpec documents the "Get Pet" and "List Pets" endpoints
+const testSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "Pets", "version": "1.0.0"},
+	"paths": {
+		"/pets": {"get": {"operationId": "listPets", "summary": "List Pets", "responses": {"200": {"description": "OK"}}}},
+		"/pets/{id}": {"get": {
+			"operationId": "getPet",
+			"summary": "Get Pet",
+			"parameters": [{"name": "id", "in": "path", "required": true, "type": "string"}],
+			"responses": {"200": {"description": "OK"}}
+		}}
+	}
+}`
+
+// specFile writes a spec document to a temporary file and returns its path
+func specFile(t *testing.T, spec string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "openapi.json")
+	if err := os.WriteFile(path, []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// fakePublisher keeps published pages in memory. Pages are identified by
+// their titles; children lists the pages below each page ID, for pruning.
+type fakePublisher struct {
+	pages    map[string]string
+	parents  map[string]string
+	order    []string
+	children map[string][]confluence.Page
+	deleted  []string
+	moved    map[string]string
+	// fail makes publishing the pages with these titles fail
+	fail map[string]error
+}
+
+func newFakePublisher() *fakePublisher {
+	return &fakePublisher{
+		pages:    make(map[string]string),
+		parents:  make(map[string]string),
+		children: make(map[string][]confluence.Page),
+		moved:    make(map[string]string),
+	}
+}
+
+func (p *fakePublisher) ResolveParentPage(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+func (p *fakePublisher) CreateParentPage(ctx context.Context, apiTitle string) (string, error) {
+	title := confluence.APIPageTitle(apiTitle)
+	p.pages[title] = ""
+	return title, nil
+}
+
+func (p *fakePublisher) CreateOrUpdatePage(ctx context.Context, title, content, parentPageID string) (string, error) {
+	if err := p.fail[title]; err != nil {
+		return "", err
+	}
+	p.pages[title] = content
+	p.parents[title] = parentPageID
+	p.order = append(p.order, title)
+	return title, nil
+}
+
+func (p *fakePublisher) PageURL(pageID string) string {
+	return "https://wiki.example.com/" + pageID
+}
+
+func (p *fakePublisher) FindPage(ctx context.Context, title string) (string, error) {
+	if _, ok := p.pages[title]; ok {
+		return title, nil
+	}
+	return "", nil
+}
+
+func (p *fakePublisher) EditPage(ctx context.Context, title string, edit func(content string) string) error {
+	if content, ok := p.pages[title]; ok {
+		p.pages[title] = edit(content)
+	}
+	return nil
+}
+
+func (p *fakePublisher) ChildPages(ctx context.Context, pageID string) ([]confluence.Page, error) {
+	return p.children[pageID], nil
+}
+
+func (p *fakePublisher) DeletePage(ctx context.Context, pageID string) error {
+	p.deleted = append(p.deleted, pageID)
+	return nil
+}
+
+func (p *fakePublisher) MovePage(ctx context.Context, page confluence.Page, parentPageID string) error {
+	if _, ok := p.pages[parentPageID]; !ok {
+		return fmt.Errorf("no page %q to move %q below", parentPageID, page.Title)
+	}
+	p.moved[page.ID] = parentPageID
+	return nil
+}
@@ -0,0 +1,62 @@
+package converter
+
+import (
+	"iter"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// renderedPage is an endpoint with its rendered page, or the error that
+// rendering failed with
+type renderedPage struct {
+	endpoint swagger.EndpointInfo
+	content  string
+	err      error
+}
+
+// renderPages renders the pages of endpoints and yields them in order, with
+// the endpoints as changed by render. With
+// a concurrency of n, up to n pages are rendered in parallel ahead of the
+// one being consumed; otherwise each page is rendered when it is reached.
+func (c *Converter) renderPages(endpoints iter.Seq[swagger.EndpointInfo], render func(*swagger.EndpointInfo) (string, error)) iter.Seq[renderedPage] {
+	if c.concurrency < 2 {
+		return func(yield func(renderedPage) bool) {
+			for endpoint := range endpoints {
+				content, err := render(&endpoint)
+				if !yield(renderedPage{endpoint: endpoint, content: content, err: err}) {
+					return
+				}
+			}
+		}
+	}
+
+	return func(yield func(renderedPage) bool) {
+		// The queue holds the pending results in endpoint order; together
+		// with the result being consumed it bounds the pages in flight
+		queue := make(chan chan renderedPage, c.concurrency-1)
+		done := make(chan struct{})
+		defer close(done)
+
+		go func() {
+			defer close(queue)
+			for endpoint := range endpoints {
+				result := make(chan renderedPage, 1)
+				select {
+				case queue <- result:
+				case <-done:
+					return
+				}
+				go func() {
+					content, err := render(&endpoint)
+					result <- renderedPage{endpoint: endpoint, content: content, err: err}
+				}()
+			}
+		}()
+
+		for result := range queue {
+			if !yield(<-result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package converter
+
+import (
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/pkg/openapi"
+)
+
+// Renderer renders the storage format content of the API, endpoint and
+// model pages. The built-in formatter is the default; a custom Renderer
+// changes their layout without replacing the rest of the pipeline.
+type Renderer interface {
+	// FormatEndpointPage renders the page of one operation
+	FormatEndpointPage(path, method string, op openapi.Operation, resolver *openapi.Resolver) (string, error)
+	// FormatParentPage renders the introduction of the API page
+	FormatParentPage(apiTitle string) string
+	// FormatModelPage renders the page of a shared component schema, used
+	// by the endpoint pages titled usedBy
+	FormatModelPage(name string, schema *openapi.Schema, usedBy []string) string
+}
+
+// BannerRenderer is implemented by Renderers that place the banner of an
+// endpoint page themselves. The banner holds the links to other versions,
+// the webhook notice and the example request status, which are only known
+// once the page is about to be published. Other Renderers get the banner
+// at the top of the page.
+type BannerRenderer interface {
+	// InsertBanner places banner in content rendered by FormatEndpointPage
+	InsertBanner(content, banner string) string
+}
+
+var (
+	_ Renderer       = (*confluence.Formatter)(nil)
+	_ BannerRenderer = (*confluence.Formatter)(nil)
+)
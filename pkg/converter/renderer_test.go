@@ -0,0 +1,119 @@
+package converter_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahmadimt/SwagFluence/pkg/converter"
+	"github.com/ahmadimt/SwagFluence/pkg/openapi"
+)
+
+// petSpec is a small spec with one endpoint and one model
+const petSpec = `{
+	"swagger": "2.0",
+	"info": {"title": "Pets", "version": "1.0.0"},
+	"paths": {"/pets/{id}": {"get": {
+		"operationId": "getPet",
+		"summary": "Get Pet",
+		"parameters": [{"name": "id", "in": "path", "required": true, "type": "string"}],
+		"responses": {"200": {"description": "OK", "schema": {"$ref": "#/definitions/Pet"}}}
+	}}},
+	"definitions": {"Pet": {"type": "object", "properties": {"name": {"type": "string"}}}}
+}`
+
+// writeSpec writes a spec document to a temporary file and returns its path
+func writeSpec(t *testing.T, spec string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "openapi.json")
+	if err := os.WriteFile(path, []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// pagePublisher is a Publisher written the way a program outside this
+// module would, keeping the published pages by title
+type pagePublisher struct {
+	pages map[string]string
+}
+
+func (p *pagePublisher) ResolveParentPage(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+func (p *pagePublisher) CreateParentPage(ctx context.Context, apiTitle string) (string, error) {
+	return "parent", nil
+}
+
+func (p *pagePublisher) CreateOrUpdatePage(ctx context.Context, title, content, parentPageID string) (string, error) {
+	if p.pages == nil {
+		p.pages = make(map[string]string)
+	}
+	p.pages[title] = content
+	return title, nil
+}
+
+func (p *pagePublisher) PageURL(pageID string) string {
+	return ""
+}
+
+// plainRenderer renders every page as a single paragraph
+type plainRenderer struct{}
+
+func (plainRenderer) FormatEndpointPage(path, method string, op openapi.Operation, resolver *openapi.Resolver) (string, error) {
+	var fields []string
+	for _, code := range op.ResponseCodes() {
+		schema, err := resolver.ResolveSchema(op.Responses[code].Schema)
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, schema.PropertyNames()...)
+	}
+	return fmt.Sprintf("<p>%s %s returns %s</p>", strings.ToUpper(method), path, strings.Join(fields, ", ")), nil
+}
+
+func (plainRenderer) FormatParentPage(apiTitle string) string {
+	return "<p>" + apiTitle + "</p>"
+}
+
+func (plainRenderer) FormatModelPage(name string, schema *openapi.Schema, usedBy []string) string {
+	return "<p>" + name + "</p>"
+}
+
+func TestConverter_CustomRenderer(t *testing.T) {
+	publisher := &pagePublisher{}
+	conv := converter.New(
+		converter.WithSpecSource(writeSpec(t, petSpec)),
+		converter.WithPublisher(publisher),
+		converter.WithRenderer(plainRenderer{}),
+	)
+
+	if _, err := conv.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got, want := publisher.pages["Get Pet"], "<p>GET /pets/{id} returns name</p>"; got != want {
+		t.Errorf("endpoint page = %q, want %q", got, want)
+	}
+}
+
+func TestConverter_CustomRendererBanner(t *testing.T) {
+	publisher := &pagePublisher{}
+	conv := converter.New(
+		converter.WithSpecSource(writeSpec(t, petSpec)),
+		converter.WithPublisher(publisher),
+		converter.WithOptions(converter.Options{Versions: converter.VersionsConfig{Label: "v2", Linked: []string{"v1"}}}),
+		converter.WithRenderer(plainRenderer{}),
+	)
+
+	if _, err := conv.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	page := publisher.pages["Get Pet (v2)"]
+	if !strings.Contains(page, `ri:content-title="Get Pet (v1)"`) || !strings.HasSuffix(page, "<p>GET /pets/{id} returns name</p>") {
+		t.Errorf("endpoint page = %q, want the version links above the rendered page", page)
+	}
+}
@@ -0,0 +1,102 @@
+package converter
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/ahmadimt/SwagFluence/internal/diff"
+	"github.com/ahmadimt/SwagFluence/internal/smoke"
+)
+
+// Report summarizes a conversion run
+type Report struct {
+	// Source is the spec URL or file the run converted
+	Source        string       `json:"source"`
+	APITitle      string       `json:"apiTitle"`
+	APIVersion    string       `json:"apiVersion"`
+	ParentPageID  string       `json:"parentPageId,omitempty"`
+	ParentPageURL string       `json:"parentPageUrl,omitempty"`
+	Pages         []PageResult `json:"pages"`
+	Warnings      []string     `json:"warnings,omitempty"`
+	// Changes lists differences from the baseline spec, when one was given
+	Changes  []diff.Change `json:"changes,omitempty"`
+	IssueKey string        `json:"issueKey,omitempty"`
+	IssueURL string        `json:"issueUrl,omitempty"`
+	// PDFFiles lists the PDF exports written to disk
+	PDFFiles []string `json:"pdfFiles,omitempty"`
+	// Pruned lists the titles of stale endpoint pages deleted or archived
+	Pruned []string `json:"pruned,omitempty"`
+	// Interrupted is set when the run was cancelled before all pages were
+	// processed
+	Interrupted bool `json:"interrupted,omitempty"`
+	// Endpoints is the number of endpoints in the spec
+	Endpoints int `json:"endpoints"`
+	// Error is the error that failed the run, if any
+	Error string `json:"error,omitempty"`
+}
+
+// PageResult records the outcome of publishing a single endpoint page
+type PageResult struct {
+	Title  string `json:"title"`
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	PageID string `json:"pageId,omitempty"`
+	URL    string `json:"url,omitempty"`
+	// Change is create, update or unchanged, when the publisher reports it
+	Change string `json:"change,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// Smoke is the result of executing the example request, when enabled
+	Smoke *smoke.Result `json:"smoke,omitempty"`
+}
+
+// Succeeded returns the number of pages published without error
+func (r *Report) Succeeded() int {
+	count := 0
+	for _, page := range r.Pages {
+		if page.Error == "" {
+			count++
+		}
+	}
+	return count
+}
+
+// Failed returns the pages that could not be published
+func (r *Report) Failed() []PageResult {
+	var failed []PageResult
+	for _, page := range r.Pages {
+		if page.Error != "" {
+			failed = append(failed, page)
+		}
+	}
+	return failed
+}
+
+// SmokeCounts returns the number of verified, failing and skipped example
+// requests. Pages without an example request, such as webhooks, are not
+// counted.
+func (r *Report) SmokeCounts() (verified, failing, skipped int) {
+	for _, page := range r.Pages {
+		switch {
+		case page.Smoke == nil:
+		case page.Smoke.Verified:
+			verified++
+		case page.Smoke.Skipped:
+			skipped++
+		default:
+			failing++
+		}
+	}
+	return verified, failing, skipped
+}
+
+// printFailures prints a table of the pages that failed and why
+func printFailures(out io.Writer, failed []PageResult) {
+	fmt.Fprintf(out, "\n%d pages failed:\n", len(failed))
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PAGE\tENDPOINT\tERROR")
+	for _, page := range failed {
+		fmt.Fprintf(w, "%s\t%s %s\t%s\n", page.Title, page.Method, page.Path, page.Error)
+	}
+	w.Flush()
+}
@@ -0,0 +1,21 @@
+package converter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ahmadimt/SwagFluence/internal/confluence"
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// publishAuthentication publishes the page describing the security schemes,
+// which endpoint pages link to
+func (c *Converter) publishAuthentication(ctx context.Context, scope, parentPageID string, spec *swagger.Spec) error {
+	title := confluence.AuthenticationPageTitle(scope)
+	if _, err := c.publishPage(ctx, title, c.formatter.FormatAuthenticationPage(spec), parentPageID); err != nil {
+		return fmt.Errorf("failed to publish authentication page: %w", err)
+	}
+
+	fmt.Fprintf(c.progress, "Published %s\n\n", title)
+	return nil
+}
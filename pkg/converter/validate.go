@@ -0,0 +1,102 @@
+package converter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// Validate parses the spec, runs the lint rules and renders every endpoint
+// page without publishing anything. Pages that cannot be rendered, e.g.
+// because of a broken $ref, are recorded as failed in the report.
+func (c *Converter) Validate(ctx context.Context, source string) (*Report, error) {
+	report := &Report{}
+
+	spec, err := c.parser.Parse(ctx, source)
+	if err != nil {
+		return report, fmt.Errorf("failed to parse swagger: %w", err)
+	}
+
+	fmt.Fprintf(c.progress, "Successfully parsed: %s v%s\n", spec.Info.Title, spec.Info.Version)
+	report.APITitle = spec.Info.Title
+	report.APIVersion = spec.Info.Version
+
+	lintErr := c.lint(spec, report)
+	c.titleWarnings(spec, report)
+
+	resolver := swagger.NewResolver(spec)
+	renderer := c.renderer(c.formatter.WithSecurity(spec, ""))
+	for endpoint := range c.parser.Endpoints(spec) {
+		result := PageResult{
+			Title:  endpoint.Title,
+			Method: endpoint.Method,
+			Path:   endpoint.Path,
+		}
+		err := checkSchemas(endpoint.Operation, resolver)
+		if err == nil {
+			_, err = renderer.FormatEndpointPage(endpoint.Path, endpoint.Method, endpoint.Operation, resolver)
+		}
+		if err != nil {
+			result.Error = err.Error()
+			fmt.Fprintf(c.progress, "  %s %s: %v\n", strings.ToUpper(endpoint.Method), endpoint.Path, err)
+		}
+		report.Pages = append(report.Pages, result)
+	}
+	fmt.Fprintf(c.progress, "Rendered %d of %d endpoint pages\n", report.Succeeded(), len(report.Pages))
+
+	if lintErr != nil {
+		return report, lintErr
+	}
+	if failed := len(report.Failed()); failed > 0 {
+		return report, fmt.Errorf("%d endpoint pages cannot be rendered", failed)
+	}
+	return report, nil
+}
+
+// checkSchemas resolves the request and response body schemas of an
+// operation. The formatter renders unresolvable schemas as empty tables, so
+// broken references are only caught here.
+func checkSchemas(op swagger.Operation, resolver *swagger.Resolver) error {
+	check := func(location string, schema *swagger.Schema) error {
+		if _, err := resolver.ResolveSchema(schema); err != nil {
+			return fmt.Errorf("%s: %w", location, err)
+		}
+		return nil
+	}
+
+	for _, param := range op.Parameters {
+		if err := check("request body", param.Schema); err != nil {
+			return err
+		}
+	}
+	if op.RequestBody != nil {
+		for _, contentType := range swagger.SortedKeys(op.RequestBody.Content) {
+			if err := check("request body "+contentType, op.RequestBody.Content[contentType].Schema); err != nil {
+				return err
+			}
+		}
+	}
+	for _, code := range swagger.SortedKeys(op.Responses) {
+		response := op.Responses[code]
+		if err := check("response "+code, response.Schema); err != nil {
+			return err
+		}
+		for _, contentType := range swagger.SortedKeys(response.Content) {
+			if err := check("response "+code+" "+contentType, response.Content[contentType].Schema); err != nil {
+				return err
+			}
+		}
+		for _, name := range swagger.SortedKeys(response.Headers) {
+			header, err := resolver.ResolveHeader(response.Headers[name])
+			if err != nil {
+				return fmt.Errorf("response %s header %s: %w", code, name, err)
+			}
+			if err := check("response "+code+" header "+name, header.Schema); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
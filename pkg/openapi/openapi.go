@@ -0,0 +1,64 @@
+// Package openapi exposes the spec model of pkg/converter to programs
+// outside this module, as aliases of the internal types
+package openapi
+
+import (
+	"github.com/ahmadimt/SwagFluence/internal/swagger"
+)
+
+// Parser reads Swagger 2.0 and OpenAPI 3.x specs from URLs, files or stdin
+type Parser = swagger.Parser
+
+// Resolver resolves $refs against the spec they come from
+type Resolver = swagger.Resolver
+
+// Spec is a parsed specification
+type Spec = swagger.Spec
+
+// Info describes the API
+type Info = swagger.Info
+
+// EndpointInfo is one operation of the spec with the title of its page
+type EndpointInfo = swagger.EndpointInfo
+
+// Operation describes an API operation
+type Operation = swagger.Operation
+
+// Parameter describes an operation parameter
+type Parameter = swagger.Parameter
+
+// RequestBody describes an OpenAPI 3.x request body
+type RequestBody = swagger.RequestBody
+
+// MediaType is the schema and examples of one content type
+type MediaType = swagger.MediaType
+
+// Responses maps status codes to responses
+type Responses = swagger.Responses
+
+// Response describes an operation response
+type Response = swagger.Response
+
+// Header describes a response header
+type Header = swagger.Header
+
+// Schema describes a data schema
+type Schema = swagger.Schema
+
+// Property describes a schema property
+type Property = swagger.Property
+
+// NewParser creates a Parser with the default spec settings
+func NewParser() *Parser {
+	return swagger.NewParser()
+}
+
+// NewResolver creates a Resolver for spec
+func NewResolver(spec *Spec) *Resolver {
+	return swagger.NewResolver(spec)
+}
+
+// ExtractRefName returns the component name a $ref points to
+func ExtractRefName(ref string) string {
+	return swagger.ExtractRefName(ref)
+}